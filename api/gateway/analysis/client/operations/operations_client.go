@@ -51,6 +51,8 @@ type ClientService interface {
 
 	CreatePolicy(params *CreatePolicyParams) (*CreatePolicyCreated, error)
 
+	CreateQuery(params *CreateQueryParams) (*CreateQueryCreated, error)
+
 	CreateRule(params *CreateRuleParams) (*CreateRuleCreated, error)
 
 	DeleteGlobals(params *DeleteGlobalsParams) (*DeleteGlobalsOK, error)
@@ -63,6 +65,8 @@ type ClientService interface {
 
 	GetPolicy(params *GetPolicyParams) (*GetPolicyOK, error)
 
+	GetQuery(params *GetQueryParams) (*GetQueryOK, error)
+
 	GetRule(params *GetRuleParams) (*GetRuleOK, error)
 
 	ListGlobals(params *ListGlobalsParams) (*ListGlobalsOK, error)
@@ -75,6 +79,8 @@ type ClientService interface {
 
 	ModifyPolicy(params *ModifyPolicyParams) (*ModifyPolicyOK, error)
 
+	ModifyQuery(params *ModifyQueryParams) (*ModifyQueryOK, error)
+
 	ModifyRule(params *ModifyRuleParams) (*ModifyRuleOK, error)
 
 	Suppress(params *SuppressParams) (*SuppressOK, error)
@@ -186,6 +192,40 @@ func (a *Client) CreatePolicy(params *CreatePolicyParams) (*CreatePolicyCreated,
 	panic(msg)
 }
 
+/*
+  CreateQuery creates a new scheduled query
+*/
+func (a *Client) CreateQuery(params *CreateQueryParams) (*CreateQueryCreated, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateQueryParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "CreateQuery",
+		Method:             "POST",
+		PathPattern:        "/query",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &CreateQueryReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateQueryCreated)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for CreateQuery: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   CreateRule creates a new log analysis rule
 */
@@ -390,6 +430,40 @@ func (a *Client) GetPolicy(params *GetPolicyParams) (*GetPolicyOK, error) {
 	panic(msg)
 }
 
+/*
+  GetQuery gets scheduled query details
+*/
+func (a *Client) GetQuery(params *GetQueryParams) (*GetQueryOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetQueryParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetQuery",
+		Method:             "GET",
+		PathPattern:        "/query",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetQueryReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetQueryOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetQuery: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   GetRule gets rule details
 */
@@ -594,6 +668,40 @@ func (a *Client) ModifyPolicy(params *ModifyPolicyParams) (*ModifyPolicyOK, erro
 	panic(msg)
 }
 
+/*
+  ModifyQuery modifies an existing scheduled query
+*/
+func (a *Client) ModifyQuery(params *ModifyQueryParams) (*ModifyQueryOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewModifyQueryParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ModifyQuery",
+		Method:             "POST",
+		PathPattern:        "/query/update",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ModifyQueryReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ModifyQueryOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ModifyQuery: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   ModifyRule modifies an existing rule
 */
@@ -46,6 +46,9 @@ const (
 
 	// AnalysisTypeRULE captures enum value "RULE"
 	AnalysisTypeRULE AnalysisType = "RULE"
+
+	// AnalysisTypeSCHEDULEDQUERY captures enum value "SCHEDULED_QUERY"
+	AnalysisTypeSCHEDULEDQUERY AnalysisType = "SCHEDULED_QUERY"
 )
 
 // for schema
@@ -53,7 +56,7 @@ var analysisTypeEnum []interface{}
 
 func init() {
 	var res []AnalysisType
-	if err := json.Unmarshal([]byte(`["GLOBAL","POLICY","RULE"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["GLOBAL","POLICY","RULE","SCHEDULED_QUERY"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
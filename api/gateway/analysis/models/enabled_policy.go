@@ -43,6 +43,9 @@ type EnabledPolicy struct {
 	// id
 	ID ID `json:"id,omitempty"`
 
+	// lookback minutes
+	LookbackMinutes LookbackMinutes `json:"lookbackMinutes,omitempty"`
+
 	// output ids
 	OutputIds OutputIds `json:"outputIds,omitempty"`
 
@@ -52,6 +55,9 @@ type EnabledPolicy struct {
 	// resource types
 	ResourceTypes TypeSet `json:"resourceTypes,omitempty"`
 
+	// schedule
+	Schedule Schedule `json:"schedule,omitempty"`
+
 	// severity
 	Severity Severity `json:"severity,omitempty"`
 
@@ -81,6 +87,10 @@ func (m *EnabledPolicy) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateLookbackMinutes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateOutputIds(formats); err != nil {
 		res = append(res, err)
 	}
@@ -93,6 +103,10 @@ func (m *EnabledPolicy) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSchedule(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSeverity(formats); err != nil {
 		res = append(res, err)
 	}
@@ -163,6 +177,22 @@ func (m *EnabledPolicy) validateID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *EnabledPolicy) validateLookbackMinutes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LookbackMinutes) { // not required
+		return nil
+	}
+
+	if err := m.LookbackMinutes.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("lookbackMinutes")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *EnabledPolicy) validateOutputIds(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.OutputIds) { // not required
@@ -211,6 +241,22 @@ func (m *EnabledPolicy) validateResourceTypes(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *EnabledPolicy) validateSchedule(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Schedule) { // not required
+		return nil
+	}
+
+	if err := m.Schedule.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("schedule")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *EnabledPolicy) validateSeverity(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Severity) { // not required
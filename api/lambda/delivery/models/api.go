@@ -32,6 +32,9 @@ const (
 
 	// PolicyType identifies the Alert to be for a Policy
 	PolicyType = "POLICY"
+
+	// SourceFreshnessType identifies the Alert to be for a log source that stopped (or resumed) sending data
+	SourceFreshnessType = "SOURCE_FRESHNESS"
 )
 
 // LambdaInput is the invocation event expected by the Lambda function.
@@ -118,8 +121,8 @@ type Alert struct {
 	// ID is the rule that triggered the alert.
 	AnalysisID string `json:"analysisId" validate:"required"`
 
-	// Type specifies if an alert is for a policy or a rule
-	Type string `json:"type" validate:"oneof=RULE POLICY"`
+	// Type specifies if an alert is for a policy, a rule or the freshness of a source
+	Type string `json:"type" validate:"oneof=RULE POLICY SOURCE_FRESHNESS"`
 
 	// CreatedAt is the creation timestamp (seconds since epoch).
 	CreatedAt time.Time `json:"createdAt" validate:"required"`
@@ -0,0 +1,105 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import "time"
+
+// LambdaInput is the request structure for the indicators-api Lambda function.
+type LambdaInput struct {
+	SearchIndicators          *SearchIndicatorsInput          `json:"searchIndicators"`
+	GetIndicatorSearchResults *GetIndicatorSearchResultsInput `json:"getIndicatorSearchResults"`
+}
+
+// SearchIndicatorsInput starts a search for the rows of all logs where an indicator appeared in a time range.
+//
+// If the search does not complete in time, the output has status "running" and the results
+// can be retrieved later using the query id.
+// Example:
+//
+//	{
+//	    "searchIndicators": {
+//	        "value": "192.168.0.1",
+//	        "indicatorType": "ip_addresses",
+//	        "logTypes": ["AWS.VPCFlow"],
+//	        "startTime": "2020-06-17T00:00:00Z",
+//	        "endTime": "2020-06-18T00:00:00Z",
+//	        "pageSize": 25
+//	    }
+//	}
+type SearchIndicatorsInput struct {
+	// The indicator value to search for
+	Value string `json:"value" validate:"required"`
+	// The type of the indicator, the name of the "p_any" field without the prefix (e.g. ip_addresses)
+	IndicatorType string `json:"indicatorType"`
+	// Restrict the search to these log types
+	LogTypes []string `json:"logTypes" validate:"omitempty,dive,required"`
+
+	StartTime time.Time `json:"startTime" validate:"required"`
+	EndTime   time.Time `json:"endTime" validate:"required,gtfield=StartTime"`
+
+	// Number of hits to return per page
+	PageSize int `json:"pageSize" validate:"omitempty,min=1,max=500"`
+}
+
+// SearchIndicatorsOutput returns the first page of hits of a search
+type SearchIndicatorsOutput = IndicatorSearchResults
+
+// GetIndicatorSearchResultsInput returns a page of hits of a previous search
+// Example:
+//
+//	{
+//	    "getIndicatorSearchResults": {
+//	        "queryId": "7c3ba5f6-8b6b-4b2b-b1d5-5d4f3c0a3f9e",
+//	        "paginationToken": "token",
+//	        "pageSize": 25
+//	    }
+//	}
+type GetIndicatorSearchResultsInput struct {
+	QueryID         string  `json:"queryId" validate:"required"`
+	PaginationToken *string `json:"paginationToken"`
+	PageSize        int     `json:"pageSize" validate:"omitempty,min=1,max=500"`
+}
+
+// GetIndicatorSearchResultsOutput returns a page of hits of a search
+type GetIndicatorSearchResultsOutput = IndicatorSearchResults
+
+// IndicatorSearchResults is a page of the hits of an indicator search, most recent first
+type IndicatorSearchResults struct {
+	QueryID string `json:"queryId"`
+	// Status is either "running" or "succeeded", hits are only returned for succeeded searches
+	Status string         `json:"status"`
+	Hits   []IndicatorHit `json:"hits"`
+	// Set if there are more hits to return
+	PaginationToken *string `json:"paginationToken,omitempty"`
+}
+
+// IndicatorHit is a row of a log where the indicator appeared
+type IndicatorHit struct {
+	IndicatorType string    `json:"indicatorType"`
+	Value         string    `json:"value"`
+	LogType       string    `json:"logType"`
+	EventTime     time.Time `json:"eventTime"`
+	RowID         string    `json:"rowId"`
+}
+
+// Status values of indicator searches
+const (
+	SearchStatusRunning   = "running"
+	SearchStatusSucceeded = "succeeded"
+)
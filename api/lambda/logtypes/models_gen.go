@@ -23,6 +23,20 @@ package logtypesapi
 // LogTypesAPI available endpoints
 type LogTypesAPI interface {
 	ListAvailableLogTypes() (ListAvailableLogTypesResponse, error)
+
+	GetLogTypeRetention(input GetLogTypeRetentionInput) (GetLogTypeRetentionResponse, error)
+
+	ListLogTypeRetention() (ListLogTypeRetentionResponse, error)
+
+	PutLogTypeRetention(input PutLogTypeRetentionInput) (PutLogTypeRetentionResponse, error)
+
+	GetLookupTable(input GetLookupTableInput) (GetLookupTableResponse, error)
+
+	ListLookupTables() (ListLookupTablesResponse, error)
+
+	PutLookupTable(input PutLookupTableInput) (PutLookupTableResponse, error)
+
+	DeleteLookupTable(input DeleteLookupTableInput) error
 }
 
 // Models for LogTypesAPI
@@ -30,8 +44,115 @@ type LogTypesAPI interface {
 // LogTypesAPIPayload is the payload for calls to LogTypesAPI endpoints.
 type LogTypesAPIPayload struct {
 	ListAvailableLogTypes *struct{}
+	GetLogTypeRetention   *GetLogTypeRetentionInput
+	ListLogTypeRetention  *struct{}
+	PutLogTypeRetention   *PutLogTypeRetentionInput
+	GetLookupTable        *GetLookupTableInput
+	ListLookupTables      *struct{}
+	PutLookupTable        *PutLookupTableInput
+	DeleteLookupTable     *DeleteLookupTableInput
+}
+
+type DeleteLookupTableInput struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type GetLogTypeRetentionInput struct {
+	LogType string `json:"logType" validate:"required"`
+}
+
+type GetLogTypeRetentionResponse struct {
+	LogType       string `json:"logType" validate:"required"`
+	RetentionDays int    `json:"retentionDays" validate:"min=0"`
+}
+
+type GetLookupTableInput struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type GetLookupTableResponse struct {
+	Name        string `json:"name" validate:"required,max=64"`
+	Description string `json:"description,omitempty"`
+	S3URL       string `json:"s3URL" validate:"required,startswith=s3://"`
+	Format      string `json:"format" validate:"oneof=csv json"`
+	Columns     struct {
+		Name string `json:"name" validate:"required"`
+		Type string `json:"type" validate:"oneof=string bigint double boolean"`
+	} `json:"columns" validate:"min=1,dive"`
+	KeyColumn string `json:"keyColumn" validate:"required"`
+	Selectors struct {
+		LogType string `json:"logType" validate:"required"`
+		Field   string `json:"field" validate:"required"`
+	} `json:"selectors" validate:"min=1,dive"`
 }
 
 type ListAvailableLogTypesResponse struct {
 	LogTypes string `json:"logTypes"`
 }
+
+type ListLogTypeRetentionResponse struct {
+	Retention struct {
+		LogType       string `json:"logType" validate:"required"`
+		RetentionDays int    `json:"retentionDays" validate:"min=0"`
+	} `json:"retention"`
+}
+
+type ListLookupTablesResponse struct {
+	Tables struct {
+		Name        string `json:"name" validate:"required,max=64"`
+		Description string `json:"description,omitempty"`
+		S3URL       string `json:"s3URL" validate:"required,startswith=s3://"`
+		Format      string `json:"format" validate:"oneof=csv json"`
+		Columns     struct {
+			Name string `json:"name" validate:"required"`
+			Type string `json:"type" validate:"oneof=string bigint double boolean"`
+		} `json:"columns" validate:"min=1,dive"`
+		KeyColumn string `json:"keyColumn" validate:"required"`
+		Selectors struct {
+			LogType string `json:"logType" validate:"required"`
+			Field   string `json:"field" validate:"required"`
+		} `json:"selectors" validate:"min=1,dive"`
+	} `json:"tables"`
+}
+
+type PutLogTypeRetentionInput struct {
+	LogType       string `json:"logType" validate:"required"`
+	RetentionDays int    `json:"retentionDays" validate:"min=0"`
+}
+
+type PutLogTypeRetentionResponse struct {
+	LogType       string `json:"logType" validate:"required"`
+	RetentionDays int    `json:"retentionDays" validate:"min=0"`
+}
+
+type PutLookupTableInput struct {
+	Name        string `json:"name" validate:"required,max=64"`
+	Description string `json:"description,omitempty"`
+	S3URL       string `json:"s3URL" validate:"required,startswith=s3://"`
+	Format      string `json:"format" validate:"oneof=csv json"`
+	Columns     struct {
+		Name string `json:"name" validate:"required"`
+		Type string `json:"type" validate:"oneof=string bigint double boolean"`
+	} `json:"columns" validate:"min=1,dive"`
+	KeyColumn string `json:"keyColumn" validate:"required"`
+	Selectors struct {
+		LogType string `json:"logType" validate:"required"`
+		Field   string `json:"field" validate:"required"`
+	} `json:"selectors" validate:"min=1,dive"`
+}
+
+type PutLookupTableResponse struct {
+	Name        string `json:"name" validate:"required,max=64"`
+	Description string `json:"description,omitempty"`
+	S3URL       string `json:"s3URL" validate:"required,startswith=s3://"`
+	Format      string `json:"format" validate:"oneof=csv json"`
+	Columns     struct {
+		Name string `json:"name" validate:"required"`
+		Type string `json:"type" validate:"oneof=string bigint double boolean"`
+	} `json:"columns" validate:"min=1,dive"`
+	KeyColumn string `json:"keyColumn" validate:"required"`
+	Selectors struct {
+		LogType string `json:"logType" validate:"required"`
+		Field   string `json:"field" validate:"required"`
+	} `json:"selectors" validate:"min=1,dive"`
+}
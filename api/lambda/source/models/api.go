@@ -34,8 +34,9 @@ type LambdaInput struct {
 	UpdateIntegrationLastScanEnd   *UpdateIntegrationLastScanEndInput   `json:"updateIntegrationLastScanEnd"`
 	UpdateIntegrationLastScanStart *UpdateIntegrationLastScanStartInput `json:"updateIntegrationLastScanStart"`
 
-	FullScan     *FullScanInput     `json:"fullScan"`
-	UpdateStatus *UpdateStatusInput `json:"updateStatus"`
+	FullScan       *FullScanInput       `json:"fullScan"`
+	UpdateStatus   *UpdateStatusInput   `json:"updateStatus"`
+	CheckFreshness *CheckFreshnessInput `json:"checkFreshness"`
 }
 
 //
@@ -85,6 +86,13 @@ type PutIntegrationSettings struct {
 	LogTypes           []string `json:"logTypes" validate:"omitempty,min=1"`
 
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+
+	// Log sources only, an unset value disables freshness alerts
+	FreshnessThresholdMins int `json:"freshnessThresholdMins" validate:"omitempty,min=15"`
+	// Log sources only, freshness alerts are MEDIUM if unset
+	FreshnessAlertSeverity string `json:"freshnessAlertSeverity" validate:"omitempty,oneof=INFO LOW MEDIUM HIGH CRITICAL"`
+	// Log sources only, freshness alerts go to the default outputs of their severity if unset
+	FreshnessAlertOutputIds []string `json:"freshnessAlertOutputIds" validate:"omitempty,dive,uuid4"`
 }
 
 //
@@ -109,6 +117,13 @@ type UpdateIntegrationSettingsInput struct {
 	LogTypes           []string `json:"logTypes" validate:"omitempty,min=1"`
 
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+
+	// Log sources only, an unset value keeps the current threshold and 0 disables freshness alerts
+	FreshnessThresholdMins *int `json:"freshnessThresholdMins" validate:"omitempty,eq=0|min=15"`
+	// Log sources only, an unset value keeps the current severity and an empty one resets it to MEDIUM
+	FreshnessAlertSeverity *string `json:"freshnessAlertSeverity" validate:"omitempty,eq=|oneof=INFO LOW MEDIUM HIGH CRITICAL"`
+	// Log sources only, an unset value keeps the current outputs and an empty list resets them to the defaults
+	FreshnessAlertOutputIds []string `json:"freshnessAlertOutputIds" validate:"omitempty,dive,uuid4"`
 }
 
 // DeleteIntegrationInput is used to delete a specific item from the database.
@@ -125,6 +140,18 @@ type FullScanInput struct {
 	Integrations []*SourceIntegrationMetadata
 }
 
+//
+// CheckFreshness: Used by a scheduled rule to alert on log sources that stopped sending data
+//
+
+// CheckFreshnessInput has no parameters, all log sources with a freshness threshold are checked.
+//
+// Sample request:
+// {
+//	"checkFreshness": {}
+// }
+type CheckFreshnessInput struct{}
+
 //
 // GetIntegrationTemplate: Used by the frontend to provide templates for users
 //
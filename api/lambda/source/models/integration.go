@@ -34,6 +34,8 @@ type SourceIntegrationStatus struct {
 	ScanStatus        string     `json:"scanStatus,omitempty"`
 	EventStatus       string     `json:"eventStatus,omitempty"`
 	LastEventReceived *time.Time `json:"lastEventReceived,omitempty"`
+	// StaleAlertTime is set when a freshness alert has been sent and is cleared once data resumes
+	StaleAlertTime *time.Time `json:"staleAlertTime,omitempty"`
 }
 
 // SourceIntegrationScanInformation is detail about the last snapshot.
@@ -61,6 +63,12 @@ type SourceIntegrationMetadata struct {
 	LogProcessingRole  string     `json:"logProcessingRole,omitempty"`
 	StackName          string     `json:"stackName,omitempty"`
 	SqsConfig          *SqsConfig `json:"sqsConfig,omitempty"`
+	// FreshnessThresholdMins is how long a log source can go without sending data before an alert is raised
+	FreshnessThresholdMins int `json:"freshnessThresholdMins,omitempty"`
+	// FreshnessAlertSeverity is the severity of freshness alerts, MEDIUM if unset
+	FreshnessAlertSeverity string `json:"freshnessAlertSeverity,omitempty"`
+	// FreshnessAlertOutputIds are the destinations of freshness alerts, the defaults of their severity if unset
+	FreshnessAlertOutputIds []string `json:"freshnessAlertOutputIds,omitempty"`
 }
 
 func (info *SourceIntegration) RequiredLogTypes() (logTypes []string) {
@@ -85,15 +93,24 @@ type SourceIntegrationHealth struct {
 	ProcessingRoleStatus SourceIntegrationItemStatus `json:"processingRoleStatus,omitempty"`
 	S3BucketStatus       SourceIntegrationItemStatus `json:"s3BucketStatus,omitempty"`
 	KMSKeyStatus         SourceIntegrationItemStatus `json:"kmsKeyStatus,omitempty"`
+	// Checks for each link of the ingestion chain from the bucket to Panther, nil if the check was not performed
+	S3NotificationsStatus *SourceIntegrationItemStatus `json:"s3NotificationsStatus,omitempty"`
+	SNSTopicPolicyStatus  *SourceIntegrationItemStatus `json:"snsTopicPolicyStatus,omitempty"`
+	SNSSubscriptionStatus *SourceIntegrationItemStatus `json:"snsSubscriptionStatus,omitempty"`
+	LatestObjectStatus    *SourceIntegrationItemStatus `json:"latestObjectStatus,omitempty"`
+	SampleObjectStatus    *SourceIntegrationItemStatus `json:"sampleObjectStatus,omitempty"`
 
 	// Checks for Sqs integrations
-	SqsStatus SourceIntegrationItemStatus `json:"sqsStatus"`
+	SqsStatus       SourceIntegrationItemStatus  `json:"sqsStatus"`
+	SqsPolicyStatus *SourceIntegrationItemStatus `json:"sqsPolicyStatus,omitempty"`
 }
 
 type SourceIntegrationItemStatus struct {
 	Healthy      bool   `json:"healthy"`
 	Message      string `json:"message"`
 	ErrorMessage string `json:"rawErrorMessage,omitempty"`
+	// Remediation describes how to fix an unhealthy item
+	Remediation string `json:"remediation,omitempty"`
 }
 
 type SourceIntegrationTemplate struct {
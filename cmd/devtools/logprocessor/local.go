@@ -0,0 +1,201 @@
+package logprocessor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/cmd/devtools/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// ListFiles expands directories in paths to the files they contain, sorted by path.
+// S3 URLs and '-' for stdin are returned as is.
+func ListFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		if path == "-" || strings.HasPrefix(path, "s3://") {
+			files = append(files, path)
+			continue
+		}
+		err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.Mode().IsRegular() {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list files in %q", path)
+		}
+	}
+	return files, nil
+}
+
+// SendDataStreams sends a data stream for each file to streams using input for the source fields and log types.
+// Files are opened one at a time, when the processor requests the next stream, and closed once fully read.
+// It closes streams when done and returns the first error opening a file.
+func SendDataStreams(streams chan<- *common.DataStream, s3Client s3iface.S3API, files []string, input common.DataStream) error {
+	defer close(streams)
+	for _, file := range files {
+		r, err := pantherlog.OpenInput(s3Client, file)
+		if err != nil {
+			return err
+		}
+		stream := input
+		stream.Reader = &closeOnEOF{r: r}
+		streams <- &stream
+	}
+	return nil
+}
+
+// closeOnEOF closes the underlying reader once it is fully read
+type closeOnEOF struct {
+	r   io.ReadCloser
+	err error
+}
+
+func (r *closeOnEOF) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.r.Read(p)
+	if err != nil {
+		r.err = err
+		if closeErr := r.r.Close(); closeErr != nil && err == io.EOF {
+			r.err = closeErr
+		}
+	}
+	return n, err
+}
+
+// LogTypeStats are the processing stats for a log type
+type LogTypeStats struct {
+	LogType string
+	Events  uint64
+	Files   uint64
+	Bytes   uint64
+}
+
+// Stats collects per log type stats from a destination and its notifier
+type Stats struct {
+	mu       sync.Mutex
+	logTypes map[string]*LogTypeStats
+}
+
+func (s *Stats) update(logType string, fn func(stats *LogTypeStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.logTypes == nil {
+		s.logTypes = make(map[string]*LogTypeStats)
+	}
+	stats, ok := s.logTypes[logType]
+	if !ok {
+		stats = &LogTypeStats{
+			LogType: logType,
+		}
+		s.logTypes[logType] = stats
+	}
+	fn(stats)
+}
+
+// Summary returns the stats for all log types sorted by log type
+func (s *Stats) Summary() []LogTypeStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	summary := make([]LogTypeStats, 0, len(s.logTypes))
+	for _, stats := range s.logTypes {
+		summary = append(summary, *stats)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].LogType < summary[j].LogType
+	})
+	return summary
+}
+
+// Destination counts the events sent to a destination
+func (s *Stats) Destination(dest destinations.Destination) destinations.Destination {
+	return &statsDestination{
+		Destination: dest,
+		stats:       s,
+	}
+}
+
+// Notifier counts the files and bytes in the notifications sent by a notifier
+func (s *Stats) Notifier(notifier destinations.Notifier) destinations.Notifier {
+	return &statsNotifier{
+		Notifier: notifier,
+		stats:    s,
+	}
+}
+
+type statsDestination struct {
+	destinations.Destination
+	stats *Stats
+}
+
+// SendEvents implements destinations.Destination interface
+func (d *statsDestination) SendEvents(parsedEventChannel chan *parsers.Result, errChan chan error) {
+	events := make(chan *parsers.Result, cap(parsedEventChannel))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.Destination.SendEvents(events, errChan)
+	}()
+	for event := range parsedEventChannel {
+		d.stats.update(event.PantherLogType, func(stats *LogTypeStats) {
+			stats.Events++
+		})
+		events <- event
+	}
+	close(events)
+	<-done
+}
+
+type statsNotifier struct {
+	destinations.Notifier
+	stats *Stats
+}
+
+// Notify implements destinations.Notifier interface
+func (n *statsNotifier) Notify(logType string, notification *models.S3Notification) error {
+	if err := n.Notifier.Notify(logType, notification); err != nil {
+		return err
+	}
+	n.stats.update(logType, func(stats *LogTypeStats) {
+		for _, record := range notification.Records {
+			stats.Files++
+			stats.Bytes += uint64(record.S3.Object.Size)
+		}
+	})
+	return nil
+}
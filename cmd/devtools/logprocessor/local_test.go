@@ -0,0 +1,121 @@
+package logprocessor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+func TestListFilesAndSendDataStreams(t *testing.T) {
+	assert := require.New(t)
+	dir, err := ioutil.TempDir("", "logprocessor")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(os.MkdirAll(filepath.Join(dir, "b"), 0700))
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "b", "2.log"), []byte("two\n"), 0600))
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "1.log"), []byte("one\n"), 0600))
+
+	files, err := ListFiles([]string{dir, "s3://bucket/key"})
+	assert.NoError(err)
+	assert.Equal([]string{
+		filepath.Join(dir, "1.log"),
+		filepath.Join(dir, "b", "2.log"),
+		"s3://bucket/key",
+	}, files)
+
+	_, err = ListFiles([]string{filepath.Join(dir, "missing")})
+	assert.Error(err)
+
+	streams := make(chan *common.DataStream)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- SendDataStreams(streams, nil, files[:2], common.DataStream{
+			SourceID: "source",
+			LogTypes: []string{"Foo.Bar"},
+		})
+	}()
+	var data []string
+	for stream := range streams {
+		assert.Equal("source", stream.SourceID)
+		assert.Equal([]string{"Foo.Bar"}, stream.LogTypes)
+		body, err := ioutil.ReadAll(stream.Reader)
+		assert.NoError(err)
+		data = append(data, string(body))
+	}
+	assert.NoError(<-errc)
+	assert.Equal([]string{"one\n", "two\n"}, data)
+
+	streams = make(chan *common.DataStream)
+	go func() {
+		errc <- SendDataStreams(streams, nil, []string{filepath.Join(dir, "missing")}, common.DataStream{})
+	}()
+	for range streams {
+		t.Fatal("unexpected stream")
+	}
+	assert.Error(<-errc)
+}
+
+type testDestination struct {
+	events int
+}
+
+func (d *testDestination) SendEvents(parsedEventChannel chan *parsers.Result, _ chan error) {
+	for range parsedEventChannel {
+		d.events++
+	}
+}
+
+type testNotifier struct{}
+
+func (testNotifier) Notify(_ string, _ *models.S3Notification) error {
+	return nil
+}
+
+func TestStats(t *testing.T) {
+	assert := require.New(t)
+	stats := &Stats{}
+	dest := &testDestination{}
+	events := make(chan *parsers.Result, 3)
+	for _, logType := range []string{"Foo.Bar", "Foo.Baz", "Foo.Bar"} {
+		result := &parsers.Result{}
+		result.PantherLogType = logType
+		events <- result
+	}
+	close(events)
+	stats.Destination(dest).SendEvents(events, make(chan error))
+	assert.Equal(3, dest.events)
+
+	notifier := stats.Notifier(testNotifier{})
+	assert.NoError(notifier.Notify("Foo.Bar", models.NewS3ObjectPutNotification("bucket", "key", 42)))
+	assert.NoError(notifier.Notify("Foo.Bar", models.NewS3ObjectPutNotification("bucket", "key", 8)))
+	assert.Equal([]LogTypeStats{
+		{LogType: "Foo.Bar", Events: 2, Files: 2, Bytes: 50},
+		{LogType: "Foo.Baz", Events: 1},
+	}, stats.Summary())
+}
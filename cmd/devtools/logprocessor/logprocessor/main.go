@@ -19,16 +19,24 @@ package main
  */
 
 import (
-	"compress/gzip"
 	"flag"
 	"log"
 	"os"
+	"path/filepath"
 	"runtime"
 	"runtime/pprof"
-	"strconv"
-
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/sns"
+	jsoniter "github.com/json-iterator/go"
 	"go.uber.org/zap"
 
+	"github.com/panther-labs/panther/cmd/devtools/logprocessor"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor"
@@ -36,7 +44,17 @@ import (
 )
 
 /*
-Run log processor locally for profiling purposes.
+Run log processor locally for testing and profiling purposes.
+
+Input files are passed with -file or as arguments, directories are processed recursively.
+Files can be local paths or S3 URLs and can be compressed with gzip or zstd.
+
+In local mode (-local dir) no AWS resources are needed. The output files are written to dir/data using the same
+partitioned layout as the processed data bucket and the notifications are written as JSON files to dir/notifications.
+Otherwise output is written to -bucket, which can be on an S3 compatible service (ie MinIO) by setting -endpoint.
+Notifications are published to -topic or written to the -notifications directory.
+
+Per log type stats are printed to stdout when done.
 
 Profiles can then be visualized with the pprof tool: go tool pprof cpu.prof
 */
@@ -46,12 +64,14 @@ var (
 	flagSourceID    = flag.String("source-id", "", "The source-id to use.")
 	flagSourceLabel = flag.String("source-label", "", "The source-id to use.")
 	TOPICARN        = flag.String("topic", "", "The arn for log processor notifications")
-	QUEUEURL        = flag.String("queue", "", "The url of the input queue")
-	TIMEOUT         = flag.Int("timeout", 900, "timeout in sec")
-	FILE            = flag.String("file", "", "The file to process (assumed to be gzipped).")
+	FILE            = flag.String("file", "", "The file to process.")
 	LOGTYPE         = flag.String("logtype", "", "The logType.")
 	MEMORYSIZE      = flag.Int("lambdaSize", 1024, "The memory size of the lambda")
 
+	LOCAL         = flag.String("local", "", "Process locally writing output files and notifications to `dir`")
+	ENDPOINT      = flag.String("endpoint", "", "The endpoint of an S3 compatible service to write to (ie MinIO)")
+	NOTIFICATIONS = flag.String("notifications", "", "Write notifications as JSON files to `dir` instead of a topic")
+
 	VERBOSE = flag.Bool("verbose", false, "verbose logging")
 
 	CPUPROFILE = flag.String("cpuprofile", "", "write cpu profile to `file`")
@@ -61,34 +81,47 @@ var (
 func main() {
 	flag.Parse()
 
-	if *BUCKET == "" {
-		log.Fatal("-bucket not set")
+	inputs := flag.Args()
+	if *FILE != "" {
+		inputs = append([]string{*FILE}, inputs...)
 	}
-	if *TOPICARN == "" {
-		log.Fatal("-topic not set")
+	if len(inputs) == 0 {
+		log.Fatal("no input files")
 	}
-	if *QUEUEURL == "" {
-		log.Fatal("-queue not set")
+	if *LOCAL == "" {
+		if *BUCKET == "" {
+			log.Fatal("-bucket not set")
+		}
+		if *TOPICARN == "" && *NOTIFICATIONS == "" {
+			log.Fatal("-topic or -notifications not set")
+		}
 	}
 
-	os.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", strconv.Itoa(*MEMORYSIZE))
-	os.Setenv("S3_BUCKET", *BUCKET)
-	os.Setenv("SNS_TOPIC_ARN", *TOPICARN)
-	os.Setenv("SQS_QUEUE_URL", *QUEUEURL)
-	os.Setenv("TIME_LIMIT_SEC", strconv.Itoa(*TIMEOUT))
-
 	log.Printf("cores: %d", runtime.NumCPU())
-	log.Printf("input %s", *FILE)
+	log.Printf("input %s", strings.Join(inputs, " "))
 
-	fileReader, err := os.Open(*FILE)
+	files, err := logprocessor.ListFiles(inputs)
 	if err != nil {
 		log.Fatal(err)
 	}
-	gzipReader, err := gzip.NewReader(fileReader)
-	if err != nil {
-		log.Fatal(err)
+
+	var sess *session.Session
+	newSession := func() *session.Session {
+		if sess == nil {
+			sess = session.Must(session.NewSessionWithOptions(session.Options{
+				SharedConfigState: session.SharedConfigEnable,
+			}))
+		}
+		return sess
+	}
+
+	var s3Client s3iface.S3API
+	for _, file := range files {
+		if strings.HasPrefix(file, "s3://") {
+			s3Client = s3.New(newSession())
+			break
+		}
 	}
-	streamChan := make(chan *common.DataStream, 1)
 
 	var logTypes []string
 	if *LOGTYPE != "" {
@@ -98,15 +131,15 @@ func main() {
 		logTypes = registry.Default().LogTypes()
 	}
 
-	dataStream := &common.DataStream{
-		Reader:      gzipReader,
-		SourceID:    *flagSourceID,
-		SourceLabel: *flagSourceLabel,
-		LogTypes:    logTypes,
-	}
-
-	streamChan <- dataStream
-	close(streamChan)
+	streamChan := make(chan *common.DataStream)
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- logprocessor.SendDataStreams(streamChan, s3Client, files, common.DataStream{
+			SourceID:    *flagSourceID,
+			SourceLabel: *flagSourceLabel,
+			LogTypes:    logTypes,
+		})
+	}()
 
 	if *CPUPROFILE != "" {
 		f, err := os.Create(*CPUPROFILE)
@@ -134,13 +167,61 @@ func main() {
 	// Use a properly configured JSON instance with AWS Glue quirks
 	jsonAPI := common.BuildJSON()
 
+	var store destinations.ObjectStore
+	var notifier destinations.Notifier
+	switch {
+	case *LOCAL != "":
+		bucket := *BUCKET
+		if bucket == "" {
+			bucket = "local"
+		}
+		store = &destinations.FileObjectStore{
+			Dir:        filepath.Join(*LOCAL, "data"),
+			BucketName: bucket,
+		}
+		notifier = &destinations.FileNotifier{
+			Dir: filepath.Join(*LOCAL, "notifications"),
+		}
+	default:
+		s3Config := aws.NewConfig()
+		if *ENDPOINT != "" {
+			s3Config = s3Config.WithEndpoint(*ENDPOINT).WithS3ForcePathStyle(true)
+		}
+		store = &destinations.S3ObjectStore{
+			Uploader:   s3manager.NewUploaderWithClient(s3.New(newSession(), s3Config)),
+			BucketName: *BUCKET,
+		}
+		if *TOPICARN != "" {
+			notifier = &destinations.SNSNotifier{
+				Client:   sns.New(newSession()),
+				TopicARN: *TOPICARN,
+			}
+		} else {
+			notifier = &destinations.FileNotifier{
+				Dir: *NOTIFICATIONS,
+			}
+		}
+	}
+
 	// Use the global registry
-	dest := destinations.CreateS3Destination(registry.Default(), jsonAPI)
+	stats := &logprocessor.Stats{}
+	dest := destinations.NewS3Destination(store, stats.Notifier(notifier), registry.Default(), jsonAPI, *MEMORYSIZE)
 
-	err = processor.Process(streamChan, dest)
+	err = processor.Process(streamChan, stats.Destination(dest), nil)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if err := <-streamErr; err != nil {
+		log.Fatal(err)
+	}
+
+	summary, err := jsoniter.MarshalIndent(stats.Summary(), "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := os.Stdout.Write(append(summary, '\n')); err != nil {
+		log.Fatal(err)
+	}
 
 	if *MEMPROFILE != "" {
 		f, err := os.Create(*MEMPROFILE)
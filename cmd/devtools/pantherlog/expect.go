@@ -0,0 +1,131 @@
+package pantherlog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// Use a JSON API that sorts object keys for stable output
+var jsonAPI = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// IgnoredFields are not compared to the expected events because their values change on each run
+var IgnoredFields = []string{"p_row_id", "p_parse_time"}
+
+// Mismatch is a difference between an expected and an actual event
+type Mismatch struct {
+	// The number of the event in the output
+	Line   int
+	Expect map[string]interface{}
+	Actual map[string]interface{}
+}
+
+func (m *Mismatch) String() string {
+	switch {
+	case m.Expect == nil:
+		return fmt.Sprintf("event %d: unexpected event", m.Line)
+	case m.Actual == nil:
+		return fmt.Sprintf("event %d: missing event", m.Line)
+	}
+	var fields []string
+	for name, value := range m.Expect {
+		if !reflect.DeepEqual(value, m.Actual[name]) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range m.Actual {
+		if _, ok := m.Expect[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	var b strings.Builder
+	fmt.Fprintf(&b, "event %d: fields differ", m.Line)
+	for _, name := range fields {
+		expect, _ := jsonAPI.MarshalToString(m.Expect[name])
+		actual, _ := jsonAPI.MarshalToString(m.Actual[name])
+		fmt.Fprintf(&b, "\n  %s:\n    - %s\n    + %s", name, expect, actual)
+	}
+	return b.String()
+}
+
+// DiffEvents compares NDJSON events to the expected NDJSON events, ignoring the values of IgnoredFields.
+// Events are compared in order.
+func DiffEvents(expect, actual io.Reader) ([]Mismatch, error) {
+	expectEvents, err := readEvents(expect)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read expected events")
+	}
+	actualEvents, err := readEvents(actual)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read events")
+	}
+	var mismatches []Mismatch
+	for i := 0; i < len(expectEvents) || i < len(actualEvents); i++ {
+		m := Mismatch{Line: i + 1}
+		if i < len(expectEvents) {
+			m.Expect = expectEvents[i]
+		}
+		if i < len(actualEvents) {
+			m.Actual = actualEvents[i]
+		}
+		if m.Expect == nil || m.Actual == nil || !reflect.DeepEqual(m.Expect, m.Actual) {
+			mismatches = append(mismatches, m)
+		}
+	}
+	return mismatches, nil
+}
+
+func readEvents(r io.Reader) ([]map[string]interface{}, error) {
+	var events []map[string]interface{}
+	lines := bufio.NewScanner(r)
+	lines.Buffer(nil, maxLineSize)
+	numLines := 0
+	for lines.Scan() {
+		numLines++
+		line := bytes.TrimSpace(lines.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		event := map[string]interface{}{}
+		if err := jsonAPI.Unmarshal(line, &event); err != nil {
+			return nil, errors.Wrapf(err, "invalid JSON event at line %d", numLines)
+		}
+		for _, name := range IgnoredFields {
+			delete(event, name)
+		}
+		events = append(events, event)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// maxLineSize is the maximum size of a line in NDJSON input
+const maxLineSize = 10 * 1024 * 1024
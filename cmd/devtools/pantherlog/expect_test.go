@@ -0,0 +1,57 @@
+package pantherlog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEvents(t *testing.T) {
+	assert := require.New(t)
+	expect := `{"foo":"bar","p_row_id":"a","p_parse_time":"2020-01-01T00:00:00Z"}
+
+{"foo":"baz","n":1}
+`
+	actual := `{"foo":"bar","p_row_id":"b","p_parse_time":"2020-01-02T00:00:00Z"}
+{"foo":"baz","n":2,"extra":true}
+{"foo":"qux"}
+`
+	mismatches, err := DiffEvents(strings.NewReader(expect), strings.NewReader(actual))
+	assert.NoError(err)
+	assert.Len(mismatches, 2)
+	assert.Equal(2, mismatches[0].Line)
+	assert.Equal("event 2: fields differ\n  extra:\n    - null\n    + true\n  n:\n    - 1\n    + 2", mismatches[0].String())
+	assert.Equal(3, mismatches[1].Line)
+	assert.Equal("event 3: unexpected event", mismatches[1].String())
+
+	mismatches, err = DiffEvents(strings.NewReader(expect), strings.NewReader(`{"foo":"bar"}`))
+	assert.NoError(err)
+	assert.Len(mismatches, 1)
+	assert.Equal("event 2: missing event", mismatches[0].String())
+
+	mismatches, err = DiffEvents(strings.NewReader(expect), strings.NewReader(expect))
+	assert.NoError(err)
+	assert.Empty(mismatches)
+
+	_, err = DiffEvents(strings.NewReader(expect), strings.NewReader(`{"foo":`))
+	assert.Error(err)
+}
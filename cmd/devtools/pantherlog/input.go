@@ -0,0 +1,133 @@
+package pantherlog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+var (
+	magicGzip = []byte{0x1f, 0x8b}
+	magicZstd = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// OpenInput opens a file path or an S3 URL (s3://bucket/key) for reading.
+// The path `-` reads from stdin.
+// Compressed input (gzip, zstd) is detected and decompressed automatically.
+// Decompressing zstd input requires the `zstd` command to be installed.
+func OpenInput(s3Client s3iface.S3API, path string) (io.ReadCloser, error) {
+	var r io.ReadCloser
+	switch {
+	case path == "-":
+		r = os.Stdin
+	case strings.HasPrefix(path, "s3://"):
+		bucket, key, err := awsglue.ParseS3URL(path)
+		if err != nil {
+			return nil, err
+		}
+		if s3Client == nil {
+			return nil, errors.Errorf("cannot read %q without an S3 client", path)
+		}
+		output, err := s3Client.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get %q", path)
+		}
+		r = output.Body
+	default:
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		r = f
+	}
+	return Decompress(r)
+}
+
+// Decompress detects gzip or zstd compressed input and returns a reader for the uncompressed data.
+// Closing the returned reader closes r.
+func Decompress(r io.ReadCloser) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	// Peek returns an error if the input is shorter than the magic bytes, which means it is not compressed
+	magic, _ := br.Peek(len(magicZstd))
+	switch {
+	case bytes.HasPrefix(magic, magicGzip):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			_ = r.Close()
+			return nil, errors.Wrap(err, "failed to read gzip input")
+		}
+		return &readCloser{Reader: zr, close: r.Close}, nil
+	case bytes.HasPrefix(magic, magicZstd):
+		return decompressZstd(br, r)
+	default:
+		return &readCloser{Reader: br, close: r.Close}, nil
+	}
+}
+
+func decompressZstd(br io.Reader, r io.Closer) (io.ReadCloser, error) {
+	cmd := exec.Command("zstd", "-d", "-c")
+	cmd.Stdin = br
+	cmd.Stderr = os.Stderr
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		_ = r.Close()
+		return nil, errors.Wrap(err, "failed to run zstd, zstd input requires the zstd command")
+	}
+	return &readCloser{
+		Reader: out,
+		close: func() error {
+			// Close the pipes first so that zstd exits if it has not processed all input
+			_ = out.Close()
+			err := r.Close()
+			if waitErr := cmd.Wait(); err == nil {
+				err = waitErr
+			}
+			return err
+		},
+	}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (r *readCloser) Close() error {
+	return r.close()
+}
@@ -0,0 +1,81 @@
+package pantherlog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestOpenInput(t *testing.T) {
+	assert := require.New(t)
+	const data = "foo\nbar\n"
+	dir, err := ioutil.TempDir("", "pantherlog")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	plainFile := filepath.Join(dir, "plain.log")
+	assert.NoError(ioutil.WriteFile(plainFile, []byte(data), 0600))
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, err = zw.Write([]byte(data))
+	assert.NoError(err)
+	assert.NoError(zw.Close())
+	gzipFile := filepath.Join(dir, "compressed.log.gz")
+	assert.NoError(ioutil.WriteFile(gzipFile, gz.Bytes(), 0600))
+
+	for _, path := range []string{plainFile, gzipFile} {
+		r, err := OpenInput(nil, path)
+		assert.NoError(err, path)
+		actual, err := ioutil.ReadAll(r)
+		assert.NoError(err, path)
+		assert.Equal(data, string(actual), path)
+		assert.NoError(r.Close(), path)
+	}
+
+	s3Mock := &testutils.S3Mock{}
+	s3Mock.On("GetObject", &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("path/to/logs.gz"),
+	}).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(bytes.NewReader(gz.Bytes())),
+	}, nil).Once()
+	r, err := OpenInput(s3Mock, "s3://bucket/path/to/logs.gz")
+	assert.NoError(err)
+	actual, err := ioutil.ReadAll(r)
+	assert.NoError(err)
+	assert.Equal(data, string(actual))
+	assert.NoError(r.Close())
+	s3Mock.AssertExpectations(t)
+
+	_, err = OpenInput(nil, "s3://bucket/key")
+	assert.Error(err)
+	_, err = OpenInput(nil, filepath.Join(dir, "missing.log"))
+	assert.Error(err)
+}
@@ -19,21 +19,33 @@ package main
  */
 
 // This tool's purpose is to test parsers and classifier against sample log files locally at the CLI using pipes.
-// It reads logs from `stdin`, classifies each log line writing the resulting JSON to `stdout`.
+// It reads logs from `stdin` or the files passed as arguments, classifies each log line writing the resulting JSON to `stdout`.
+// Files can be local paths or S3 URLs and can be compressed with gzip or zstd.
 // When the `-debug` flag is passed it writes information about parsing to `stderr`
 // Example usage:
 // $ cat foo/bar/sample.log | pantherlog
 // $ cat foo/bar/sample.log bar/baz/sample.log | pantherlog
 // $ cat foo/bar/sample.log bar/baz/sample.log | pantherlog -debug
+// $ pantherlog -logtypes AWS.CloudTrail s3://bucket/AWSLogs/cloudtrail.json.gz
+// $ pantherlog -logtypes AWS.VPCFlow -expect vpcflow.golden.jsonl vpcflow.log
+// $ pantherlog -schema AWS.CloudTrail
 
 import (
 	"bufio"
+	"bytes"
 	"flag"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/cmd/devtools/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/classification"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
@@ -44,12 +56,32 @@ var (
 	debug       = flag.Bool("debug", false, "Log debug to stderr")
 	sourceID    = flag.String("source-id", "", "Set source id")
 	sourceLabel = flag.String("source-label", "", "Set source label")
+	logTypes    = flag.String("logtypes", "", "Comma separated list of log types to classify logs as (defaults to all log types)")
+	schema      = flag.String("schema", "", "Print the Glue DDL and JSON Schema of a log type and exit")
+	stats       = flag.Bool("stats", false, "Print classifier and parser stats to stderr when done")
+	expect      = flag.String("expect", "", "Compare events to the expected NDJSON events in `file` instead of printing them")
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run processes the inputs and returns the exit code.
+// It returns instead of exiting so that deferred flushes of stdout and stderr always run.
+func run() int {
 	flag.Parse()
 
-	stdin := os.Stdin
+	if *schema != "" {
+		entry := registry.Default().Get(*schema)
+		if entry == nil {
+			log.Fatalf("unknown log type %q", *schema)
+		}
+		if err := pantherlog.WriteSchema(os.Stdout, entry.GlueTableMeta(), ""); err != nil {
+			log.Fatal(err)
+		}
+		return 0
+	}
+
 	var stderr io.Writer
 	if *debug {
 		w := bufio.NewWriter(os.Stderr)
@@ -61,54 +93,160 @@ func main() {
 
 	debugLog := log.New(stderr, "[DEBUG] ", log.LstdFlags)
 
-	stdout := os.Stdout
-	out := bufio.NewWriter(stdout)
-	defer out.Flush()
+	var out *bufio.Writer
+	var expectOutput bytes.Buffer
+	if *expect != "" {
+		out = bufio.NewWriter(&expectOutput)
+	} else {
+		out = bufio.NewWriter(os.Stdout)
+		defer out.Flush()
+	}
 
 	jsonAPI := common.BuildJSON()
 
 	parsers := registry.AvailableParsers()
+	if *logTypes != "" {
+		selected := make(map[string]bool)
+		for _, logType := range strings.Split(*logTypes, ",") {
+			logType = strings.TrimSpace(logType)
+			if _, ok := parsers[logType]; !ok {
+				log.Fatalf("unknown log type %q", logType)
+			}
+			selected[logType] = true
+		}
+		for logType := range parsers {
+			if !selected[logType] {
+				delete(parsers, logType)
+			}
+		}
+	}
+
+	inputs := flag.Args()
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+	var s3Client s3iface.S3API
+	for _, input := range inputs {
+		if strings.HasPrefix(input, "s3://") {
+			sess := session.Must(session.NewSessionWithOptions(session.Options{
+				SharedConfigState: session.SharedConfigEnable,
+			}))
+			s3Client = s3.New(sess)
+			break
+		}
+	}
 
 	classifier := classification.NewClassifier(parsers)
-	lines := bufio.NewScanner(stdin)
 	numLines := 0
 	numEvents := 0
-	for lines.Scan() {
-		line := lines.Text()
-		if line == "" {
-			debugLog.Printf("Empty line %d\n", numLines)
-			continue
-		}
-		numLines++
-		result := classifier.Classify(line)
-		if result == nil {
-			debugLog.Printf("Failed to classify line %d\n", numLines)
-			os.Exit(1)
-			return
+	numUnclassified := 0
+	scanFailed := false
+	for _, input := range inputs {
+		r, err := pantherlog.OpenInput(s3Client, input)
+		if err != nil {
+			log.Fatal(err)
 		}
-		debugLog.Printf("Line=%d Type=%q NumEvents=%d\n", numLines, unbox.String(result.LogType), len(result.Events))
-		for _, event := range result.Events {
-			// Add source fields
-			event.PantherSourceID = *sourceID
-			event.PantherSourceLabel = *sourceLabel
-
-			data, err := jsonAPI.Marshal(event)
-			if err != nil {
-				log.Fatal(err)
+		debugLog.Printf("Reading %s\n", input)
+		lines := bufio.NewScanner(r)
+		for lines.Scan() {
+			line := lines.Text()
+			if line == "" {
+				debugLog.Printf("Empty line %d\n", numLines)
+				continue
 			}
-			if _, err := out.Write(data); err != nil {
-				log.Fatal(err)
+			numLines++
+			result := classifier.Classify(line)
+			if result.LogType == nil {
+				debugLog.Printf("Failed to classify line %d\n", numLines)
+				numUnclassified++
+				continue
 			}
-			if err := out.WriteByte('\n'); err != nil {
-				log.Fatal(err)
+			debugLog.Printf("Line=%d Type=%q NumEvents=%d\n", numLines, unbox.String(result.LogType), len(result.Events))
+			for _, event := range result.Events {
+				// Add source fields
+				event.PantherSourceID = *sourceID
+				event.PantherSourceLabel = *sourceLabel
+
+				data, err := jsonAPI.Marshal(event)
+				if err != nil {
+					log.Fatal(err)
+				}
+				if _, err := out.Write(data); err != nil {
+					log.Fatal(err)
+				}
+				if err := out.WriteByte('\n'); err != nil {
+					log.Fatal(err)
+				}
+				numEvents++
 			}
-			numEvents++
 		}
-	}
-	if err := lines.Err(); err != nil {
-		debugLog.Printf("Scan failed %s\n", err)
-		os.Exit(1)
+		if err := lines.Err(); err != nil {
+			debugLog.Printf("Scan failed %s\n", err)
+			scanFailed = true
+		}
+		if err := r.Close(); err != nil {
+			log.Fatal(err)
+		}
 	}
 	debugLog.Printf("Scanned %d lines\n", numLines)
 	debugLog.Printf("Parsed %d events\n", numEvents)
+	debugLog.Printf("Failed to classify %d lines\n", numUnclassified)
+
+	if *stats {
+		printStats(classifier)
+	}
+
+	exitCode := 0
+	if numUnclassified > 0 || scanFailed {
+		exitCode = 1
+	}
+	if *expect != "" {
+		if err := out.Flush(); err != nil {
+			log.Fatal(err)
+		}
+		if !checkExpect(*expect, &expectOutput) {
+			exitCode = 1
+		}
+	}
+	if numUnclassified > 0 {
+		log.Printf("failed to classify %d of %d lines", numUnclassified, numLines)
+	}
+	return exitCode
+}
+
+func printStats(classifier classification.ClassifierAPI) {
+	stats := struct {
+		Classifier *classification.ClassifierStats
+		Parsers    map[string]*classification.ParserStats
+	}{
+		Classifier: classifier.Stats(),
+		Parsers:    classifier.ParserStats(),
+	}
+	data, err := jsoniter.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := os.Stderr.Write(append(data, '\n')); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func checkExpect(expectFile string, output io.Reader) bool {
+	f, err := os.Open(expectFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	mismatches, err := pantherlog.DiffEvents(f, output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for i := range mismatches {
+		log.Println(mismatches[i].String())
+	}
+	if len(mismatches) > 0 {
+		log.Printf("%d events do not match %s", len(mismatches), expectFile)
+		return false
+	}
+	return true
 }
@@ -0,0 +1,246 @@
+package pantherlog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+// GlueDDL returns the Athena DDL statement creating the Glue table of a log type.
+// The table location is in bucket, if empty a placeholder is used.
+func GlueDDL(table *awsglue.GlueTableMetadata, bucket string) string {
+	if bucket == "" {
+		bucket = "<processed-data-bucket>"
+	}
+	columns, structFieldNames := awsglue.InferJSONColumns(table.EventStruct(), awsglue.GlueMappings...)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE EXTERNAL TABLE `%s`.`%s` (\n", table.DatabaseName(), table.TableName())
+	for i, col := range columns {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(&b, "  `%s` %s COMMENT '%s'", col.Name, col.Type, escapeSQL(col.Comment))
+	}
+	b.WriteString("\n)\n")
+	fmt.Fprintf(&b, "COMMENT '%s'\n", escapeSQL(table.Description()))
+
+	partitions := table.PartitionKeys()
+	b.WriteString("PARTITIONED BY (")
+	for i, p := range partitions {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "`%s` %s", p.Name, p.Type)
+	}
+	b.WriteString(")\n")
+
+	// Mappings are required because columns are case sensitive
+	mappings := make(map[string]string)
+	for _, col := range columns {
+		mappings[strings.ToLower(col.Name)] = col.Name
+	}
+	for _, name := range structFieldNames {
+		mappings[strings.ToLower(name)] = name
+	}
+	keys := make([]string, 0, len(mappings))
+	for key := range mappings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	b.WriteString("ROW FORMAT SERDE 'org.openx.data.jsonserde.JsonSerDe'\n")
+	b.WriteString("WITH SERDEPROPERTIES (\n  'serialization.format' = '1',\n  'case.insensitive' = 'false'")
+	for _, key := range keys {
+		fmt.Fprintf(&b, ",\n  'mapping.%s' = '%s'", key, escapeSQL(mappings[key]))
+	}
+	b.WriteString("\n)\n")
+	b.WriteString("STORED AS INPUTFORMAT 'org.apache.hadoop.mapred.TextInputFormat'\n")
+	b.WriteString("OUTPUTFORMAT 'org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat'\n")
+	fmt.Fprintf(&b, "LOCATION 's3://%s/%s';\n", bucket, table.Prefix())
+	return b.String()
+}
+
+func escapeSQL(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
+
+// JSONSchema returns a JSON Schema describing the events stored in the Glue table of a log type.
+func JSONSchema(table *awsglue.GlueTableMetadata) (map[string]interface{}, error) {
+	columns, _ := awsglue.InferJSONColumns(table.EventStruct(), awsglue.GlueMappings...)
+	properties := make(map[string]interface{}, len(columns))
+	var required []string
+	for _, col := range columns {
+		prop, err := jsonSchemaType(col.Type)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid type of column %q", col.Name)
+		}
+		if col.Comment != "" {
+			prop["description"] = col.Comment
+		}
+		properties[col.Name] = prop
+		if col.Required {
+			required = append(required, col.Name)
+		}
+	}
+	schema := map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       table.LogType(),
+		"description": table.Description(),
+		"type":        "object",
+		"properties":  properties,
+	}
+	if required != nil {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// jsonSchemaType converts a Glue type (ie `array<struct<foo:string>>`) to a JSON Schema type
+func jsonSchemaType(glueType string) (map[string]interface{}, error) {
+	p := glueTypeParser{input: glueType}
+	typ, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if p.input != "" {
+		return nil, errors.Errorf("unexpected %q in Glue type %q", p.input, glueType)
+	}
+	return typ, nil
+}
+
+type glueTypeParser struct {
+	input string
+}
+
+func (p *glueTypeParser) parse() (map[string]interface{}, error) {
+	name := p.ident()
+	switch name {
+	case "string", "char", "varchar":
+		return map[string]interface{}{"type": "string"}, nil
+	case "timestamp":
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	case "date":
+		return map[string]interface{}{"type": "string", "format": "date"}, nil
+	case "bigint", "int", "smallint", "tinyint":
+		return map[string]interface{}{"type": "integer"}, nil
+	case "double", "float", "decimal":
+		return map[string]interface{}{"type": "number"}, nil
+	case "boolean":
+		return map[string]interface{}{"type": "boolean"}, nil
+	case "array":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		items, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case "map":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		if _, err := p.parse(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+		values, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case "struct":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		properties := make(map[string]interface{})
+		for {
+			field := p.ident()
+			if field == "" {
+				return nil, errors.Errorf("missing struct field name at %q", p.input)
+			}
+			if err := p.expect(':'); err != nil {
+				return nil, err
+			}
+			typ, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			properties[field] = typ
+			if p.expect(',') != nil {
+				break
+			}
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}, nil
+	default:
+		return nil, errors.Errorf("unknown Glue type %q", name)
+	}
+}
+
+func (p *glueTypeParser) ident() string {
+	n := strings.IndexAny(p.input, "<>,:")
+	if n == -1 {
+		n = len(p.input)
+	}
+	name := p.input[:n]
+	p.input = p.input[n:]
+	return strings.TrimSpace(name)
+}
+
+func (p *glueTypeParser) expect(c byte) error {
+	if p.input == "" || p.input[0] != c {
+		return errors.Errorf("expected %q at %q", c, p.input)
+	}
+	p.input = p.input[1:]
+	return nil
+}
+
+// WriteSchema writes the Glue DDL and the JSON Schema of a log type table
+func WriteSchema(w io.Writer, table *awsglue.GlueTableMetadata, bucket string) error {
+	schema, err := JSONSchema(table)
+	if err != nil {
+		return err
+	}
+	data, err := jsonAPI.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "-- Glue DDL\n%s\n-- JSON Schema\n%s\n", GlueDDL(table, bucket), data); err != nil {
+		return err
+	}
+	return nil
+}
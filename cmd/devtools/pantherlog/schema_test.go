@@ -0,0 +1,124 @@
+package pantherlog
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+type testEvent struct {
+	Time    time.Time         `json:"time" validate:"required" description:"The event's time"`
+	Message string            `json:"message" description:"The message"`
+	Tags    map[string]string `json:"tags,omitempty" description:"The tags"`
+	Remote  struct {
+		Addr string `json:"addr"`
+		Port int64  `json:"port"`
+	} `json:"remote" description:"The remote host"`
+}
+
+func testTable() *awsglue.GlueTableMetadata {
+	return awsglue.NewGlueTableMetadata(models.LogData, "Test.Log", "Test logs", awsglue.GlueTableHourly, testEvent{})
+}
+
+func TestGlueDDL(t *testing.T) {
+	expect := "CREATE EXTERNAL TABLE `panther_logs`.`test_log` (\n" +
+		"  `time` timestamp COMMENT 'The event''s time',\n" +
+		"  `message` string COMMENT 'The message',\n" +
+		"  `tags` map<string,string> COMMENT 'The tags',\n" +
+		"  `remote` struct<addr:string,port:bigint> COMMENT 'The remote host'\n" +
+		")\n" +
+		"COMMENT 'Test logs'\n" +
+		"PARTITIONED BY (`year` int, `month` int, `day` int, `hour` int)\n" +
+		"ROW FORMAT SERDE 'org.openx.data.jsonserde.JsonSerDe'\n" +
+		"WITH SERDEPROPERTIES (\n" +
+		"  'serialization.format' = '1',\n" +
+		"  'case.insensitive' = 'false',\n" +
+		"  'mapping.addr' = 'addr',\n" +
+		"  'mapping.message' = 'message',\n" +
+		"  'mapping.port' = 'port',\n" +
+		"  'mapping.remote' = 'remote',\n" +
+		"  'mapping.tags' = 'tags',\n" +
+		"  'mapping.time' = 'time'\n" +
+		")\n" +
+		"STORED AS INPUTFORMAT 'org.apache.hadoop.mapred.TextInputFormat'\n" +
+		"OUTPUTFORMAT 'org.apache.hadoop.hive.ql.io.HiveIgnoreKeyTextOutputFormat'\n" +
+		"LOCATION 's3://bucket/logs/test_log/';\n"
+	require.Equal(t, expect, GlueDDL(testTable(), "bucket"))
+}
+
+func TestJSONSchema(t *testing.T) {
+	assert := require.New(t)
+	schema, err := JSONSchema(testTable())
+	assert.NoError(err)
+	actual, err := jsonAPI.Marshal(schema)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title": "Test.Log",
+		"description": "Test logs",
+		"type": "object",
+		"required": ["time"],
+		"properties": {
+			"time": {"type": "string", "format": "date-time", "description": "The event's time"},
+			"message": {"type": "string", "description": "The message"},
+			"tags": {"type": "object", "additionalProperties": {"type": "string"}, "description": "The tags"},
+			"remote": {
+				"type": "object",
+				"properties": {
+					"addr": {"type": "string"},
+					"port": {"type": "integer"}
+				},
+				"description": "The remote host"
+			}
+		}
+	}`, string(actual))
+
+	var buf bytes.Buffer
+	assert.NoError(WriteSchema(&buf, testTable(), ""))
+	assert.Contains(buf.String(), "LOCATION 's3://<processed-data-bucket>/logs/test_log/';")
+	assert.Contains(buf.String(), `"title": "Test.Log"`)
+}
+
+func TestJSONSchemaType(t *testing.T) {
+	assert := require.New(t)
+	typ, err := jsonSchemaType("array<struct<a:array<bigint>,b:map<string,boolean>>>")
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"a": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}},
+				"b": map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "boolean"}},
+			},
+		},
+	}, typ)
+
+	for _, invalid := range []string{"array<string", "struct<>", "foo", "string>", "map<string>"} {
+		_, err := jsonSchemaType(invalid)
+		assert.Error(err, invalid)
+	}
+}
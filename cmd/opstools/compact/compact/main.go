@@ -0,0 +1,234 @@
+package main
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/panther-labs/panther/internal/log_analysis/compactor"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/awscfn"
+	"github.com/panther-labs/panther/pkg/prompt"
+	"github.com/panther-labs/panther/tools/cfnstacks"
+)
+
+const (
+	banner = "merges small objects in closed partitions of log tables"
+
+	dateFormat = "2006-01-02"
+)
+
+var (
+	REGION = flag.String("region", "",
+		"The Panther AWS region (optional, defaults to session env vars) where the data bucket exists.")
+	REGEXP = flag.String("regexp", "",
+		"Regular expression used to filter the set of log types compacted, defaults to all log types (no regexp)")
+	START = flag.String("start", "",
+		"Start date of the form YYYY-MM-DD")
+	END = flag.String("end", "",
+		"End date of the form YYYY-MM-DD (inclusive), defaults to the last closed partition")
+	SMALLMB = flag.Int("small.mb", compactor.DefaultSmallObjectSizeBytes/(1024*1024),
+		"Objects smaller than this size in MB are merged")
+	MAXMB = flag.Int("max.mb", compactor.DefaultMaxObjectSizeBytes/(1024*1024),
+		"The largest compressed size in MB of a merged object")
+	DRYRUN = flag.Bool("dryrun", false,
+		"If true, report the objects that would be merged without changing anything")
+	INTERACTIVE = flag.Bool("interactive", true,
+		"If true, prompt for required flags if not set")
+	VERBOSE = flag.Bool("verbose", false,
+		"Enable verbose logging")
+
+	logger *zap.SugaredLogger
+
+	startDate    time.Time
+	endDate      time.Time
+	matchLogType *regexp.Regexp
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(),
+		"%s %s\nUsage:\n",
+		filepath.Base(os.Args[0]), banner)
+	flag.PrintDefaults()
+	fmt.Fprintf(flag.CommandLine.Output(),
+		"NOTE: do not run concurrently with another compaction of the same log types, events could be duplicated\n")
+}
+
+func init() {
+	flag.Usage = usage
+}
+
+func logInit() {
+	config := zap.NewDevelopmentConfig() // DEBUG by default
+	if !*VERBOSE {
+		// In normal mode, hide DEBUG messages
+		config.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	}
+
+	// Always disable and file/line numbers, error traces and use color-coded log levels and short timestamps
+	config.DisableCaller = true
+	config.DisableStacktrace = true
+	config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+
+	rawLogger, err := config.Build()
+	if err != nil {
+		log.Fatalf("failed to build logger: %s", err)
+	}
+	zap.ReplaceGlobals(rawLogger)
+	logger = rawLogger.Sugar()
+}
+
+func main() {
+	flag.Parse()
+
+	logInit() // must be done after parsing flags
+
+	sess, err := session.NewSession()
+	if err != nil {
+		logger.Fatal(err)
+		return
+	}
+
+	if *REGION != "" { //override
+		sess.Config.Region = REGION
+	} else {
+		REGION = sess.Config.Region
+	}
+
+	promptFlags()
+	validateFlags()
+
+	// find the bucket holding the processed data
+	const processDataBucketStack = cfnstacks.Bootstrap
+	outputs := awscfn.StackOutputs(cloudformation.New(sess), logger, processDataBucketStack)
+	var dataBucket string
+	if dataBucket = outputs["ProcessedDataBucket"]; dataBucket == "" {
+		logger.Fatalf("could not find processed data bucket in %s outputs", processDataBucketStack)
+	}
+
+	logCompactor := compactor.New(s3.New(sess), s3manager.NewUploader(sess), glue.New(sess), dataBucket)
+	logCompactor.SmallObjectSizeBytes = int64(*SMALLMB) * 1024 * 1024
+	logCompactor.MaxObjectSizeBytes = int64(*MAXMB) * 1024 * 1024
+	logCompactor.DryRun = *DRYRUN
+
+	for _, table := range registry.AvailableTables() {
+		if !matchLogType.MatchString(table.LogType()) {
+			continue
+		}
+		if *VERBOSE {
+			logger.Infof("compacting %s.%s from %s to %s", table.DatabaseName(), table.TableName(),
+				startDate.Format(dateFormat), endDate.Format(dateFormat))
+		}
+		results, _, err := logCompactor.CompactPartitions(table, startDate, endDate, nil)
+		for _, result := range results {
+			report(result)
+		}
+		if err != nil {
+			logger.Fatalf("failed compacting %s.%s: %v", table.DatabaseName(), table.TableName(), err)
+		}
+	}
+}
+
+func report(result *compactor.PartitionResult) {
+	name := fmt.Sprintf("%s.%s", result.DatabaseName, result.TableName)
+	if result.DryRun {
+		logger.Infof("%s: would merge %d objects in %s (%d objects -> %d objects)",
+			name, len(result.MergedObjects), result.Prefix, result.ObjectsBefore, result.ObjectsAfter)
+		return
+	}
+	logger.Infof("%s: merged %d objects with %d events in %s (%d objects -> %d objects)",
+		name, len(result.MergedObjects), result.Events, result.Prefix, result.ObjectsBefore, result.ObjectsAfter)
+}
+
+func promptFlags() {
+	if !*INTERACTIVE {
+		return
+	}
+
+	if *START == "" {
+		*START = prompt.Read("Enter a day as YYYY-MM-DD to start compaction: ", prompt.DateValidator)
+	}
+
+	if *REGEXP == "" {
+		*REGEXP = prompt.Read("Enter regex to select a subset of log types (or <enter> for all tables): ",
+			prompt.RegexValidator)
+	}
+}
+
+func validateFlags() {
+	var err error
+	defer func() {
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			flag.Usage()
+			os.Exit(-2)
+		}
+	}()
+
+	if *START == "" {
+		err = errors.New("-start not set")
+		return
+	}
+	startDate, err = time.Parse(dateFormat, *START)
+	if err != nil {
+		err = errors.Wrapf(err, "cannot read -start")
+		return
+	}
+
+	if *END == "" {
+		endDate = time.Now().UTC() // partitions that are not closed are skipped
+	} else {
+		endDate, err = time.Parse(dateFormat, *END)
+		if err != nil {
+			err = errors.Wrapf(err, "cannot read -end")
+			return
+		}
+		endDate = endDate.Add(24*time.Hour - time.Nanosecond) // include the whole day
+	}
+	if endDate.Before(startDate) {
+		err = errors.New("-end must be >= -start")
+		return
+	}
+
+	if *SMALLMB <= 0 || *MAXMB < *SMALLMB {
+		err = errors.New("-small.mb must be > 0 and <= -max.mb")
+		return
+	}
+
+	matchLogType, err = regexp.Compile(*REGEXP)
+	if err != nil {
+		err = errors.Wrapf(err, "cannot read -regexp")
+		return
+	}
+}
@@ -19,6 +19,7 @@ package main
  */
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
@@ -43,6 +44,7 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/athenaviews"
 	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
 	"github.com/panther-labs/panther/internal/log_analysis/gluetables"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/awscfn"
 	"github.com/panther-labs/panther/pkg/genericapi"
 	"github.com/panther-labs/panther/pkg/prompt"
@@ -53,6 +55,12 @@ const (
 	banner = "updates glue table and partition schemas"
 
 	dateFormat = "2006-01-02"
+
+	breakingArchive = "archive"
+	breakingRefuse  = "refuse"
+
+	formatText = "text"
+	formatJSON = "json"
 )
 
 var (
@@ -62,6 +70,13 @@ var (
 		"Regular expression used to filter the set of log types updated, defaults to all log types (no regexp)")
 	START = flag.String("start", "",
 		"Start date of the form YYYY-MM-DD, if not set use the create date of the table")
+	BREAKING = flag.String("breaking", breakingRefuse,
+		"How to handle breaking schema changes: '"+breakingArchive+"' moves the deployed partitions to a versioned table before updating it, '"+
+			breakingRefuse+"' leaves the table untouched")
+	DRYRUN = flag.Bool("dry-run", false,
+		"If true, report the drift of the deployed tables and partitions from the schemas in code without updating anything")
+	FORMAT = flag.String("format", formatText,
+		"Format of the -dry-run report: '"+formatText+"' or '"+formatJSON+"'")
 	INTERACTIVE = flag.Bool("interactive", true,
 		"If true, prompt for required flags if not set")
 	VERBOSE = flag.Bool("verbose", true,
@@ -71,6 +86,7 @@ var (
 
 	startDate    time.Time
 	matchLogType *regexp.Regexp
+	schemaPolicy awsglue.SchemaUpdatePolicy
 
 	version string // we expect this to be set by the build tool as `-X main.version=<some version>`
 
@@ -139,6 +155,11 @@ func main() {
 	promptFlags()
 	validateFlags()
 
+	if *DRYRUN {
+		reportDrift()
+		return
+	}
+
 	// for each registered table, update the table, for each time partition, update the schema
 	for _, table := range updateRegisteredTables() {
 		name := fmt.Sprintf("%s.%s", table.DatabaseName(), table.TableName())
@@ -205,6 +226,21 @@ func validateFlags() {
 		err = errors.Wrapf(err, "cannot read -regexp")
 		return
 	}
+
+	if *FORMAT != formatText && *FORMAT != formatJSON {
+		err = errors.Errorf("-format must be one of %s, %s", formatText, formatJSON)
+		return
+	}
+
+	switch *BREAKING {
+	case breakingArchive:
+		schemaPolicy = awsglue.ArchiveBreakingChanges
+	case breakingRefuse:
+		schemaPolicy = awsglue.RefuseBreakingChanges
+	default:
+		err = errors.Errorf("-breaking must be one of %s, %s", breakingArchive, breakingRefuse)
+		return
+	}
 }
 
 func updateRegisteredTables() (tables []*awsglue.GlueTableMetadata) {
@@ -258,8 +294,14 @@ func updateRegisteredTables() (tables []*awsglue.GlueTableMetadata) {
 		if *VERBOSE {
 			logger.Infof("updating registered tables for %s", logType)
 		}
-		logTable, ruleTable, err := gluetables.CreateOrUpdateGlueTablesForLogType(glueClient, logType, dataBucket)
+		logTable := registry.Lookup(logType).GlueTableMeta()
+		ruleTable, diffs, err := gluetables.UpdateGlueTables(glueClient, dataBucket, logTable, schemaPolicy)
+		reportSchemaChanges(diffs)
 		if err != nil {
+			if _, refused := errors.Cause(err).(*awsglue.SchemaChangeError); refused {
+				logger.Warnf("skipping %s: %v", logType, err)
+				continue
+			}
 			logger.Fatalf("error updating table definitions: %v", err)
 		}
 		tables = append(tables, logTable)
@@ -273,3 +315,48 @@ func updateRegisteredTables() (tables []*awsglue.GlueTableMetadata) {
 
 	return tables
 }
+
+// reportDrift writes the differences between the deployed tables and the code to stdout.
+// It does not check the deployed Panther version because the point is to see the impact of an upgrade.
+func reportDrift() {
+	deployedLogTables, err := gluetables.DeployedLogTables(glueClient)
+	if err != nil {
+		logger.Fatalf("error finding deployed tables: %v", err)
+	}
+	var logTables []*awsglue.GlueTableMetadata
+	for _, logTable := range deployedLogTables {
+		if matchLogType.MatchString(logTable.LogType()) {
+			logTables = append(logTables, logTable)
+		}
+	}
+	if *VERBOSE {
+		logger.Infof("checking %d deployed log types for drift", len(logTables))
+	}
+	report, err := gluetables.DetectDrift(glueClient, s3Client, logTables, startDate, time.Now())
+	if err != nil {
+		logger.Fatalf("error detecting drift: %v", err)
+	}
+	if *FORMAT == formatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	} else {
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		logger.Fatalf("error writing report: %v", err)
+	}
+}
+
+func reportSchemaChanges(diffs []*awsglue.SchemaDiff) {
+	for _, diff := range diffs {
+		switch {
+		case diff.IsBreaking():
+			logger.Warnf("breaking schema changes: %s", diff)
+		case diff.HasChanges():
+			logger.Infof("additive schema changes: %s", diff)
+		case *VERBOSE:
+			logger.Debugf("%s", diff)
+		}
+	}
+}
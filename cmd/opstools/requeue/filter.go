@@ -0,0 +1,112 @@
+package requeue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// Filter selects messages by their body
+type Filter struct {
+	// Path is a JSON path into the message body (ie `Records.0.s3.bucket.name`).
+	// String values that hold JSON (ie the `Message` of SNS notifications) are decoded when the path continues into them.
+	Path []string
+	// Pattern is matched against the value at Path or the whole body if Path is empty.
+	// If nil, a message matches if the value at Path exists.
+	Pattern *regexp.Regexp
+}
+
+// NewFilter builds a filter from a dot separated JSON path and a regular expression, either can be empty.
+// It returns nil if both are empty.
+func NewFilter(path, pattern string) (*Filter, error) {
+	if path == "" && pattern == "" {
+		return nil, nil
+	}
+	filter := &Filter{}
+	if path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), "."); path != "" {
+		filter.Path = strings.Split(path, ".")
+	}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+		}
+		filter.Pattern = re
+	}
+	return filter, nil
+}
+
+// Match checks if a message body passes the filter
+func (f *Filter) Match(body string) bool {
+	if f == nil {
+		return true
+	}
+	value := body
+	if len(f.Path) > 0 {
+		var ok bool
+		if value, ok = lookupPath(body, f.Path); !ok {
+			return false
+		}
+	}
+	if f.Pattern == nil {
+		return true
+	}
+	return f.Pattern.MatchString(value)
+}
+
+// lookupPath finds the value at path in a JSON document.
+// String values are returned as is, other values are returned as JSON.
+func lookupPath(doc string, path []string) (string, bool) {
+	var value interface{} = doc
+	for _, key := range path {
+		// descend into JSON encoded strings, the body itself is one
+		if s, ok := value.(string); ok {
+			var decoded interface{}
+			if err := jsoniter.UnmarshalFromString(s, &decoded); err != nil {
+				return "", false
+			}
+			value = decoded
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[key]; !ok {
+				return "", false
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return "", false
+			}
+			value = v[index]
+		default:
+			return "", false
+		}
+	}
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+	data, err := jsoniter.MarshalToString(value)
+	return data, err == nil
+}
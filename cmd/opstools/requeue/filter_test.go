@@ -0,0 +1,67 @@
+package requeue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	assert := require.New(t)
+	// An SNS notification wrapping an S3 event as sent to the log processor queue
+	body := `{"Type":"Notification","TopicArn":"arn:aws:sns:us-east-1:123456789012:topic",` +
+		`"Message":"{\"Records\":[{\"s3\":{\"bucket\":{\"name\":\"foo\"},\"object\":{\"key\":\"bar/baz.gz\",\"size\":42}}}]}"}`
+
+	for _, tc := range []struct {
+		Path    string
+		Pattern string
+		Match   bool
+	}{
+		{"", "foo", true},
+		{"", "^qux", false},
+		{"Type", "", true},
+		{"$.Type", "^Notification$", true},
+		{"Subject", "", false},
+		{"Message.Records.0.s3.bucket.name", "^foo$", true},
+		{"Message.Records.0.s3.object.key", `\.json$`, false},
+		{"Message.Records.0.s3.object.size", "^42$", true},
+		{"Message.Records.0.s3.bucket", `"name":"foo"`, true},
+		{"Message.Records.1.s3", "", false},
+		{"Message.Records.x", "", false},
+		{"TopicArn.foo", "", false},
+	} {
+		filter, err := NewFilter(tc.Path, tc.Pattern)
+		assert.NoError(err)
+		assert.Equal(tc.Match, filter.Match(body), "%s %s", tc.Path, tc.Pattern)
+	}
+
+	filter, err := NewFilter("", "")
+	assert.NoError(err)
+	assert.Nil(filter)
+	assert.True(filter.Match("anything"))
+
+	filter, err = NewFilter("Type", "")
+	assert.NoError(err)
+	assert.False(filter.Match("not json"))
+
+	_, err = NewFilter("", "(")
+	assert.Error(err)
+}
@@ -19,90 +19,403 @@ package requeue
  */
 
 import (
+	"bufio"
+	"io"
+	"math/rand"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 )
 
 const (
 	waitTimeSeconds          = 20
 	messageBatchSize         = 10
 	visibilityTimeoutSeconds = 2 * waitTimeSeconds
+	sendTimeout              = time.Minute
+
+	// DefaultScanVisibilityTimeout is how long messages are hidden while scanning a queue without removing them.
+	// It should be long enough for the whole scan, otherwise the scan stops early when messages become visible again.
+	DefaultScanVisibilityTimeout = 300
+
+	maxMessageLineSize = 1024 * 1024 // SQS messages are at most 256KB, escaping and attributes can add to that
 )
 
+// Message is a queue message as written by list, sample and export and read by replay
+type Message struct {
+	MessageID string `json:"messageId,omitempty"`
+	Body      string `json:"body"`
+	// Attributes are the system attributes of the message (ie ApproximateReceiveCount, SentTimestamp)
+	Attributes        map[string]*string                    `json:"attributes,omitempty"`
+	MessageAttributes map[string]*sqs.MessageAttributeValue `json:"messageAttributes,omitempty"`
+}
+
+func newMessage(msg *sqs.Message) *Message {
+	return &Message{
+		MessageID:         aws.StringValue(msg.MessageId),
+		Body:              aws.StringValue(msg.Body),
+		Attributes:        msg.Attributes,
+		MessageAttributes: msg.MessageAttributes,
+	}
+}
+
+// sendEntry builds the entry to send a message to another queue, preserving message attributes and FIFO fields
+func (m *Message) sendEntry(id int) *sqs.SendMessageBatchRequestEntry {
+	entry := &sqs.SendMessageBatchRequestEntry{
+		Id:                aws.String(strconv.Itoa(id)),
+		MessageBody:       aws.String(m.Body),
+		MessageAttributes: m.MessageAttributes,
+	}
+	if groupID := m.Attributes[sqs.MessageSystemAttributeNameMessageGroupId]; groupID != nil {
+		entry.MessageGroupId = groupID
+		entry.MessageDeduplicationId = m.Attributes[sqs.MessageSystemAttributeNameMessageDeduplicationId]
+	}
+	return entry
+}
+
+// Input configures which messages are processed and what is done with them
+type Input struct {
+	FromQueue string
+	ToQueue   string // required if Move is set
+	// Only process messages matching the filter, if nil all messages match
+	Filter *Filter
+	// Send matched messages to ToQueue and delete them from FromQueue
+	Move bool
+	// Delete matched messages from FromQueue
+	Drop bool
+	// If set, matched messages are written to Output as JSON lines
+	Output io.Writer
+	// If non-zero, a random sample of this many matched messages is written to Output instead of all of them
+	Sample int
+	// If non-zero, stop after this many matched messages
+	Limit int
+	// How long to hide received messages, defaults to DefaultScanVisibilityTimeout
+	VisibilityTimeout int64
+}
+
+func (input *Input) validate() error {
+	switch {
+	case input.Move && input.Drop:
+		return errors.New("cannot both move and drop messages")
+	case input.Move && input.ToQueue == "":
+		return errors.New("no queue to move messages to")
+	case input.Sample > 0 && (input.Move || input.Drop):
+		return errors.New("cannot move or drop a sample of messages")
+	case input.Sample > 0 && input.Output == nil:
+		return errors.New("no output for sample")
+	}
+	return nil
+}
+
+type Stats struct {
+	Received int // number of distinct messages received
+	Matched  int
+	Moved    int
+	Dropped  int
+	// number of matched messages that could not be deleted from the queue after moving or dropping them
+	NotDeleted int
+}
+
+// Requeue moves all messages from one queue to another
 func Requeue(sqsClient sqsiface.SQSAPI, region, fromQueueName, toQueueName string) error {
-	fromQueueURL, err := sqsClient.GetQueueUrl(&sqs.GetQueueUrlInput{
-		QueueName: &fromQueueName,
-	})
+	stats := &Stats{}
+	err := Process(sqsClient, region, &Input{
+		FromQueue:         fromQueueName,
+		ToQueue:           toQueueName,
+		Move:              true,
+		VisibilityTimeout: visibilityTimeoutSeconds,
+	}, stats)
 	if err != nil {
-		return errors.Wrapf(err, "cannot find source queue %s in region %s", fromQueueName, region)
+		return err
 	}
+	zap.S().Debugf("Successfully requeued %d messages.", stats.Moved)
+	return nil
+}
 
-	toQueueURL, err := sqsClient.GetQueueUrl(&sqs.GetQueueUrlInput{
-		QueueName: &toQueueName,
+// Process scans the messages in a queue and handles the ones matching the filter.
+// Messages that are not removed from the queue are made visible again once the scan is done.
+func Process(sqsClient sqsiface.SQSAPI, region string, input *Input, stats *Stats) (err error) {
+	if err := input.validate(); err != nil {
+		return err
+	}
+	visibilityTimeout := input.VisibilityTimeout
+	if visibilityTimeout <= 0 {
+		visibilityTimeout = DefaultScanVisibilityTimeout
+	}
+
+	fromQueueURL, err := sqsClient.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: &input.FromQueue,
 	})
 	if err != nil {
-		return errors.Wrapf(err, "cannot find destination queue %s in region %s", toQueueName, region)
+		return errors.Wrapf(err, "cannot find source queue %s in region %s", input.FromQueue, region)
 	}
-
-	zap.S().Debugf("Moving messages from %s to %s", fromQueueName, toQueueName)
-	totalMessages := 0
-	for {
-		resp, err := sqsClient.ReceiveMessage(&sqs.ReceiveMessageInput{
-			WaitTimeSeconds:     aws.Int64(waitTimeSeconds),
-			MaxNumberOfMessages: aws.Int64(messageBatchSize),
-			VisibilityTimeout:   aws.Int64(visibilityTimeoutSeconds),
-			QueueUrl:            fromQueueURL.QueueUrl,
+	toQueueURL := &sqs.GetQueueUrlOutput{}
+	if input.Move {
+		toQueueURL, err = sqsClient.GetQueueUrl(&sqs.GetQueueUrlInput{
+			QueueName: &input.ToQueue,
 		})
-
 		if err != nil {
-			return errors.Wrapf(err, "failure receiving messages to move from %s", fromQueueName)
+			return errors.Wrapf(err, "cannot find destination queue %s in region %s", input.ToQueue, region)
 		}
+	}
 
-		messages := resp.Messages
-		numberOfMessages := len(messages)
-		totalMessages += numberOfMessages
-		if numberOfMessages == 0 {
-			zap.S().Debugf("Successfully requeued %d messages.", totalMessages)
-			return nil
+	p := &processor{
+		sqsClient:    sqsClient,
+		input:        input,
+		stats:        stats,
+		fromQueueURL: aws.StringValue(fromQueueURL.QueueUrl),
+		toQueueURL:   aws.StringValue(toQueueURL.QueueUrl),
+		receipts:     make(map[string]*string),
+		rand:         rand.New(rand.NewSource(time.Now().UnixNano())), // nolint: gosec
+	}
+	defer func() {
+		if releaseErr := p.release(); releaseErr != nil && err == nil {
+			err = releaseErr
 		}
+	}()
 
-		zap.S().Debugf("Moving %d message(s)...", numberOfMessages)
+	zap.S().Debugf("Scanning messages in %s", input.FromQueue)
+	for {
+		messages, err := sqsbatch.ReceiveMessageWithAttributes(sqsClient, p.fromQueueURL, waitTimeSeconds, visibilityTimeout)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		matched, numNew := p.filter(messages)
+		if numNew == 0 {
+			// All messages were received before, the visibility timeout expired and we went through the whole queue
+			break
+		}
+		if err := p.handle(matched); err != nil {
+			return err
+		}
+		if input.Limit > 0 && stats.Matched >= input.Limit {
+			break
+		}
+	}
+	return p.writeSample()
+}
+
+type processor struct {
+	sqsClient    sqsiface.SQSAPI
+	input        *Input
+	stats        *Stats
+	fromQueueURL string
+	toQueueURL   string
+	// receipts of messages to make visible again when done, by message id
+	receipts map[string]*string
+	sample   []*Message
+	rand     *rand.Rand
+}
+
+// filter returns the new messages matching the filter and the number of new messages
+func (p *processor) filter(messages []*sqs.Message) (matched []*sqs.Message, numNew int) {
+	for _, msg := range messages {
+		id := aws.StringValue(msg.MessageId)
+		_, seen := p.receipts[id]
+		// keep the receipt of the latest receive, previous ones are no longer valid
+		p.receipts[id] = msg.ReceiptHandle
+		if seen {
+			continue
+		}
+		numNew++
+		p.stats.Received++
+		if p.input.Limit > 0 && p.stats.Matched >= p.input.Limit {
+			continue
+		}
+		if !p.input.Filter.Match(aws.StringValue(msg.Body)) {
+			continue
+		}
+		p.stats.Matched++
+		matched = append(matched, msg)
+	}
+	return matched, numNew
+}
 
-		var sendMessageBatchRequestEntries []*sqs.SendMessageBatchRequestEntry
-		for index, element := range messages {
-			sendMessageBatchRequestEntries = append(sendMessageBatchRequestEntries, &sqs.SendMessageBatchRequestEntry{
-				Id:          aws.String(strconv.Itoa(index)),
-				MessageBody: element.Body,
-			})
+func (p *processor) handle(matched []*sqs.Message) error {
+	if len(matched) == 0 {
+		return nil
+	}
+	switch {
+	case p.input.Sample > 0:
+		p.addSample(matched)
+	case p.input.Output != nil:
+		if err := writeMessages(p.input.Output, matched); err != nil {
+			return err
 		}
+	}
 
-		_, err = sqsClient.SendMessageBatch(&sqs.SendMessageBatchInput{
-			Entries:  sendMessageBatchRequestEntries,
-			QueueUrl: toQueueURL.QueueUrl,
+	if p.input.Move {
+		zap.S().Debugf("Moving %d message(s)...", len(matched))
+		entries := make([]*sqs.SendMessageBatchRequestEntry, len(matched))
+		for i, msg := range matched {
+			entries[i] = newMessage(msg).sendEntry(i)
+		}
+		_, err := sqsbatch.SendMessageBatch(p.sqsClient, sendTimeout, &sqs.SendMessageBatchInput{
+			Entries:  entries,
+			QueueUrl: &p.toQueueURL,
 		})
 		if err != nil {
-			return errors.Wrapf(err, "failure moving messages to %s", toQueueName)
+			return errors.Wrapf(err, "failure moving messages to %s", p.input.ToQueue)
 		}
+	}
 
-		var deleteMessageBatchRequestEntries []*sqs.DeleteMessageBatchRequestEntry
-		for index, element := range messages {
-			deleteMessageBatchRequestEntries = append(deleteMessageBatchRequestEntries, &sqs.DeleteMessageBatchRequestEntry{
-				Id:            aws.String(strconv.Itoa(index)),
-				ReceiptHandle: element.ReceiptHandle,
-			})
+	if p.input.Move || p.input.Drop {
+		receipts := make([]*string, len(matched))
+		for i, msg := range matched {
+			receipts[i] = msg.ReceiptHandle
+			delete(p.receipts, aws.StringValue(msg.MessageId)) // no need to release
 		}
+		failed := sqsbatch.DeleteMessageBatch(p.sqsClient, p.fromQueueURL, receipts)
+		if len(failed) > 0 {
+			p.releaseFailed(matched, failed)
+		}
+		deleted := len(matched) - len(failed)
+		if p.input.Move {
+			p.stats.Moved += deleted
+		} else {
+			p.stats.Dropped += deleted
+		}
+	}
+	return nil
+}
 
-		_, err = sqsClient.DeleteMessageBatch(&sqs.DeleteMessageBatchInput{
-			Entries:  deleteMessageBatchRequestEntries,
-			QueueUrl: fromQueueURL.QueueUrl,
-		})
-		if err != nil {
-			return errors.Wrapf(err, "failure deleting moved messages from %s", fromQueueName)
+// releaseFailed keeps the messages that could not be deleted to make them visible again when done
+func (p *processor) releaseFailed(matched []*sqs.Message, failed []*string) {
+	p.stats.NotDeleted += len(failed)
+	for _, receipt := range failed {
+		for _, msg := range matched {
+			if aws.StringValue(msg.ReceiptHandle) == aws.StringValue(receipt) {
+				p.receipts[aws.StringValue(msg.MessageId)] = msg.ReceiptHandle
+				break
+			}
+		}
+	}
+	zap.S().Warnf("%d message(s) could not be deleted from %s and remain in the queue", len(failed), p.input.FromQueue)
+}
+
+// addSample keeps a uniform random sample of matched messages using reservoir sampling
+func (p *processor) addSample(matched []*sqs.Message) {
+	// the number of messages matched before this batch
+	n := p.stats.Matched - len(matched)
+	for _, msg := range matched {
+		n++
+		if len(p.sample) < p.input.Sample {
+			p.sample = append(p.sample, newMessage(msg))
+			continue
+		}
+		if i := p.rand.Intn(n); i < p.input.Sample {
+			p.sample[i] = newMessage(msg)
 		}
 	}
 }
+
+func (p *processor) writeSample() error {
+	if p.input.Sample == 0 {
+		return nil
+	}
+	return writeJSONLines(p.input.Output, p.sample)
+}
+
+// release makes the messages left in the queue visible again
+func (p *processor) release() error {
+	if len(p.receipts) == 0 {
+		return nil
+	}
+	receipts := make([]*string, 0, len(p.receipts))
+	for _, receipt := range p.receipts {
+		receipts = append(receipts, receipt)
+	}
+	return sqsbatch.ChangeMessageVisibilityBatch(p.sqsClient, p.fromQueueURL, receipts, 0)
+}
+
+func writeMessages(w io.Writer, messages []*sqs.Message) error {
+	out := make([]*Message, len(messages))
+	for i, msg := range messages {
+		out[i] = newMessage(msg)
+	}
+	return writeJSONLines(w, out)
+}
+
+// writeJSONLines writes messages as JSON lines.
+// If w is a file, it is synced so messages are not lost if they are deleted from the queue afterwards.
+func writeJSONLines(w io.Writer, messages []*Message) error {
+	stream := jsoniter.ConfigDefault.BorrowStream(w)
+	defer jsoniter.ConfigDefault.ReturnStream(stream)
+	for _, msg := range messages {
+		stream.WriteVal(msg)
+		stream.WriteRaw("\n")
+		if stream.Error != nil {
+			return errors.Wrap(stream.Error, "failed to write messages")
+		}
+	}
+	if err := stream.Flush(); err != nil {
+		return errors.Wrap(err, "failed to write messages")
+	}
+	if f, ok := w.(interface{ Sync() error }); ok {
+		if err := f.Sync(); err != nil {
+			return errors.Wrap(err, "failed to sync messages")
+		}
+	}
+	return nil
+}
+
+// ReadMessages reads messages written as JSON lines
+func ReadMessages(r io.Reader) ([]*Message, error) {
+	var messages []*Message
+	lines := bufio.NewScanner(r)
+	lines.Buffer(make([]byte, 0, 64*1024), maxMessageLineSize)
+	numLines := 0
+	for lines.Scan() {
+		numLines++
+		line := strings.TrimSpace(lines.Text())
+		if line == "" {
+			continue
+		}
+		msg := &Message{}
+		if err := jsoniter.UnmarshalFromString(line, msg); err != nil {
+			return nil, errors.Wrapf(err, "invalid message at line %d", numLines)
+		}
+		messages = append(messages, msg)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read messages")
+	}
+	return messages, nil
+}
+
+// Replay sends messages (ie exported and edited) to a queue.
+func Replay(sqsClient sqsiface.SQSAPI, region, toQueueName string, messages []*Message) error {
+	toQueueURL, err := sqsClient.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: &toQueueName,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "cannot find destination queue %s in region %s", toQueueName, region)
+	}
+	entries := make([]*sqs.SendMessageBatchRequestEntry, len(messages))
+	for i, msg := range messages {
+		entries[i] = msg.sendEntry(i)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	zap.S().Debugf("Replaying %d message(s) to %s", len(entries), toQueueName)
+	_, err = sqsbatch.SendMessageBatch(sqsClient, sendTimeout, &sqs.SendMessageBatchInput{
+		Entries:  entries,
+		QueueUrl: toQueueURL.QueueUrl,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failure replaying messages to %s", toQueueName)
+	}
+	return nil
+}
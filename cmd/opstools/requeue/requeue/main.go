@@ -37,7 +37,7 @@ import (
 )
 
 const (
-	banner = "moves messages from one sqs queue to another"
+	banner = "moves messages from one sqs queue to another, optionally inspecting, filtering, exporting or dropping them"
 )
 
 var (
@@ -47,6 +47,17 @@ var (
 	INTERACTIVE = flag.Bool("interactive", true, "If true, prompt for required flags if not set")
 	VERBOSE     = flag.Bool("verbose", false, "Enable verbose logging")
 
+	LIST       = flag.Bool("list", false, "Write matching messages with their attributes to stdout as JSON lines instead of moving them")
+	SAMPLE     = flag.Int("sample", 0, "Write a random sample of this many matching messages to stdout instead of moving them")
+	EXPORT     = flag.String("export", "", "Write matching messages to this file as JSON lines instead of moving them")
+	DROP       = flag.Bool("drop", false, "Delete matching messages instead of moving them (requires -match.path or -match.regex)")
+	REPLAY     = flag.String("replay", "", "Send the messages in this file (as written by -export) to -to.q")
+	MATCHPATH  = flag.String("match.path", "", "Only handle messages with a value at this dot separated JSON path in the body")
+	MATCHREGEX = flag.String("match.regex", "", "Only handle messages with a body (or value at -match.path) matching this regular expression")
+	LIMIT      = flag.Int("limit", 0, "If non-zero, stop after this many matching messages")
+	VISIBILITY = flag.Int64("visibility", requeue.DefaultScanVisibilityTimeout,
+		"Seconds to hide received messages while scanning, should be long enough to scan the whole queue")
+
 	logger *zap.SugaredLogger
 )
 
@@ -99,10 +110,63 @@ func main() {
 	promptFlags()
 	validateFlags()
 
-	err = requeue.Requeue(sqs.New(sess), *sess.Config.Region, *FROMQ, *TOQ)
+	sqsClient := sqs.New(sess)
+	region := *sess.Config.Region
+	if *REPLAY != "" {
+		replay(sqsClient, region)
+		return
+	}
+
+	filter, err := requeue.NewFilter(*MATCHPATH, *MATCHREGEX)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	input := &requeue.Input{
+		FromQueue:         *FROMQ,
+		ToQueue:           *TOQ,
+		Filter:            filter,
+		Drop:              *DROP,
+		Sample:            *SAMPLE,
+		Limit:             *LIMIT,
+		VisibilityTimeout: *VISIBILITY,
+	}
+	switch {
+	case *SAMPLE > 0 || *LIST:
+		input.Output = os.Stdout
+	case *EXPORT != "":
+		f, err := os.Create(*EXPORT)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		defer f.Close()
+		input.Output = f
+	}
+	// Move messages unless only inspecting or dropping them
+	input.Move = input.Output == nil && !input.Drop
+
+	stats := &requeue.Stats{}
+	err = requeue.Process(sqsClient, region, input, stats)
 	if err != nil {
 		log.Fatal(err)
 	}
+	logger.Infof("received %d messages from %s, %d matched, %d moved to %s, %d dropped, %d could not be deleted",
+		stats.Received, *FROMQ, stats.Matched, stats.Moved, *TOQ, stats.Dropped, stats.NotDeleted)
+}
+
+func replay(sqsClient *sqs.SQS, region string) {
+	f, err := os.Open(*REPLAY)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	defer f.Close()
+	messages, err := requeue.ReadMessages(f)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if err := requeue.Replay(sqsClient, region, *TOQ, messages); err != nil {
+		logger.Fatal(err)
+	}
+	logger.Infof("replayed %d messages from %s to %s", len(messages), *REPLAY, *TOQ)
 }
 
 func promptFlags() {
@@ -131,6 +195,11 @@ func validateFlags() {
 		return
 	}
 
+	if *DROP && *MATCHPATH == "" && *MATCHREGEX == "" {
+		err = errors.New("-drop requires -match.path or -match.regex (use -match.regex . to drop all messages)")
+		return
+	}
+
 	if *FROMQ == "" {
 		/*
 		  default to our dlq naming convention where:
@@ -0,0 +1,283 @@
+package requeue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	testRegion     = "us-east-1"
+	testFromQueue  = "test-dlq"
+	testToQueue    = "test-queue"
+	testFromURL    = "https://sqs.us-east-1.amazonaws.com/123456789012/test-dlq"
+	testToURL      = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	testPoisonBody = `{"poison":true}`
+)
+
+func testMessages(bodies ...string) []*sqs.Message {
+	messages := make([]*sqs.Message, len(bodies))
+	for i, body := range bodies {
+		messages[i] = &sqs.Message{
+			MessageId:     aws.String("id-" + strconv.Itoa(i)),
+			ReceiptHandle: aws.String("receipt-" + strconv.Itoa(i)),
+			Body:          aws.String(body),
+			Attributes: map[string]*string{
+				sqs.MessageSystemAttributeNameApproximateReceiveCount: aws.String("3"),
+			},
+			MessageAttributes: map[string]*sqs.MessageAttributeValue{
+				"foo": {DataType: aws.String("String"), StringValue: aws.String("bar")},
+			},
+		}
+	}
+	return messages
+}
+
+func newTestSQS(messages []*sqs.Message) *testutils.SqsMock {
+	sqsClient := &testutils.SqsMock{}
+	sqsClient.On("GetQueueUrl", &sqs.GetQueueUrlInput{QueueName: aws.String(testFromQueue)}).
+		Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(testFromURL)}, nil).Once()
+	sqsClient.On("ReceiveMessage", mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{Messages: messages}, nil).Once()
+	sqsClient.On("ReceiveMessage", mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{}, nil).Once()
+	return sqsClient
+}
+
+func TestProcessMove(t *testing.T) {
+	assert := require.New(t)
+	sqsClient := newTestSQS(testMessages(`{"ok":true}`, testPoisonBody))
+	sqsClient.On("GetQueueUrl", &sqs.GetQueueUrlInput{QueueName: aws.String(testToQueue)}).
+		Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(testToURL)}, nil).Once()
+	var sent []*sqs.SendMessageBatchRequestEntry
+	sqsClient.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{
+		Successful: []*sqs.SendMessageBatchResultEntry{{}},
+	}, nil).Once().Run(func(args mock.Arguments) {
+		input := args.Get(0).(*sqs.SendMessageBatchInput)
+		assert.Equal(testToURL, aws.StringValue(input.QueueUrl))
+		sent = input.Entries
+	})
+	sqsClient.On("DeleteMessageBatch", &sqs.DeleteMessageBatchInput{
+		QueueUrl: aws.String(testFromURL),
+		Entries: []*sqs.DeleteMessageBatchRequestEntry{
+			{Id: aws.String("0"), ReceiptHandle: aws.String("receipt-0")},
+		},
+	}).Return(&sqs.DeleteMessageBatchOutput{}, nil).Once()
+	sqsClient.On("ChangeMessageVisibilityBatch", &sqs.ChangeMessageVisibilityBatchInput{
+		QueueUrl: aws.String(testFromURL),
+		Entries: []*sqs.ChangeMessageVisibilityBatchRequestEntry{
+			{Id: aws.String("0"), ReceiptHandle: aws.String("receipt-1"), VisibilityTimeout: aws.Int64(0)},
+		},
+	}).Return(&sqs.ChangeMessageVisibilityBatchOutput{}, nil).Once()
+
+	filter, err := NewFilter("ok", "")
+	assert.NoError(err)
+	stats := &Stats{}
+	err = Process(sqsClient, testRegion, &Input{
+		FromQueue: testFromQueue,
+		ToQueue:   testToQueue,
+		Filter:    filter,
+		Move:      true,
+	}, stats)
+	assert.NoError(err)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(&Stats{Received: 2, Matched: 1, Moved: 1}, stats)
+	assert.Len(sent, 1)
+	assert.Equal(`{"ok":true}`, aws.StringValue(sent[0].MessageBody))
+	assert.Equal("bar", aws.StringValue(sent[0].MessageAttributes["foo"].StringValue))
+}
+
+func TestProcessExportAndDrop(t *testing.T) {
+	assert := require.New(t)
+	sqsClient := newTestSQS(testMessages(`{"ok":true}`, testPoisonBody))
+	sqsClient.On("DeleteMessageBatch", mock.Anything).Return(&sqs.DeleteMessageBatchOutput{}, nil).Once()
+	sqsClient.On("ChangeMessageVisibilityBatch", mock.Anything).Return(&sqs.ChangeMessageVisibilityBatchOutput{}, nil).Once()
+
+	filter, err := NewFilter("", "poison")
+	assert.NoError(err)
+	var out bytes.Buffer
+	stats := &Stats{}
+	err = Process(sqsClient, testRegion, &Input{
+		FromQueue: testFromQueue,
+		Filter:    filter,
+		Output:    &out,
+		Drop:      true,
+	}, stats)
+	assert.NoError(err)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(&Stats{Received: 2, Matched: 1, Dropped: 1}, stats)
+	deleted := sqsClient.Calls[2].Arguments.Get(0).(*sqs.DeleteMessageBatchInput)
+	assert.Equal("receipt-1", aws.StringValue(deleted.Entries[0].ReceiptHandle))
+
+	messages, err := ReadMessages(&out)
+	assert.NoError(err)
+	assert.Equal([]*Message{
+		{
+			MessageID: "id-1",
+			Body:      testPoisonBody,
+			Attributes: map[string]*string{
+				sqs.MessageSystemAttributeNameApproximateReceiveCount: aws.String("3"),
+			},
+			MessageAttributes: map[string]*sqs.MessageAttributeValue{
+				"foo": {DataType: aws.String("String"), StringValue: aws.String("bar")},
+			},
+		},
+	}, messages)
+}
+
+func TestProcessDropDeleteFailed(t *testing.T) {
+	assert := require.New(t)
+	sqsClient := newTestSQS(testMessages(testPoisonBody, testPoisonBody))
+	sqsClient.On("DeleteMessageBatch", mock.Anything).Return(&sqs.DeleteMessageBatchOutput{
+		Successful: []*sqs.DeleteMessageBatchResultEntry{{Id: aws.String("0")}},
+		Failed:     []*sqs.BatchResultErrorEntry{{Id: aws.String("1"), Message: aws.String("receipt handle expired")}},
+	}, nil).Once()
+	// the message that could not be deleted is made visible again
+	sqsClient.On("ChangeMessageVisibilityBatch", &sqs.ChangeMessageVisibilityBatchInput{
+		QueueUrl: aws.String(testFromURL),
+		Entries: []*sqs.ChangeMessageVisibilityBatchRequestEntry{
+			{Id: aws.String("0"), ReceiptHandle: aws.String("receipt-1"), VisibilityTimeout: aws.Int64(0)},
+		},
+	}).Return(&sqs.ChangeMessageVisibilityBatchOutput{}, nil).Once()
+
+	stats := &Stats{}
+	err := Process(sqsClient, testRegion, &Input{
+		FromQueue: testFromQueue,
+		Drop:      true,
+	}, stats)
+	assert.NoError(err)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(&Stats{Received: 2, Matched: 2, Dropped: 1, NotDeleted: 1}, stats)
+}
+
+func TestProcessSample(t *testing.T) {
+	assert := require.New(t)
+	bodies := make([]string, 10)
+	for i := range bodies {
+		bodies[i] = strconv.Itoa(i)
+	}
+	messages := testMessages(bodies...)
+	sqsClient := &testutils.SqsMock{}
+	sqsClient.On("GetQueueUrl", mock.Anything).
+		Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(testFromURL)}, nil).Once()
+	sqsClient.On("ReceiveMessage", mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{Messages: messages}, nil).Once()
+	// The visibility timeout expired and the same messages are received again
+	sqsClient.On("ReceiveMessage", mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{Messages: messages[:2]}, nil).Once()
+	sqsClient.On("ChangeMessageVisibilityBatch", mock.Anything).Return(&sqs.ChangeMessageVisibilityBatchOutput{}, nil).Once()
+
+	var out bytes.Buffer
+	stats := &Stats{}
+	err := Process(sqsClient, testRegion, &Input{
+		FromQueue: testFromQueue,
+		Output:    &out,
+		Sample:    3,
+	}, stats)
+	assert.NoError(err)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(&Stats{Received: 10, Matched: 10}, stats)
+	sample, err := ReadMessages(&out)
+	assert.NoError(err)
+	assert.Len(sample, 3)
+	released := sqsClient.Calls[3].Arguments.Get(0).(*sqs.ChangeMessageVisibilityBatchInput)
+	assert.Len(released.Entries, 10)
+}
+
+func TestProcessLimit(t *testing.T) {
+	assert := require.New(t)
+	sqsClient := &testutils.SqsMock{}
+	sqsClient.On("GetQueueUrl", mock.Anything).
+		Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(testFromURL)}, nil).Once()
+	sqsClient.On("ReceiveMessage", mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{Messages: testMessages("a", "b", "c")}, nil).Once()
+	sqsClient.On("ChangeMessageVisibilityBatch", mock.Anything).Return(&sqs.ChangeMessageVisibilityBatchOutput{}, nil).Once()
+
+	var out bytes.Buffer
+	stats := &Stats{}
+	err := Process(sqsClient, testRegion, &Input{
+		FromQueue: testFromQueue,
+		Output:    &out,
+		Limit:     2,
+	}, stats)
+	assert.NoError(err)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(&Stats{Received: 3, Matched: 2}, stats)
+	messages, err := ReadMessages(&out)
+	assert.NoError(err)
+	assert.Len(messages, 2)
+}
+
+func TestProcessInvalidInput(t *testing.T) {
+	for _, input := range []*Input{
+		{Move: true, Drop: true, ToQueue: testToQueue},
+		{Move: true},
+		{Sample: 1, Drop: true, Output: &bytes.Buffer{}},
+		{Sample: 1},
+	} {
+		require.Error(t, Process(&testutils.SqsMock{}, testRegion, input, &Stats{}))
+	}
+}
+
+func TestReplay(t *testing.T) {
+	assert := require.New(t)
+	exported := `{"messageId":"id-0","body":"{\"fixed\":true}","attributes":{"MessageGroupId":"group"},` +
+		`"messageAttributes":{"foo":{"DataType":"String","StringValue":"bar"}}}
+
+{"body":"plain"}
+`
+	messages, err := ReadMessages(bytes.NewBufferString(exported))
+	assert.NoError(err)
+	assert.Len(messages, 2)
+
+	sqsClient := &testutils.SqsMock{}
+	sqsClient.On("GetQueueUrl", &sqs.GetQueueUrlInput{QueueName: aws.String(testToQueue)}).
+		Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(testToURL)}, nil).Once()
+	sqsClient.On("SendMessageBatch", &sqs.SendMessageBatchInput{
+		QueueUrl: aws.String(testToURL),
+		Entries: []*sqs.SendMessageBatchRequestEntry{
+			{
+				Id:             aws.String("0"),
+				MessageBody:    aws.String(`{"fixed":true}`),
+				MessageGroupId: aws.String("group"),
+				MessageAttributes: map[string]*sqs.MessageAttributeValue{
+					"foo": {DataType: aws.String("String"), StringValue: aws.String("bar")},
+				},
+			},
+			{
+				Id:          aws.String("1"),
+				MessageBody: aws.String("plain"),
+			},
+		},
+	}).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+	assert.NoError(Replay(sqsClient, testRegion, testToQueue, messages))
+	sqsClient.AssertExpectations(t)
+
+	_, err = ReadMessages(bytes.NewBufferString("{"))
+	assert.Error(err)
+}
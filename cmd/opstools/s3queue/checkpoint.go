@@ -0,0 +1,154 @@
+package s3queue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// checkpointInterval is the minimum time between checkpoint file writes
+const checkpointInterval = time.Second
+
+// Checkpoint records the progress of a backfill so it can be resumed
+type Checkpoint struct {
+	S3Path string `json:"s3path"`
+	// StartAfter is the key of the last object sent, all objects listed before it have been sent
+	StartAfter string    `json:"startAfter"`
+	NumFiles   uint64    `json:"numFiles"`
+	NumBytes   uint64    `json:"numBytes"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// LoadCheckpoint reads a checkpoint file.
+// If the file does not exist a new checkpoint for s3path is returned.
+func LoadCheckpoint(path, s3path string) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Checkpoint{
+				S3Path: s3path,
+			}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read checkpoint %q", path)
+	}
+	checkpoint := Checkpoint{}
+	if err := jsoniter.Unmarshal(data, &checkpoint); err != nil {
+		return nil, errors.Wrapf(err, "invalid checkpoint %q", path)
+	}
+	if checkpoint.S3Path != s3path {
+		return nil, errors.Errorf("checkpoint %q is for %s not %s", path, checkpoint.S3Path, s3path)
+	}
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint to a file.
+// The file is replaced atomically so a crash will not leave a partially written checkpoint.
+func (c *Checkpoint) Save(path string) error {
+	data, err := jsoniter.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal checkpoint")
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create checkpoint file")
+	}
+	defer os.Remove(tmp.Name()) // nolint: errcheck
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "failed to write checkpoint file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to write checkpoint file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "failed to replace checkpoint file")
+}
+
+// checkpointTracker advances a checkpoint as objects are sent out of order by concurrent writers.
+// The checkpoint only advances past an object once it and all objects listed before it have been sent.
+type checkpointTracker struct {
+	path       string
+	checkpoint *Checkpoint
+
+	mu       sync.Mutex
+	next     uint64 // the sequence number of the first object not yet sent
+	sent     map[uint64]*s3Object
+	lastSave time.Time
+}
+
+func newCheckpointTracker(path string, checkpoint *Checkpoint) *checkpointTracker {
+	return &checkpointTracker{
+		path:       path,
+		checkpoint: checkpoint,
+		sent:       make(map[uint64]*s3Object),
+		lastSave:   time.Now(),
+	}
+}
+
+// Sent records objects as sent and saves the checkpoint if it advanced.
+// It is safe to call from multiple goroutines and is a no-op on a nil tracker.
+func (t *checkpointTracker) Sent(objects []*s3Object) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, obj := range objects {
+		t.sent[obj.seq] = obj
+	}
+	advanced := false
+	for {
+		obj, ok := t.sent[t.next]
+		if !ok {
+			break
+		}
+		delete(t.sent, t.next)
+		t.next++
+		t.checkpoint.StartAfter = obj.key
+		t.checkpoint.NumFiles++
+		t.checkpoint.NumBytes += uint64(obj.size)
+		advanced = true
+	}
+	if advanced && time.Since(t.lastSave) >= checkpointInterval {
+		return t.save()
+	}
+	return nil
+}
+
+// Close saves the final checkpoint
+func (t *checkpointTracker) Close() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.save()
+}
+
+func (t *checkpointTracker) save() error {
+	t.checkpoint.UpdatedAt = time.Now().UTC()
+	t.lastSave = time.Now()
+	return t.checkpoint.Save(t.path)
+}
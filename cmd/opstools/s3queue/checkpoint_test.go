@@ -0,0 +1,74 @@
+package s3queue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	assert := require.New(t)
+	dir, err := ioutil.TempDir("", "s3queue")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint.json")
+
+	checkpoint, err := LoadCheckpoint(path, testS3Path)
+	assert.NoError(err)
+	assert.Equal(&Checkpoint{S3Path: testS3Path}, checkpoint)
+
+	tracker := newCheckpointTracker(path, checkpoint)
+	objects := []*s3Object{
+		{seq: 0, key: "a", size: 1},
+		{seq: 1, key: "b", size: 2},
+		{seq: 2, key: "c", size: 3},
+		{seq: 3, key: "d", size: 4},
+	}
+	// sent out of order, cannot advance past the first object
+	assert.NoError(tracker.Sent(objects[1:3]))
+	assert.Equal("", checkpoint.StartAfter)
+	assert.NoError(tracker.Sent(objects[:1]))
+	assert.Equal("c", checkpoint.StartAfter)
+	assert.Equal(uint64(3), checkpoint.NumFiles)
+	assert.Equal(uint64(6), checkpoint.NumBytes)
+	assert.NoError(tracker.Close())
+
+	loaded, err := LoadCheckpoint(path, testS3Path)
+	assert.NoError(err)
+	assert.Equal("c", loaded.StartAfter)
+	assert.Equal(uint64(3), loaded.NumFiles)
+	assert.False(loaded.UpdatedAt.IsZero())
+
+	_, err = LoadCheckpoint(path, "s3://other/prefix")
+	assert.Error(err)
+
+	assert.NoError(ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = LoadCheckpoint(path, testS3Path)
+	assert.Error(err)
+
+	// nil trackers are no-ops
+	var nilTracker *checkpointTracker
+	assert.NoError(nilTracker.Sent(objects))
+	assert.NoError(nilTracker.Close())
+}
@@ -0,0 +1,209 @@
+package s3queue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Filter selects which of the listed objects are sent
+type Filter struct {
+	// Only send objects last modified in [ModifiedAfter, ModifiedBefore), zero values are unbounded
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	// Only send objects with a date in their key in [KeyAfter, KeyBefore), zero values are unbounded.
+	// The date in the key is the start of the period it denotes (ie `2020/01/02` is 2020-01-02T00:00:00Z).
+	// Objects without a date in their key are skipped if either is set.
+	KeyAfter  time.Time
+	KeyBefore time.Time
+	// Only send objects with keys matching any of the Include patterns (if any) and none of the Exclude patterns.
+	Include []*Glob
+	Exclude []*Glob
+}
+
+// Match checks if an object passes the filter
+func (f *Filter) Match(key string, lastModified time.Time) bool {
+	if f == nil {
+		return true
+	}
+	if !inRange(lastModified, f.ModifiedAfter, f.ModifiedBefore) {
+		return false
+	}
+	if !f.KeyAfter.IsZero() || !f.KeyBefore.IsZero() {
+		tm, ok := KeyTime(key)
+		if !ok || !inRange(tm, f.KeyAfter, f.KeyBefore) {
+			return false
+		}
+	}
+	if len(f.Include) > 0 && !matchAny(f.Include, key) {
+		return false
+	}
+	return !matchAny(f.Exclude, key)
+}
+
+func inRange(tm, after, before time.Time) bool {
+	if !after.IsZero() && tm.Before(after) {
+		return false
+	}
+	if !before.IsZero() && !tm.Before(before) {
+		return false
+	}
+	return true
+}
+
+func matchAny(globs []*Glob, key string) bool {
+	for _, g := range globs {
+		if g.Match(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Glob is a pattern matching object keys.
+// `*` matches any sequence of characters except `/`, `**` matches any sequence of characters and `?` matches
+// any single character except `/`.
+type Glob struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// CompileGlob compiles a glob pattern
+func CompileGlob(pattern string) (*Glob, error) {
+	if pattern == "" {
+		return nil, errors.New("empty glob pattern")
+	}
+	var expr strings.Builder
+	expr.WriteByte('^')
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				expr.WriteString(".*")
+				i++
+				continue
+			}
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteByte('$')
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid glob pattern %q", pattern)
+	}
+	return &Glob{
+		pattern: pattern,
+		re:      re,
+	}, nil
+}
+
+// CompileGlobs compiles a comma separated list of glob patterns
+func CompileGlobs(patterns string) ([]*Glob, error) {
+	var globs []*Glob
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		g, err := CompileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// Match checks if key matches the pattern
+func (g *Glob) Match(key string) bool {
+	return g.re.MatchString(key)
+}
+
+func (g *Glob) String() string {
+	return g.pattern
+}
+
+// Date formats found in object keys, the capture groups are year, month, day and optionally hour
+var keyTimePatterns = []*regexp.Regexp{
+	// Hive style partitions (ie `year=2020/month=01/day=02/hour=03`)
+	regexp.MustCompile(`year=(\d{4})/month=(\d{2})/day=(\d{2})(?:/hour=(\d{2}))?`),
+	// Path prefixes used by AWS services and Firehose (ie `2020/01/02/03/`)
+	regexp.MustCompile(`(?:^|/)(\d{4})/(\d{2})/(\d{2})(?:/(\d{2}))?(?:/|$)`),
+	// Compact timestamps in file names used by CloudTrail and VPC flow logs (ie `20200102T0305Z`)
+	regexp.MustCompile(`(\d{4})(\d{2})(\d{2})T(\d{2})\d{2}Z`),
+	// ISO dates (ie `2020-01-02` or `2020-01-02-03`)
+	regexp.MustCompile(`(\d{4})-(\d{2})-(\d{2})(?:[T-](\d{2}))?`),
+}
+
+// KeyTime finds a date embedded in an object key
+func KeyTime(key string) (time.Time, bool) {
+	for _, re := range keyTimePatterns {
+		for _, match := range re.FindAllStringSubmatch(key, -1) {
+			if tm, ok := matchTime(match[1:]); ok {
+				return tm, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func matchTime(parts []string) (time.Time, bool) {
+	var values [4]int
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, false
+		}
+		values[i] = n
+	}
+	year, month, day, hour := values[0], values[1], values[2], values[3]
+	tm := time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.UTC)
+	// Reject values that overflow (ie month 13 or hour 25)
+	if tm.Year() != year || int(tm.Month()) != month || tm.Day() != day || tm.Hour() != hour {
+		return time.Time{}, false
+	}
+	return tm, true
+}
+
+// ParseTime parses a time flag value, either an RFC3339 timestamp or a date (YYYY-MM-DD)
+func ParseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if tm, err := time.Parse(time.RFC3339, value); err == nil {
+		return tm.UTC(), nil
+	}
+	tm, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid time %q, expecting RFC3339 or YYYY-MM-DD", value)
+	}
+	return tm, nil
+}
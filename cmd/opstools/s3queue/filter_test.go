@@ -0,0 +1,142 @@
+package s3queue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyTime(t *testing.T) {
+	for key, expect := range map[string]string{
+		"logs/year=2020/month=01/day=02/hour=03/file.json.gz":               "2020-01-02T03:00:00Z",
+		"logs/year=2020/month=01/day=02/file.json.gz":                       "2020-01-02T00:00:00Z",
+		"AWSLogs/123456789012/CloudTrail/us-east-1/2020/01/02/file.json.gz": "2020-01-02T00:00:00Z",
+		"firehose/2020/01/02/03/stream-1-2020-01-02-03-04-05-uuid.gz":       "2020-01-02T03:00:00Z",
+		"2020/01/02/file.gz": "2020-01-02T00:00:00Z",
+		"vpc/123456789012_vpcflowlogs_us-east-1_fl-1234_20200102T0305Z_hash.log.gz": "2020-01-02T03:00:00Z",
+		"logs/2020-01-02.log":           "2020-01-02T00:00:00Z",
+		"logs/2020-01-02T03:04:05Z.log": "2020-01-02T03:00:00Z",
+		// invalid dates are skipped
+		"logs/2020/13/02/2020-01-03.log": "2020-01-03T00:00:00Z",
+	} {
+		tm, ok := KeyTime(key)
+		require.True(t, ok, key)
+		require.Equal(t, expect, tm.Format(time.RFC3339), key)
+	}
+	for _, key := range []string{"logs/file.gz", "logs/2020/13/45/file.gz", "logs/12345/file.gz"} {
+		_, ok := KeyTime(key)
+		require.False(t, ok, key)
+	}
+}
+
+func TestGlob(t *testing.T) {
+	assert := require.New(t)
+	for pattern, keys := range map[string]map[string]bool{
+		"*.gz": {
+			"file.gz":      true,
+			"logs/file.gz": false,
+			"file.json":    false,
+		},
+		"logs/*/file.gz": {
+			"logs/a/file.gz":   true,
+			"logs/a/b/file.gz": false,
+		},
+		"**/CloudTrail-Digest/**": {
+			"AWSLogs/123/CloudTrail-Digest/us-east-1/file.json.gz": true,
+			"AWSLogs/123/CloudTrail/us-east-1/file.json.gz":        false,
+		},
+		"**.json.g?": {
+			"logs/a/file.json.gz": true,
+			"logs/a/file.json.g/": false,
+		},
+		"logs/[a]+.gz": {
+			"logs/[a]+.gz": true,
+			"logs/aa.gz":   false,
+		},
+	} {
+		g, err := CompileGlob(pattern)
+		assert.NoError(err)
+		assert.Equal(pattern, g.String())
+		for key, expect := range keys {
+			assert.Equal(expect, g.Match(key), "%s %s", pattern, key)
+		}
+	}
+	_, err := CompileGlob("")
+	assert.Error(err)
+
+	globs, err := CompileGlobs("*.gz, **/digest/** ,")
+	assert.NoError(err)
+	assert.Len(globs, 2)
+	assert.Equal("**/digest/**", globs[1].String())
+}
+
+func TestFilter(t *testing.T) {
+	assert := require.New(t)
+	mustGlobs := func(patterns string) []*Glob {
+		globs, err := CompileGlobs(patterns)
+		assert.NoError(err)
+		return globs
+	}
+	modified := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var filter *Filter
+	assert.True(filter.Match("any", modified))
+
+	filter = &Filter{
+		ModifiedAfter:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		ModifiedBefore: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	assert.True(filter.Match("logs/file.gz", modified))
+	assert.False(filter.Match("logs/file.gz", modified.Add(24*time.Hour)))
+	assert.False(filter.Match("logs/file.gz", filter.ModifiedBefore))
+	assert.True(filter.Match("logs/file.gz", filter.ModifiedAfter))
+
+	filter = &Filter{
+		KeyAfter: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	assert.True(filter.Match("logs/2020/01/02/file.gz", modified))
+	assert.False(filter.Match("logs/2020/01/01/file.gz", modified))
+	assert.False(filter.Match("logs/file.gz", modified))
+
+	filter = &Filter{
+		Include: mustGlobs("**.gz"),
+		Exclude: mustGlobs("**/digest/**"),
+	}
+	assert.True(filter.Match("logs/file.gz", modified))
+	assert.False(filter.Match("logs/file.json", modified))
+	assert.False(filter.Match("logs/digest/file.gz", modified))
+}
+
+func TestParseTime(t *testing.T) {
+	assert := require.New(t)
+	tm, err := ParseTime("")
+	assert.NoError(err)
+	assert.True(tm.IsZero())
+	tm, err = ParseTime("2020-01-02")
+	assert.NoError(err)
+	assert.Equal(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), tm)
+	tm, err = ParseTime("2020-01-02T03:04:05+01:00")
+	assert.NoError(err)
+	assert.Equal(time.Date(2020, 1, 2, 2, 4, 5, 0, time.UTC), tm)
+	_, err = ParseTime("01/02/2020")
+	assert.Error(err)
+}
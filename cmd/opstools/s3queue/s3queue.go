@@ -19,7 +19,9 @@ package s3queue
  */
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log"
 	"math"
 	"net/url"
@@ -38,6 +40,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 )
 
@@ -48,45 +51,132 @@ const (
 )
 
 type Stats struct {
-	NumFiles uint64
-	NumBytes uint64
+	NumFiles   uint64
+	NumBytes   uint64
+	NumSkipped uint64 // objects listed that did not match the filter
+}
+
+// Input configures a backfill
+type Input struct {
+	Account     string // the account of the log processor, used in the fake topic arn of notifications
+	S3Path      string // the s3 path to list (e.g., s3://mybucket/myprefix)
+	S3Region    string // the region of the bucket
+	QueueName   string // the log processor queue
+	Concurrency int    // the number of concurrent sqs writers
+	Limit       uint64 // if non-zero, limit the number of files sent
+	Filter      *Filter
+	// If set, the log processor uses this source for the objects instead of matching bucket and prefix.
+	// The objects must still be in the bucket and under the prefix of the source.
+	SourceID string
+	// If non-zero, wait for the queue to have less than this many messages before sending more
+	MaxQueueDepth int
+	// If set, matching objects are written to DryRun (one per line) instead of being sent
+	DryRun io.Writer
+	// If set, progress is saved to this file and a backfill is resumed from it
+	CheckpointFile string
 }
 
 func S3Queue(sess *session.Session, account, s3path, s3region, queueName string,
 	concurrency int, limit uint64, stats *Stats) (err error) {
 
-	return s3Queue(s3.New(sess.Copy(&aws.Config{Region: &s3region})), sqs.New(sess),
-		account, s3path, queueName, concurrency, limit, stats)
+	return Backfill(context.Background(), sess, &Input{
+		Account:     account,
+		S3Path:      s3path,
+		S3Region:    s3region,
+		QueueName:   queueName,
+		Concurrency: concurrency,
+		Limit:       limit,
+	}, stats)
+}
+
+// Backfill lists s3 objects and posts s3 notifications for them to the log processor queue.
+// If ctx is canceled, listing stops and the objects already listed are sent before returning.
+func Backfill(ctx context.Context, sess *session.Session, input *Input, stats *Stats) error {
+	return backfill(ctx, s3.New(sess.Copy(&aws.Config{Region: &input.S3Region})), sqs.New(sess), input, stats)
 }
 
 func s3Queue(s3Client s3iface.S3API, sqsClient sqsiface.SQSAPI, account, s3path, queueName string,
 	concurrency int, limit uint64, stats *Stats) (failed error) {
 
-	queueURL, err := sqsClient.GetQueueUrl(&sqs.GetQueueUrlInput{
-		QueueName: &queueName,
-	})
-	if err != nil {
-		return errors.Wrapf(err, "could not get queue url for %s", queueName)
-	}
+	return backfill(context.Background(), s3Client, sqsClient, &Input{
+		Account:     account,
+		S3Path:      s3path,
+		QueueName:   queueName,
+		Concurrency: concurrency,
+		Limit:       limit,
+	}, stats)
+}
+
+func backfill(ctx context.Context, s3Client s3iface.S3API, sqsClient sqsiface.SQSAPI, input *Input,
+	stats *Stats) (failed error) {
 
-	// the account id is taken from this arn to assume role for reading in the log processor
-	topicARN := fmt.Sprintf(fakeTopicArnTemplate, account)
+	var checkpoint *Checkpoint
+	if input.CheckpointFile != "" {
+		var err error
+		checkpoint, err = LoadCheckpoint(input.CheckpointFile, input.S3Path)
+		if err != nil {
+			return err
+		}
+		if checkpoint.StartAfter != "" {
+			log.Printf("resuming after %s (%d files sent)", checkpoint.StartAfter, checkpoint.NumFiles)
+		}
+	}
+	var startAfter string
+	if checkpoint != nil {
+		startAfter = checkpoint.StartAfter
+	}
 
 	errChan := make(chan error)
-	notifyChan := make(chan *events.S3Event, 1000)
+	notifyChan := make(chan *s3Object, 1000)
 
 	var queueWg sync.WaitGroup
-	for i := 0; i < concurrency; i++ {
+	if input.DryRun != nil {
 		queueWg.Add(1)
 		go func() {
-			queueNotifications(sqsClient, topicARN, queueURL.QueueUrl, notifyChan, errChan)
+			printObjects(input.DryRun, notifyChan, errChan)
 			queueWg.Done()
 		}()
+	} else {
+		queueURL, err := sqsClient.GetQueueUrl(&sqs.GetQueueUrlInput{
+			QueueName: &input.QueueName,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not get queue url for %s", input.QueueName)
+		}
+
+		w := &queueWriter{
+			sqsClient: sqsClient,
+			// the account id is taken from this arn to assume role for reading in the log processor
+			topicARN: fmt.Sprintf(fakeTopicArnTemplate, input.Account),
+			queueURL: queueURL.QueueUrl,
+			sourceID: input.SourceID,
+			throttle: newQueueThrottle(sqsClient, queueURL.QueueUrl, input.MaxQueueDepth),
+		}
+		if checkpoint != nil {
+			w.checkpoint = newCheckpointTracker(input.CheckpointFile, checkpoint)
+			defer func() {
+				if err := w.checkpoint.Close(); err != nil && failed == nil {
+					failed = err
+				}
+			}()
+		}
+
+		concurrency := input.Concurrency
+		if concurrency < 1 {
+			concurrency = 1
+		}
+		for i := 0; i < concurrency; i++ {
+			queueWg.Add(1)
+			go func() {
+				w.queueNotifications(notifyChan, errChan)
+				queueWg.Done()
+			}()
+		}
 	}
 
 	queueWg.Add(1)
 	go func() {
-		listPath(s3Client, s3path, limit, notifyChan, errChan, stats)
+		listPath(ctx, s3Client, input.S3Path, startAfter, input.Filter, input.Limit, notifyChan, errChan, stats)
 		queueWg.Done()
 	}()
 
@@ -106,9 +196,18 @@ func s3Queue(s3Client s3iface.S3API, sqsClient sqsiface.SQSAPI, account, s3path,
 	return failed
 }
 
-// Given an s3path (e.g., s3://mybucket/myprefix) list files and send to notifyChan
-func listPath(s3Client s3iface.S3API, s3path string, limit uint64,
-	notifyChan chan *events.S3Event, errChan chan error, stats *Stats) {
+// s3Object is a listed object to send, seq is its position in the listing
+type s3Object struct {
+	seq          uint64
+	bucket       string
+	key          string
+	size         int64
+	lastModified time.Time
+}
+
+// Given an s3path (e.g., s3://mybucket/myprefix) list files after startAfter matching filter and send to notifyChan
+func listPath(ctx context.Context, s3Client s3iface.S3API, s3path, startAfter string, filter *Filter, limit uint64,
+	notifyChan chan *s3Object, errChan chan error, stats *Stats) {
 
 	if limit == 0 {
 		limit = math.MaxUint64
@@ -145,103 +244,171 @@ func listPath(s3Client s3iface.S3API, s3path string, limit uint64,
 		Prefix:  aws.String(prefix),
 		MaxKeys: aws.Int64(pageSize),
 	}
-	err = s3Client.ListObjectsV2Pages(inputParams, func(page *s3.ListObjectsV2Output, morePages bool) bool {
+	if startAfter != "" {
+		inputParams.StartAfter = aws.String(startAfter)
+	}
+	var seq uint64
+	err = s3Client.ListObjectsV2PagesWithContext(ctx, inputParams, func(page *s3.ListObjectsV2Output, morePages bool) bool {
 		for _, value := range page.Contents {
 			if *value.Size > 0 { // we only care about objects with size
+				if !filter.Match(*value.Key, aws.TimeValue(value.LastModified)) {
+					stats.NumSkipped++
+					continue
+				}
 				stats.NumFiles++
 				if stats.NumFiles%progressNotify == 0 {
 					log.Printf("listed %d files ...", stats.NumFiles)
 				}
 				stats.NumBytes += (uint64)(*value.Size)
-				notifyChan <- &events.S3Event{
-					Records: []events.S3EventRecord{
-						{
-							S3: events.S3Entity{
-								Bucket: events.S3Bucket{
-									Name: bucket,
-								},
-								Object: events.S3Object{
-									Key: *value.Key,
-								},
-							},
-						},
-					},
+				notifyChan <- &s3Object{
+					seq:          seq,
+					bucket:       bucket,
+					key:          *value.Key,
+					size:         *value.Size,
+					lastModified: aws.TimeValue(value.LastModified),
 				}
+				seq++
 				if stats.NumFiles >= limit {
 					break
 				}
 			}
 		}
-		return stats.NumFiles < limit // "To stop iterating, return false from the fn function."
+		// "To stop iterating, return false from the fn function."
+		return stats.NumFiles < limit && ctx.Err() == nil
 	})
-	if err != nil {
+	if err != nil && ctx.Err() == nil {
 		errChan <- err
 	}
 }
 
-// post message per file as-if it was an S3 notification
-func queueNotifications(sqsClient sqsiface.SQSAPI, topicARN string, queueURL *string,
-	notifyChan chan *events.S3Event, errChan chan error) {
+// printObjects writes objects to w, one per line
+func printObjects(w io.Writer, notifyChan chan *s3Object, errChan chan error) {
+	var failed bool
+	for obj := range notifyChan {
+		if failed { // drain channel
+			continue
+		}
+		_, err := fmt.Fprintf(w, "s3://%s/%s\t%d\t%s\n", obj.bucket, obj.key, obj.size,
+			obj.lastModified.UTC().Format(time.RFC3339))
+		if err != nil {
+			errChan <- err
+			failed = true
+		}
+	}
+}
 
+// queueWriter sends s3 notifications to the log processor queue
+type queueWriter struct {
+	sqsClient  sqsiface.SQSAPI
+	topicARN   string
+	queueURL   *string
+	sourceID   string
+	throttle   *queueThrottle
+	checkpoint *checkpointTracker
+}
+
+// post message per file as-if it was an S3 notification
+func (w *queueWriter) queueNotifications(notifyChan chan *s3Object, errChan chan error) {
 	sendMessageBatchInput := &sqs.SendMessageBatchInput{
-		QueueUrl: queueURL,
+		QueueUrl: w.queueURL,
 	}
+	var batch []*s3Object
 
 	// we have 1 file per notification to limit blast radius in case of failure.
 	const (
 		batchTimeout = time.Minute
 		batchSize    = 10
 	)
+	sendBatch := func() error {
+		if err := w.throttle.Wait(len(sendMessageBatchInput.Entries)); err != nil {
+			return err
+		}
+		if _, err := sqsbatch.SendMessageBatch(w.sqsClient, batchTimeout, sendMessageBatchInput); err != nil {
+			return errors.Wrapf(err, "failed to send %#v", sendMessageBatchInput)
+		}
+		if err := w.checkpoint.Sent(batch); err != nil {
+			return err
+		}
+		sendMessageBatchInput.Entries = make([]*sqs.SendMessageBatchRequestEntry, 0, batchSize) // reset
+		batch = make([]*s3Object, 0, batchSize)
+		return nil
+	}
 	var failed bool
-	for s3Notification := range notifyChan {
+	for obj := range notifyChan {
 		if failed { // drain channel
 			continue
 		}
 
 		zap.L().Debug("sending file to SQS",
-			zap.String("bucket", s3Notification.Records[0].S3.Bucket.Name),
-			zap.String("key", s3Notification.Records[0].S3.Object.Key))
+			zap.String("bucket", obj.bucket),
+			zap.String("key", obj.key))
 
-		ctnJSON, err := jsoniter.MarshalToString(s3Notification)
-		if err != nil {
-			errChan <- errors.Wrapf(err, "failed to marshal %#v", s3Notification)
-			failed = true
-			continue
-		}
-
-		// make it look like an SNS notification
-		snsNotification := events.SNSEntity{
-			Type:     "Notification",
-			TopicArn: topicARN, // this is needed by the log processor to get account associated with the S3 object
-			Message:  ctnJSON,
-		}
-		message, err := jsoniter.MarshalToString(snsNotification)
+		entry, err := w.newMessageEntry(obj, len(sendMessageBatchInput.Entries))
 		if err != nil {
-			errChan <- errors.Wrapf(err, "failed to marshal %#v", snsNotification)
+			errChan <- err
 			failed = true
 			continue
 		}
-
-		sendMessageBatchInput.Entries = append(sendMessageBatchInput.Entries, &sqs.SendMessageBatchRequestEntry{
-			Id:          aws.String(strconv.Itoa(len(sendMessageBatchInput.Entries))),
-			MessageBody: &message,
-		})
+		sendMessageBatchInput.Entries = append(sendMessageBatchInput.Entries, entry)
+		batch = append(batch, obj)
 		if len(sendMessageBatchInput.Entries)%batchSize == 0 {
-			_, err = sqsbatch.SendMessageBatch(sqsClient, batchTimeout, sendMessageBatchInput)
-			if err != nil {
-				errChan <- errors.Wrapf(err, "failed to send %#v", sendMessageBatchInput)
+			if err := sendBatch(); err != nil {
+				errChan <- err
 				failed = true
 				continue
 			}
-			sendMessageBatchInput.Entries = make([]*sqs.SendMessageBatchRequestEntry, 0, batchSize) // reset
 		}
 	}
 
 	// send remaining
 	if !failed && len(sendMessageBatchInput.Entries) > 0 {
-		_, err := sqsbatch.SendMessageBatch(sqsClient, batchTimeout, sendMessageBatchInput)
-		if err != nil {
-			errChan <- errors.Wrapf(err, "failed to send %#v", sendMessageBatchInput)
+		if err := sendBatch(); err != nil {
+			errChan <- err
 		}
 	}
 }
+
+func (w *queueWriter) newMessageEntry(obj *s3Object, id int) (*sqs.SendMessageBatchRequestEntry, error) {
+	s3Notification := &events.S3Event{
+		Records: []events.S3EventRecord{
+			{
+				S3: events.S3Entity{
+					Bucket: events.S3Bucket{
+						Name: obj.bucket,
+					},
+					Object: events.S3Object{
+						Key: obj.key,
+					},
+				},
+			},
+		},
+	}
+	ctnJSON, err := jsoniter.MarshalToString(s3Notification)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %#v", s3Notification)
+	}
+
+	// make it look like an SNS notification
+	snsNotification := events.SNSEntity{
+		Type:     "Notification",
+		TopicArn: w.topicARN, // this is needed by the log processor to get account associated with the S3 object
+		Message:  ctnJSON,
+	}
+	if w.sourceID != "" {
+		snsNotification.MessageAttributes = map[string]interface{}{
+			sources.SourceIDAttribute: map[string]interface{}{
+				"Type":  "String",
+				"Value": w.sourceID,
+			},
+		}
+	}
+	message, err := jsoniter.MarshalToString(snsNotification)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %#v", snsNotification)
+	}
+
+	return &sqs.SendMessageBatchRequestEntry{
+		Id:          aws.String(strconv.Itoa(id)),
+		MessageBody: &message,
+	}, nil
+}
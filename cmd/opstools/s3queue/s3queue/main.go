@@ -19,6 +19,7 @@ package main
  */
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
@@ -56,6 +57,17 @@ var (
 	INTERACTIVE = flag.Bool("interactive", true, "If true, prompt for required flags if not set")
 	VERBOSE     = flag.Bool("verbose", false, "Enable verbose logging")
 
+	MODIFIEDAFTER  = flag.String("modified-after", "", "Only send objects last modified at or after this time (RFC3339 or YYYY-MM-DD)")
+	MODIFIEDBEFORE = flag.String("modified-before", "", "Only send objects last modified before this time (RFC3339 or YYYY-MM-DD)")
+	KEYAFTER       = flag.String("key-after", "", "Only send objects with a date in their key at or after this time (RFC3339 or YYYY-MM-DD)")
+	KEYBEFORE      = flag.String("key-before", "", "Only send objects with a date in their key before this time (RFC3339 or YYYY-MM-DD)")
+	INCLUDE        = flag.String("include", "", "Comma separated globs, only send objects with keys matching any of them ('**' matches '/')")
+	EXCLUDE        = flag.String("exclude", "", "Comma separated globs, do not send objects with keys matching any of them ('**' matches '/')")
+	SOURCEID       = flag.String("source-id", "", "If set, process the objects as this source, they must be in its bucket and prefix")
+	MAXQUEUEDEPTH  = flag.Int("max-queue-depth", 0, "If non-zero, wait for the queue to have less than this many messages before sending more")
+	DRYRUN         = flag.Bool("dry-run", false, "If true, print the objects that would be sent to stdout instead of sending them")
+	CHECKPOINT     = flag.String("checkpoint", "", "Save progress to this file and resume from it if it exists")
+
 	logger *zap.SugaredLogger
 )
 
@@ -109,10 +121,11 @@ func main() {
 
 	promptFlags()
 	validateFlags()
+	filter := buildFilter()
 
 	s3Region := getS3Region(sess, *S3PATH)
 
-	if *ACCOUNT == "" {
+	if *ACCOUNT == "" && !*DRYRUN {
 		identity, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
 		if err != nil {
 			logger.Fatalf("failed to get caller identity: %v", err)
@@ -132,21 +145,76 @@ func main() {
 	}
 
 	stats := &s3queue.Stats{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	go func() {
 		sig := make(chan os.Signal, 1)
 		signal.Notify(sig, syscall.SIGTERM, syscall.SIGINT)
 		caught := <-sig // wait for it
+		// stop listing and let the files already listed be sent so the checkpoint is accurate
+		logger.Warnf("caught %v, stopping (send again to exit immediately)", caught)
+		cancel()
+		caught = <-sig
 		logger.Fatalf("caught %v, sent %d files (%.2fMB) to %s in %v",
 			caught, stats.NumFiles, float32(stats.NumBytes)/(1024.0*1024.0), *TOQ, time.Since(startTime))
 	}()
 
-	err = s3queue.S3Queue(sess, *ACCOUNT, *S3PATH, s3Region, *TOQ, *CONCURRENCY, *LIMIT, stats)
-	if err != nil {
+	input := &s3queue.Input{
+		Account:        aws.StringValue(ACCOUNT),
+		S3Path:         *S3PATH,
+		S3Region:       s3Region,
+		QueueName:      *TOQ,
+		Concurrency:    *CONCURRENCY,
+		Limit:          *LIMIT,
+		Filter:         filter,
+		SourceID:       *SOURCEID,
+		MaxQueueDepth:  *MAXQUEUEDEPTH,
+		CheckpointFile: *CHECKPOINT,
+	}
+	if *DRYRUN {
+		input.DryRun = os.Stdout
+	}
+	err = s3queue.Backfill(ctx, sess, input, stats)
+	switch {
+	case err != nil:
 		logger.Fatal(err)
-	} else {
-		logger.Infof("sent %d files (%.2fMB) to %s (%s) in %v",
-			stats.NumFiles, float32(stats.NumBytes)/(1024.0*1024.0), *TOQ, *REGION, time.Since(startTime))
+	case *DRYRUN:
+		logger.Infof("found %d files (%.2fMB) to send, skipped %d files in %v",
+			stats.NumFiles, float32(stats.NumBytes)/(1024.0*1024.0), stats.NumSkipped, time.Since(startTime))
+	default:
+		logger.Infof("sent %d files (%.2fMB) to %s (%s), skipped %d files in %v",
+			stats.NumFiles, float32(stats.NumBytes)/(1024.0*1024.0), *TOQ, *REGION, stats.NumSkipped, time.Since(startTime))
+	}
+}
+
+func buildFilter() *s3queue.Filter {
+	var err error
+	defer func() {
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			flag.Usage()
+			os.Exit(-2)
+		}
+	}()
+
+	filter := &s3queue.Filter{}
+	for flagValue, tm := range map[*string]*time.Time{
+		MODIFIEDAFTER:  &filter.ModifiedAfter,
+		MODIFIEDBEFORE: &filter.ModifiedBefore,
+		KEYAFTER:       &filter.KeyAfter,
+		KEYBEFORE:      &filter.KeyBefore,
+	} {
+		if *tm, err = s3queue.ParseTime(*flagValue); err != nil {
+			return nil
+		}
+	}
+	if filter.Include, err = s3queue.CompileGlobs(*INCLUDE); err != nil {
+		return nil
+	}
+	if filter.Exclude, err = s3queue.CompileGlobs(*EXCLUDE); err != nil {
+		return nil
 	}
+	return filter
 }
 
 func promptFlags() {
@@ -19,16 +19,26 @@ package s3queue
  */
 
 import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 )
 
 const (
@@ -49,7 +59,7 @@ func TestS3Queue(t *testing.T) {
 			},
 		},
 	}
-	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Once()
+	s3Client.On("ListObjectsV2PagesWithContext", mock.Anything, mock.Anything).Return(page, nil).Once()
 	sqsClient := &mockSQS{}
 	sqsClient.On("GetQueueUrl", mock.Anything).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("arn")}, nil).Once()
 	sqsClient.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
@@ -77,7 +87,7 @@ func TestS3QueueLimit(t *testing.T) {
 			},
 		},
 	}
-	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Once()
+	s3Client.On("ListObjectsV2PagesWithContext", mock.Anything, mock.Anything).Return(page, nil).Once()
 	sqsClient := &mockSQS{}
 	sqsClient.On("GetQueueUrl", mock.Anything).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("arn")}, nil).Once()
 	sqsClient.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
@@ -102,7 +112,7 @@ func TestS3QueueBatch(t *testing.T) {
 	page := &s3.ListObjectsV2Output{
 		Contents: contents,
 	}
-	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(page, nil).Once()
+	s3Client.On("ListObjectsV2PagesWithContext", mock.Anything, mock.Anything).Return(page, nil).Once()
 	sqsClient := &mockSQS{}
 	sqsClient.On("GetQueueUrl", mock.Anything).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("arn")}, nil).Once()
 	sqsClient.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).Times(3)
@@ -115,12 +125,141 @@ func TestS3QueueBatch(t *testing.T) {
 	assert.Equal(t, uint64(len(contents)), stats.NumFiles)
 }
 
+func TestBackfill(t *testing.T) {
+	assert := require.New(t)
+	dir, err := ioutil.TempDir("", "s3queue")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	checkpointFile := filepath.Join(dir, "checkpoint.json")
+	assert.NoError((&Checkpoint{S3Path: testS3Path, StartAfter: "bar/0"}).Save(checkpointFile))
+
+	modified := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	s3Client := &mockS3{}
+	page := &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{Size: aws.Int64(1), Key: aws.String("bar/2020/01/02/1.gz"), LastModified: &modified},
+			{Size: aws.Int64(2), Key: aws.String("bar/2020/01/02/2.json"), LastModified: &modified},
+			{Size: aws.Int64(3), Key: aws.String("bar/2019/12/31/3.gz"), LastModified: &modified},
+			{Size: aws.Int64(4), Key: aws.String("bar/2020/01/03/4.gz"), LastModified: &modified},
+		},
+	}
+	s3Client.On("ListObjectsV2PagesWithContext", &s3.ListObjectsV2Input{
+		Bucket:     aws.String(testBucket),
+		Prefix:     aws.String(testKey),
+		MaxKeys:    aws.Int64(pageSize),
+		StartAfter: aws.String("bar/0"),
+	}, mock.Anything).Return(page, nil).Once()
+	sqsClient := &mockSQS{}
+	sqsClient.On("GetQueueUrl", mock.Anything).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("arn")}, nil).Once()
+	sqsClient.On("GetQueueAttributes", mock.Anything).Return(&sqs.GetQueueAttributesOutput{
+		Attributes: map[string]*string{
+			sqs.QueueAttributeNameApproximateNumberOfMessages: aws.String("0"),
+		},
+	}, nil).Once()
+	var entries []*sqs.SendMessageBatchRequestEntry
+	sqsClient.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil).Once().Run(func(args mock.Arguments) {
+		entries = args.Get(0).(*sqs.SendMessageBatchInput).Entries // entries are reset after sending
+	})
+
+	include, err := CompileGlobs("**.gz")
+	assert.NoError(err)
+	stats := &Stats{}
+	err = backfill(context.Background(), s3Client, sqsClient, &Input{
+		Account:     testAccount,
+		S3Path:      testS3Path,
+		QueueName:   testQueueName,
+		Concurrency: 1,
+		Filter: &Filter{
+			KeyAfter: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			Include:  include,
+		},
+		SourceID:       "source-id",
+		MaxQueueDepth:  100,
+		CheckpointFile: checkpointFile,
+	}, stats)
+	assert.NoError(err)
+	s3Client.AssertExpectations(t)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(&Stats{NumFiles: 2, NumBytes: 5, NumSkipped: 2}, stats)
+
+	assert.Len(entries, 2)
+	var notification sources.SnsNotification
+	assert.NoError(jsoniter.UnmarshalFromString(*entries[0].MessageBody, &notification))
+	assert.Equal("arn:aws:sns:us-east-1:"+testAccount+":panther-fake-s3queue-topic", notification.TopicArn)
+	assert.Equal(map[string]interface{}{
+		sources.SourceIDAttribute: map[string]interface{}{"Type": "String", "Value": "source-id"},
+	}, notification.MessageAttributes)
+	objects, err := sources.ParseNotification(notification.Message)
+	assert.NoError(err)
+	assert.Equal([]*sources.S3ObjectInfo{{S3Bucket: testBucket, S3ObjectKey: "bar/2020/01/02/1.gz"}}, objects)
+
+	checkpoint, err := LoadCheckpoint(checkpointFile, testS3Path)
+	assert.NoError(err)
+	assert.Equal("bar/2020/01/03/4.gz", checkpoint.StartAfter)
+	assert.Equal(uint64(2), checkpoint.NumFiles)
+	assert.Equal(uint64(5), checkpoint.NumBytes)
+}
+
+func TestBackfillDryRun(t *testing.T) {
+	modified := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	s3Client := &mockS3{}
+	page := &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{Size: aws.Int64(1), Key: aws.String("bar/1.gz"), LastModified: &modified},
+			{Size: aws.Int64(0), Key: aws.String("bar/empty.gz"), LastModified: &modified},
+		},
+	}
+	s3Client.On("ListObjectsV2PagesWithContext", mock.Anything, mock.Anything).Return(page, nil).Once()
+	sqsClient := &mockSQS{}
+
+	var out bytes.Buffer
+	stats := &Stats{}
+	err := backfill(context.Background(), s3Client, sqsClient, &Input{
+		S3Path: testS3Path,
+		DryRun: &out,
+	}, stats)
+	require.NoError(t, err)
+	s3Client.AssertExpectations(t)
+	sqsClient.AssertExpectations(t)
+	assert.Equal(t, "s3://foo/bar/1.gz\t1\t2020-01-02T03:04:05Z\n", out.String())
+	assert.Equal(t, uint64(1), stats.NumFiles)
+}
+
+func TestQueueThrottle(t *testing.T) {
+	assert := require.New(t)
+	sqsClient := &mockSQS{}
+	depth := func(n string) *sqs.GetQueueAttributesOutput {
+		return &sqs.GetQueueAttributesOutput{
+			Attributes: map[string]*string{
+				sqs.QueueAttributeNameApproximateNumberOfMessages: aws.String(n),
+			},
+		}
+	}
+	sqsClient.On("GetQueueAttributes", mock.Anything).Return(depth("15"), nil).Once()
+	sqsClient.On("GetQueueAttributes", mock.Anything).Return(depth("5"), nil).Once()
+	sqsClient.On("GetQueueAttributes", mock.Anything).Return(depth("x"), nil).Once()
+
+	assert.Nil(newQueueThrottle(sqsClient, aws.String("url"), 0))
+	throttle := newQueueThrottle(sqsClient, aws.String("url"), 10)
+	throttle.interval = time.Millisecond
+	assert.NoError(throttle.Wait(5))
+	assert.Equal(10, throttle.depth)
+	throttle.lastCheck = time.Time{}
+	assert.Error(throttle.Wait(5))
+	sqsClient.AssertExpectations(t)
+
+	var nilThrottle *queueThrottle
+	assert.NoError(nilThrottle.Wait(10))
+}
+
 type mockS3 struct {
 	s3iface.S3API
 	mock.Mock
 }
 
-func (m *mockS3) ListObjectsV2Pages(input *s3.ListObjectsV2Input, f func(page *s3.ListObjectsV2Output, morePages bool) bool) error {
+func (m *mockS3) ListObjectsV2PagesWithContext(ctx aws.Context, input *s3.ListObjectsV2Input,
+	f func(page *s3.ListObjectsV2Output, morePages bool) bool, _ ...request.Option) error {
+
 	args := m.Called(input, f)
 	f(args.Get(0).(*s3.ListObjectsV2Output), false)
 	return args.Error(1)
@@ -141,3 +280,8 @@ func (m *mockSQS) SendMessageBatch(input *sqs.SendMessageBatchInput) (*sqs.SendM
 	args := m.Called(input)
 	return args.Get(0).(*sqs.SendMessageBatchOutput), args.Error(1)
 }
+
+func (m *mockSQS) GetQueueAttributes(input *sqs.GetQueueAttributesInput) (*sqs.GetQueueAttributesOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.GetQueueAttributesOutput), args.Error(1)
+}
@@ -0,0 +1,107 @@
+package s3queue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// defaultQueueDepthInterval is how often the depth of the queue is checked when throttling
+const defaultQueueDepthInterval = 10 * time.Second
+
+// queueThrottle blocks writers while the number of messages in the queue is above a limit,
+// so that a backfill does not build a backlog that delays processing of new data.
+type queueThrottle struct {
+	sqsClient sqsiface.SQSAPI
+	queueURL  *string
+	maxDepth  int
+	interval  time.Duration
+
+	mu        sync.Mutex
+	depth     int // the last known depth plus the messages sent since
+	lastCheck time.Time
+}
+
+func newQueueThrottle(sqsClient sqsiface.SQSAPI, queueURL *string, maxDepth int) *queueThrottle {
+	if maxDepth <= 0 {
+		return nil
+	}
+	return &queueThrottle{
+		sqsClient: sqsClient,
+		queueURL:  queueURL,
+		maxDepth:  maxDepth,
+		interval:  defaultQueueDepthInterval,
+	}
+}
+
+// Wait blocks until the queue has room for numMessages.
+// Writers are serialized while waiting. It is a no-op on a nil throttle.
+func (t *queueThrottle) Wait(numMessages int) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for {
+		if time.Since(t.lastCheck) >= t.interval {
+			depth, err := t.queueDepth()
+			if err != nil {
+				return err
+			}
+			t.depth = depth
+			t.lastCheck = time.Now()
+		}
+		if t.depth+numMessages <= t.maxDepth || t.depth == 0 {
+			t.depth += numMessages
+			return nil
+		}
+		zap.L().Info("waiting for queue depth to drop",
+			zap.Int("depth", t.depth), zap.Int("maxDepth", t.maxDepth))
+		time.Sleep(t.interval - time.Since(t.lastCheck))
+	}
+}
+
+func (t *queueThrottle) queueDepth() (int, error) {
+	output, err := t.sqsClient.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameApproximateNumberOfMessages)},
+		QueueUrl:       t.queueURL,
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failure getting message count from %s", aws.StringValue(t.queueURL))
+	}
+	value := output.Attributes[sqs.QueueAttributeNameApproximateNumberOfMessages]
+	if value == nil {
+		return 0, errors.Errorf("failure getting %s count from %s",
+			sqs.QueueAttributeNameApproximateNumberOfMessages, aws.StringValue(t.queueURL))
+	}
+	depth, err := strconv.Atoi(*value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failure reading %s (%s) count from %s",
+			sqs.QueueAttributeNameApproximateNumberOfMessages, *value, aws.StringValue(t.queueURL))
+	}
+	return depth, nil
+}
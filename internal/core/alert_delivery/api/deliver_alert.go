@@ -27,6 +27,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/gateway/analysis/client/operations"
+	analysisModels "github.com/panther-labs/panther/api/gateway/analysis/models"
 	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
 	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	alertTable "github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
@@ -99,22 +100,11 @@ func populateAlertData(alertItem *alertTable.AlertItem) (*deliveryModels.Alert,
 		zap.String("ruleVersion", alertItem.RuleVersion),
 	}
 
-	response, err := analysisClient.Operations.GetRule(&operations.GetRuleParams{
-		RuleID:     alertItem.RuleID,
-		VersionID:  &alertItem.RuleVersion,
-		HTTPClient: httpClient,
-	})
+	rule, err := getRule(alertItem)
 	if err != nil {
 		zap.L().Error("Error retrieving rule", append(commonFields, zap.Error(err))...)
 		return nil, &genericapi.InternalError{Message: genericErrorMessage}
 	}
-
-	if response == nil {
-		zap.L().Error("Rule response was nil", commonFields...)
-		return nil, &genericapi.InternalError{Message: genericErrorMessage}
-	}
-
-	rule := response.GetPayload()
 	if rule == nil {
 		zap.L().Error("Rule response payload was nil", commonFields...)
 		return nil, &genericapi.InvalidInputError{Message: genericErrorMessage}
@@ -139,6 +129,38 @@ func populateAlertData(alertItem *alertTable.AlertItem) (*deliveryModels.Alert,
 	}, nil
 }
 
+// getRule - fetches the rule or the scheduled query that generated an alert
+func getRule(alertItem *alertTable.AlertItem) (*analysisModels.Rule, error) {
+	if alertItem.AnalysisType == string(analysisModels.AnalysisTypeSCHEDULEDQUERY) {
+		response, err := analysisClient.Operations.GetQuery(&operations.GetQueryParams{
+			QueryID:    alertItem.RuleID,
+			VersionID:  &alertItem.RuleVersion,
+			HTTPClient: httpClient,
+		})
+		if err != nil || response.GetPayload() == nil {
+			return nil, err
+		}
+		query := response.GetPayload()
+		return &analysisModels.Rule{
+			Description: query.Description,
+			DisplayName: query.DisplayName,
+			ID:          query.ID,
+			Runbook:     query.Runbook,
+			Tags:        query.Tags,
+		}, nil
+	}
+
+	response, err := analysisClient.Operations.GetRule(&operations.GetRuleParams{
+		RuleID:     alertItem.RuleID,
+		VersionID:  &alertItem.RuleVersion,
+		HTTPClient: httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetPayload(), nil
+}
+
 // getAlertOutputMapping - gets a map for a given alert to it's outputIds
 func getAlertOutputMapping(alert *deliveryModels.Alert, outputIds []string) (AlertOutputMap, error) {
 	// Initialize our Alert -> Output map
@@ -190,6 +190,46 @@ func TestPopulateAlert(t *testing.T) {
 	mockRoundTripper.AssertExpectations(t)
 }
 
+func TestPopulateAlertScheduledQuery(t *testing.T) {
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient = &http.Client{Transport: mockRoundTripper}
+	analysisConfig := analysisApiClient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	analysisClient = analysisApiClient.NewHTTPClientWithConfig(nil, analysisConfig)
+
+	timeNow := time.Now().UTC()
+	alertItem := &alertTable.AlertItem{
+		AlertID:      "alert-id",
+		RuleID:       "Test.Query.ID",
+		RuleVersion:  "version",
+		Title:        aws.String("Test Alert"),
+		CreationTime: timeNow,
+		Severity:     "HIGH",
+		AnalysisType: string(analysisModels.AnalysisTypeSCHEDULEDQUERY),
+	}
+	query := &analysisModels.ScheduledQuery{
+		Description: "A test query",
+		DisplayName: "Test Query Name",
+		ID:          "Test.Query.ID",
+		Runbook:     "A runbook link",
+		Tags:        []string{"test"},
+	}
+
+	// the scheduled query endpoint is used instead of the rule endpoint
+	isQueryRequest := mock.MatchedBy(func(request *http.Request) bool {
+		return strings.HasSuffix(request.URL.Path, "/query")
+	})
+	mockRoundTripper.On("RoundTrip", isQueryRequest).Return(generateResponse(query, http.StatusOK), nil).Once()
+	alert, err := populateAlertData(alertItem)
+	require.NoError(t, err)
+	require.Equal(t, "Test.Query.ID", alert.AnalysisID)
+	require.Equal(t, "Test Query Name", aws.StringValue(alert.AnalysisName))
+	require.Equal(t, "A test query", aws.StringValue(alert.AnalysisDescription))
+	require.Equal(t, []string{"test"}, alert.Tags)
+	mockRoundTripper.AssertExpectations(t)
+}
+
 func generateResponse(body interface{}, httpCode int) *http.Response {
 	serializedBody, _ := jsoniter.MarshalToString(body)
 	return &http.Response{StatusCode: httpCode, Body: ioutil.NopCloser(strings.NewReader(serializedBody))}
@@ -38,6 +38,7 @@ var (
 	policyURLPrefix = os.Getenv("POLICY_URL_PREFIX")
 	appDomainURL    = os.Getenv("APP_DOMAIN_URL")
 	alertURLPrefix  = os.Getenv("ALERT_URL_PREFIX")
+	sourceURLPrefix = os.Getenv("SOURCE_URL_PREFIX")
 )
 
 // HTTPWrapper encapsulates the Golang's http client
@@ -107,7 +108,7 @@ const detailedMessageTemplate = "%s\nFor more details please visit: %s\nSeverity
 // if they have `null` fields. However, we need to ensure there are no `null` arrays or
 // objects.
 type Notification struct {
-	// [REQUIRED] The Policy or Rule ID
+	// [REQUIRED] The Policy or Rule ID, or the source ID for source freshness alerts
 	ID string `json:"id"`
 
 	// [REQUIRED] The timestamp (RFC3339) of the alert at creation.
@@ -116,7 +117,7 @@ type Notification struct {
 	// [REQUIRED] The severity enum of the alert set in Panther UI. Will be one of INFO LOW MEDIUM HIGH CRITICAL.
 	Severity string `json:"severity"`
 
-	// [REQUIRED] The Type enum if an alert is for a rule or policy. Will be one of RULE POLICY.
+	// [REQUIRED] The Type enum if an alert is for a rule, policy or source. Will be one of RULE POLICY SOURCE_FRESHNESS.
 	Type string `json:"type"`
 
 	// [REQUIRED] Link to the alert in Panther UI
@@ -164,6 +165,9 @@ func generateNotificationFromAlert(alert *alertModels.Alert) Notification {
 }
 
 func generateAlertMessage(alert *alertModels.Alert) string {
+	if alert.Type == alertModels.SourceFreshnessType {
+		return aws.StringValue(alert.Title)
+	}
 	if alert.Type == alertModels.RuleType {
 		return getDisplayName(alert) + " triggered"
 	}
@@ -182,6 +186,9 @@ func generateDetailedAlertMessage(alert *alertModels.Alert) string {
 }
 
 func generateAlertTitle(alert *alertModels.Alert) string {
+	if alert.Type == alertModels.SourceFreshnessType {
+		return "System Alert: " + aws.StringValue(alert.Title)
+	}
 	if alert.IsResent {
 		return "[Re-sent]: " + *alert.Title
 	}
@@ -208,5 +215,8 @@ func generateURL(alert *alertModels.Alert) string {
 	if alert.Type == alertModels.RuleType {
 		return alertURLPrefix + *alert.AlertID
 	}
+	if alert.Type == alertModels.SourceFreshnessType {
+		return sourceURLPrefix
+	}
 	return policyURLPrefix + alert.AnalysisID
 }
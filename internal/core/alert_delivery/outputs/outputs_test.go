@@ -31,6 +31,7 @@ import (
 func init() {
 	policyURLPrefix = "https://panther.io/policies/"
 	alertURLPrefix = "https://panther.io/alerts/"
+	sourceURLPrefix = "https://panther.io/sources/"
 }
 
 type mockHTTPWrapper struct {
@@ -82,3 +83,13 @@ func TestGenerateAlertTitlePolicyId(t *testing.T) {
 	}
 	assert.Equal(t, "Policy Failure: policy.id", generateAlertTitle(alert))
 }
+
+func TestGenerateAlertTitleSourceFreshness(t *testing.T) {
+	alert := &alertModel.Alert{
+		Type:  alertModel.SourceFreshnessType,
+		Title: aws.String("Source my-source has not sent data for over 2h"),
+	}
+	assert.Equal(t, "System Alert: Source my-source has not sent data for over 2h", generateAlertTitle(alert))
+	assert.Equal(t, "Source my-source has not sent data for over 2h", generateAlertMessage(alert))
+	assert.Equal(t, "https://panther.io/sources/", generateURL(alert))
+}
@@ -0,0 +1,105 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/internal/log_analysis/scheduled_queries/schedule"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// CreateQuery adds a new scheduled query to the Dynamo table.
+func CreateQuery(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseUpdateQuery(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	item := queryItem(input)
+	if _, err := writeItem(item, input.UserID, aws.Bool(false)); err != nil {
+		if err == errExists {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusConflict}
+		}
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	return gatewayapi.MarshalResponse(item.ScheduledQuery(), http.StatusCreated)
+}
+
+// body parsing shared by CreateQuery and ModifyQuery
+func parseUpdateQuery(request *events.APIGatewayProxyRequest) (*models.UpdateScheduledQuery, error) {
+	var result models.UpdateScheduledQuery
+	if err := jsoniter.UnmarshalFromString(request.Body, &result); err != nil {
+		return nil, err
+	}
+
+	// in case it is not set, put a default. Minimum value for DedupPeriodMinutes is 15, so 0 means it's not set
+	if result.DedupPeriodMinutes == 0 {
+		result.DedupPeriodMinutes = defaultDedupPeriodMinutes
+	}
+
+	if err := result.Validate(nil); err != nil {
+		return nil, err
+	}
+
+	// Alerts from a query have the log types of the query
+	if len(result.LogTypes) == 0 {
+		return nil, errors.New("logTypes: at least one log type is required")
+	}
+
+	if _, err := schedule.Parse(string(result.Schedule)); err != nil {
+		return nil, fmt.Errorf("schedule: %v", err)
+	}
+
+	// Query names are embedded in emails, alert outputs, etc. Prevent a possible injection attack
+	if genericapi.ContainsHTML(string(result.DisplayName)) {
+		return nil, fmt.Errorf("display name: %v", genericapi.ErrContainsHTML)
+	}
+
+	return &result, nil
+}
+
+func queryItem(input *models.UpdateScheduledQuery) *tableItem {
+	return &tableItem{
+		Body:               input.Body,
+		DedupPeriodMinutes: input.DedupPeriodMinutes,
+		Description:        input.Description,
+		DisplayName:        input.DisplayName,
+		Enabled:            input.Enabled,
+		ID:                 input.ID,
+		LookbackMinutes:    input.LookbackMinutes,
+		OutputIds:          input.OutputIds,
+		Reference:          input.Reference,
+		ResourceTypes:      input.LogTypes,
+		Runbook:            input.Runbook,
+		Schedule:           input.Schedule,
+		Severity:           input.Severity,
+		Tags:               input.Tags,
+		Type:               typeQuery,
+	}
+}
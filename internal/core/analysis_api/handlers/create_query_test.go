@@ -0,0 +1,74 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+const testQueryBody = `{
+	"body": "SELECT sourceIPAddress AS p_alert_dedup FROM panther_logs.aws_cloudtrail WHERE {partition_filter}",
+	"enabled": true,
+	"id": "Query.Root.Logins",
+	"logTypes": ["AWS.CloudTrail"],
+	"lookbackMinutes": 60,
+	"schedule": "rate(1 hour)",
+	"severity": "HIGH",
+	"userId": "5f54cf4a-ec56-44c2-83bc-8b742600f307"
+}`
+
+func TestParseUpdateQuery(t *testing.T) {
+	result, err := parseUpdateQuery(&events.APIGatewayProxyRequest{Body: testQueryBody})
+	require.NoError(t, err)
+	assert.Equal(t, models.DedupPeriodMinutes(defaultDedupPeriodMinutes), result.DedupPeriodMinutes)
+	assert.Equal(t, models.Schedule("rate(1 hour)"), result.Schedule)
+
+	item := queryItem(result)
+	assert.Equal(t, typeQuery, item.Type)
+	assert.Equal(t, models.TypeSet{"AWS.CloudTrail"}, item.ResourceTypes)
+}
+
+func TestParseUpdateQueryInvalidSchedule(t *testing.T) {
+	body := `{"body": "SELECT 1 AS one", "enabled": true, "id": "q", "logTypes": ["AWS.CloudTrail"], "lookbackMinutes": 60,
+		"schedule": "cron(61 * * * *)", "severity": "LOW", "userId": "5f54cf4a-ec56-44c2-83bc-8b742600f307"}`
+	_, err := parseUpdateQuery(&events.APIGatewayProxyRequest{Body: body})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "schedule")
+}
+
+func TestParseUpdateQueryNoLogTypes(t *testing.T) {
+	body := `{"body": "SELECT 1 AS one", "enabled": true, "id": "q", "logTypes": [], "lookbackMinutes": 60,
+		"schedule": "rate(5 minutes)", "severity": "LOW", "userId": "5f54cf4a-ec56-44c2-83bc-8b742600f307"}`
+	_, err := parseUpdateQuery(&events.APIGatewayProxyRequest{Body: body})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logTypes")
+}
+
+func TestParseUpdateQueryLookback(t *testing.T) {
+	body := `{"body": "SELECT 1 AS one", "enabled": true, "id": "q", "logTypes": ["AWS.CloudTrail"], "lookbackMinutes": 0,
+		"schedule": "rate(5 minutes)", "severity": "LOW", "userId": "5f54cf4a-ec56-44c2-83bc-8b742600f307"}`
+	_, err := parseUpdateQuery(&events.APIGatewayProxyRequest{Body: body})
+	require.Error(t, err)
+}
@@ -38,6 +38,7 @@ const (
 	typePolicy       = string(models.AnalysisTypePOLICY)
 	typeGlobal       = string(models.AnalysisTypeGLOBAL)
 	typeRule         = string(models.AnalysisTypeRULE)
+	typeQuery        = string(models.AnalysisTypeSCHEDULEDQUERY)
 	maxDynamoBackoff = 30 * time.Second
 )
 
@@ -60,6 +61,7 @@ type tableItem struct {
 	ID                        models.ID                        `json:"id"`
 	LastModified              models.ModifyTime                `json:"lastModified"`
 	LastModifiedBy            models.UserID                    `json:"lastModifiedBy"`
+	LookbackMinutes           models.LookbackMinutes           `json:"lookbackMinutes,omitempty"`
 
 	// Lowercase versions of string fields for easy filtering
 	LowerDisplayName string   `json:"lowerDisplayName,omitempty"`
@@ -71,12 +73,13 @@ type tableItem struct {
 	Reports       models.Reports      `json:"reports,omitempty"`
 	ResourceTypes models.TypeSet      `json:"resourceTypes,omitempty" dynamodbav:"resourceTypes,stringset,omitempty"`
 	Runbook       models.Runbook      `json:"runbook,omitempty"`
+	Schedule      models.Schedule     `json:"schedule,omitempty"`
 	Severity      models.Severity     `json:"severity"`
 	Suppressions  models.Suppressions `json:"suppressions,omitempty" dynamodbav:"suppressions,stringset,omitempty"`
 	Tags          models.Tags         `json:"tags,omitempty" dynamodbav:"tags,stringset,omitempty"`
 	Tests         []*models.UnitTest  `json:"tests,omitempty"`
 
-	// Logic type (policy or rule)
+	// Logic type (policy, rule, global or scheduled query)
 	Type string `json:"type"`
 
 	VersionID models.VersionID `json:"versionId,omitempty"`
@@ -175,6 +178,34 @@ func (r *tableItem) Rule() *models.Rule {
 	return result
 }
 
+// ScheduledQuery converts a Dynamo row into a ScheduledQuery external model.
+func (r *tableItem) ScheduledQuery() *models.ScheduledQuery {
+	r.normalize()
+	result := &models.ScheduledQuery{
+		Body:               r.Body,
+		CreatedAt:          r.CreatedAt,
+		CreatedBy:          r.CreatedBy,
+		DedupPeriodMinutes: r.DedupPeriodMinutes,
+		Description:        r.Description,
+		DisplayName:        r.DisplayName,
+		Enabled:            r.Enabled,
+		ID:                 r.ID,
+		LastModified:       r.LastModified,
+		LastModifiedBy:     r.LastModifiedBy,
+		LogTypes:           r.ResourceTypes,
+		LookbackMinutes:    r.LookbackMinutes,
+		OutputIds:          r.OutputIds,
+		Reference:          r.Reference,
+		Runbook:            r.Runbook,
+		Schedule:           r.Schedule,
+		Severity:           r.Severity,
+		Tags:               r.Tags,
+		VersionID:          r.VersionID,
+	}
+	gatewayapi.ReplaceMapSliceNils(result)
+	return result
+}
+
 // Global converts a Dynamo row into a Global external model.
 func (r *tableItem) Global() *models.Global {
 	r.normalize()
@@ -50,7 +50,12 @@ func GetGlobal(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyRe
 	return handleGet(request, typeGlobal)
 }
 
-// Handle GET request for GetPolicy, GetRule, and GetGlobal
+// GetQuery retrieves a scheduled query from Dynamo or S3.
+func GetQuery(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	return handleGet(request, typeQuery)
+}
+
+// Handle GET request for GetPolicy, GetRule, GetGlobal, and GetQuery
 func handleGet(request *events.APIGatewayProxyRequest, codeType string) *events.APIGatewayProxyResponse {
 	input, err := parseGet(request, codeType)
 	if err != nil {
@@ -96,6 +101,9 @@ func handleGet(request *events.APIGatewayProxyRequest, codeType string) *events.
 		}
 		return gatewayapi.MarshalResponse(rule, http.StatusOK)
 	}
+	if codeType == typeQuery {
+		return gatewayapi.MarshalResponse(item.ScheduledQuery(), http.StatusOK)
+	}
 	return gatewayapi.MarshalResponse(item.Global(), http.StatusOK)
 }
 
@@ -110,6 +118,8 @@ func parseGet(request *events.APIGatewayProxyRequest, codeType string) (*getPara
 		idKey = "ruleId"
 	} else if codeType == typeGlobal {
 		idKey = "globalId"
+	} else if codeType == typeQuery {
+		idKey = "queryId"
 	}
 	id, err := url.QueryUnescape(request.QueryStringParameters[idKey])
 	if err != nil {
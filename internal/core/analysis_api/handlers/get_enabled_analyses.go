@@ -32,7 +32,7 @@ import (
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
 
-// GetEnabledAnalyses fetches all enabled policies or rules.
+// GetEnabledAnalyses fetches all enabled policies, rules or scheduled queries.
 func GetEnabledAnalyses(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
 	analysisType, err := parseAnalysisType(request)
 	if err != nil {
@@ -50,9 +50,11 @@ func GetEnabledAnalyses(request *events.APIGatewayProxyRequest) *events.APIGatew
 			Body:               policy.Body,
 			DedupPeriodMinutes: policy.DedupPeriodMinutes,
 			ID:                 policy.ID,
+			LookbackMinutes:    policy.LookbackMinutes,
 			OutputIds:          policy.OutputIds,
 			Reports:            policy.Reports,
 			ResourceTypes:      policy.ResourceTypes,
+			Schedule:           policy.Schedule,
 			Severity:           policy.Severity,
 			Suppressions:       policy.Suppressions,
 			Tags:               policy.Tags,
@@ -84,9 +86,11 @@ func buildEnabledScan(ruleType string) (*dynamodb.ScanInput, error) {
 		expression.Name("body"),
 		expression.Name("dedupPeriodMinutes"),
 		expression.Name("id"),
+		expression.Name("lookbackMinutes"),
 		expression.Name("outputIds"),
 		expression.Name("reports"),
 		expression.Name("resourceTypes"),
+		expression.Name("schedule"),
 		expression.Name("severity"),
 		expression.Name("suppressions"),
 		expression.Name("tags"),
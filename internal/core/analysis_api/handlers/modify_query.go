@@ -0,0 +1,47 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// ModifyQuery updates an existing scheduled query.
+func ModifyQuery(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseUpdateQuery(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	item := queryItem(input)
+	if _, err := writeItem(item, input.UserID, aws.Bool(true)); err != nil {
+		if err == errNotExists || err == errWrongType {
+			// errWrongType means we tried to modify a query which is actually a rule or policy.
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+		}
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	return gatewayapi.MarshalResponse(item.ScheduledQuery(), http.StatusOK)
+}
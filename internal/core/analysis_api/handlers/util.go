@@ -232,7 +232,7 @@ func writeItem(item *tableItem, userID models.UserID, mustExist *bool) (int, err
 		return changeType, err
 	}
 
-	if item.Type == typeRule {
+	if item.Type == typeRule || item.Type == typeQuery {
 		return changeType, nil
 	}
 
@@ -267,6 +267,7 @@ func itemUpdated(oldItem, newItem *tableItem) bool {
 		oldItem.Runbook == newItem.Runbook && oldItem.Severity == newItem.Severity &&
 		oldItem.DedupPeriodMinutes == newItem.DedupPeriodMinutes &&
 		oldItem.Threshold == newItem.Threshold &&
+		oldItem.LookbackMinutes == newItem.LookbackMinutes && oldItem.Schedule == newItem.Schedule &&
 		setEquality(oldItem.ResourceTypes, newItem.ResourceTypes) &&
 		setEquality(oldItem.Suppressions, newItem.Suppressions) && setEquality(oldItem.Tags, newItem.Tags) &&
 		len(oldItem.AutoRemediationParameters) == len(newItem.AutoRemediationParameters) &&
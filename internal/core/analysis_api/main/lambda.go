@@ -47,6 +47,11 @@ var methodHandlers = map[string]gatewayapi.RequestHandler{
 	"POST /global/update": handlers.ModifyGlobal,
 	"POST /global/delete": handlers.DeleteGlobal,
 
+	// Scheduled queries only
+	"GET /query":         handlers.GetQuery,
+	"POST /query":        handlers.CreateQuery,
+	"POST /query/update": handlers.ModifyQuery,
+
 	// Rules and Policies
 	"POST /delete": handlers.DeletePolicies,
 	"GET /enabled": handlers.GetEnabledAnalyses,
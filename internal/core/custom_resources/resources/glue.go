@@ -38,6 +38,8 @@ type UpdateGlueTablesProperties struct {
 	// TablesSignature should change every time the tables change (for CF master.yml this can be the Panther version)
 	TablesSignature     string `validate:"required"`
 	ProcessedDataBucket string `validate:"required"`
+	// EnablePartitionProjection configures Athena partition projection on the tables instead of registering partitions
+	EnablePartitionProjection bool `json:",string"`
 }
 
 func customUpdateGlueTables(_ context.Context, event cfn.Event) (string, map[string]interface{}, error) {
@@ -69,11 +71,24 @@ func customUpdateGlueTables(_ context.Context, event cfn.Event) (string, map[str
 		}
 		logTypes := make([]string, len(deployedLogTables))
 		for i, logTable := range deployedLogTables {
-			zap.L().Info("updating table", zap.String("database", logTable.DatabaseName()), zap.String("table", logTable.TableName()))
+			zap.L().Info("updating table",
+				zap.String("database", logTable.DatabaseName()),
+				zap.String("table", logTable.TableName()),
+				zap.Bool("partitionProjection", props.EnablePartitionProjection))
+
+			if props.EnablePartitionProjection {
+				logTable = logTable.WithPartitionProjection(awsglue.PartitionProjectionFromYear)
+			} else {
+				logTable = logTable.WithoutPartitionProjection()
+			}
 
 			// update catalog
 			_, err := gluetables.CreateOrUpdateGlueTables(glueClient, props.ProcessedDataBucket, logTable)
-			if err != nil {
+			if schemaErr, refused := errors.Cause(err).(*awsglue.SchemaChangeError); refused {
+				// do not fail the deployment, the table keeps its deployed schema until archived explicitly
+				zap.L().Error("breaking schema change refused, run `gluesync -breaking archive` to apply it",
+					zap.String("changes", schemaErr.Diff.String()))
+			} else if err != nil {
 				return "", nil, err
 			}
 
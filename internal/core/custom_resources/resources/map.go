@@ -120,7 +120,9 @@ var CustomResources = map[string]cfn.CustomResourceFunction{
 	// Updates databases and table schemas
 	//
 	// Parameters:
-	//    DeploymentId:  string (required)
+	//    TablesSignature:           string (required)
+	//    ProcessedDataBucket:       string (required)
+	//    EnablePartitionProjection: bool (default: false)
 	// Outputs: None
 	// PhysicalId: custom:glue:update-tables
 	"Custom::UpdateGlueTables": customUpdateGlueTables,
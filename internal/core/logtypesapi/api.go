@@ -33,10 +33,28 @@ import (
 type LogTypesAPI struct {
 	NativeLogTypes func() []string
 	Database       LogTypesDatabase
+	// If set, lookup table data must be stored under LookupTablesPrefix in this bucket
+	LookupTablesBucket string
 }
 
 // LogTypesDatabase handles the external actions required for LogTypesAPI to be implemented
 type LogTypesDatabase interface {
 	// Return an index of available log types
 	IndexLogTypes(ctx context.Context) ([]string, error)
+	// Return the retention setting of a log type or nil if it is not set
+	GetRetention(ctx context.Context, logType string) (*LogTypeRetention, error)
+	// Return all retention settings
+	ListRetention(ctx context.Context) ([]LogTypeRetention, error)
+	// Store the retention setting of a log type
+	PutRetention(ctx context.Context, retention *LogTypeRetention) error
+	// Remove the retention setting of a log type
+	DeleteRetention(ctx context.Context, logType string) error
+	// Return a lookup table or nil if it does not exist
+	GetLookupTable(ctx context.Context, name string) (*LookupTable, error)
+	// Return all lookup tables
+	ListLookupTables(ctx context.Context) ([]LookupTable, error)
+	// Store a lookup table
+	PutLookupTable(ctx context.Context, table *LookupTable) error
+	// Remove a lookup table
+	DeleteLookupTable(ctx context.Context, name string) error
 }
@@ -18,14 +18,89 @@ package logtypesapi_test
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
-import "context"
+import (
+	"context"
+	"sort"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+)
 
 // TestCase implements logtypes.ExternalAPI
 // TODO: Generate test cases with go generate
 type TestCase struct {
 	ListLogTypesOutput []string
+	Retention          map[string]int
+	LookupTables       map[string]logtypesapi.LookupTable
 }
 
 func (t *TestCase) IndexLogTypes(_ context.Context) ([]string, error) {
 	return t.ListLogTypesOutput, nil
 }
+
+func (t *TestCase) GetRetention(_ context.Context, logType string) (*logtypesapi.LogTypeRetention, error) {
+	days, ok := t.Retention[logType]
+	if !ok {
+		return nil, nil
+	}
+	return &logtypesapi.LogTypeRetention{
+		LogType:       logType,
+		RetentionDays: days,
+	}, nil
+}
+
+func (t *TestCase) ListRetention(_ context.Context) (retention []logtypesapi.LogTypeRetention, _ error) {
+	for logType, days := range t.Retention {
+		retention = append(retention, logtypesapi.LogTypeRetention{
+			LogType:       logType,
+			RetentionDays: days,
+		})
+	}
+	sort.Slice(retention, func(i, j int) bool {
+		return retention[i].LogType < retention[j].LogType
+	})
+	return retention, nil
+}
+
+func (t *TestCase) PutRetention(_ context.Context, retention *logtypesapi.LogTypeRetention) error {
+	if t.Retention == nil {
+		t.Retention = make(map[string]int)
+	}
+	t.Retention[retention.LogType] = retention.RetentionDays
+	return nil
+}
+
+func (t *TestCase) DeleteRetention(_ context.Context, logType string) error {
+	delete(t.Retention, logType)
+	return nil
+}
+
+func (t *TestCase) GetLookupTable(_ context.Context, name string) (*logtypesapi.LookupTable, error) {
+	table, ok := t.LookupTables[name]
+	if !ok {
+		return nil, nil
+	}
+	return &table, nil
+}
+
+func (t *TestCase) ListLookupTables(_ context.Context) (tables []logtypesapi.LookupTable, _ error) {
+	for _, table := range t.LookupTables {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name < tables[j].Name
+	})
+	return tables, nil
+}
+
+func (t *TestCase) PutLookupTable(_ context.Context, table *logtypesapi.LookupTable) error {
+	if t.LookupTables == nil {
+		t.LookupTables = make(map[string]logtypesapi.LookupTable)
+	}
+	t.LookupTables[table.Name] = *table
+	return nil
+}
+
+func (t *TestCase) DeleteLookupTable(_ context.Context, name string) error {
+	delete(t.LookupTables, name)
+	return nil
+}
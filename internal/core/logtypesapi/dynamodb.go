@@ -41,6 +41,8 @@ var L = lambdalogger.FromContext
 
 const (
 	recordKindStatus      = "status"
+	recordKindRetention   = "retention"
+	recordKindLookup      = "lookup"
 	attrAvailableLogTypes = "AvailableLogTypes"
 )
 
@@ -68,6 +70,172 @@ func (d *DynamoDBLogTypes) IndexLogTypes(ctx context.Context) ([]string, error)
 	return item.AvailableLogTypes, nil
 }
 
+func (d *DynamoDBLogTypes) GetRetention(ctx context.Context, logType string) (*LogTypeRetention, error) {
+	input := dynamodb.GetItemInput{
+		TableName: aws.String(d.TableName),
+		Key:       retentionRecordKey(logType),
+	}
+
+	output, err := d.DB.GetItemWithContext(ctx, &input)
+	if err != nil {
+		L(ctx).Error(`failed to get DynamoDB item`, zap.Error(err))
+		return nil, err
+	}
+	if output.Item == nil {
+		return nil, nil
+	}
+
+	item := retentionRecord{}
+	if err := dynamodbattribute.UnmarshalMap(output.Item, &item); err != nil {
+		L(ctx).Error(`failed to unmarshal DynamoDB item`, zap.Error(err))
+		return nil, err
+	}
+	return &item.LogTypeRetention, nil
+}
+
+func (d *DynamoDBLogTypes) ListRetention(ctx context.Context) ([]LogTypeRetention, error) {
+	input := dynamodb.QueryInput{
+		TableName:              aws.String(d.TableName),
+		KeyConditionExpression: aws.String(`RecordKind = :kind`),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":kind": {S: aws.String(recordKindRetention)},
+		},
+	}
+
+	var retention []LogTypeRetention
+	var itemErr error
+	err := d.DB.QueryPagesWithContext(ctx, &input, func(page *dynamodb.QueryOutput, _ bool) bool {
+		for _, attr := range page.Items {
+			item := retentionRecord{}
+			if itemErr = dynamodbattribute.UnmarshalMap(attr, &item); itemErr != nil {
+				return false
+			}
+			retention = append(retention, item.LogTypeRetention)
+		}
+		return true
+	})
+	if err == nil {
+		err = itemErr
+	}
+	if err != nil {
+		L(ctx).Error(`failed to query DynamoDB items`, zap.Error(err))
+		return nil, err
+	}
+	return retention, nil
+}
+
+func (d *DynamoDBLogTypes) PutRetention(ctx context.Context, retention *LogTypeRetention) error {
+	input := dynamodb.PutItemInput{
+		TableName: aws.String(d.TableName),
+		Item: mustMarshalMap(&retentionRecord{
+			recordKey:        retentionKey(retention.LogType),
+			LogTypeRetention: *retention,
+		}),
+	}
+
+	if _, err := d.DB.PutItemWithContext(ctx, &input); err != nil {
+		L(ctx).Error(`failed to put DynamoDB item`, zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (d *DynamoDBLogTypes) DeleteRetention(ctx context.Context, logType string) error {
+	input := dynamodb.DeleteItemInput{
+		TableName: aws.String(d.TableName),
+		Key:       retentionRecordKey(logType),
+	}
+
+	if _, err := d.DB.DeleteItemWithContext(ctx, &input); err != nil {
+		L(ctx).Error(`failed to delete DynamoDB item`, zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (d *DynamoDBLogTypes) GetLookupTable(ctx context.Context, name string) (*LookupTable, error) {
+	input := dynamodb.GetItemInput{
+		TableName: aws.String(d.TableName),
+		Key:       lookupRecordKey(name),
+	}
+
+	output, err := d.DB.GetItemWithContext(ctx, &input)
+	if err != nil {
+		L(ctx).Error(`failed to get DynamoDB item`, zap.Error(err))
+		return nil, err
+	}
+	if output.Item == nil {
+		return nil, nil
+	}
+
+	item := lookupRecord{}
+	if err := dynamodbattribute.UnmarshalMap(output.Item, &item); err != nil {
+		L(ctx).Error(`failed to unmarshal DynamoDB item`, zap.Error(err))
+		return nil, err
+	}
+	return &item.LookupTable, nil
+}
+
+func (d *DynamoDBLogTypes) ListLookupTables(ctx context.Context) ([]LookupTable, error) {
+	input := dynamodb.QueryInput{
+		TableName:              aws.String(d.TableName),
+		KeyConditionExpression: aws.String(`RecordKind = :kind`),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":kind": {S: aws.String(recordKindLookup)},
+		},
+	}
+
+	var tables []LookupTable
+	var itemErr error
+	err := d.DB.QueryPagesWithContext(ctx, &input, func(page *dynamodb.QueryOutput, _ bool) bool {
+		for _, attr := range page.Items {
+			item := lookupRecord{}
+			if itemErr = dynamodbattribute.UnmarshalMap(attr, &item); itemErr != nil {
+				return false
+			}
+			tables = append(tables, item.LookupTable)
+		}
+		return true
+	})
+	if err == nil {
+		err = itemErr
+	}
+	if err != nil {
+		L(ctx).Error(`failed to query DynamoDB items`, zap.Error(err))
+		return nil, err
+	}
+	return tables, nil
+}
+
+func (d *DynamoDBLogTypes) PutLookupTable(ctx context.Context, table *LookupTable) error {
+	input := dynamodb.PutItemInput{
+		TableName: aws.String(d.TableName),
+		Item: mustMarshalMap(&lookupRecord{
+			recordKey:   lookupKey(table.Name),
+			LookupTable: *table,
+		}),
+	}
+
+	if _, err := d.DB.PutItemWithContext(ctx, &input); err != nil {
+		L(ctx).Error(`failed to put DynamoDB item`, zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (d *DynamoDBLogTypes) DeleteLookupTable(ctx context.Context, name string) error {
+	input := dynamodb.DeleteItemInput{
+		TableName: aws.String(d.TableName),
+		Key:       lookupRecordKey(name),
+	}
+
+	if _, err := d.DB.DeleteItemWithContext(ctx, &input); err != nil {
+		L(ctx).Error(`failed to delete DynamoDB item`, zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func mustMarshalMap(val interface{}) map[string]*dynamodb.AttributeValue {
 	attr, err := dynamodbattribute.MarshalMap(val)
 	if err != nil {
@@ -87,3 +255,37 @@ func statusRecordKey() map[string]*dynamodb.AttributeValue {
 		RecordKind: recordKindStatus,
 	})
 }
+
+type retentionRecord struct {
+	recordKey
+	LogTypeRetention
+}
+
+func retentionKey(logType string) recordKey {
+	return recordKey{
+		RecordID:   logType,
+		RecordKind: recordKindRetention,
+	}
+}
+
+func retentionRecordKey(logType string) map[string]*dynamodb.AttributeValue {
+	key := retentionKey(logType)
+	return mustMarshalMap(&key)
+}
+
+type lookupRecord struct {
+	recordKey
+	LookupTable
+}
+
+func lookupKey(name string) recordKey {
+	return recordKey{
+		RecordID:   name,
+		RecordKind: recordKindLookup,
+	}
+}
+
+func lookupRecordKey(name string) map[string]*dynamodb.AttributeValue {
+	key := lookupKey(name)
+	return mustMarshalMap(&key)
+}
@@ -0,0 +1,74 @@
+package logtypesapi
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionRecord(t *testing.T) {
+	item := mustMarshalMap(&retentionRecord{
+		recordKey: retentionKey("AWS.CloudTrail"),
+		LogTypeRetention: LogTypeRetention{
+			LogType:       "AWS.CloudTrail",
+			RetentionDays: 365,
+		},
+	})
+	require.Equal(t, map[string]*dynamodb.AttributeValue{
+		"RecordID":      {S: aws.String("AWS.CloudTrail")},
+		"RecordKind":    {S: aws.String("retention")},
+		"logType":       {S: aws.String("AWS.CloudTrail")},
+		"retentionDays": {N: aws.String("365")},
+	}, item)
+	require.Equal(t, map[string]*dynamodb.AttributeValue{
+		"RecordID":   {S: aws.String("AWS.CloudTrail")},
+		"RecordKind": {S: aws.String("retention")},
+	}, retentionRecordKey("AWS.CloudTrail"))
+
+	record := retentionRecord{}
+	require.NoError(t, dynamodbattribute.UnmarshalMap(item, &record))
+	require.Equal(t, LogTypeRetention{LogType: "AWS.CloudTrail", RetentionDays: 365}, record.LogTypeRetention)
+}
+
+func TestLookupRecord(t *testing.T) {
+	table := LookupTable{
+		Name:      "known_hosts",
+		S3URL:     "s3://bucket/hosts.csv",
+		Format:    LookupFormatCSV,
+		Columns:   []LookupColumn{{Name: "ip", Type: LookupColumnString}},
+		KeyColumn: "ip",
+		Selectors: []LookupSelector{{LogType: "AWS.VPCFlow", Field: "srcAddr"}},
+	}
+	item := mustMarshalMap(&lookupRecord{
+		recordKey:   lookupKey(table.Name),
+		LookupTable: table,
+	})
+	require.Equal(t, &dynamodb.AttributeValue{S: aws.String("lookup")}, item["RecordKind"])
+	require.Equal(t, &dynamodb.AttributeValue{S: aws.String("known_hosts")}, item["RecordID"])
+	require.Equal(t, &dynamodb.AttributeValue{S: aws.String("ip")}, item["keyColumn"])
+
+	record := lookupRecord{}
+	require.NoError(t, dynamodbattribute.UnmarshalMap(item, &record))
+	require.Equal(t, table, record.LookupTable)
+}
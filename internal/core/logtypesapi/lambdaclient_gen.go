@@ -39,6 +39,13 @@ type LogTypesAPILambdaClient struct {
 
 type LogTypesAPIPayload struct {
 	ListAvailableLogTypes *struct{}
+	GetLogTypeRetention   *GetLogTypeRetentionInput
+	ListLogTypeRetention  *struct{}
+	PutLogTypeRetention   *LogTypeRetention
+	GetLookupTable        *GetLookupTableInput
+	ListLookupTables      *struct{}
+	PutLookupTable        *LookupTable
+	DeleteLookupTable     *DeleteLookupTableInput
 }
 
 func (c *LogTypesAPILambdaClient) ListAvailableLogTypes(ctx context.Context) (*AvailableLogTypes, error) {
@@ -52,6 +59,94 @@ func (c *LogTypesAPILambdaClient) ListAvailableLogTypes(ctx context.Context) (*A
 	return &reply, nil
 }
 
+func (c *LogTypesAPILambdaClient) GetLogTypeRetention(ctx context.Context, input *GetLogTypeRetentionInput) (*LogTypeRetention, error) {
+	if input == nil {
+		input = &GetLogTypeRetentionInput{}
+	}
+	payload := LogTypesAPIPayload{
+		GetLogTypeRetention: input,
+	}
+	reply := LogTypeRetention{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) ListLogTypeRetention(ctx context.Context) (*LogTypesRetention, error) {
+	payload := LogTypesAPIPayload{
+		ListLogTypeRetention: &struct{}{},
+	}
+	reply := LogTypesRetention{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) PutLogTypeRetention(ctx context.Context, input *LogTypeRetention) (*LogTypeRetention, error) {
+	if input == nil {
+		input = &LogTypeRetention{}
+	}
+	payload := LogTypesAPIPayload{
+		PutLogTypeRetention: input,
+	}
+	reply := LogTypeRetention{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) GetLookupTable(ctx context.Context, input *GetLookupTableInput) (*LookupTable, error) {
+	if input == nil {
+		input = &GetLookupTableInput{}
+	}
+	payload := LogTypesAPIPayload{
+		GetLookupTable: input,
+	}
+	reply := LookupTable{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) ListLookupTables(ctx context.Context) (*LookupTables, error) {
+	payload := LogTypesAPIPayload{
+		ListLookupTables: &struct{}{},
+	}
+	reply := LookupTables{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) PutLookupTable(ctx context.Context, input *LookupTable) (*LookupTable, error) {
+	if input == nil {
+		input = &LookupTable{}
+	}
+	payload := LogTypesAPIPayload{
+		PutLookupTable: input,
+	}
+	reply := LookupTable{}
+	if err := c.invoke(ctx, &payload, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (c *LogTypesAPILambdaClient) DeleteLookupTable(ctx context.Context, input *DeleteLookupTableInput) error {
+	if input == nil {
+		input = &DeleteLookupTableInput{}
+	}
+	payload := LogTypesAPIPayload{
+		DeleteLookupTable: input,
+	}
+	return c.invoke(ctx, &payload, nil)
+}
+
 func (c *LogTypesAPILambdaClient) invoke(ctx context.Context, payload, reply interface{}) error {
 	if validate := c.Validate; validate != nil {
 		if err := validate(payload); err != nil {
@@ -0,0 +1,165 @@
+package logtypesapi
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Lookup table file formats
+const (
+	LookupFormatCSV  = "csv"
+	LookupFormatJSON = "json"
+)
+
+// Lookup table column types
+const (
+	LookupColumnString  = "string"
+	LookupColumnBigInt  = "bigint"
+	LookupColumnDouble  = "double"
+	LookupColumnBoolean = "boolean"
+	// LookupColumnCIDR holds IP networks (ie `10.0.0.0/8`) or addresses, a CIDR key column matches the IP addresses
+	// of events to the most specific network containing them.
+	LookupColumnCIDR = "cidr"
+)
+
+// LookupTablesPrefix is the S3 prefix of lookup table data in the lookup tables bucket.
+// The log processor can only read lookup tables stored under this prefix.
+const LookupTablesPrefix = "lookups/"
+
+// LookupTable is a table of reference data stored in S3 used to enrich log events.
+// Events of the selected log types are matched to the table rows by the value of the selector field and
+// matching rows are added to the `p_enrichment` field under the table name.
+type LookupTable struct {
+	// The name of the table, used as the key in `p_enrichment`
+	Name        string `json:"name" validate:"required,max=64"`
+	Description string `json:"description,omitempty"`
+	// The S3 URL of the table data file (s3://bucket/lookups/key)
+	S3URL string `json:"s3URL" validate:"required,startswith=s3://"`
+	// The format of the data file, `csv` files must have a header row, `json` files have one JSON object per line
+	Format string `json:"format" validate:"oneof=csv json"`
+	// The schema of the table rows
+	Columns []LookupColumn `json:"columns" validate:"min=1,dive"`
+	// The column holding the value events are matched on
+	KeyColumn string `json:"keyColumn" validate:"required"`
+	// The fields of log events matched to the key column
+	Selectors []LookupSelector `json:"selectors" validate:"min=1,dive"`
+}
+
+// LookupColumn is a column in the schema of a lookup table
+type LookupColumn struct {
+	Name string `json:"name" validate:"required"`
+	Type string `json:"type" validate:"oneof=string bigint double boolean cidr"`
+}
+
+// LookupSelector selects the field of a log type that is matched to the key column of a lookup table.
+type LookupSelector struct {
+	LogType string `json:"logType" validate:"required"`
+	// Dot separated path to a field in the JSON event (ie `srcAddr` or `userIdentity.arn`).
+	// Panther fields can be selected too (ie `p_any_ip_addresses`).
+	Field string `json:"field" validate:"required"`
+}
+
+var lookupTableNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+type GetLookupTableInput struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// GetLookupTable returns a lookup table
+func (api *LogTypesAPI) GetLookupTable(ctx context.Context, input *GetLookupTableInput) (*LookupTable, error) {
+	table, err := api.Database.GetLookupTable(ctx, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if table == nil {
+		return nil, errors.Errorf("lookup table %q not found", input.Name)
+	}
+	return table, nil
+}
+
+type LookupTables struct {
+	Tables []LookupTable `json:"tables"`
+}
+
+// ListLookupTables lists all lookup tables
+func (api *LogTypesAPI) ListLookupTables(ctx context.Context) (*LookupTables, error) {
+	tables, err := api.Database.ListLookupTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &LookupTables{
+		Tables: tables,
+	}, nil
+}
+
+// PutLookupTable creates or updates a lookup table
+func (api *LogTypesAPI) PutLookupTable(ctx context.Context, input *LookupTable) (*LookupTable, error) {
+	if err := api.checkLookupTable(ctx, input); err != nil {
+		return nil, err
+	}
+	if err := api.Database.PutLookupTable(ctx, input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+type DeleteLookupTableInput struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// DeleteLookupTable removes a lookup table
+func (api *LogTypesAPI) DeleteLookupTable(ctx context.Context, input *DeleteLookupTableInput) error {
+	return api.Database.DeleteLookupTable(ctx, input.Name)
+}
+
+func (api *LogTypesAPI) checkLookupTable(ctx context.Context, table *LookupTable) error {
+	if !lookupTableNameRegexp.MatchString(table.Name) {
+		return errors.Errorf("invalid lookup table name %q", table.Name)
+	}
+	if api.LookupTablesBucket != "" {
+		if location := "s3://" + api.LookupTablesBucket + "/" + LookupTablesPrefix; !strings.HasPrefix(table.S3URL, location) {
+			return errors.Errorf("lookup table data must be stored under %s", location)
+		}
+	}
+	columns := make(map[string]bool, len(table.Columns))
+	for _, col := range table.Columns {
+		if columns[col.Name] {
+			return errors.Errorf("duplicate lookup table column %q", col.Name)
+		}
+		columns[col.Name] = true
+	}
+	if !columns[table.KeyColumn] {
+		return errors.Errorf("key column %q is not defined in the lookup table columns", table.KeyColumn)
+	}
+	available, err := api.ListAvailableLogTypes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, selector := range table.Selectors {
+		if !containsString(available.LogTypes, selector.LogType) {
+			return errors.Errorf("unknown log type %q", selector.LogType)
+		}
+	}
+	return nil
+}
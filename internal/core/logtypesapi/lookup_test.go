@@ -0,0 +1,94 @@
+package logtypesapi_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+)
+
+func TestAPI_LookupTables(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	api := logtypesapi.LogTypesAPI{
+		Database: &TestCase{
+			ListLogTypesOutput: []string{"AWS.CloudTrail", "AWS.VPCFlow"},
+		},
+		LookupTablesBucket: "bucket",
+	}
+
+	table := logtypesapi.LookupTable{
+		Name:   "known_hosts",
+		S3URL:  "s3://bucket/lookups/hosts.csv",
+		Format: logtypesapi.LookupFormatCSV,
+		Columns: []logtypesapi.LookupColumn{
+			{Name: "ip", Type: logtypesapi.LookupColumnString},
+			{Name: "owner", Type: logtypesapi.LookupColumnString},
+		},
+		KeyColumn: "ip",
+		Selectors: []logtypesapi.LookupSelector{
+			{LogType: "AWS.VPCFlow", Field: "srcAddr"},
+			{LogType: "AWS.CloudTrail", Field: "p_any_ip_addresses"},
+		},
+	}
+	actual, err := api.PutLookupTable(ctx, &table)
+	assert.NoError(err)
+	assert.Equal(&table, actual)
+
+	actual, err = api.GetLookupTable(ctx, &logtypesapi.GetLookupTableInput{Name: "known_hosts"})
+	assert.NoError(err)
+	assert.Equal(&table, actual)
+
+	list, err := api.ListLookupTables(ctx)
+	assert.NoError(err)
+	assert.Equal(&logtypesapi.LookupTables{Tables: []logtypesapi.LookupTable{table}}, list)
+
+	invalid := table
+	invalid.KeyColumn = "host"
+	_, err = api.PutLookupTable(ctx, &invalid)
+	assert.Error(err)
+	assert.Contains(err.Error(), `key column "host"`)
+
+	invalid = table
+	invalid.Name = "known-hosts"
+	_, err = api.PutLookupTable(ctx, &invalid)
+	assert.Error(err)
+	assert.Contains(err.Error(), `invalid lookup table name "known-hosts"`)
+
+	invalid = table
+	invalid.S3URL = "s3://other-bucket/lookups/hosts.csv"
+	_, err = api.PutLookupTable(ctx, &invalid)
+	assert.Error(err)
+	assert.Contains(err.Error(), `lookup table data must be stored under s3://bucket/lookups/`)
+
+	invalid = table
+	invalid.Selectors = []logtypesapi.LookupSelector{{LogType: "Unknown.Logs", Field: "ip"}}
+	_, err = api.PutLookupTable(ctx, &invalid)
+	assert.Error(err)
+	assert.Contains(err.Error(), `unknown log type "Unknown.Logs"`)
+
+	assert.NoError(api.DeleteLookupTable(ctx, &logtypesapi.DeleteLookupTableInput{Name: "known_hosts"}))
+	_, err = api.GetLookupTable(ctx, &logtypesapi.GetLookupTableInput{Name: "known_hosts"})
+	assert.Error(err)
+	assert.Contains(err.Error(), `lookup table "known_hosts" not found`)
+}
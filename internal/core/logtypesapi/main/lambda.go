@@ -34,6 +34,8 @@ import (
 var config = struct {
 	Debug             bool
 	LogTypesTableName string `required:"true" split_words:"true"`
+	// Lookup table data are stored in the processed data bucket
+	ProcessedDataBucket string `required:"true" split_words:"true"`
 }{}
 
 func main() {
@@ -55,6 +57,7 @@ func main() {
 			DB:        dynamodb.New(session.Must(session.NewSession())),
 			TableName: config.LogTypesTableName,
 		},
+		LookupTablesBucket: config.ProcessedDataBucket,
 	}
 
 	validate := validator.New()
@@ -0,0 +1,95 @@
+package logtypesapi
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// LogTypeRetention is the data retention setting of a log type
+type LogTypeRetention struct {
+	LogType string `json:"logType" validate:"required"`
+	// The number of days the data of the log type are kept, zero keeps the data indefinitely
+	RetentionDays int `json:"retentionDays" validate:"min=0"`
+}
+
+type GetLogTypeRetentionInput struct {
+	LogType string `json:"logType" validate:"required"`
+}
+
+// GetLogTypeRetention returns the data retention setting of a log type
+func (api *LogTypesAPI) GetLogTypeRetention(ctx context.Context, input *GetLogTypeRetentionInput) (*LogTypeRetention, error) {
+	retention, err := api.Database.GetRetention(ctx, input.LogType)
+	if err != nil {
+		return nil, err
+	}
+	if retention == nil {
+		return &LogTypeRetention{
+			LogType: input.LogType,
+		}, nil
+	}
+	return retention, nil
+}
+
+type LogTypesRetention struct {
+	Retention []LogTypeRetention `json:"retention"`
+}
+
+// ListLogTypeRetention lists the log types with a data retention setting
+func (api *LogTypesAPI) ListLogTypeRetention(ctx context.Context) (*LogTypesRetention, error) {
+	retention, err := api.Database.ListRetention(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &LogTypesRetention{
+		Retention: retention,
+	}, nil
+}
+
+// PutLogTypeRetention sets the data retention of a log type, zero retention days removes the setting
+func (api *LogTypesAPI) PutLogTypeRetention(ctx context.Context, input *LogTypeRetention) (*LogTypeRetention, error) {
+	available, err := api.ListAvailableLogTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(available.LogTypes, input.LogType) {
+		return nil, errors.Errorf("unknown log type %q", input.LogType)
+	}
+
+	if input.RetentionDays == 0 {
+		err = api.Database.DeleteRetention(ctx, input.LogType)
+	} else {
+		err = api.Database.PutRetention(ctx, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package logtypesapi_test
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+)
+
+func TestAPI_LogTypeRetention(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	api := logtypesapi.LogTypesAPI{
+		Database: &TestCase{
+			ListLogTypesOutput: []string{"AWS.CloudTrail", "AWS.VPCFlow"},
+		},
+		NativeLogTypes: func() []string {
+			return []string{"Nginx.Access"}
+		},
+	}
+
+	// not set
+	actual, err := api.GetLogTypeRetention(ctx, &logtypesapi.GetLogTypeRetentionInput{LogType: "AWS.CloudTrail"})
+	assert.NoError(err)
+	assert.Equal(&logtypesapi.LogTypeRetention{LogType: "AWS.CloudTrail"}, actual)
+
+	for _, retention := range []logtypesapi.LogTypeRetention{
+		{LogType: "AWS.CloudTrail", RetentionDays: 365},
+		{LogType: "AWS.VPCFlow", RetentionDays: 30},
+		{LogType: "Nginx.Access", RetentionDays: 90},
+	} {
+		input := retention
+		actual, err = api.PutLogTypeRetention(ctx, &input)
+		assert.NoError(err)
+		assert.Equal(&retention, actual)
+	}
+
+	actual, err = api.GetLogTypeRetention(ctx, &logtypesapi.GetLogTypeRetentionInput{LogType: "AWS.VPCFlow"})
+	assert.NoError(err)
+	assert.Equal(&logtypesapi.LogTypeRetention{LogType: "AWS.VPCFlow", RetentionDays: 30}, actual)
+
+	// zero days removes the setting
+	_, err = api.PutLogTypeRetention(ctx, &logtypesapi.LogTypeRetention{LogType: "AWS.VPCFlow"})
+	assert.NoError(err)
+
+	list, err := api.ListLogTypeRetention(ctx)
+	assert.NoError(err)
+	assert.Equal(&logtypesapi.LogTypesRetention{
+		Retention: []logtypesapi.LogTypeRetention{
+			{LogType: "AWS.CloudTrail", RetentionDays: 365},
+			{LogType: "Nginx.Access", RetentionDays: 90},
+		},
+	}, list)
+
+	_, err = api.PutLogTypeRetention(ctx, &logtypesapi.LogTypeRetention{LogType: "Unknown.Logs", RetentionDays: 1})
+	assert.Error(err)
+	assert.Contains(err.Error(), `unknown log type "Unknown.Logs"`)
+}
@@ -0,0 +1,208 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// The severity of freshness alerts of sources that do not set one
+const defaultFreshnessAlertSeverity = "MEDIUM"
+
+const staleSourceRunbook = "Run the health check of the source in the Panther UI to find which part of " +
+	"the ingestion chain stopped delivering data, and verify that the source itself is still producing logs."
+
+var (
+	checkFreshnessInternalError = &genericapi.InternalError{Message: "Failed to check the freshness of all sources"}
+)
+
+// freshnessChange is the outcome of comparing the last event received from a source against its threshold
+type freshnessChange int
+
+const (
+	freshnessUnchanged freshnessChange = iota
+	// The source exceeded its threshold and no alert has been sent yet
+	freshnessStale
+	// The source sent data again after a freshness alert
+	freshnessRecovered
+	// The threshold was removed while the source was stale
+	freshnessDisabled
+)
+
+// CheckFreshness alerts on log sources that have not received data within their freshness threshold.
+//
+// A single alert is sent when a source goes stale and a recovery notification is sent once data resumes.
+func (API) CheckFreshness(_ *models.CheckFreshnessInput) error {
+	items, err := dynamoClient.ScanIntegrations(nil)
+	if err != nil {
+		zap.L().Error("failed to list integrations", zap.Error(err))
+		return checkFreshnessInternalError
+	}
+
+	now := time.Now().UTC()
+	failed := false
+	for _, item := range items {
+		integration := itemToIntegration(item)
+		if err := updateFreshness(integration, now); err != nil {
+			zap.L().Error("failed to check source freshness",
+				zap.Error(err),
+				zap.String("integrationId", integration.IntegrationID))
+			failed = true
+		}
+	}
+	if failed {
+		return checkFreshnessInternalError
+	}
+	return nil
+}
+
+func updateFreshness(integration *models.SourceIntegration, now time.Time) error {
+	switch evaluateFreshness(integration, now) {
+	case freshnessStale:
+		if err := sendFreshnessAlert(staleSourceAlert(integration, now)); err != nil {
+			return err
+		}
+		return dynamoClient.UpdateStaleAlertTime(integration.IntegrationID, &now)
+	case freshnessRecovered:
+		if err := sendFreshnessAlert(recoveredSourceAlert(integration, now)); err != nil {
+			return err
+		}
+		return dynamoClient.UpdateStaleAlertTime(integration.IntegrationID, nil)
+	case freshnessDisabled:
+		return dynamoClient.UpdateStaleAlertTime(integration.IntegrationID, nil)
+	default:
+		return nil
+	}
+}
+
+func evaluateFreshness(integration *models.SourceIntegration, now time.Time) freshnessChange {
+	alerted := integration.StaleAlertTime != nil
+	if integration.FreshnessThresholdMins <= 0 {
+		if alerted {
+			return freshnessDisabled
+		}
+		return freshnessUnchanged
+	}
+
+	stale := now.Sub(lastSeen(integration)) > freshnessThreshold(integration)
+	switch {
+	case stale && !alerted:
+		return freshnessStale
+	case !stale && alerted:
+		return freshnessRecovered
+	default:
+		return freshnessUnchanged
+	}
+}
+
+// lastSeen falls back to the creation time for sources that never received any data
+func lastSeen(integration *models.SourceIntegration) time.Time {
+	if integration.LastEventReceived != nil {
+		return *integration.LastEventReceived
+	}
+	return integration.CreatedAtTime
+}
+
+// freshnessAlertSeverity also routes the alert to the default outputs of the severity if the source sets no outputs
+func freshnessAlertSeverity(integration *models.SourceIntegration) string {
+	if integration.FreshnessAlertSeverity != "" {
+		return integration.FreshnessAlertSeverity
+	}
+	return defaultFreshnessAlertSeverity
+}
+
+func freshnessThreshold(integration *models.SourceIntegration) time.Duration {
+	return time.Duration(integration.FreshnessThresholdMins) * time.Minute
+}
+
+func staleSourceAlert(integration *models.SourceIntegration, now time.Time) *deliveryModels.Alert {
+	threshold := freshnessThreshold(integration)
+	var description string
+	if integration.LastEventReceived != nil {
+		description = fmt.Sprintf("No data has been received from source %s since %s, exceeding the expected freshness of %s.",
+			integration.IntegrationLabel, integration.LastEventReceived.UTC().Format(time.RFC3339), formatThreshold(threshold))
+	} else {
+		description = fmt.Sprintf("No data has been received from source %s since it was created at %s, exceeding the expected freshness of %s.",
+			integration.IntegrationLabel, integration.CreatedAtTime.UTC().Format(time.RFC3339), formatThreshold(threshold))
+	}
+	return &deliveryModels.Alert{
+		AnalysisID:          integration.IntegrationID,
+		AnalysisName:        aws.String(integration.IntegrationLabel),
+		AnalysisDescription: aws.String(description),
+		Type:                deliveryModels.SourceFreshnessType,
+		CreatedAt:           now,
+		Severity:            freshnessAlertSeverity(integration),
+		OutputIds:           integration.FreshnessAlertOutputIds,
+		Runbook:             aws.String(staleSourceRunbook),
+		Title: aws.String(fmt.Sprintf("Source %s has not sent data for over %s",
+			integration.IntegrationLabel, formatThreshold(threshold))),
+	}
+}
+
+func recoveredSourceAlert(integration *models.SourceIntegration, now time.Time) *deliveryModels.Alert {
+	description := fmt.Sprintf("Source %s resumed sending data at %s. It was reported stale at %s.",
+		integration.IntegrationLabel,
+		lastSeen(integration).UTC().Format(time.RFC3339),
+		integration.StaleAlertTime.UTC().Format(time.RFC3339))
+	return &deliveryModels.Alert{
+		AnalysisID:          integration.IntegrationID,
+		AnalysisName:        aws.String(integration.IntegrationLabel),
+		AnalysisDescription: aws.String(description),
+		Type:                deliveryModels.SourceFreshnessType,
+		CreatedAt:           now,
+		Severity:            freshnessAlertSeverity(integration),
+		OutputIds:           integration.FreshnessAlertOutputIds,
+		Title:               aws.String(fmt.Sprintf("Source %s resumed sending data", integration.IntegrationLabel)),
+	}
+}
+
+// formatThreshold prints whole hours as such, e.g. "2h" instead of "2h0m0s"
+func formatThreshold(threshold time.Duration) string {
+	if threshold%time.Hour == 0 {
+		return fmt.Sprintf("%dh", threshold/time.Hour)
+	}
+	return fmt.Sprintf("%dm", threshold/time.Minute)
+}
+
+// sendFreshnessAlert puts the alert in the alert delivery queue, it is delivered like rule alerts
+func sendFreshnessAlert(alert *deliveryModels.Alert) error {
+	body, err := jsoniter.MarshalToString(alert)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal alert")
+	}
+	_, err = sqsClient.SendMessage(&sqs.SendMessageInput{
+		QueueUrl:    &env.AlertQueueURL,
+		MessageBody: &body,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to send alert")
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestEvaluateFreshness(t *testing.T) {
+	now := time.Date(2020, 10, 10, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-30 * time.Minute)
+	old := now.Add(-3 * time.Hour)
+
+	newIntegration := func(thresholdMins int, lastEvent, staleAlert *time.Time) *models.SourceIntegration {
+		integration := &models.SourceIntegration{}
+		integration.CreatedAtTime = now.Add(-24 * time.Hour)
+		integration.FreshnessThresholdMins = thresholdMins
+		integration.LastEventReceived = lastEvent
+		integration.StaleAlertTime = staleAlert
+		return integration
+	}
+
+	assert.Equal(t, freshnessUnchanged, evaluateFreshness(newIntegration(0, &old, nil), now))
+	assert.Equal(t, freshnessDisabled, evaluateFreshness(newIntegration(0, &old, &recent), now))
+	assert.Equal(t, freshnessUnchanged, evaluateFreshness(newIntegration(60, &recent, nil), now))
+	assert.Equal(t, freshnessStale, evaluateFreshness(newIntegration(60, &old, nil), now))
+	assert.Equal(t, freshnessUnchanged, evaluateFreshness(newIntegration(60, &old, &recent), now))
+	assert.Equal(t, freshnessRecovered, evaluateFreshness(newIntegration(60, &recent, &old), now))
+	// Sources that never received data are measured from their creation time
+	assert.Equal(t, freshnessStale, evaluateFreshness(newIntegration(60, nil, nil), now))
+	assert.Equal(t, freshnessUnchanged, evaluateFreshness(newIntegration(48*60, nil, nil), now))
+}
+
+func TestFormatThreshold(t *testing.T) {
+	assert.Equal(t, "2h", formatThreshold(2*time.Hour))
+	assert.Equal(t, "90m", formatThreshold(90*time.Minute))
+}
+
+func TestCheckFreshness(t *testing.T) {
+	now := time.Now().UTC()
+	env.AlertQueueURL = "https://sqs.us-west-2.amazonaws.com/123456789012/panther-alerts-queue"
+	item := func(id, label string, thresholdMins int, lastEvent time.Time, staleAlert *time.Time) map[string]*dynamodb.AttributeValue {
+		attributes := map[string]*dynamodb.AttributeValue{
+			"integrationId":          {S: aws.String(id)},
+			"integrationLabel":       {S: aws.String(label)},
+			"integrationType":        {S: aws.String(models.IntegrationTypeAWS3)},
+			"createdAtTime":          {S: aws.String(now.Add(-24 * time.Hour).Format(time.RFC3339))},
+			"lastEventReceived":      {S: aws.String(lastEvent.Format(time.RFC3339))},
+			"freshnessThresholdMins": {N: aws.String("60")},
+		}
+		if thresholdMins == 0 {
+			delete(attributes, "freshnessThresholdMins")
+		}
+		if staleAlert != nil {
+			attributes["staleAlertTime"] = &dynamodb.AttributeValue{S: aws.String(staleAlert.Format(time.RFC3339))}
+		}
+		return attributes
+	}
+	staleAlertTime := now.Add(-2 * time.Hour)
+	const outputID = "6b8b6b45-84a4-4a5c-9c8b-5b2a1a6e1d3b"
+	recovered := item("recovered", "recovered-source", 60, now.Add(-10*time.Minute), &staleAlertTime)
+	recovered["freshnessAlertSeverity"] = &dynamodb.AttributeValue{S: aws.String("HIGH")}
+	recovered["freshnessAlertOutputIds"] = &dynamodb.AttributeValue{SS: aws.StringSlice([]string{outputID})}
+
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: dynamoMock, TableName: "test"}
+	dynamoMock.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			item("fresh", "fresh-source", 60, now.Add(-10*time.Minute), nil),
+			item("stale", "stale-source", 60, now.Add(-3*time.Hour), nil),
+			recovered,
+			item("still-stale", "still-stale-source", 60, now.Add(-3*time.Hour), &staleAlertTime),
+			item("disabled", "disabled-source", 0, now.Add(-3*time.Hour), &staleAlertTime),
+		},
+	}, nil).Once()
+	dynamoMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Times(3)
+
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+	var alerts []*deliveryModels.Alert
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil).Run(func(args mock.Arguments) {
+		input := args.Get(0).(*sqs.SendMessageInput)
+		assert.Equal(t, env.AlertQueueURL, *input.QueueUrl)
+		alert := &deliveryModels.Alert{}
+		require.NoError(t, jsoniter.UnmarshalFromString(*input.MessageBody, alert))
+		alerts = append(alerts, alert)
+	}).Times(2)
+
+	require.NoError(t, apiTest.CheckFreshness(&models.CheckFreshnessInput{}))
+	dynamoMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "stale", alerts[0].AnalysisID)
+	assert.Equal(t, deliveryModels.SourceFreshnessType, alerts[0].Type)
+	assert.Equal(t, defaultFreshnessAlertSeverity, alerts[0].Severity)
+	assert.Empty(t, alerts[0].OutputIds)
+	assert.Equal(t, "Source stale-source has not sent data for over 1h", *alerts[0].Title)
+	assert.Equal(t, "recovered", alerts[1].AnalysisID)
+	assert.Equal(t, deliveryModels.SourceFreshnessType, alerts[1].Type)
+	assert.Equal(t, "Source recovered-source resumed sending data", *alerts[1].Title)
+	// The severity and outputs of the source are used
+	assert.Equal(t, "HIGH", alerts[1].Severity)
+	assert.Equal(t, []string{outputID}, alerts[1].OutputIds)
+
+	// The stale alert time is recorded for the stale source and cleared for the others
+	updates := map[string]string{}
+	for _, call := range dynamoMock.Calls {
+		if call.Method != "UpdateItem" {
+			continue
+		}
+		input := call.Arguments.Get(0).(*dynamodb.UpdateItemInput)
+		updates[*input.Key["integrationId"].S] = *input.UpdateExpression
+	}
+	assert.Len(t, updates, 3)
+	assert.Contains(t, updates["stale"], "SET")
+	assert.Contains(t, updates["recovered"], "REMOVE")
+	assert.Contains(t, updates["disabled"], "REMOVE")
+}
+
+func TestCheckFreshnessSendFailure(t *testing.T) {
+	now := time.Now().UTC()
+	env.AlertQueueURL = "https://sqs.us-west-2.amazonaws.com/123456789012/panther-alerts-queue"
+	dynamoMock := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: dynamoMock, TableName: "test"}
+	dynamoMock.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{
+				"integrationId":          {S: aws.String("stale")},
+				"integrationType":        {S: aws.String(models.IntegrationTypeSqs)},
+				"createdAtTime":          {S: aws.String(now.Add(-24 * time.Hour).Format(time.RFC3339))},
+				"freshnessThresholdMins": {N: aws.String("60")},
+				"sqsConfig":              {M: map[string]*dynamodb.AttributeValue{}},
+			},
+		},
+	}, nil).Once()
+
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, assert.AnError).Once()
+
+	// The stale alert time is not recorded so that the alert is sent again on the next run
+	assert.Equal(t, checkFreshnessInternalError, apiTest.CheckFreshness(&models.CheckFreshnessInput{}))
+	dynamoMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+}
@@ -0,0 +1,525 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/awssqs"
+)
+
+const (
+	// The most recent object of a source older than this is reported as unhealthy
+	latestObjectMaxAge = 24 * time.Hour
+	// Limits on the walk of the prefix hierarchy looking for the most recent object
+	latestObjectMaxDepth = 10
+	latestObjectMaxPages = 10
+
+	updateRoleRemediation = "Update the CloudFormation stack of the log processing role with the latest Panther template, " +
+		"it grants the read only permissions required by the health check."
+)
+
+// checkIngestionChain validates each link of the chain that delivers new objects from the source bucket to Panther
+func checkIngestionChain(out *models.SourceIntegrationHealth, roleCredentials *credentials.Credentials, roleARN, bucketRegion string,
+	input *models.CheckIntegrationInput) {
+
+	s3Client := s3.New(awsSession, &aws.Config{Credentials: roleCredentials, Region: &bucketRegion})
+	notificationsStatus, topics := checkBucketNotifications(s3Client, input.S3Bucket, input.S3Prefix, env.LogProcessorQueueArn)
+	out.S3NotificationsStatus = &notificationsStatus
+	if notificationsStatus.Healthy {
+		newSNSClient := func(region string) snsiface.SNSAPI {
+			return sns.New(awsSession, &aws.Config{Credentials: roleCredentials, Region: &region})
+		}
+		policyStatus, subscriptionStatus := checkTopics(newSNSClient, topics, input.S3Bucket, env.LogProcessorQueueArn)
+		out.SNSTopicPolicyStatus, out.SNSSubscriptionStatus = &policyStatus, &subscriptionStatus
+	}
+	latestStatus, latest := checkLatestObject(s3Client, input.S3Bucket, input.S3Prefix, time.Now())
+	sampleStatus := checkSampleObject(s3Client, input.S3Bucket, latest, roleARN, input.KmsKey)
+	out.LatestObjectStatus, out.SampleObjectStatus = &latestStatus, &sampleStatus
+}
+
+// checkBucketNotifications checks that new objects under the prefix are notified to Panther.
+// It returns the SNS topics receiving the notifications, none if they are sent directly to the log processor queue.
+func checkBucketNotifications(client s3iface.S3API, bucket, prefix, queueARN string) (models.SourceIntegrationItemStatus, []string) {
+	config, err := client.GetBucketNotificationConfiguration(&s3.GetBucketNotificationConfigurationRequest{
+		Bucket: &bucket,
+	})
+	if err != nil {
+		return models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			Message:      "An error occurred while trying to get the event notification configuration of the S3 bucket.",
+			ErrorMessage: err.Error(),
+			Remediation:  updateRoleRemediation,
+		}, nil
+	}
+
+	location := s3Location(bucket, prefix)
+	var topics []string
+	for _, topicConfig := range config.TopicConfigurations {
+		if notifiesObjectCreated(topicConfig.Events, topicConfig.Filter, prefix) {
+			topics = append(topics, aws.StringValue(topicConfig.TopicArn))
+		}
+	}
+	if len(topics) > 0 {
+		return models.SourceIntegrationItemStatus{
+			Healthy: true,
+			Message: fmt.Sprintf("New objects in %s are notified to SNS topic %s.", location, strings.Join(topics, ", ")),
+		}, topics
+	}
+	for _, queueConfig := range config.QueueConfigurations {
+		if aws.StringValue(queueConfig.QueueArn) == queueARN && notifiesObjectCreated(queueConfig.Events, queueConfig.Filter, prefix) {
+			return models.SourceIntegrationItemStatus{
+				Healthy: true,
+				Message: fmt.Sprintf("New objects in %s are notified to the Panther queue.", location),
+			}, nil
+		}
+	}
+	return models.SourceIntegrationItemStatus{
+		Healthy: false,
+		Message: fmt.Sprintf("No event notification is configured for new objects in %s.", location),
+		Remediation: fmt.Sprintf("Add an event notification for s3:ObjectCreated:* events with prefix '%s' to bucket %s "+
+			"that publishes to the SNS topic created by the panther-log-processing-notifications template.", prefix, bucket),
+	}, nil
+}
+
+// notifiesObjectCreated checks if a notification configuration covers new objects with the prefix
+func notifiesObjectCreated(events []*string, filter *s3.NotificationConfigurationFilter, prefix string) bool {
+	created := false
+	for _, event := range events {
+		if strings.HasPrefix(aws.StringValue(event), "s3:ObjectCreated:") {
+			created = true
+			break
+		}
+	}
+	if !created {
+		return false
+	}
+	if filter == nil || filter.Key == nil {
+		return true
+	}
+	for _, rule := range filter.Key.FilterRules {
+		if strings.EqualFold(aws.StringValue(rule.Name), s3.FilterRuleNamePrefix) {
+			filterPrefix := aws.StringValue(rule.Value)
+			// a notification for part of the prefix still delivers data
+			return strings.HasPrefix(prefix, filterPrefix) || strings.HasPrefix(filterPrefix, prefix)
+		}
+	}
+	return true
+}
+
+// checkTopics checks that the SNS topics notified by the bucket allow S3 to publish and are subscribed by the Panther queue
+func checkTopics(newSNSClient func(region string) snsiface.SNSAPI, topics []string, bucket, queueARN string) (
+	policyStatus, subscriptionStatus models.SourceIntegrationItemStatus) {
+
+	if len(topics) == 0 {
+		status := models.SourceIntegrationItemStatus{
+			Healthy: true,
+			Message: "Event notifications are sent directly to the Panther queue without an SNS topic.",
+		}
+		return status, status
+	}
+
+	policyStatus = models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: fmt.Sprintf("The policy of SNS topic %s allows S3 to publish notifications.", strings.Join(topics, ", ")),
+	}
+	subscriptionStatus = models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: fmt.Sprintf("The Panther queue is subscribed to SNS topic %s.", strings.Join(topics, ", ")),
+	}
+	for _, topic := range topics {
+		topicARN, err := arn.Parse(topic)
+		if err != nil {
+			status := models.SourceIntegrationItemStatus{
+				Healthy:      false,
+				Message:      fmt.Sprintf("The SNS topic ARN '%s' is invalid.", topic),
+				ErrorMessage: err.Error(),
+				Remediation:  fmt.Sprintf("Fix the event notification configuration of bucket %s.", bucket),
+			}
+			return status, status
+		}
+		client := newSNSClient(topicARN.Region)
+
+		if policyStatus.Healthy {
+			attributes, err := client.GetTopicAttributes(&sns.GetTopicAttributesInput{TopicArn: &topic})
+			switch {
+			case err != nil:
+				policyStatus = models.SourceIntegrationItemStatus{
+					Healthy:      false,
+					Message:      fmt.Sprintf("An error occurred while trying to get the attributes of SNS topic %s.", topic),
+					ErrorMessage: err.Error(),
+					Remediation:  updateRoleRemediation,
+				}
+			case !policyAllowsS3Publish(aws.StringValue(attributes.Attributes["Policy"]), topicARN.Partition, bucket):
+				policyStatus = models.SourceIntegrationItemStatus{
+					Healthy: false,
+					Message: fmt.Sprintf("The policy of SNS topic %s does not allow bucket %s to publish notifications.", topic, bucket),
+					Remediation: fmt.Sprintf("Add a statement to the access policy of SNS topic %s that allows the s3.amazonaws.com "+
+						"service principal to sns:Publish for source ARN arn:%s:s3:::%s.", topic, topicARN.Partition, bucket),
+				}
+			}
+		}
+
+		if subscriptionStatus.Healthy {
+			subscribed, err := isSubscribed(client, topic, queueARN)
+			switch {
+			case err != nil:
+				subscriptionStatus = models.SourceIntegrationItemStatus{
+					Healthy:      false,
+					Message:      fmt.Sprintf("An error occurred while trying to list the subscriptions of SNS topic %s.", topic),
+					ErrorMessage: err.Error(),
+					Remediation:  updateRoleRemediation,
+				}
+			case !subscribed:
+				subscriptionStatus = models.SourceIntegrationItemStatus{
+					Healthy: false,
+					Message: fmt.Sprintf("The Panther queue is not subscribed to SNS topic %s.", topic),
+					Remediation: fmt.Sprintf("Subscribe the SQS queue %s to SNS topic %s, "+
+						"for example by deploying the panther-log-processing-notifications template.", queueARN, topic),
+				}
+			}
+		}
+	}
+	return policyStatus, subscriptionStatus
+}
+
+func isSubscribed(client snsiface.SNSAPI, topic, queueARN string) (bool, error) {
+	input := &sns.ListSubscriptionsByTopicInput{TopicArn: &topic}
+	for {
+		output, err := client.ListSubscriptionsByTopic(input)
+		if err != nil {
+			return false, err
+		}
+		for _, subscription := range output.Subscriptions {
+			if aws.StringValue(subscription.Protocol) == "sqs" && aws.StringValue(subscription.Endpoint) == queueARN {
+				return true, nil
+			}
+		}
+		if output.NextToken == nil {
+			return false, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// policyAllowsS3Publish checks if a topic policy has a statement allowing S3 to publish notifications for the bucket.
+// It is not a full policy evaluation, Deny statements and conditions on keys other than aws:SourceArn are ignored.
+func policyAllowsS3Publish(policy, partition, bucket string) bool {
+	var doc struct {
+		Statement policyStatements `json:"Statement"`
+	}
+	if err := jsoniter.UnmarshalFromString(policy, &doc); err != nil {
+		return false
+	}
+	bucketARN := fmt.Sprintf("arn:%s:s3:::%s", partition, bucket)
+	for _, statement := range doc.Statement {
+		if statement.Effect != "Allow" || !statement.Action.Matches("sns:Publish") || !statement.allowsService("s3.amazonaws.com") {
+			continue
+		}
+		if statement.allowsSourceARN(bucketARN) {
+			return true
+		}
+	}
+	return false
+}
+
+type policyStatement struct {
+	Effect    string                             `json:"Effect"`
+	Principal jsoniter.RawMessage                `json:"Principal"`
+	Action    policyValues                       `json:"Action"`
+	Condition map[string]map[string]policyValues `json:"Condition"`
+}
+
+func (s *policyStatement) allowsService(service string) bool {
+	var anyone string
+	if err := jsoniter.Unmarshal(s.Principal, &anyone); err == nil {
+		return anyone == "*"
+	}
+	var principal map[string]policyValues
+	if err := jsoniter.Unmarshal(s.Principal, &principal); err != nil {
+		return false
+	}
+	return principal["Service"].Matches(service) || principal["AWS"].Contains("*")
+}
+
+func (s *policyStatement) allowsSourceARN(sourceARN string) bool {
+	for _, keys := range s.Condition {
+		for key, values := range keys {
+			if strings.EqualFold(key, "aws:SourceArn") && !values.Matches(sourceARN) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// policyStatements is either a single statement or a list of statements
+type policyStatements []policyStatement
+
+func (s *policyStatements) UnmarshalJSON(data []byte) error {
+	var statement policyStatement
+	if err := jsoniter.Unmarshal(data, &statement); err == nil {
+		*s = policyStatements{statement}
+		return nil
+	}
+	var statements []policyStatement
+	if err := jsoniter.Unmarshal(data, &statements); err != nil {
+		return err
+	}
+	*s = statements
+	return nil
+}
+
+// policyValues is either a single string or a list of strings
+type policyValues []string
+
+func (v *policyValues) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := jsoniter.Unmarshal(data, &value); err == nil {
+		*v = policyValues{value}
+		return nil
+	}
+	var values []string
+	if err := jsoniter.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*v = values
+	return nil
+}
+
+// Contains checks if any of the values is equal to value
+func (v policyValues) Contains(value string) bool {
+	for _, s := range v {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks if any of the values matches value with case insensitive * and ? wildcards
+func (v policyValues) Matches(value string) bool {
+	for _, pattern := range v {
+		expr := strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(pattern))
+		if matched, _ := regexp.MatchString("(?i)^"+expr+"$", value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLatestObject checks that objects were written recently under the prefix and returns the most recent one.
+// Listing a large bucket is too slow, so it only looks into the last sub prefix of each level of the hierarchy.
+// This finds the most recent object for the common layouts of logs that are partitioned by time.
+func checkLatestObject(client s3iface.S3API, bucket, prefix string, now time.Time) (models.SourceIntegrationItemStatus, *s3.Object) {
+	var latest *s3.Object
+	location := s3Location(bucket, prefix)
+	for depth, current := 0, prefix; depth < latestObjectMaxDepth; depth++ {
+		lastPrefix, err := listPrefixLevel(client, bucket, current, func(object *s3.Object) {
+			// skip empty objects such as the "folders" created by the console
+			if aws.Int64Value(object.Size) > 0 && (latest == nil || object.LastModified.After(*latest.LastModified)) {
+				latest = object
+			}
+		})
+		if err != nil {
+			return models.SourceIntegrationItemStatus{
+				Healthy:      false,
+				Message:      fmt.Sprintf("An error occurred while trying to list the objects in %s.", location),
+				ErrorMessage: err.Error(),
+				Remediation:  updateRoleRemediation,
+			}, nil
+		}
+		if lastPrefix == "" {
+			break
+		}
+		current = lastPrefix
+	}
+
+	if latest == nil {
+		return models.SourceIntegrationItemStatus{
+			Healthy:     false,
+			Message:     fmt.Sprintf("No objects were found in %s.", location),
+			Remediation: fmt.Sprintf("Verify that logs are being written to %s and that the prefix of the source is correct.", location),
+		}, nil
+	}
+	objectLocation := s3Location(bucket, aws.StringValue(latest.Key))
+	lastModified := latest.LastModified.UTC().Format(time.RFC3339)
+	if age := now.Sub(*latest.LastModified); age > latestObjectMaxAge {
+		return models.SourceIntegrationItemStatus{
+			Healthy: false,
+			Message: fmt.Sprintf("The most recent object found, %s, was written %s ago at %s.",
+				objectLocation, age.Round(time.Minute), lastModified),
+			Remediation: fmt.Sprintf("Verify that the service writing logs to %s is running, objects are expected at least every %d hours.",
+				location, int(latestObjectMaxAge.Hours())),
+		}, latest
+	}
+	return models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: fmt.Sprintf("The most recent object found, %s, was written at %s.", objectLocation, lastModified),
+	}, latest
+}
+
+// listPrefixLevel lists the objects directly under prefix and returns the last sub prefix
+func listPrefixLevel(client s3iface.S3API, bucket, prefix string, handleObject func(object *s3.Object)) (lastPrefix string, err error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:    &bucket,
+		Prefix:    &prefix,
+		Delimiter: aws.String("/"),
+	}
+	for page := 0; page < latestObjectMaxPages; page++ {
+		output, err := client.ListObjectsV2(input)
+		if err != nil {
+			return "", err
+		}
+		for _, object := range output.Contents {
+			handleObject(object)
+		}
+		if n := len(output.CommonPrefixes); n > 0 {
+			lastPrefix = aws.StringValue(output.CommonPrefixes[n-1].Prefix)
+		}
+		if !aws.BoolValue(output.IsTruncated) {
+			break
+		}
+		input.ContinuationToken = output.NextContinuationToken
+	}
+	return lastPrefix, nil
+}
+
+// checkSampleObject checks that the log processing role can read and decrypt an object of the source
+func checkSampleObject(client s3iface.S3API, bucket string, object *s3.Object, roleARN, kmsKey string) models.SourceIntegrationItemStatus {
+	if object == nil {
+		return models.SourceIntegrationItemStatus{
+			Healthy:     false,
+			Message:     "There is no object to read in the source.",
+			Remediation: "Objects are read and decrypted once logs are written to the source.",
+		}
+	}
+
+	location := s3Location(bucket, aws.StringValue(object.Key))
+	output, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: &bucket,
+		Key:    object.Key,
+		Range:  aws.String("bytes=0-0"), // reading a single byte is enough to decrypt the object
+	})
+	if err != nil {
+		status := models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			Message:      fmt.Sprintf("An error occurred while trying to read %s.", location),
+			ErrorMessage: err.Error(),
+			Remediation:  fmt.Sprintf("Allow %s to s3:GetObject in %s.", roleARN, location),
+		}
+		if strings.Contains(strings.ToLower(err.Error()), "kms") {
+			status.Message = fmt.Sprintf("The log processing role is not allowed to decrypt %s.", location)
+			if kmsKey == "" {
+				status.Remediation = fmt.Sprintf("The object is encrypted with a KMS key that is not configured in the source. "+
+					"Add the key to the source, update the stack of the log processing role and allow %s to kms:Decrypt "+
+					"in the key policy.", roleARN)
+			} else {
+				status.Remediation = fmt.Sprintf("Allow %s to kms:Decrypt in the key policy of %s.", roleARN, kmsKey)
+			}
+		}
+		return status
+	}
+	_, _ = io.Copy(ioutil.Discard, output.Body)
+	_ = output.Body.Close()
+
+	message := fmt.Sprintf("We were able to read %s", location)
+	if keyID := aws.StringValue(output.SSEKMSKeyId); keyID != "" {
+		message += fmt.Sprintf(" and decrypt it with KMS key %s", keyID)
+	}
+	return models.SourceIntegrationItemStatus{
+		Healthy: true,
+		Message: message + ".",
+	}
+}
+
+// checkSqsQueuePolicy checks that the queue policy allows exactly the principals and source ARNs configured in the source
+func checkSqsQueuePolicy(policy *awssqs.SqsPolicy, config *models.SqsConfig) models.SourceIntegrationItemStatus {
+	expected := make(map[string]string)
+	if expectedPolicy := createSourceSqsQueuePolicy(config.AllowedPrincipalArns, config.AllowedSourceArns); expectedPolicy != nil {
+		for _, statement := range expectedPolicy.Statements {
+			expected[statement.SID] = marshalStatement(statement)
+		}
+	}
+	actual := make(map[string]string)
+	var unexpected []string
+	for _, statement := range policy.Statements {
+		actual[statement.SID] = marshalStatement(statement)
+		if _, ok := expected[statement.SID]; !ok {
+			unexpected = append(unexpected, statement.SID)
+		}
+	}
+	var missing []string
+	for _, allowed := range append(config.AllowedPrincipalArns, config.AllowedSourceArns...) {
+		if actual[allowed] != expected[allowed] {
+			missing = append(missing, allowed)
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		return models.SourceIntegrationItemStatus{
+			Healthy:     false,
+			Message:     fmt.Sprintf("The queue policy does not allow %s to send data.", strings.Join(missing, ", ")),
+			Remediation: "Save the settings of the source to restore the queue policy.",
+		}
+	case len(unexpected) > 0:
+		return models.SourceIntegrationItemStatus{
+			Healthy: false,
+			Message: fmt.Sprintf("The queue policy has statements %s that are not configured in the source.", strings.Join(unexpected, ", ")),
+			Remediation: "Add the principals or source ARNs to the settings of the source if they should send data, " +
+				"otherwise save the settings of the source to restore the queue policy.",
+		}
+	case len(expected) == 0:
+		return models.SourceIntegrationItemStatus{
+			Healthy: true,
+			Message: "No AWS principals or source ARNs outside the Panther account are allowed to send data to the queue.",
+		}
+	default:
+		return models.SourceIntegrationItemStatus{
+			Healthy: true,
+			Message: "The queue policy allows the principals and source ARNs configured in the source.",
+		}
+	}
+}
+
+func marshalStatement(statement awssqs.SqsPolicyStatement) string {
+	// the standard library configuration sorts map keys so that equal statements have the same JSON
+	data, _ := jsoniter.ConfigCompatibleWithStandardLibrary.MarshalToString(statement)
+	return data
+}
+
+func s3Location(bucket, key string) string {
+	return "s3://" + bucket + "/" + key
+}
@@ -0,0 +1,400 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/awssqs"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	testHealthBucket   = "test-bucket"
+	testHealthTopicARN = "arn:aws:sns:us-west-2:123456789012:panther-notifications-topic"
+	testHealthQueueARN = "arn:aws:sqs:us-east-1:111111111111:panther-input-data-notifications-queue"
+	testHealthRoleARN  = "arn:aws:iam::123456789012:role/PantherLogProcessingRole-test"
+)
+
+func TestCheckBucketNotifications(t *testing.T) {
+	created := aws.StringSlice([]string{"s3:ObjectCreated:*"})
+	prefixFilter := func(prefix string) *s3.NotificationConfigurationFilter {
+		return &s3.NotificationConfigurationFilter{
+			Key: &s3.KeyFilter{
+				FilterRules: []*s3.FilterRule{{Name: aws.String("Prefix"), Value: aws.String(prefix)}},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		Name    string
+		Config  *s3.NotificationConfiguration
+		Healthy bool
+		Topics  []string
+	}{
+		{
+			Name:   "none",
+			Config: &s3.NotificationConfiguration{},
+		},
+		{
+			Name: "topic",
+			Config: &s3.NotificationConfiguration{
+				TopicConfigurations: []*s3.TopicConfiguration{
+					{TopicArn: aws.String("arn:aws:sns:us-west-2:123456789012:other"), Events: created, Filter: prefixFilter("other/")},
+					{TopicArn: aws.String(testHealthTopicARN), Events: created, Filter: prefixFilter("logs")},
+				},
+			},
+			Healthy: true,
+			Topics:  []string{testHealthTopicARN},
+		},
+		{
+			Name: "removed events",
+			Config: &s3.NotificationConfiguration{
+				TopicConfigurations: []*s3.TopicConfiguration{
+					{TopicArn: aws.String(testHealthTopicARN), Events: aws.StringSlice([]string{"s3:ObjectRemoved:*"})},
+				},
+			},
+		},
+		{
+			Name: "queue",
+			Config: &s3.NotificationConfiguration{
+				QueueConfigurations: []*s3.QueueConfiguration{
+					{QueueArn: aws.String(testHealthQueueARN), Events: aws.StringSlice([]string{"s3:ObjectCreated:Put"})},
+				},
+			},
+			Healthy: true,
+		},
+		{
+			Name: "other queue",
+			Config: &s3.NotificationConfiguration{
+				QueueConfigurations: []*s3.QueueConfiguration{
+					{QueueArn: aws.String("arn:aws:sqs:us-east-1:123456789012:other"), Events: created},
+				},
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			s3Client := &testutils.S3Mock{}
+			s3Client.On("GetBucketNotificationConfiguration", &s3.GetBucketNotificationConfigurationRequest{
+				Bucket: aws.String(testHealthBucket),
+			}).Return(tc.Config, nil).Once()
+			status, topics := checkBucketNotifications(s3Client, testHealthBucket, "logs/cloudtrail/", testHealthQueueARN)
+			s3Client.AssertExpectations(t)
+			require.Equal(t, tc.Healthy, status.Healthy, status.Message)
+			require.Equal(t, tc.Topics, topics)
+			if !tc.Healthy {
+				require.Contains(t, status.Remediation, "s3:ObjectCreated:*")
+			}
+		})
+	}
+
+	s3Client := &testutils.S3Mock{}
+	s3Client.On("GetBucketNotificationConfiguration", mock.Anything).
+		Return(&s3.NotificationConfiguration{}, awserr.New("AccessDenied", "Access Denied", nil)).Once()
+	status, topics := checkBucketNotifications(s3Client, testHealthBucket, "", testHealthQueueARN)
+	require.False(t, status.Healthy)
+	require.Equal(t, updateRoleRemediation, status.Remediation)
+	require.Nil(t, topics)
+}
+
+func TestCheckTopics(t *testing.T) {
+	allowBucket := `{"Version":"2012-10-17","Statement":[
+{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111111111111:root"},"Action":"sns:Subscribe","Resource":"*"},
+{"Effect":"Allow","Principal":{"Service":["s3.amazonaws.com"]},"Action":["sns:Publish"],"Resource":"*",
+ "Condition":{"ArnLike":{"aws:SourceArn":"arn:aws:s3:::test-*"}}}]}`
+	allowOtherBucket := `{"Statement":{"Effect":"Allow","Principal":{"Service":"s3.amazonaws.com"},"Action":"SNS:*",
+"Condition":{"ArnEquals":{"aws:SourceArn":"arn:aws:s3:::other-bucket"}}}}`
+
+	var regions []string
+	newClient := func(snsClient *testutils.SnsMock) func(region string) snsiface.SNSAPI {
+		return func(region string) snsiface.SNSAPI {
+			regions = append(regions, region)
+			return snsClient
+		}
+	}
+
+	snsClient := &testutils.SnsMock{}
+	snsClient.On("GetTopicAttributes", &sns.GetTopicAttributesInput{TopicArn: aws.String(testHealthTopicARN)}).
+		Return(&sns.GetTopicAttributesOutput{Attributes: map[string]*string{"Policy": aws.String(allowBucket)}}, nil).Once()
+	snsClient.On("ListSubscriptionsByTopic", &sns.ListSubscriptionsByTopicInput{TopicArn: aws.String(testHealthTopicARN)}).
+		Return(&sns.ListSubscriptionsByTopicOutput{
+			Subscriptions: []*sns.Subscription{{Protocol: aws.String("email"), Endpoint: aws.String("foo@example.com")}},
+			NextToken:     aws.String("next"),
+		}, nil).Once()
+	snsClient.On("ListSubscriptionsByTopic", &sns.ListSubscriptionsByTopicInput{
+		TopicArn:  aws.String(testHealthTopicARN),
+		NextToken: aws.String("next"),
+	}).Return(&sns.ListSubscriptionsByTopicOutput{
+		Subscriptions: []*sns.Subscription{{Protocol: aws.String("sqs"), Endpoint: aws.String(testHealthQueueARN)}},
+	}, nil).Once()
+	policyStatus, subscriptionStatus := checkTopics(newClient(snsClient), []string{testHealthTopicARN}, testHealthBucket, testHealthQueueARN)
+	snsClient.AssertExpectations(t)
+	require.True(t, policyStatus.Healthy, policyStatus.Message)
+	require.True(t, subscriptionStatus.Healthy, subscriptionStatus.Message)
+	require.Equal(t, []string{"us-west-2"}, regions)
+
+	snsClient = &testutils.SnsMock{}
+	snsClient.On("GetTopicAttributes", mock.Anything).
+		Return(&sns.GetTopicAttributesOutput{Attributes: map[string]*string{"Policy": aws.String(allowOtherBucket)}}, nil).Once()
+	snsClient.On("ListSubscriptionsByTopic", mock.Anything).Return(&sns.ListSubscriptionsByTopicOutput{}, nil).Once()
+	policyStatus, subscriptionStatus = checkTopics(newClient(snsClient), []string{testHealthTopicARN}, testHealthBucket, testHealthQueueARN)
+	snsClient.AssertExpectations(t)
+	require.False(t, policyStatus.Healthy)
+	require.Contains(t, policyStatus.Remediation, "arn:aws:s3:::"+testHealthBucket)
+	require.False(t, subscriptionStatus.Healthy)
+	require.Contains(t, subscriptionStatus.Remediation, testHealthQueueARN)
+
+	snsClient = &testutils.SnsMock{}
+	snsClient.On("GetTopicAttributes", mock.Anything).Return(&sns.GetTopicAttributesOutput{}, errors.New("denied")).Once()
+	snsClient.On("ListSubscriptionsByTopic", mock.Anything).Return(&sns.ListSubscriptionsByTopicOutput{}, errors.New("denied")).Once()
+	policyStatus, subscriptionStatus = checkTopics(newClient(snsClient), []string{testHealthTopicARN}, testHealthBucket, testHealthQueueARN)
+	require.False(t, policyStatus.Healthy)
+	require.Equal(t, "denied", policyStatus.ErrorMessage)
+	require.Equal(t, updateRoleRemediation, subscriptionStatus.Remediation)
+
+	policyStatus, subscriptionStatus = checkTopics(nil, nil, testHealthBucket, testHealthQueueARN)
+	require.True(t, policyStatus.Healthy)
+	require.True(t, subscriptionStatus.Healthy)
+
+	policyStatus, _ = checkTopics(nil, []string{"not-an-arn"}, testHealthBucket, testHealthQueueARN)
+	require.False(t, policyStatus.Healthy)
+}
+
+func TestPolicyAllowsS3Publish(t *testing.T) {
+	for _, tc := range []struct {
+		Policy string
+		Allows bool
+	}{
+		{`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"sns:Publish"}]}`, true},
+		{`{"Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"*"}]}`, true},
+		{`{"Statement":[{"Effect":"Allow","Principal":{"Service":"s3.amazonaws.com"},"Action":"sns:Publish",` +
+			`"Condition":{"StringEquals":{"aws:SourceAccount":"123456789012"}}}]}`, true},
+		{`{"Statement":[{"Effect":"Deny","Principal":"*","Action":"sns:Publish"}]}`, false},
+		{`{"Statement":[{"Effect":"Allow","Principal":{"Service":"cloudtrail.amazonaws.com"},"Action":"sns:Publish"}]}`, false},
+		{`{"Statement":[{"Effect":"Allow","Principal":{"Service":"s3.amazonaws.com"},"Action":"sns:Subscribe"}]}`, false},
+		{`{"Statement":[{"Effect":"Allow","Principal":{"Service":"s3.amazonaws.com"},"Action":"sns:Publish",` +
+			`"Condition":{"ArnLike":{"aws:SourceArn":["arn:aws:s3:::foo","arn:aws:s3:::test-buck?t"]}}}]}`, true},
+		{``, false},
+		{`{"Statement":"invalid"}`, false},
+	} {
+		assert.Equal(t, tc.Allows, policyAllowsS3Publish(tc.Policy, "aws", testHealthBucket), tc.Policy)
+	}
+}
+
+func TestCheckLatestObject(t *testing.T) {
+	now := time.Date(2020, 6, 2, 12, 0, 0, 0, time.UTC)
+	listLevel := func(s3Client *testutils.S3Mock, prefix string, output *s3.ListObjectsV2Output) {
+		s3Client.On("ListObjectsV2", &s3.ListObjectsV2Input{
+			Bucket:    aws.String(testHealthBucket),
+			Prefix:    aws.String(prefix),
+			Delimiter: aws.String("/"),
+		}).Return(output, nil).Once()
+	}
+	object := func(key string, size int64, lastModified time.Time) *s3.Object {
+		return &s3.Object{Key: aws.String(key), Size: aws.Int64(size), LastModified: aws.Time(lastModified)}
+	}
+	prefixes := func(prefixes ...string) (commonPrefixes []*s3.CommonPrefix) {
+		for _, p := range prefixes {
+			commonPrefixes = append(commonPrefixes, &s3.CommonPrefix{Prefix: aws.String(p)})
+		}
+		return commonPrefixes
+	}
+
+	s3Client := &testutils.S3Mock{}
+	listLevel(s3Client, "logs/", &s3.ListObjectsV2Output{
+		Contents:       []*s3.Object{object("logs/", 0, now)},
+		CommonPrefixes: prefixes("logs/2020/"),
+	})
+	listLevel(s3Client, "logs/2020/", &s3.ListObjectsV2Output{
+		CommonPrefixes:        prefixes("logs/2020/05/"),
+		IsTruncated:           aws.Bool(true),
+		NextContinuationToken: aws.String("next"),
+	})
+	s3Client.On("ListObjectsV2", &s3.ListObjectsV2Input{
+		Bucket:            aws.String(testHealthBucket),
+		Prefix:            aws.String("logs/2020/"),
+		Delimiter:         aws.String("/"),
+		ContinuationToken: aws.String("next"),
+	}).Return(&s3.ListObjectsV2Output{CommonPrefixes: prefixes("logs/2020/06/")}, nil).Once()
+	listLevel(s3Client, "logs/2020/06/", &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			object("logs/2020/06/a.gz", 10, now.Add(-2*time.Hour)),
+			object("logs/2020/06/b.gz", 10, now.Add(-time.Hour)),
+			object("logs/2020/06/c.gz", 0, now),
+		},
+	})
+	status, latest := checkLatestObject(s3Client, testHealthBucket, "logs/", now)
+	s3Client.AssertExpectations(t)
+	require.True(t, status.Healthy, status.Message)
+	require.Equal(t, "logs/2020/06/b.gz", aws.StringValue(latest.Key))
+
+	s3Client = &testutils.S3Mock{}
+	listLevel(s3Client, "logs/", &s3.ListObjectsV2Output{
+		Contents: []*s3.Object{object("logs/2020/06/b.gz", 10, now.Add(-time.Hour))},
+	})
+	status, latest = checkLatestObject(s3Client, testHealthBucket, "logs/", now.Add(48*time.Hour))
+	require.False(t, status.Healthy)
+	require.Equal(t, "The most recent object found, s3://test-bucket/logs/2020/06/b.gz, was written 49h0m0s ago at 2020-06-02T11:00:00Z.",
+		status.Message)
+	require.NotNil(t, latest)
+
+	s3Client = &testutils.S3Mock{}
+	listLevel(s3Client, "", &s3.ListObjectsV2Output{})
+	status, latest = checkLatestObject(s3Client, testHealthBucket, "", now)
+	s3Client.AssertExpectations(t)
+	require.False(t, status.Healthy)
+	require.Equal(t, "No objects were found in s3://test-bucket/.", status.Message)
+	require.Nil(t, latest)
+
+	s3Client = &testutils.S3Mock{}
+	s3Client.On("ListObjectsV2", mock.Anything).Return(&s3.ListObjectsV2Output{}, errors.New("denied")).Once()
+	status, latest = checkLatestObject(s3Client, testHealthBucket, "", now)
+	require.False(t, status.Healthy)
+	require.Equal(t, updateRoleRemediation, status.Remediation)
+	require.Nil(t, latest)
+}
+
+func TestCheckSampleObject(t *testing.T) {
+	object := &s3.Object{Key: aws.String("logs/a.gz")}
+	getObject := &s3.GetObjectInput{
+		Bucket: aws.String(testHealthBucket),
+		Key:    aws.String("logs/a.gz"),
+		Range:  aws.String("bytes=0-0"),
+	}
+
+	s3Client := &testutils.S3Mock{}
+	s3Client.On("GetObject", getObject).Return(&s3.GetObjectOutput{
+		Body:        ioutil.NopCloser(bytes.NewReader([]byte("x"))),
+		SSEKMSKeyId: aws.String("key-arn"),
+	}, nil).Once()
+	status := checkSampleObject(s3Client, testHealthBucket, object, testHealthRoleARN, "")
+	s3Client.AssertExpectations(t)
+	require.True(t, status.Healthy)
+	require.Equal(t, "We were able to read s3://test-bucket/logs/a.gz and decrypt it with KMS key key-arn.", status.Message)
+
+	kmsErr := awserr.New("AccessDenied", "User is not authorized to perform: kms:Decrypt", nil)
+	s3Client = &testutils.S3Mock{}
+	s3Client.On("GetObject", getObject).Return(&s3.GetObjectOutput{}, kmsErr).Twice()
+	status = checkSampleObject(s3Client, testHealthBucket, object, testHealthRoleARN, "key-arn")
+	require.False(t, status.Healthy)
+	require.Equal(t, "Allow "+testHealthRoleARN+" to kms:Decrypt in the key policy of key-arn.", status.Remediation)
+	status = checkSampleObject(s3Client, testHealthBucket, object, testHealthRoleARN, "")
+	require.Contains(t, status.Remediation, "not configured in the source")
+
+	s3Client = &testutils.S3Mock{}
+	s3Client.On("GetObject", getObject).Return(&s3.GetObjectOutput{}, awserr.New("AccessDenied", "Access Denied", nil)).Once()
+	status = checkSampleObject(s3Client, testHealthBucket, object, testHealthRoleARN, "")
+	require.False(t, status.Healthy)
+	require.Equal(t, "Allow "+testHealthRoleARN+" to s3:GetObject in s3://test-bucket/logs/a.gz.", status.Remediation)
+
+	status = checkSampleObject(s3Client, testHealthBucket, nil, testHealthRoleARN, "")
+	require.False(t, status.Healthy)
+}
+
+func TestCheckSqsQueueHealth(t *testing.T) {
+	config := &models.SqsConfig{
+		AllowedPrincipalArns: []string{"arn:aws:iam::123456789012:root"},
+		AllowedSourceArns:    []string{"arn:aws:sns:us-east-1:123456789012:topic"},
+		QueueURL:             "https://sqs.us-east-1.amazonaws.com/111111111111/panther-source-id",
+	}
+	policy, err := jsoniter.MarshalToString(createSourceSqsQueuePolicy(config.AllowedPrincipalArns, config.AllowedSourceArns))
+	require.NoError(t, err)
+	sqsMock := &testutils.SqsMock{}
+	sqsClient = sqsMock
+	sqsMock.On("GetQueueAttributes", &sqs.GetQueueAttributesInput{
+		AttributeNames: aws.StringSlice([]string{awssqs.PolicyAttributeName}),
+		QueueUrl:       aws.String(config.QueueURL),
+	}).Return(&sqs.GetQueueAttributesOutput{
+		Attributes: map[string]*string{awssqs.PolicyAttributeName: aws.String(policy)},
+	}, nil).Twice()
+
+	health, err := API{}.CheckIntegration(&models.CheckIntegrationInput{
+		IntegrationType: models.IntegrationTypeSqs,
+		SqsConfig:       config,
+	})
+	require.NoError(t, err)
+	require.True(t, health.SqsStatus.Healthy)
+	require.True(t, health.SqsPolicyStatus.Healthy, health.SqsPolicyStatus.Message)
+
+	// validation of integrations skips the deep checks
+	health, err = checkIntegration(&models.CheckIntegrationInput{
+		IntegrationType: models.IntegrationTypeSqs,
+		SqsConfig:       config,
+	}, false)
+	require.NoError(t, err)
+	require.True(t, health.SqsStatus.Healthy)
+	require.Nil(t, health.SqsPolicyStatus)
+	sqsMock.AssertExpectations(t)
+}
+
+func TestCheckSqsQueuePolicy(t *testing.T) {
+	principal := "arn:aws:iam::123456789012:root"
+	source := "arn:aws:sns:us-east-1:123456789012:topic"
+	policy := func(principals, sources []string) *awssqs.SqsPolicy {
+		data, err := jsoniter.Marshal(createSourceSqsQueuePolicy(principals, sources))
+		require.NoError(t, err)
+		// round trip like the policy read from the queue
+		decoded := &awssqs.SqsPolicy{}
+		require.NoError(t, jsoniter.Unmarshal(data, decoded))
+		return decoded
+	}
+
+	status := checkSqsQueuePolicy(policy([]string{principal}, []string{source}), &models.SqsConfig{
+		AllowedPrincipalArns: []string{principal},
+		AllowedSourceArns:    []string{source},
+	})
+	require.True(t, status.Healthy, status.Message)
+
+	status = checkSqsQueuePolicy(policy([]string{principal}, nil), &models.SqsConfig{
+		AllowedPrincipalArns: []string{principal},
+		AllowedSourceArns:    []string{source},
+	})
+	require.False(t, status.Healthy)
+	require.Equal(t, "The queue policy does not allow "+source+" to send data.", status.Message)
+
+	status = checkSqsQueuePolicy(policy([]string{principal}, []string{source}), &models.SqsConfig{
+		AllowedPrincipalArns: []string{principal},
+	})
+	require.False(t, status.Healthy)
+	require.Equal(t, "The queue policy has statements "+source+" that are not configured in the source.", status.Message)
+
+	// the same ARN allowed as a principal instead of a source
+	status = checkSqsQueuePolicy(policy([]string{source}, nil), &models.SqsConfig{
+		AllowedSourceArns: []string{source},
+	})
+	require.False(t, status.Healthy)
+
+	status = checkSqsQueuePolicy(&awssqs.SqsPolicy{}, &models.SqsConfig{})
+	require.True(t, status.Healthy)
+}
@@ -28,11 +28,11 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/awssqs"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
@@ -48,16 +48,22 @@ var (
 	checkIntegrationInternalError = &genericapi.InternalError{Message: "Failed to validate source. Please try again later"}
 )
 
-// CheckIntegration adds a set of new integrations in a batch.
+// CheckIntegration checks the health of an integration, including each link of the ingestion chain of log sources.
 func (api API) CheckIntegration(input *models.CheckIntegrationInput) (*models.SourceIntegrationHealth, error) {
-	zap.L().Debug("beginning source configuration check")
+	return checkIntegration(input, true)
+}
+
+// checkIntegration checks the health of an integration.
+// Deep checks of the ingestion chain are informative and are skipped when validating new integrations.
+func checkIntegration(input *models.CheckIntegrationInput, deep bool) (*models.SourceIntegrationHealth, error) {
+	zap.L().Debug("beginning source configuration check", zap.Bool("deep", deep))
 	switch input.IntegrationType {
 	case models.IntegrationTypeAWSScan:
 		return checkAwsScanIntegration(input), nil
 	case models.IntegrationTypeAWS3:
-		return checkAwsS3Integration(input), nil
+		return checkAwsS3Integration(input, deep), nil
 	case models.IntegrationTypeSqs:
-		return checkSqsQueueHealth(input), nil
+		return checkSqsQueueHealth(input, deep), nil
 	default:
 		return nil, checkIntegrationInternalError
 	}
@@ -83,7 +89,7 @@ func checkAwsScanIntegration(input *models.CheckIntegrationInput) *models.Source
 	return out
 }
 
-func checkAwsS3Integration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+func checkAwsS3Integration(input *models.CheckIntegrationInput, deep bool) *models.SourceIntegrationHealth {
 	out := &models.SourceIntegrationHealth{
 		IntegrationType: input.IntegrationType,
 	}
@@ -91,8 +97,12 @@ func checkAwsS3Integration(input *models.CheckIntegrationInput) *models.SourceIn
 	logProcessingRole := generateLogProcessingRoleArn(input.AWSAccountID, input.IntegrationLabel)
 	roleCreds, out.ProcessingRoleStatus = getCredentialsWithStatus(logProcessingRole)
 	if out.ProcessingRoleStatus.Healthy {
-		out.S3BucketStatus = checkBucket(roleCreds, input.S3Bucket)
+		var bucketRegion string
+		bucketRegion, out.S3BucketStatus = checkBucket(roleCreds, input.S3Bucket)
 		out.KMSKeyStatus = checkKey(roleCreds, input.KmsKey)
+		if deep && out.S3BucketStatus.Healthy {
+			checkIngestionChain(out, roleCreds, logProcessingRole, bucketRegion, input)
+		}
 	}
 	return out
 }
@@ -144,19 +154,20 @@ func checkKey(roleCredentials *credentials.Credentials, key string) models.Sourc
 	}
 }
 
-func checkBucket(roleCredentials *credentials.Credentials, bucket string) models.SourceIntegrationItemStatus {
+// checkBucket checks that the bucket is accessible and returns its region
+func checkBucket(roleCredentials *credentials.Credentials, bucket string) (string, models.SourceIntegrationItemStatus) {
 	s3Client := s3.New(awsSession, &aws.Config{Credentials: roleCredentials})
 
-	_, err := s3Client.GetBucketLocation(&s3.GetBucketLocationInput{Bucket: &bucket})
+	location, err := s3Client.GetBucketLocation(&s3.GetBucketLocationInput{Bucket: &bucket})
 	if err != nil {
-		return models.SourceIntegrationItemStatus{
+		return "", models.SourceIntegrationItemStatus{
 			Healthy:      false,
 			Message:      "An error occurred while trying to get the region of the specified S3 bucket.",
 			ErrorMessage: err.Error(),
 		}
 	}
 
-	return models.SourceIntegrationItemStatus{
+	return s3.NormalizeBucketLocation(aws.StringValue(location.LocationConstraint)), models.SourceIntegrationItemStatus{
 		Healthy: true,
 		Message: "We were able to call s3:GetBucketLocation on the specified S3 bucket.",
 	}
@@ -187,8 +198,8 @@ func getCredentialsWithStatus(roleARN string) (*credentials.Credentials, models.
 	}
 }
 
-func evaluateIntegration(api API, integration *models.CheckIntegrationInput) (string, bool, error) {
-	status, err := api.CheckIntegration(integration)
+func evaluateIntegration(_ API, integration *models.CheckIntegrationInput) (string, bool, error) {
+	status, err := checkIntegration(integration, false)
 	if err != nil {
 		zap.L().Error("integration failed configuration check",
 			zap.Error(err),
@@ -236,7 +247,7 @@ func evaluateIntegration(api API, integration *models.CheckIntegrationInput) (st
 }
 
 // Check the health of the SQS source
-func checkSqsQueueHealth(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+func checkSqsQueueHealth(input *models.CheckIntegrationInput, deep bool) *models.SourceIntegrationHealth {
 	health := &models.SourceIntegrationHealth{
 		IntegrationType: input.IntegrationType,
 	}
@@ -251,10 +262,7 @@ func checkSqsQueueHealth(input *models.CheckIntegrationInput) *models.SourceInte
 		return health
 	}
 
-	getAttributesInput := &sqs.GetQueueAttributesInput{
-		QueueUrl: &input.SqsConfig.QueueURL,
-	}
-	_, err := sqsClient.GetQueueAttributes(getAttributesInput)
+	policy, err := awssqs.GetQueuePolicy(sqsClient, input.SqsConfig.QueueURL)
 	if err != nil {
 		health.SqsStatus.Healthy = false
 		health.SqsStatus.Message = "An error occurred while trying to get the attributes of the specified SQS queue."
@@ -264,5 +272,9 @@ func checkSqsQueueHealth(input *models.CheckIntegrationInput) *models.SourceInte
 
 	health.SqsStatus.Healthy = true
 	health.SqsStatus.Message = "We were able to call sqs:GetQueueAttributes on the specified SQS queue."
+	if deep {
+		policyStatus := checkSqsQueuePolicy(policy, input.SqsConfig)
+		health.SqsPolicyStatus = &policyStatus
+	}
 	return health
 }
@@ -19,13 +19,30 @@ package api
  */
 
 import (
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
 	"github.com/panther-labs/panther/internal/log_analysis/athenaviews"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
 	"github.com/panther-labs/panther/internal/log_analysis/gluetables"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 )
 
 func addGlueTables(logTypes []string) error {
 	for _, logType := range logTypes {
-		_, _, err := gluetables.CreateOrUpdateGlueTablesForLogType(glueClient, logType, env.ProcessedDataBucket)
+		logTable := registry.Lookup(logType).GlueTableMeta()
+		// tables deployed with partition projection are updated in place, new tables need the deployment setting
+		if env.EnablePartitionProjection {
+			logTable = logTable.WithPartitionProjection(awsglue.PartitionProjectionFromYear)
+		}
+		_, err := gluetables.CreateOrUpdateGlueTables(glueClient, env.ProcessedDataBucket, logTable)
+		if schemaErr, refused := errors.Cause(err).(*awsglue.SchemaChangeError); refused {
+			// the deployed table keeps its schema until archived explicitly, it can still be used by the source
+			zap.L().Error("breaking schema change refused, run `gluesync -breaking archive` to apply it",
+				zap.String("logType", logType),
+				zap.String("changes", schemaErr.Diff.String()))
+			continue
+		}
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,70 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestAddGlueTablesPartitionProjection(t *testing.T) {
+	env.EnablePartitionProjection = true
+	defer func() { env.EnablePartitionProjection = false }()
+	mockGlue := &testutils.GlueMock{}
+	glueClient = mockGlue
+	mockAthena := &testutils.AthenaMock{}
+	athenaClient = mockAthena
+
+	// create the log and rule tables
+	mockGlue.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, nil).Twice()
+	// create/replace the all_logs, all_rule_matches and all_indicators views
+	mockGlue.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{}, nil).Times(len(registry.AvailableLogTypes()))
+	mockAthena.On("StartQueryExecution", mock.Anything).Return(&athena.StartQueryExecutionOutput{
+		QueryExecutionId: aws.String("test-query-1234"),
+	}, nil).Times(3)
+	mockAthena.On("GetQueryExecution", mock.Anything).Return(&athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String("test-query-1234"),
+			Status: &athena.QueryExecutionStatus{
+				State: aws.String(athena.QueryExecutionStateSucceeded),
+			},
+		},
+	}, nil).Times(3)
+	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Times(3)
+
+	require.NoError(t, addGlueTables([]string{"AWS.VPCFlow"}))
+	mockGlue.AssertExpectations(t)
+	mockAthena.AssertExpectations(t)
+
+	for _, call := range mockGlue.Calls {
+		if input, ok := call.Arguments.Get(0).(*glue.CreateTableInput); ok {
+			assert.Equal(t, "true", aws.StringValue(input.TableInput.Parameters["projection.enabled"]),
+				aws.StringValue(input.TableInput.Name))
+		}
+	}
+}
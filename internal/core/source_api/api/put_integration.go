@@ -257,6 +257,9 @@ func generateNewIntegration(input *models.PutIntegrationInput) *models.SourceInt
 		metadata.LogTypes = input.LogTypes
 		metadata.StackName = getStackName(input.IntegrationType, input.IntegrationLabel)
 		metadata.LogProcessingRole = generateLogProcessingRoleArn(input.AWSAccountID, input.IntegrationLabel)
+		metadata.FreshnessThresholdMins = input.FreshnessThresholdMins
+		metadata.FreshnessAlertSeverity = input.FreshnessAlertSeverity
+		metadata.FreshnessAlertOutputIds = input.FreshnessAlertOutputIds
 	case models.IntegrationTypeSqs:
 		metadata.SqsConfig = &models.SqsConfig{
 			S3Bucket:             env.InputDataBucketName,
@@ -267,6 +270,9 @@ func generateNewIntegration(input *models.PutIntegrationInput) *models.SourceInt
 			LogTypes:             input.SqsConfig.LogTypes,
 			QueueURL:             SourceSqsQueueURL(metadata.IntegrationID),
 		}
+		metadata.FreshnessThresholdMins = input.FreshnessThresholdMins
+		metadata.FreshnessAlertSeverity = input.FreshnessAlertSeverity
+		metadata.FreshnessAlertOutputIds = input.FreshnessAlertOutputIds
 	}
 	return &models.SourceIntegration{
 		SourceIntegrationMetadata: metadata,
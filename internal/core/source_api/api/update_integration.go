@@ -101,9 +101,27 @@ func normalizeIntegration(item *ddb.Integration, input *models.UpdateIntegration
 		item.S3Prefix = input.S3Prefix
 		item.KmsKey = input.KmsKey
 		item.LogTypes = input.LogTypes
+		if input.FreshnessThresholdMins != nil {
+			item.FreshnessThresholdMins = *input.FreshnessThresholdMins
+		}
+		if input.FreshnessAlertSeverity != nil {
+			item.FreshnessAlertSeverity = *input.FreshnessAlertSeverity
+		}
+		if input.FreshnessAlertOutputIds != nil {
+			item.FreshnessAlertOutputIds = input.FreshnessAlertOutputIds
+		}
 	case models.IntegrationTypeSqs:
 		item.IntegrationLabel = input.IntegrationLabel
 		item.SqsConfig.LogTypes = input.SqsConfig.LogTypes
+		if input.FreshnessThresholdMins != nil {
+			item.FreshnessThresholdMins = *input.FreshnessThresholdMins
+		}
+		if input.FreshnessAlertSeverity != nil {
+			item.FreshnessAlertSeverity = *input.FreshnessAlertSeverity
+		}
+		if input.FreshnessAlertOutputIds != nil {
+			item.FreshnessAlertOutputIds = input.FreshnessAlertOutputIds
+		}
 
 		newAllowedPrincipals := input.SqsConfig.AllowedPrincipalArns
 		newAllowedSources := input.SqsConfig.AllowedSourceArns
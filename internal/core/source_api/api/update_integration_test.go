@@ -76,19 +76,20 @@ func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 	athenaClient = mockAthena
 
 	getResponse := &dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
-		"integrationId":   {S: aws.String(testIntegrationID)},
-		"integrationType": {S: aws.String(models.IntegrationTypeAWS3)},
+		"integrationId":          {S: aws.String(testIntegrationID)},
+		"integrationType":        {S: aws.String(models.IntegrationTypeAWS3)},
+		"freshnessThresholdMins": {N: aws.String("60")},
 	}}
 	mockClient.On("GetItem", mock.Anything).Return(getResponse, nil)
 	mockClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
 
 	// create the tables
 	mockGlue.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, nil).Twice()
-	// create/replace the view
+	// create/replace the all_logs, all_rule_matches and all_indicators views
 	mockGlue.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{}, nil).Times(len(registry.AvailableLogTypes()))
 	mockAthena.On("StartQueryExecution", mock.Anything).Return(&athena.StartQueryExecutionOutput{
 		QueryExecutionId: aws.String("test-query-1234"),
-	}, nil).Twice()
+	}, nil).Times(3)
 	mockAthena.On("GetQueryExecution", mock.Anything).Return(&athena.GetQueryExecutionOutput{
 		QueryExecution: &athena.QueryExecution{
 			QueryExecutionId: aws.String("test-query-1234"),
@@ -96,8 +97,8 @@ func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 				State: aws.String(athena.QueryExecutionStateSucceeded),
 			},
 		},
-	}, nil).Twice()
-	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Twice()
+	}, nil).Times(3)
+	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Times(3)
 
 	result, err := apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
 		S3Bucket: "test-bucket-1",
@@ -114,6 +115,8 @@ func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 			S3Prefix:        "prefix/",
 			KmsKey:          "arn:aws:kms:us-west-2:111111111111:key/27803c7e-9fa5-4fcb-9525-ee11c953d329",
 			LogTypes:        []string{"AWS.VPCFlow"},
+			// the threshold is kept if the input does not set it
+			FreshnessThresholdMins: 60,
 		},
 	}
 	assert.NoError(t, err)
@@ -121,6 +124,61 @@ func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestUpdateIntegrationFreshnessThreshold(t *testing.T) {
+	validator, err := models.Validator()
+	require.NoError(t, err)
+	input := models.UpdateIntegrationSettingsInput{
+		IntegrationID:    testIntegrationID,
+		IntegrationLabel: "new-label",
+	}
+	assert.NoError(t, validator.Struct(&input))
+	for _, mins := range []int{0, 15, 120} {
+		input.FreshnessThresholdMins = aws.Int(mins)
+		assert.NoError(t, validator.Struct(&input), mins)
+	}
+	input.FreshnessThresholdMins = aws.Int(10)
+	assert.Error(t, validator.Struct(&input))
+
+	// 0 disables freshness alerts
+	item := &ddb.Integration{IntegrationType: models.IntegrationTypeAWS3, FreshnessThresholdMins: 60}
+	require.NoError(t, normalizeIntegration(item, &models.UpdateIntegrationSettingsInput{FreshnessThresholdMins: aws.Int(0)}))
+	assert.Equal(t, 0, item.FreshnessThresholdMins)
+}
+
+func TestUpdateIntegrationFreshnessAlert(t *testing.T) {
+	validator, err := models.Validator()
+	require.NoError(t, err)
+	input := models.UpdateIntegrationSettingsInput{
+		IntegrationID:    testIntegrationID,
+		IntegrationLabel: "new-label",
+	}
+	for _, severity := range []string{"", "INFO", "CRITICAL"} {
+		input.FreshnessAlertSeverity = aws.String(severity)
+		assert.NoError(t, validator.Struct(&input), severity)
+	}
+	input.FreshnessAlertSeverity = aws.String("URGENT")
+	assert.Error(t, validator.Struct(&input))
+	input.FreshnessAlertSeverity = nil
+	input.FreshnessAlertOutputIds = []string{"not-a-uuid"}
+	assert.Error(t, validator.Struct(&input))
+
+	const outputID = "6b8b6b45-84a4-4a5c-9c8b-5b2a1a6e1d3b"
+	item := &ddb.Integration{IntegrationType: models.IntegrationTypeAWS3, FreshnessAlertSeverity: "HIGH"}
+	// unset values keep the current settings
+	require.NoError(t, normalizeIntegration(item, &models.UpdateIntegrationSettingsInput{
+		FreshnessAlertOutputIds: []string{outputID},
+	}))
+	assert.Equal(t, "HIGH", item.FreshnessAlertSeverity)
+	assert.Equal(t, []string{outputID}, item.FreshnessAlertOutputIds)
+	// empty values reset to the defaults
+	require.NoError(t, normalizeIntegration(item, &models.UpdateIntegrationSettingsInput{
+		FreshnessAlertSeverity:  aws.String(""),
+		FreshnessAlertOutputIds: []string{},
+	}))
+	assert.Empty(t, item.FreshnessAlertSeverity)
+	assert.Empty(t, item.FreshnessAlertOutputIds)
+}
+
 func TestUpdateIntegrationValidTime(t *testing.T) {
 	now := time.Now()
 	validator, err := models.Validator()
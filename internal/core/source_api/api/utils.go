@@ -33,6 +33,7 @@ func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
 		IntegrationType:  input.IntegrationType,
 	}
 	item.LastEventReceived = input.LastEventReceived
+	item.StaleAlertTime = input.StaleAlertTime
 
 	switch input.IntegrationType {
 	case models.IntegrationTypeAWS3:
@@ -43,6 +44,9 @@ func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
 		item.LogTypes = input.LogTypes
 		item.StackName = input.StackName
 		item.LogProcessingRole = generateLogProcessingRoleArn(input.AWSAccountID, input.IntegrationLabel)
+		item.FreshnessThresholdMins = input.FreshnessThresholdMins
+		item.FreshnessAlertSeverity = input.FreshnessAlertSeverity
+		item.FreshnessAlertOutputIds = input.FreshnessAlertOutputIds
 	case models.IntegrationTypeAWSScan:
 		item.AWSAccountID = input.AWSAccountID
 		item.CWEEnabled = input.CWEEnabled
@@ -64,6 +68,9 @@ func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
 			AllowedPrincipalArns: input.SqsConfig.AllowedPrincipalArns,
 			AllowedSourceArns:    input.SqsConfig.AllowedSourceArns,
 		}
+		item.FreshnessThresholdMins = input.FreshnessThresholdMins
+		item.FreshnessAlertSeverity = input.FreshnessAlertSeverity
+		item.FreshnessAlertOutputIds = input.FreshnessAlertOutputIds
 	}
 	return item
 }
@@ -77,6 +84,7 @@ func itemToIntegration(item *ddb.Integration) *models.SourceIntegration {
 	integration.CreatedAtTime = item.CreatedAtTime
 	integration.CreatedBy = item.CreatedBy
 	integration.LastEventReceived = item.LastEventReceived
+	integration.StaleAlertTime = item.StaleAlertTime
 
 	switch item.IntegrationType {
 	case models.IntegrationTypeAWS3:
@@ -87,6 +95,9 @@ func itemToIntegration(item *ddb.Integration) *models.SourceIntegration {
 		integration.LogTypes = item.LogTypes
 		integration.StackName = item.StackName
 		integration.LogProcessingRole = item.LogProcessingRole
+		integration.FreshnessThresholdMins = item.FreshnessThresholdMins
+		integration.FreshnessAlertSeverity = item.FreshnessAlertSeverity
+		integration.FreshnessAlertOutputIds = item.FreshnessAlertOutputIds
 	case models.IntegrationTypeAWSScan:
 		integration.AWSAccountID = item.AWSAccountID
 		integration.CWEEnabled = item.CWEEnabled
@@ -108,6 +119,9 @@ func itemToIntegration(item *ddb.Integration) *models.SourceIntegration {
 			AllowedPrincipalArns: item.SqsConfig.AllowedPrincipalArns,
 			AllowedSourceArns:    item.SqsConfig.AllowedSourceArns,
 		}
+		integration.FreshnessThresholdMins = item.FreshnessThresholdMins
+		integration.FreshnessAlertSeverity = item.FreshnessAlertSeverity
+		integration.FreshnessAlertOutputIds = item.FreshnessAlertOutputIds
 	}
 	return integration
 }
@@ -66,6 +66,9 @@ type envConfig struct {
 	InputDataRoleArn        string `required:"true" split_words:"true"`
 	InputDataBucketName     string `required:"true" split_words:"true"`
 	InputDataTopicArn       string `required:"true" split_words:"true"`
+	AlertQueueURL           string `required:"true" split_words:"true"`
+	// Create new log tables with Athena partition projection, like the deployment does for existing tables
+	EnablePartitionProjection bool `split_words:"true"`
 }
 
 // Setup parses the environment and constructs AWS and http clients on a cold Lambda start.
@@ -46,12 +46,17 @@ type Integration struct {
 	LogProcessingRole string   `json:"logProcessingRole,omitempty"`
 
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+
+	FreshnessThresholdMins  int      `json:"freshnessThresholdMins,omitempty"`
+	FreshnessAlertSeverity  string   `json:"freshnessAlertSeverity,omitempty"`
+	FreshnessAlertOutputIds []string `json:"freshnessAlertOutputIds,omitempty" dynamodbav:",stringset"`
 }
 
 type IntegrationStatus struct {
 	ScanStatus        string     `json:"scanStatus,omitempty"`
 	EventStatus       string     `json:"eventStatus,omitempty"`
 	LastEventReceived *time.Time `json:"lastEventReceived,omitempty"`
+	StaleAlertTime    *time.Time `json:"staleAlertTime,omitempty"`
 }
 
 type SqsConfig struct {
@@ -19,6 +19,8 @@ package ddb
  */
 
 import (
+	"time"
+
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/pkg/errors"
@@ -46,3 +48,31 @@ func (ddb *DDB) UpdateStatus(integrationID string, status IntegrationStatus) err
 	}
 	return nil
 }
+
+// UpdateStaleAlertTime records when a freshness alert was sent for an integration, a nil time clears it.
+func (ddb *DDB) UpdateStaleAlertTime(integrationID string, staleAlertTime *time.Time) error {
+	var updateExpression expression.UpdateBuilder
+	if staleAlertTime == nil {
+		updateExpression = expression.Remove(expression.Name("staleAlertTime"))
+	} else {
+		updateExpression = expression.Set(expression.Name("staleAlertTime"), expression.Value(staleAlertTime))
+	}
+	expr, err := expression.NewBuilder().WithUpdate(updateExpression).Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate update expression")
+	}
+	updateRequest := &dynamodb.UpdateItemInput{
+		TableName: &ddb.TableName,
+		Key: map[string]*dynamodb.AttributeValue{
+			hashKey: {S: &integrationID},
+		},
+		UpdateExpression:          expr.Update(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	if _, err = ddb.Client.UpdateItem(updateRequest); err != nil {
+		return errors.Wrap(err, "failed to update item")
+	}
+	return nil
+}
@@ -59,6 +59,10 @@ var (
 		Name:  "AnalysisType",
 		Value: "Rule",
 	}
+	scheduledQueryTypeDimension = metrics.Dimension{
+		Name:  "AnalysisType",
+		Value: "ScheduledQuery",
+	}
 )
 
 type Handler struct {
@@ -72,13 +76,13 @@ type Handler struct {
 func (h *Handler) Do(oldAlertDedupEvent, newAlertDedupEvent *AlertDedupEvent) (err error) {
 	var oldRule *ruleModel.Rule
 	if oldAlertDedupEvent != nil {
-		oldRule, err = h.Cache.Get(oldAlertDedupEvent.RuleID, oldAlertDedupEvent.RuleVersion)
+		oldRule, err = h.getRule(oldAlertDedupEvent)
 		if err != nil {
 			return errors.Wrapf(err, "failed to get rule information for %s.%s", oldAlertDedupEvent.RuleID, oldAlertDedupEvent.RuleVersion)
 		}
 	}
 
-	newRule, err := h.Cache.Get(newAlertDedupEvent.RuleID, newAlertDedupEvent.RuleVersion)
+	newRule, err := h.getRule(newAlertDedupEvent)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get rule information for %s.%s", newAlertDedupEvent.RuleID, newAlertDedupEvent.RuleVersion)
 	}
@@ -93,6 +97,14 @@ func (h *Handler) Do(oldAlertDedupEvent, newAlertDedupEvent *AlertDedupEvent) (e
 	return h.updateExistingAlert(newAlertDedupEvent)
 }
 
+// getRule returns the rule or scheduled query that generated an event
+func (h *Handler) getRule(event *AlertDedupEvent) (*ruleModel.Rule, error) {
+	if event.AnalysisType == string(ruleModel.AnalysisTypeSCHEDULEDQUERY) {
+		return h.Cache.GetScheduledQuery(event.RuleID, event.RuleVersion)
+	}
+	return h.Cache.Get(event.RuleID, event.RuleVersion)
+}
+
 func shouldIgnoreChange(rule *ruleModel.Rule, alertDedupEvent *AlertDedupEvent) bool {
 	// If the number of matched events hasn't crossed the threshold for the rule, don't create a new alert.
 	return alertDedupEvent.EventCount < int64(rule.Threshold)
@@ -121,9 +133,13 @@ func (h *Handler) handleNewAlert(rule *ruleModel.Rule, event *AlertDedupEvent) e
 
 	err := h.sendAlertNotification(rule, event)
 	if err == nil {
+		typeDimension := analysisTypeDimension
+		if event.AnalysisType == string(ruleModel.AnalysisTypeSCHEDULEDQUERY) {
+			typeDimension = scheduledQueryTypeDimension
+		}
 		staticLogger.LogSingle(1,
 			metrics.Dimension{Name: "Severity", Value: string(rule.Severity)},
-			analysisTypeDimension,
+			typeDimension,
 		)
 	}
 	return err
@@ -179,6 +195,7 @@ func (h *Handler) storeNewAlert(rule *ruleModel.Rule, alertDedup *AlertDedupEven
 			UpdateTime:   alertDedup.UpdateTime,
 			EventCount:   alertDedup.EventCount,
 			LogTypes:     alertDedup.LogTypes,
+			AnalysisType: alertDedup.AnalysisType,
 		},
 	}
 
@@ -599,6 +599,100 @@ func TestHandleShouldCreateAlertIfThresholdNowReached(t *testing.T) {
 	mockRoundTripper.AssertExpectations(t)
 }
 
+func TestHandleStoreAndSendNotificationScheduledQuery(t *testing.T) {
+	t.Parallel()
+	ddbMock := &testutils.DynamoDBMock{}
+	sqsMock := &testutils.SqsMock{}
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient := &http.Client{Transport: mockRoundTripper}
+	policyConfig := policiesclient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
+	handler := &Handler{
+		AlertTable:       "alertsTable",
+		AlertingQueueURL: "queueUrl",
+		Cache:            NewCache(httpClient, policyClient),
+		DdbClient:        ddbMock,
+		SqsClient:        sqsMock,
+	}
+
+	queryDedupEvent := *newAlertDedupEvent
+	queryDedupEvent.AnalysisType = string(ruleModel.AnalysisTypeSCHEDULEDQUERY)
+	queryDedupEvent.GeneratedTitle = nil
+	queryDedupEvent.EventCount = 1
+	testQueryResponse := &ruleModel.ScheduledQuery{
+		ID:          "ruleId",
+		Description: "Description",
+		DisplayName: "DisplayName",
+		Severity:    "HIGH",
+		Runbook:     "Runbook",
+		Tags:        []string{"Tag"},
+	}
+
+	expectedAlertNotification := &alertModel.Alert{
+		CreatedAt:           queryDedupEvent.UpdateTime,
+		AnalysisDescription: aws.String(string(testQueryResponse.Description)),
+		AnalysisID:          queryDedupEvent.RuleID,
+		AnalysisName:        aws.String(string(testQueryResponse.DisplayName)),
+		Version:             aws.String(queryDedupEvent.RuleVersion),
+		Runbook:             aws.String(string(testQueryResponse.Runbook)),
+		Severity:            string(testQueryResponse.Severity),
+		Tags:                []string{"Tag"},
+		Type:                alertModel.RuleType,
+		AlertID:             aws.String("b25dc23fb2a0b362da8428dbec1381a8"),
+		Title:               aws.String(string(testQueryResponse.DisplayName)),
+	}
+	expectedMarshaledAlertNotification, err := jsoniter.MarshalToString(expectedAlertNotification)
+	require.NoError(t, err)
+	expectedSendMessageInput := &sqs.SendMessageInput{
+		MessageBody: aws.String(expectedMarshaledAlertNotification),
+		QueueUrl:    aws.String("queueUrl"),
+	}
+
+	// the query is fetched from the scheduled query endpoint
+	isQueryRequest := mock.MatchedBy(func(request *http.Request) bool {
+		return strings.HasSuffix(request.URL.Path, "/query")
+	})
+	mockRoundTripper.On("RoundTrip", isQueryRequest).Return(generateResponse(testQueryResponse, http.StatusOK), nil).Once()
+	sqsMock.On("SendMessage", expectedSendMessageInput).Return(&sqs.SendMessageOutput{}, nil)
+
+	expectedAlert := &Alert{
+		ID:                  "b25dc23fb2a0b362da8428dbec1381a8",
+		TimePartition:       "defaultPartition",
+		Severity:            string(testQueryResponse.Severity),
+		Title:               string(testQueryResponse.DisplayName),
+		RuleDisplayName:     aws.String(string(testQueryResponse.DisplayName)),
+		FirstEventMatchTime: queryDedupEvent.CreationTime,
+		AlertDedupEvent: AlertDedupEvent{
+			RuleID:              queryDedupEvent.RuleID,
+			RuleVersion:         queryDedupEvent.RuleVersion,
+			LogTypes:            queryDedupEvent.LogTypes,
+			EventCount:          queryDedupEvent.EventCount,
+			DeduplicationString: queryDedupEvent.DeduplicationString,
+			UpdateTime:          queryDedupEvent.UpdateTime,
+			CreationTime:        queryDedupEvent.UpdateTime,
+			AnalysisType:        "SCHEDULED_QUERY",
+		},
+	}
+
+	expectedMarshaledAlert, err := dynamodbattribute.MarshalMap(expectedAlert)
+	require.NoError(t, err)
+	require.Equal(t, "SCHEDULED_QUERY", aws.StringValue(expectedMarshaledAlert["analysisType"].S))
+
+	expectedPutItemRequest := &dynamodb.PutItemInput{
+		Item:      expectedMarshaledAlert,
+		TableName: aws.String("alertsTable"),
+	}
+
+	ddbMock.On("PutItem", expectedPutItemRequest).Return(&dynamodb.PutItemOutput{}, nil)
+	require.NoError(t, handler.Do(nil, &queryDedupEvent))
+
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+	mockRoundTripper.AssertExpectations(t)
+}
+
 func generateResponse(body interface{}, httpCode int) *http.Response {
 	serializedBody, _ := jsoniter.MarshalToString(body)
 	return &http.Response{StatusCode: httpCode, Body: ioutil.NopCloser(strings.NewReader(serializedBody))}
@@ -42,8 +42,9 @@ type AlertDedupEvent struct {
 	UpdateTime          time.Time `dynamodbav:"updateTime,string"`
 	EventCount          int64     `dynamodbav:"eventCount,number"`
 	LogTypes            []string  `dynamodbav:"logTypes,stringset"`
-	GeneratedTitle      *string   `dynamodbav:"-"` // The title that was generated dynamically using Python. Might be null.
-	AlertCount          int64     `dynamodbav:"-"` // There is no need to store this item in DDB
+	AnalysisType        string    `dynamodbav:"analysisType,omitempty"` // Empty for rules, SCHEDULED_QUERY for scheduled queries
+	GeneratedTitle      *string   `dynamodbav:"-"`                      // The title that was generated dynamically using Python. Might be null.
+	AlertCount          int64     `dynamodbav:"-"`                      // There is no need to store this item in DDB
 }
 
 // Alert contains all the fields associated to the alert stored in DDB
@@ -124,6 +125,11 @@ func FromDynamodDBAttribute(input map[string]events.DynamoDBAttributeValue) (eve
 		LogTypes:            logTypes.StringSet(),
 	}
 
+	analysisType := getOptionalAttribute("analysisType", input)
+	if analysisType != nil {
+		result.AnalysisType = analysisType.String()
+	}
+
 	generatedTitle := getOptionalAttribute("title", input)
 	if generatedTitle != nil {
 		result.GeneratedTitle = aws.String(generatedTitle.String())
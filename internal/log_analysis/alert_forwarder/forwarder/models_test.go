@@ -70,6 +70,14 @@ func TestConvertAttributeWithoutOptionalFields(t *testing.T) {
 	require.Equal(t, expectedAlertDedup, alertDedupEvent)
 }
 
+func TestConvertAttributeScheduledQuery(t *testing.T) {
+	ddbItem := getNewTestCase()
+	ddbItem["analysisType"] = events.NewStringAttribute("SCHEDULED_QUERY")
+	alertDedupEvent, err := FromDynamodDBAttribute(ddbItem)
+	require.NoError(t, err)
+	require.Equal(t, "SCHEDULED_QUERY", alertDedupEvent.AnalysisType)
+}
+
 func TestMissingRuleId(t *testing.T) {
 	testInput := getNewTestCase()
 	delete(testInput, "ruleId")
@@ -62,6 +62,21 @@ func (c *RuleCache) Get(id, version string) (*models.Rule, error) {
 	return value.(*models.Rule), nil
 }
 
+// GetScheduledQuery returns a scheduled query as a rule with a threshold of 1
+func (c *RuleCache) GetScheduledQuery(id, version string) (*models.Rule, error) {
+	key := "query:" + cacheKey(id, version)
+	value, ok := c.cache.Get(key)
+	if !ok {
+		rule, err := c.getScheduledQuery(id, version)
+		if err != nil {
+			return nil, err
+		}
+		value = rule
+		c.cache.Add(key, value)
+	}
+	return value.(*models.Rule), nil
+}
+
 func cacheKey(id, version string) string {
 	return id + ":" + version
 }
@@ -79,3 +94,33 @@ func (c *RuleCache) getRule(id, version string) (*models.Rule, error) {
 	}
 	return rule.Payload, nil
 }
+
+func (c *RuleCache) getScheduledQuery(id, version string) (*models.Rule, error) {
+	zap.L().Debug("calling analysis API to retrieve information for scheduled query", zap.String("queryId", id), zap.String("queryVersion", version))
+	query, err := c.policyClient.Operations.GetQuery(&policiesoperations.GetQueryParams{
+		QueryID:    id,
+		VersionID:  &version,
+		HTTPClient: c.httpClient,
+	})
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch information for queryID [%s], version [%s]", id, version)
+	}
+	return &models.Rule{
+		Body:               query.Payload.Body,
+		DedupPeriodMinutes: query.Payload.DedupPeriodMinutes,
+		Description:        query.Payload.Description,
+		DisplayName:        query.Payload.DisplayName,
+		Enabled:            query.Payload.Enabled,
+		ID:                 query.Payload.ID,
+		LogTypes:           query.Payload.LogTypes,
+		OutputIds:          query.Payload.OutputIds,
+		Reference:          query.Payload.Reference,
+		Runbook:            query.Payload.Runbook,
+		Severity:           query.Payload.Severity,
+		Tags:               query.Payload.Tags,
+		// every row returned by a scheduled query is an alert
+		Threshold: 1,
+		VersionID: query.Payload.VersionID,
+	}, nil
+}
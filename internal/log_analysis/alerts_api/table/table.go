@@ -86,4 +86,6 @@ type AlertItem struct {
 	LastUpdatedBy string `json:"lastUpdatedBy"`
 	// LastUpdatedByTime - stores the timestamp of the last person who modified the Alert
 	LastUpdatedByTime time.Time `json:"lastUpdatedByTime"`
+	// AnalysisType - SCHEDULED_QUERY for alerts generated by scheduled queries, empty for rules
+	AnalysisType string `json:"analysisType,omitempty"`
 }
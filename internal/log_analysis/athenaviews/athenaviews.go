@@ -34,6 +34,13 @@ import (
 	"github.com/panther-labs/panther/pkg/awsathena"
 )
 
+const (
+	// IndicatorsViewName is the view with a row for each indicator in the logs
+	IndicatorsViewName = "all_indicators"
+	// IndicatorFieldPrefix is the prefix of the Panther fields collecting the indicators of a row
+	IndicatorFieldPrefix = parsers.PantherFieldPrefix + "any_"
+)
+
 // CreateOrReplaceViews will update Athena with all views
 func CreateOrReplaceViews(glueClient glueiface.GlueAPI, athenaClient athenaiface.AthenaAPI) (err error) {
 	// check what tables are deployed
@@ -74,6 +81,13 @@ func GenerateLogViews(tables []*awsglue.GlueTableMetadata) (sqlStatements []stri
 		return nil, err
 	}
 	sqlStatements = append(sqlStatements, sqlStatement)
+	sqlStatement, err = generateViewAllIndicators(tables)
+	if err != nil {
+		return nil, err
+	}
+	if sqlStatement != "" { // only if some table has indicator fields
+		sqlStatements = append(sqlStatements, sqlStatement)
+	}
 	// add future views here
 	return sqlStatements, nil
 }
@@ -95,23 +109,83 @@ func generateViewAllRuleMatches(tables []*awsglue.GlueTableMetadata) (sql string
 	return generateViewAllHelper("all_rule_matches", ruleTables, awsglue.RuleMatchColumns)
 }
 
-func generateViewAllHelper(viewName string, tables []*awsglue.GlueTableMetadata, extraColumns []awsglue.Column) (sql string, err error) {
-	// validate they all have the same partition keys
-	if len(tables) > 1 {
-		// create string of partition for comparison
-		genKey := func(partitions []awsglue.PartitionKey) (key string) {
-			for _, p := range partitions {
-				key += p.Name + p.Type
-			}
-			return key
+// generateViewAllIndicators creates a view with a row for each indicator value in the "p_any" fields of all log sources
+func generateViewAllIndicators(tables []*awsglue.GlueTableMetadata) (sql string, err error) {
+	if err = checkPartitionKeys(tables); err != nil {
+		return "", err
+	}
+
+	var selects []string
+	for _, table := range tables {
+		indicatorColumns := inferIndicatorColumns(table)
+		if len(indicatorColumns) == 0 {
+			continue
+		}
+		// the indicator type is the name of the column without the prefix (e.g. p_any_ip_addresses -> ip_addresses)
+		indicatorTypes := make([]string, len(indicatorColumns))
+		for i, column := range indicatorColumns {
+			indicatorTypes[i] = "'" + strings.TrimPrefix(column, IndicatorFieldPrefix) + "'"
+		}
+		selectColumns := []string{"i.indicator_type", "v.value", "p_log_type AS log_type", "p_event_time", "p_row_id"}
+		for _, partitionKey := range table.PartitionKeys() {
+			selectColumns = append(selectColumns, partitionKey.Name)
+		}
+		// unnest the indicator arrays into (type, values) rows and then each of the values into a row
+		selects = append(selects, fmt.Sprintf("select %s from %s.%s"+
+			" cross join unnest(map(array[%s],array[%s])) AS i(indicator_type,indicator_values)"+
+			" cross join unnest(i.indicator_values) AS v(value)",
+			strings.Join(selectColumns, ","), table.DatabaseName(), table.TableName(),
+			strings.Join(indicatorTypes, ","), strings.Join(indicatorColumns, ",")))
+	}
+	if len(selects) == 0 {
+		return "", nil
+	}
+
+	var sqlLines []string
+	sqlLines = append(sqlLines, fmt.Sprintf("create or replace view %s.%s as", awsglue.ViewsDatabaseName, IndicatorsViewName))
+	sqlLines = append(sqlLines, strings.Join(selects, "\n\tunion all\n"))
+	sqlLines = append(sqlLines, ";\n")
+	return strings.Join(sqlLines, "\n"), nil
+}
+
+// inferIndicatorColumns returns the sorted names of the "p_any" columns of a table
+func inferIndicatorColumns(table *awsglue.GlueTableMetadata) (indicatorColumns []string) {
+	columns, _ := awsglue.InferJSONColumns(table.EventStruct(), awsglue.GlueMappings...)
+	for _, col := range columns {
+		if strings.HasPrefix(col.Name, IndicatorFieldPrefix) {
+			indicatorColumns = append(indicatorColumns, col.Name)
+		}
+	}
+	sort.Strings(indicatorColumns)
+	return indicatorColumns
+}
+
+// checkPartitionKeys validates that all tables have the same partition keys
+func checkPartitionKeys(tables []*awsglue.GlueTableMetadata) error {
+	if len(tables) < 2 {
+		return nil
+	}
+	// create string of partition for comparison
+	genKey := func(partitions []awsglue.PartitionKey) (key string) {
+		for _, p := range partitions {
+			key += p.Name + p.Type
 		}
-		referenceKey := genKey(tables[0].PartitionKeys())
-		for _, table := range tables[1:] {
-			if referenceKey != genKey(table.PartitionKeys()) {
-				return "", errors.New("all tables do not share same partition keys for generateViewAllHelper()")
-			}
+		return key
+	}
+	referenceKey := genKey(tables[0].PartitionKeys())
+	for _, table := range tables[1:] {
+		if referenceKey != genKey(table.PartitionKeys()) {
+			return errors.New("all tables do not share same partition keys")
 		}
 	}
+	return nil
+}
+
+func generateViewAllHelper(viewName string, tables []*awsglue.GlueTableMetadata, extraColumns []awsglue.Column) (sql string, err error) {
+	// validate they all have the same partition keys
+	if err = checkPartitionKeys(tables); err != nil {
+		return "", errors.Wrap(err, "generateViewAllHelper()")
+	}
 
 	// collect the Panther fields, add "NULL" for fields not present in some tables but present in others
 	pantherViewColumns := newPantherViewColumns(tables, extraColumns)
@@ -45,9 +45,9 @@ func TestGenerateViewAllLogs(t *testing.T) {
 	table2 := awsglue.NewGlueTableMetadata(models.LogData, "table2", "test table2", awsglue.GlueTableHourly, &table2Event{})
 	// nolint (lll)
 	expectedSQL := `create or replace view panther_views.all_logs as
-select day,hour,month,NULL AS p_any_aws_account_ids,NULL AS p_any_aws_arns,NULL AS p_any_aws_instance_ids,NULL AS p_any_aws_tags,p_any_domain_names,p_any_ip_addresses,p_any_md5_hashes,p_any_sha1_hashes,p_any_sha256_hashes,p_event_time,p_log_type,p_parse_time,p_row_id,p_source_id,p_source_label,year from panther_logs.table1
+select day,hour,month,NULL AS p_any_aws_account_ids,NULL AS p_any_aws_arns,NULL AS p_any_aws_instance_ids,NULL AS p_any_aws_tags,p_any_domain_names,p_any_ip_addresses,p_any_md5_hashes,p_any_sha1_hashes,p_any_sha256_hashes,p_enrichment,p_event_time,p_log_type,p_parse_time,p_row_id,p_source_id,p_source_label,year from panther_logs.table1
 	union all
-select day,hour,month,p_any_aws_account_ids,p_any_aws_arns,p_any_aws_instance_ids,p_any_aws_tags,p_any_domain_names,p_any_ip_addresses,p_any_md5_hashes,p_any_sha1_hashes,p_any_sha256_hashes,p_event_time,p_log_type,p_parse_time,p_row_id,p_source_id,p_source_label,year from panther_logs.table2
+select day,hour,month,p_any_aws_account_ids,p_any_aws_arns,p_any_aws_instance_ids,p_any_aws_tags,p_any_domain_names,p_any_ip_addresses,p_any_md5_hashes,p_any_sha1_hashes,p_any_sha256_hashes,p_enrichment,p_event_time,p_log_type,p_parse_time,p_row_id,p_source_id,p_source_label,year from panther_logs.table2
 ;
 `
 	sql, err := generateViewAllLogs([]*awsglue.GlueTableMetadata{table1, table2})
@@ -70,3 +70,28 @@ func TestGenerateLogsViewsFail(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "no tables"))
 }
+
+func TestGenerateViewAllIndicators(t *testing.T) {
+	table1 := awsglue.NewGlueTableMetadata(models.LogData, "table1", "test table1", awsglue.GlueTableHourly, &table1Event{})
+	table2 := awsglue.NewGlueTableMetadata(models.LogData, "table2", "test table2", awsglue.GlueTableHourly, &table2Event{})
+	// nolint (lll)
+	expectedSQL := `create or replace view panther_views.all_indicators as
+select i.indicator_type,v.value,p_log_type AS log_type,p_event_time,p_row_id,year,month,day,hour from panther_logs.table1 cross join unnest(map(array['domain_names','ip_addresses','md5_hashes','sha1_hashes','sha256_hashes'],array[p_any_domain_names,p_any_ip_addresses,p_any_md5_hashes,p_any_sha1_hashes,p_any_sha256_hashes])) AS i(indicator_type,indicator_values) cross join unnest(i.indicator_values) AS v(value)
+	union all
+select i.indicator_type,v.value,p_log_type AS log_type,p_event_time,p_row_id,year,month,day,hour from panther_logs.table2 cross join unnest(map(array['aws_account_ids','aws_arns','aws_instance_ids','aws_tags','domain_names','ip_addresses','md5_hashes','sha1_hashes','sha256_hashes'],array[p_any_aws_account_ids,p_any_aws_arns,p_any_aws_instance_ids,p_any_aws_tags,p_any_domain_names,p_any_ip_addresses,p_any_md5_hashes,p_any_sha1_hashes,p_any_sha256_hashes])) AS i(indicator_type,indicator_values) cross join unnest(i.indicator_values) AS v(value)
+;
+`
+	sql, err := generateViewAllIndicators([]*awsglue.GlueTableMetadata{table1, table2})
+	require.NoError(t, err)
+	require.Equal(t, expectedSQL, sql)
+}
+
+func TestGenerateViewAllIndicatorsNoIndicators(t *testing.T) {
+	type noIndicatorsEvent struct {
+		FavoriteFruit string `description:"test field"`
+	}
+	table := awsglue.NewGlueTableMetadata(models.LogData, "table1", "test table1", awsglue.GlueTableHourly, &noIndicatorsEvent{})
+	sql, err := generateViewAllIndicators([]*awsglue.GlueTableMetadata{table})
+	require.NoError(t, err)
+	require.Empty(t, sql)
+}
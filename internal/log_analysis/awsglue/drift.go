@@ -0,0 +1,318 @@
+package awsglue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+)
+
+// TableDrift describes how a deployed table and its partitions differ from the table schema in code
+type TableDrift struct {
+	DatabaseName  string `json:"databaseName"`
+	TableName     string `json:"tableName"`
+	LogType       string `json:"logType"`
+	SchemaVersion int    `json:"schemaVersion"`
+	// Breaking is set if updating the table requires a new schema version
+	Breaking bool           `json:"breaking"`
+	Added    []ColumnChange `json:"added,omitempty"`
+	Removed  []ColumnChange `json:"removed,omitempty"`
+	Changed  []ColumnChange `json:"changed,omitempty"`
+	// PartitionProjection is set for projected tables, their partitions are computed by Athena and are not checked
+	PartitionProjection bool `json:"partitionProjection,omitempty"`
+	// StalePartitions are the partitions that SyncPartitions would update after the table is updated
+	StalePartitions []StalePartition `json:"stalePartitions,omitempty"`
+	// MissingPartitions are the partitions with data in S3 that are not in the catalog
+	MissingPartitions []PartitionRange `json:"missingPartitions,omitempty"`
+}
+
+// StalePartition is a partition whose storage descriptor does not match the table schema in code
+type StalePartition struct {
+	Time     time.Time `json:"time"`
+	Location string    `json:"location"`
+	Reasons  []string  `json:"reasons"`
+}
+
+// PartitionRange is a range of Count consecutive partitions starting at Start, End is the start of the next partition
+type PartitionRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Count int       `json:"count"`
+}
+
+// HasDrift returns true if the table or any of its partitions need to be updated
+func (d *TableDrift) HasDrift() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0 ||
+		len(d.StalePartitions) > 0 || len(d.MissingPartitions) > 0
+}
+
+// DetectDrift compares the deployed table and its partitions with the table schema in code without modifying anything.
+// Partitions are checked from start (the create time of the table if zero) to end (now if zero).
+func (gm *GlueTableMetadata) DetectDrift(glueClient glueiface.GlueAPI, s3Client s3iface.S3API,
+	start, end time.Time) (*TableDrift, error) {
+
+	tableOutput, err := GetTable(glueClient, gm.databaseName, gm.tableName)
+	if err != nil {
+		return nil, err
+	}
+	table := tableOutput.Table
+	tableInput := gm.glueTableInput("")
+	diff := gm.diffSchema(table, tableInput)
+	drift := &TableDrift{
+		DatabaseName:  gm.databaseName,
+		TableName:     gm.tableName,
+		LogType:       gm.logType,
+		SchemaVersion: diff.OldVersion,
+		Breaking:      diff.IsBreaking(),
+	}
+	for _, change := range diff.Changes {
+		switch change.Kind {
+		case ColumnAdded:
+			drift.Added = append(drift.Added, change)
+		case ColumnRemoved:
+			drift.Removed = append(drift.Removed, change)
+		default:
+			drift.Changed = append(drift.Changed, change)
+		}
+	}
+
+	if IsPartitionProjectionEnabled(table) {
+		drift.PartitionProjection = true
+		return drift, nil
+	}
+
+	bucket, _, err := ParseS3URL(aws.StringValue(table.StorageDescriptor.Location))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid location for %s.%s", gm.databaseName, gm.tableName)
+	}
+	if start.IsZero() {
+		start = aws.TimeValue(table.CreateTime)
+	}
+	start = start.UTC().Truncate(time.Hour * 24) // clip to beginning of day
+	// partitions before the last breaking schema change were moved to the archive table
+	if updatedAt, ok := SchemaUpdatedAt(table); ok && start.Before(updatedAt) {
+		start = updatedAt.Truncate(time.Hour) // the partition containing the change is still written to
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	end = end.UTC()
+
+	deployed, err := gm.checkPartitions(glueClient, bucket, tableInput.StorageDescriptor, start, end, drift)
+	if err != nil {
+		return nil, err
+	}
+	withData, err := gm.partitionsWithData(s3Client, bucket, start, end)
+	if err != nil {
+		return nil, err
+	}
+	drift.MissingPartitions = gm.missingPartitions(withData, deployed)
+	return drift, nil
+}
+
+// checkPartitions adds the stale partitions between start and end to drift and returns the times of all partitions
+func (gm *GlueTableMetadata) checkPartitions(client glueiface.GlueAPI, bucket string, expect *glue.StorageDescriptor,
+	start, end time.Time, drift *TableDrift) (map[int64]bool, error) {
+
+	deployed := make(map[int64]bool)
+	input := &glue.GetPartitionsInput{
+		DatabaseName: aws.String(gm.databaseName),
+		TableName:    aws.String(gm.tableName),
+	}
+	for {
+		output, err := client.GetPartitions(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get partitions of %s.%s", gm.databaseName, gm.tableName)
+		}
+		for _, partition := range output.Partitions {
+			partitionTime, err := gm.timebin.PartitionTimeFromValues(partition.Values)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "invalid partition in %s.%s", gm.databaseName, gm.tableName)
+			}
+			deployed[partitionTime.Unix()] = true
+			if partitionTime.Before(start) || !partitionTime.Before(end) {
+				continue
+			}
+			location := "s3://" + bucket + "/" + gm.GetPartitionPrefix(partitionTime)
+			if partition.StorageDescriptor == nil {
+				drift.StalePartitions = append(drift.StalePartitions, StalePartition{
+					Time:    partitionTime,
+					Reasons: []string{"missing storage descriptor"},
+				})
+				continue
+			}
+			if reasons := storageDescriptorDrift(partition.StorageDescriptor, expect, location); len(reasons) > 0 {
+				drift.StalePartitions = append(drift.StalePartitions, StalePartition{
+					Time:     partitionTime,
+					Location: aws.StringValue(partition.StorageDescriptor.Location),
+					Reasons:  reasons,
+				})
+			}
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	sort.Slice(drift.StalePartitions, func(i, j int) bool {
+		return drift.StalePartitions[i].Time.Before(drift.StalePartitions[j].Time)
+	})
+	return deployed, nil
+}
+
+// storageDescriptorDrift returns the reasons a partition storage descriptor differs from the expected one
+func storageDescriptorDrift(actual, expect *glue.StorageDescriptor, location string) (reasons []string) {
+	if changes := DiffColumns(actual.Columns, expect.Columns); len(changes) > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d column change(s)", len(changes)))
+	}
+	// SyncPartitions only copies the serde info to JSON partitions
+	if actual.SerdeInfo != nil && IsJSONPartition(actual) && !equalSerDeInfo(actual.SerdeInfo, expect.SerdeInfo) {
+		reasons = append(reasons, "serde info differs")
+	}
+	if strings.TrimSuffix(aws.StringValue(actual.Location), "/") != strings.TrimSuffix(location, "/") {
+		reasons = append(reasons, "location differs from "+location)
+	}
+	return reasons
+}
+
+func equalSerDeInfo(a, b *glue.SerDeInfo) bool {
+	if aws.StringValue(a.SerializationLibrary) != aws.StringValue(b.SerializationLibrary) {
+		return false
+	}
+	if len(a.Parameters) != len(b.Parameters) {
+		return false
+	}
+	for name, value := range a.Parameters {
+		other, ok := b.Parameters[name]
+		if !ok || aws.StringValue(value) != aws.StringValue(other) {
+			return false
+		}
+	}
+	return true
+}
+
+// partitionsWithData walks the partition prefixes of the table in S3 and returns the times of partitions between start and end.
+// S3 only reports common prefixes that contain objects, so every partition found has data.
+func (gm *GlueTableMetadata) partitionsWithData(client s3iface.S3API, bucket string, start, end time.Time) ([]time.Time, error) {
+	keys := gm.PartitionKeys()
+	var times []time.Time
+	var walk func(prefix string, values []*string) error
+	walk = func(prefix string, values []*string) error {
+		if len(values) == len(keys) {
+			partitionTime, err := gm.timebin.PartitionTimeFromValues(values)
+			if err == nil && !partitionTime.Before(start) && partitionTime.Before(end) {
+				times = append(times, partitionTime)
+			}
+			return nil
+		}
+		if from, to, ok := partialPartitionRange(values); !ok || !from.Before(end) || !to.After(start) {
+			return nil
+		}
+		var prefixes []string
+		input := &s3.ListObjectsV2Input{
+			Bucket:    aws.String(bucket),
+			Prefix:    aws.String(prefix),
+			Delimiter: aws.String("/"),
+		}
+		err := client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+			for _, commonPrefix := range page.CommonPrefixes {
+				prefixes = append(prefixes, aws.StringValue(commonPrefix.Prefix))
+			}
+			return true
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to list s3://%s/%s", bucket, prefix)
+		}
+		keyPrefix := keys[len(values)].Name + "="
+		for _, p := range prefixes {
+			value := strings.TrimSuffix(strings.TrimPrefix(p, prefix), "/")
+			if !strings.HasPrefix(value, keyPrefix) {
+				continue
+			}
+			next := append(values[:len(values):len(values)], aws.String(strings.TrimPrefix(value, keyPrefix)))
+			if err := walk(p, next); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(gm.Prefix(), nil); err != nil {
+		return nil, err
+	}
+	return times, nil
+}
+
+// partialPartitionRange returns the time range covered by the leading partition values (year, month, day)
+func partialPartitionRange(values []*string) (from, to time.Time, ok bool) {
+	if len(values) == 0 {
+		return time.Time{}, time.Unix(1<<62, 0), true
+	}
+	// year, month, day
+	parts := []int{0, 1, 1}
+	for i, value := range values {
+		n, err := strconv.Atoi(aws.StringValue(value))
+		if err != nil {
+			return from, to, false
+		}
+		parts[i] = n
+	}
+	from = time.Date(parts[0], time.Month(parts[1]), parts[2], 0, 0, 0, 0, time.UTC)
+	switch len(values) {
+	case 1:
+		to = from.AddDate(1, 0, 0)
+	case 2:
+		to = from.AddDate(0, 1, 0)
+	default:
+		to = from.AddDate(0, 0, 1)
+	}
+	return from, to, true
+}
+
+// missingPartitions collapses the partitions with data that are not deployed into ranges of consecutive partitions
+func (gm *GlueTableMetadata) missingPartitions(withData []time.Time, deployed map[int64]bool) (missing []PartitionRange) {
+	sort.Slice(withData, func(i, j int) bool {
+		return withData[i].Before(withData[j])
+	})
+	for _, partitionTime := range withData {
+		if deployed[partitionTime.Unix()] {
+			continue
+		}
+		if n := len(missing); n > 0 && missing[n-1].End.Equal(partitionTime) {
+			missing[n-1].End = gm.timebin.Next(partitionTime)
+			missing[n-1].Count++
+			continue
+		}
+		missing = append(missing, PartitionRange{
+			Start: partitionTime,
+			End:   gm.timebin.Next(partitionTime),
+			Count: 1,
+		})
+	}
+	return missing
+}
@@ -0,0 +1,135 @@
+package awsglue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestDetectDrift(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, schemaTestEvent{})
+	start := time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	partitionLocation := func(hour int) *string {
+		return aws.String("s3://" + metadataTestBucket + "/" + gm.GetPartitionPrefix(start.Add(time.Duration(hour)*time.Hour)))
+	}
+	upToDate := *gm.glueTableInput("").StorageDescriptor
+	upToDate.Location = partitionLocation(0)
+	stale := *testStorageDescriptor
+	stale.Location = partitionLocation(1)
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
+	glueClient.On("GetPartitions", mock.Anything).Return(&glue.GetPartitionsOutput{
+		Partitions: []*glue.Partition{
+			{Values: gm.Timebin().PartitionValuesFromTime(start), StorageDescriptor: &upToDate},
+			{Values: gm.Timebin().PartitionValuesFromTime(start.Add(time.Hour)), StorageDescriptor: &stale},
+			// outside the checked range
+			{Values: gm.Timebin().PartitionValuesFromTime(start.Add(-time.Hour)), StorageDescriptor: &stale},
+		},
+	}, nil).Once()
+
+	s3Client := &testutils.S3Mock{}
+	listPrefixes := func(prefix string, prefixes ...string) {
+		page := &s3.ListObjectsV2Output{}
+		for _, p := range prefixes {
+			page.CommonPrefixes = append(page.CommonPrefixes, &s3.CommonPrefix{Prefix: aws.String(prefix + p)})
+		}
+		s3Client.On("ListObjectsV2Pages", &s3.ListObjectsV2Input{
+			Bucket:    aws.String(metadataTestBucket),
+			Prefix:    aws.String(prefix),
+			Delimiter: aws.String("/"),
+		}, mock.Anything).Return(page, nil).Once()
+	}
+	// year=2019 is before start and is not listed
+	listPrefixes("logs/test_logs/", "year=2019/", "year=2020/")
+	listPrefixes("logs/test_logs/year=2020/", "month=01/")
+	listPrefixes("logs/test_logs/year=2020/month=01/", "day=02/", "day=03/", "day=04/")
+	listPrefixes("logs/test_logs/year=2020/month=01/day=03/", "hour=00/", "hour=01/", "hour=02/", "hour=03/", "hour=05/", "_temp/")
+
+	drift, err := gm.DetectDrift(glueClient, s3Client, start, end)
+	require.NoError(t, err)
+	glueClient.AssertExpectations(t)
+	s3Client.AssertExpectations(t)
+
+	require.True(t, drift.HasDrift())
+	require.Equal(t, &TableDrift{
+		DatabaseName:  LogProcessingDatabaseName,
+		TableName:     "test_logs",
+		LogType:       "Test.Logs",
+		SchemaVersion: 1,
+		Added:         []ColumnChange{{Name: "name", Kind: ColumnAdded, NewType: "string"}},
+		StalePartitions: []StalePartition{
+			{
+				Time:     start.Add(time.Hour),
+				Location: aws.StringValue(stale.Location),
+				Reasons:  []string{"1 column change(s)", "serde info differs"},
+			},
+		},
+		MissingPartitions: []PartitionRange{
+			{Start: start.Add(2 * time.Hour), End: start.Add(4 * time.Hour), Count: 2},
+			{Start: start.Add(5 * time.Hour), End: start.Add(6 * time.Hour), Count: 1},
+		},
+	}, drift)
+
+	data, err := json.Marshal(drift.Added)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"name":"name","kind":"added","newType":"string"}]`, string(data))
+}
+
+func TestDetectDriftProjected(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+	tableInput := gm.glueTableInput(metadataTestBucket)
+	gm.WithPartitionProjection(2020).setPartitionProjection(tableInput, metadataTestBucket, nil)
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{
+		Table: &glue.TableData{
+			CreateTime:        aws.Time(refTime),
+			StorageDescriptor: testStorageDescriptor,
+			Parameters:        tableInput.Parameters,
+		},
+	}, nil).Once()
+
+	drift, err := gm.DetectDrift(glueClient, &testutils.S3Mock{}, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	glueClient.AssertExpectations(t)
+	require.True(t, drift.PartitionProjection)
+	require.Equal(t, []ColumnChange{{Name: "col", Kind: ColumnRemoved, OldType: "int"}}, drift.Removed)
+	require.True(t, drift.Breaking)
+	require.Equal(t, 1, drift.SchemaVersion)
+}
+
+func TestStorageDescriptorDriftLocation(t *testing.T) {
+	sd := *testStorageDescriptor
+	sd.Location = aws.String("s3://testbucket/logs/table/year=2020/month=01/day=03/hour=01")
+	require.Empty(t, storageDescriptorDrift(&sd, testStorageDescriptor, "s3://testbucket/logs/table/year=2020/month=01/day=03/hour=01/"))
+	require.Equal(t, []string{"location differs from s3://testbucket/logs/table/year=2020/month=01/day=03/hour=02/"},
+		storageDescriptorDrift(&sd, testStorageDescriptor, "s3://testbucket/logs/table/year=2020/month=01/day=03/hour=02/"))
+}
@@ -20,6 +20,7 @@ package awsglue
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -82,6 +83,33 @@ func (tb GlueTableTimebin) PartitionValuesFromTime(t time.Time) (values []*strin
 	return
 }
 
+// PartitionTimeFromValues returns the start time of the partition with the values returned by PartitionValuesFromTime
+func (tb GlueTableTimebin) PartitionTimeFromValues(values []*string) (t time.Time, err error) {
+	var expectLen int
+	switch tb {
+	case GlueTableHourly:
+		expectLen = 4
+	case GlueTableDaily:
+		expectLen = 3
+	case GlueTableMonthly:
+		expectLen = 2
+	default:
+		return t, fmt.Errorf("unknown GlueTableMetadata table time bin: %d", tb)
+	}
+	if len(values) != expectLen {
+		return t, errors.Errorf("expected %d partition values, found %d", expectLen, len(values))
+	}
+
+	// year, month, day, hour
+	parts := []int{0, 1, 1, 0}
+	for i, value := range values {
+		if parts[i], err = strconv.Atoi(aws.StringValue(value)); err != nil {
+			return t, errors.Wrapf(err, "invalid partition value %q", aws.StringValue(value))
+		}
+	}
+	return time.Date(parts[0], time.Month(parts[1]), parts[2], parts[3], 0, 0, 0, time.UTC), nil
+}
+
 // PartitionS3PathFromTime constructs the S3 path for this partition
 func (tb GlueTableTimebin) PartitionS3PathFromTime(t time.Time) (s3Path string) {
 	switch tb {
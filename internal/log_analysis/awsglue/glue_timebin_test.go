@@ -22,7 +22,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGlueTableTimebinNext(t *testing.T) {
@@ -76,3 +78,21 @@ func TestGlueTableTimebinPartitionS3PathFromTime(t *testing.T) {
 	expectedPath = "year=2020/month=01/"
 	assert.Equal(t, expectedPath, tb.PartitionS3PathFromTime(refTime))
 }
+
+func TestGlueTableTimebinPartitionTimeFromValues(t *testing.T) {
+	refTime := time.Date(2020, 11, 21, 13, 0, 0, 0, time.UTC)
+	for _, tb := range []GlueTableTimebin{GlueTableHourly, GlueTableDaily, GlueTableMonthly} {
+		partitionTime, err := tb.PartitionTimeFromValues(tb.PartitionValuesFromTime(refTime))
+		require.NoError(t, err)
+		assert.Equal(t, tb.Next(partitionTime), tb.Next(refTime))
+	}
+
+	partitionTime, err := GlueTableHourly.PartitionTimeFromValues(aws.StringSlice([]string{"2020", "01", "02", "03"}))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC), partitionTime)
+
+	_, err = GlueTableHourly.PartitionTimeFromValues(aws.StringSlice([]string{"2020", "01", "02"}))
+	assert.Error(t, err)
+	_, err = GlueTableDaily.PartitionTimeFromValues(aws.StringSlice([]string{"2020", "01", "xx"}))
+	assert.Error(t, err)
+}
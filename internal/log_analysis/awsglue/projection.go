@@ -0,0 +1,200 @@
+package awsglue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/pkg/errors"
+)
+
+// Athena partition projection table parameters
+// See https://docs.aws.amazon.com/athena/latest/ug/partition-projection-setting-up.html
+const (
+	projectionEnabledParameter       = "projection.enabled"
+	projectionParameterPrefix        = "projection."
+	storageLocationTemplateParameter = "storage.location.template"
+)
+
+// PartitionProjectionFromYear is the default first year of projected partitions.
+// Earlier years are added to the projection when data for them is written (see ExtendPartitionProjection).
+const PartitionProjectionFromYear = 2020
+
+type partitionProjectionMode int
+
+const (
+	// keep the partition projection settings of the deployed table
+	keepPartitionProjection partitionProjectionMode = iota
+	enablePartitionProjection
+	disablePartitionProjection
+)
+
+// WithPartitionProjection returns a copy of the table metadata that configures Athena partition projection
+// on the table for years starting at fromYear.
+// Partitions of projected tables are computed by Athena at query time from the table's time bin,
+// so they do not need to be registered in the Glue catalog.
+func (gm *GlueTableMetadata) WithPartitionProjection(fromYear int) *GlueTableMetadata {
+	projected := *gm
+	projected.projectionMode = enablePartitionProjection
+	projected.projectionFromYear = fromYear
+	return &projected
+}
+
+// WithoutPartitionProjection returns a copy of the table metadata that removes Athena partition projection
+// from the deployed table. Partitions need to be registered using SyncPartitions afterwards.
+func (gm *GlueTableMetadata) WithoutPartitionProjection() *GlueTableMetadata {
+	unprojected := *gm
+	unprojected.projectionMode = disablePartitionProjection
+	unprojected.projectionFromYear = 0
+	return &unprojected
+}
+
+// IsPartitionProjectionEnabled checks if Athena partition projection is enabled on a deployed table
+func IsPartitionProjectionEnabled(table *glue.TableData) bool {
+	return strings.EqualFold(aws.StringValue(table.Parameters[projectionEnabledParameter]), "true")
+}
+
+// partitionProjectionParameters returns the table parameters for partition projection from fromYear to toYear
+func (gm *GlueTableMetadata) partitionProjectionParameters(bucketName string, fromYear, toYear int) map[string]*string {
+	params := map[string]*string{
+		projectionEnabledParameter: aws.String("true"),
+	}
+	template := "s3://" + bucketName + "/" + gm.prefix
+	for _, key := range gm.PartitionKeys() {
+		var valueRange, digits string
+		switch key.Name {
+		case "year":
+			valueRange = fmt.Sprintf("%d,%d", fromYear, toYear)
+		case "month":
+			valueRange, digits = "1,12", "2"
+		case "day":
+			valueRange, digits = "1,31", "2"
+		case "hour":
+			valueRange, digits = "0,23", "2"
+		}
+		params[projectionParameterPrefix+key.Name+".type"] = aws.String("integer")
+		params[projectionParameterPrefix+key.Name+".range"] = aws.String(valueRange)
+		if digits != "" {
+			params[projectionParameterPrefix+key.Name+".digits"] = aws.String(digits)
+		}
+		template += key.Name + "=${" + key.Name + "}/"
+	}
+	params[storageLocationTemplateParameter] = aws.String(template)
+	return params
+}
+
+// projectedYears returns the range of years projected on a deployed table
+func projectedYears(table *glue.TableData) (fromYear, toYear int, ok bool) {
+	yearRange := strings.Split(aws.StringValue(table.Parameters[projectionParameterPrefix+"year.range"]), ",")
+	if len(yearRange) != 2 {
+		return 0, 0, false
+	}
+	fromYear, err := strconv.Atoi(strings.TrimSpace(yearRange[0]))
+	if err != nil {
+		return 0, 0, false
+	}
+	toYear, err = strconv.Atoi(strings.TrimSpace(yearRange[1]))
+	if err != nil {
+		return 0, 0, false
+	}
+	return fromYear, toYear, true
+}
+
+// setPartitionProjection sets the partition projection parameters on a table input according to the table metadata
+// and the deployed table (nil if the table is not deployed yet).
+func (gm *GlueTableMetadata) setPartitionProjection(tableInput *glue.TableInput, bucketName string, deployed *glue.TableData) {
+	var fromYear, toYear int
+	switch gm.projectionMode {
+	case enablePartitionProjection:
+		fromYear = gm.projectionFromYear
+		// Project to next year, the range is extended by ExtendPartitionProjection as new data arrives
+		toYear = time.Now().UTC().Year() + 1
+		// never shrink the range of a deployed projection
+		if deployed != nil && IsPartitionProjectionEnabled(deployed) {
+			if deployedFromYear, deployedToYear, ok := projectedYears(deployed); ok {
+				if deployedFromYear < fromYear {
+					fromYear = deployedFromYear
+				}
+				if deployedToYear > toYear {
+					toYear = deployedToYear
+				}
+			}
+		}
+	case keepPartitionProjection:
+		if deployed == nil || !IsPartitionProjectionEnabled(deployed) {
+			return
+		}
+		var ok bool
+		if fromYear, toYear, ok = projectedYears(deployed); !ok {
+			return
+		}
+	default:
+		return
+	}
+	if tableInput.Parameters == nil {
+		tableInput.Parameters = make(map[string]*string)
+	}
+	for name, value := range gm.partitionProjectionParameters(bucketName, fromYear, toYear) {
+		tableInput.Parameters[name] = value
+	}
+}
+
+// ExtendPartitionProjection extends the projected years of a deployed table to include the time bin of tm.
+// It returns true if the table was updated.
+func (gm *GlueTableMetadata) ExtendPartitionProjection(client glueiface.GlueAPI, table *glue.TableData, tm time.Time) (bool, error) {
+	fromYear, toYear, ok := projectedYears(table)
+	if !ok {
+		return false, errors.Errorf("invalid year projection for table %s.%s", gm.databaseName, gm.tableName)
+	}
+	year := tm.UTC().Year()
+	switch {
+	case year > toYear:
+		toYear = year
+	case year < fromYear:
+		fromYear = year
+	default:
+		return false, nil
+	}
+	params := make(map[string]*string, len(table.Parameters))
+	for name, value := range table.Parameters {
+		params[name] = value
+	}
+	params[projectionParameterPrefix+"year.range"] = aws.String(fmt.Sprintf("%d,%d", fromYear, toYear))
+	_, err := client.UpdateTable(&glue.UpdateTableInput{
+		DatabaseName: &gm.databaseName,
+		TableInput: &glue.TableInput{
+			Name:              &gm.tableName,
+			Description:       table.Description,
+			PartitionKeys:     table.PartitionKeys,
+			StorageDescriptor: table.StorageDescriptor,
+			TableType:         table.TableType,
+			Parameters:        params,
+		},
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to extend partition projection of %s.%s", gm.databaseName, gm.tableName)
+	}
+	return true, nil
+}
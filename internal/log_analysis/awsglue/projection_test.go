@@ -0,0 +1,151 @@
+package awsglue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func projectedTestTable(fromYear, toYear string) *glue.TableData {
+	return &glue.TableData{
+		CreateTime:        aws.Time(refTime),
+		StorageDescriptor: testStorageDescriptor,
+		Parameters: map[string]*string{
+			projectionEnabledParameter:       aws.String("true"),
+			"projection.year.type":           aws.String("integer"),
+			"projection.year.range":          aws.String(fromYear + "," + toYear),
+			SchemaVersionParameter:           aws.String("1"),
+			"some.unrelated.parameter":       aws.String("foo"),
+			"projection.month.type":          aws.String("integer"),
+			"projection.month.range":         aws.String("1,12"),
+			"projection.month.digits":        aws.String("2"),
+			storageLocationTemplateParameter: aws.String("s3://bucket/logs/table/year=${year}/month=${month}/"),
+		},
+	}
+}
+
+func TestPartitionProjectionParameters(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+	params := aws.StringValueMap(gm.partitionProjectionParameters("bucket", 2020, 2021))
+	require.Equal(t, map[string]string{
+		"projection.enabled":        "true",
+		"projection.year.type":      "integer",
+		"projection.year.range":     "2020,2021",
+		"projection.month.type":     "integer",
+		"projection.month.range":    "1,12",
+		"projection.month.digits":   "2",
+		"projection.day.type":       "integer",
+		"projection.day.range":      "1,31",
+		"projection.day.digits":     "2",
+		"projection.hour.type":      "integer",
+		"projection.hour.range":     "0,23",
+		"projection.hour.digits":    "2",
+		"storage.location.template": "s3://bucket/logs/test_logs/year=${year}/month=${month}/day=${day}/hour=${hour}/",
+	}, params)
+
+	gm = NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableMonthly, partitionTestEvent{})
+	params = aws.StringValueMap(gm.partitionProjectionParameters("bucket", 2020, 2021))
+	require.Equal(t, "s3://bucket/logs/test_logs/year=${year}/month=${month}/", params["storage.location.template"])
+	require.NotContains(t, params, "projection.day.type")
+}
+
+func TestSetPartitionProjection(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+	nextYear := time.Now().UTC().Year() + 1
+
+	// keep, table not deployed
+	tableInput := &glue.TableInput{}
+	gm.setPartitionProjection(tableInput, "bucket", nil)
+	require.Nil(t, tableInput.Parameters)
+
+	// keep, deployed table is projected
+	tableInput = &glue.TableInput{}
+	gm.setPartitionProjection(tableInput, "bucket", projectedTestTable("2018", "2030"))
+	require.Equal(t, "2018,2030", aws.StringValue(tableInput.Parameters["projection.year.range"]))
+
+	// enable, table not deployed
+	tableInput = &glue.TableInput{}
+	gm.WithPartitionProjection(2019).setPartitionProjection(tableInput, "bucket", nil)
+	require.Equal(t, "true", aws.StringValue(tableInput.Parameters[projectionEnabledParameter]))
+	require.Equal(t, "2019,"+strconv.Itoa(nextYear), aws.StringValue(tableInput.Parameters["projection.year.range"]))
+
+	// enable never shrinks the deployed range
+	tableInput = &glue.TableInput{}
+	gm.WithPartitionProjection(2019).setPartitionProjection(tableInput, "bucket", projectedTestTable("2017", "2099"))
+	require.Equal(t, "2017,2099", aws.StringValue(tableInput.Parameters["projection.year.range"]))
+
+	// disable
+	tableInput = &glue.TableInput{}
+	gm.WithPartitionProjection(2019).WithoutPartitionProjection().setPartitionProjection(tableInput, "bucket",
+		projectedTestTable("2018", "2030"))
+	require.Nil(t, tableInput.Parameters)
+
+	// rule tables inherit the projection settings
+	require.Equal(t, enablePartitionProjection, gm.WithPartitionProjection(2019).RuleTable().projectionMode)
+}
+
+func TestCreateJSONPartitionProjected(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	// in range, nothing to do
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{Table: projectedTestTable("2019", "2030")}, nil).Once()
+	created, err := gm.CreateJSONPartition(glueClient, refTime)
+	require.NoError(t, err)
+	require.False(t, created)
+	glueClient.AssertExpectations(t)
+
+	// out of range, extend projection
+	glueClient = &testutils.GlueMock{}
+	glueClient.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{Table: projectedTestTable("2018", "2019")}, nil).Once()
+	glueClient.On("UpdateTable", mock.Anything).Return(&glue.UpdateTableOutput{}, nil).Once()
+	created, err = gm.CreateJSONPartition(glueClient, refTime)
+	require.NoError(t, err)
+	require.False(t, created)
+	glueClient.AssertExpectations(t)
+
+	updateInput := glueClient.Calls[1].Arguments.Get(0).(*glue.UpdateTableInput)
+	require.Equal(t, "2018,2020", aws.StringValue(updateInput.TableInput.Parameters["projection.year.range"]))
+	require.Equal(t, "foo", aws.StringValue(updateInput.TableInput.Parameters["some.unrelated.parameter"]))
+	require.Equal(t, gm.TableName(), aws.StringValue(updateInput.TableInput.Name))
+}
+
+func TestSyncPartitionsProjected(t *testing.T) {
+	var startDate time.Time // default unset
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{Table: projectedTestTable("2019", "2030")}, nil).Once()
+	s3Client := &testutils.S3Mock{}
+	next, err := gm.SyncPartitions(glueClient, s3Client, startDate, nil)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	glueClient.AssertExpectations(t)
+	s3Client.AssertExpectations(t)
+}
@@ -0,0 +1,315 @@
+package awsglue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+)
+
+// ColumnChangeKind classifies a change of a table column
+type ColumnChangeKind int
+
+const (
+	// ColumnAdded is a column that did not exist in the old schema
+	ColumnAdded ColumnChangeKind = iota
+	// ColumnRemoved is a column that does not exist in the new schema
+	ColumnRemoved
+	// ColumnWidened is a column whose type changed in a backwards compatible way (wider numbers, new struct fields)
+	ColumnWidened
+	// ColumnTypeChanged is a column whose type changed in a way that old data cannot be read with the new type
+	ColumnTypeChanged
+)
+
+func (k ColumnChangeKind) String() string {
+	switch k {
+	case ColumnAdded:
+		return "added"
+	case ColumnRemoved:
+		return "removed"
+	case ColumnWidened:
+		return "widened"
+	case ColumnTypeChanged:
+		return "type changed"
+	default:
+		return fmt.Sprintf("ColumnChangeKind(%d)", int(k))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler so that reports show the kind by name
+func (k ColumnChangeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// ColumnChange describes the change of a single column between two schemas
+type ColumnChange struct {
+	Name    string           `json:"name"`
+	Kind    ColumnChangeKind `json:"kind"`
+	OldType string           `json:"oldType,omitempty"`
+	NewType string           `json:"newType,omitempty"`
+}
+
+// IsBreaking returns true if old data or queries cannot be used with the new column
+func (c *ColumnChange) IsBreaking() bool {
+	return c.Kind == ColumnRemoved || c.Kind == ColumnTypeChanged
+}
+
+func (c *ColumnChange) String() string {
+	switch c.Kind {
+	case ColumnAdded:
+		return fmt.Sprintf("%s %s (%s)", c.Kind, c.Name, c.NewType)
+	case ColumnRemoved:
+		return fmt.Sprintf("%s %s (%s)", c.Kind, c.Name, c.OldType)
+	default:
+		return fmt.Sprintf("%s %s (%s -> %s)", c.Kind, c.Name, c.OldType, c.NewType)
+	}
+}
+
+// SchemaDiff holds the column changes between the deployed and the current schema of a table
+type SchemaDiff struct {
+	DatabaseName string
+	TableName    string
+	Changes      []ColumnChange
+	// OldVersion is the schema version of the deployed table
+	OldVersion int
+	// NewVersion is the schema version after the update, it is incremented for breaking changes
+	NewVersion int
+	// ArchiveTableName is set if the deployed partitions were moved to a versioned table because of breaking changes
+	ArchiveTableName string
+}
+
+// DiffColumns compares two sets of Glue columns and classifies each change
+func DiffColumns(oldColumns, newColumns []*glue.Column) (changes []ColumnChange) {
+	oldTypes := make(map[string]string, len(oldColumns))
+	for _, col := range oldColumns {
+		oldTypes[aws.StringValue(col.Name)] = aws.StringValue(col.Type)
+	}
+	newNames := make(map[string]struct{}, len(newColumns))
+	for _, col := range newColumns {
+		name, newType := aws.StringValue(col.Name), aws.StringValue(col.Type)
+		newNames[name] = struct{}{}
+		oldType, ok := oldTypes[name]
+		switch {
+		case !ok:
+			changes = append(changes, ColumnChange{Name: name, Kind: ColumnAdded, NewType: newType})
+		case strings.EqualFold(oldType, newType):
+		case IsCompatibleType(oldType, newType):
+			changes = append(changes, ColumnChange{Name: name, Kind: ColumnWidened, OldType: oldType, NewType: newType})
+		default:
+			changes = append(changes, ColumnChange{Name: name, Kind: ColumnTypeChanged, OldType: oldType, NewType: newType})
+		}
+	}
+	for _, col := range oldColumns {
+		name := aws.StringValue(col.Name)
+		if _, ok := newNames[name]; !ok {
+			changes = append(changes, ColumnChange{Name: name, Kind: ColumnRemoved, OldType: aws.StringValue(col.Type)})
+		}
+	}
+	return changes
+}
+
+// HasChanges returns true if the schema changed
+func (d *SchemaDiff) HasChanges() bool {
+	return d != nil && len(d.Changes) > 0
+}
+
+// IsBreaking returns true if any of the column changes is breaking
+func (d *SchemaDiff) IsBreaking() bool {
+	if d == nil {
+		return false
+	}
+	for i := range d.Changes {
+		if d.Changes[i].IsBreaking() {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders a human readable report of the changes
+func (d *SchemaDiff) String() string {
+	if !d.HasChanges() {
+		return fmt.Sprintf("%s.%s: no changes", d.DatabaseName, d.TableName)
+	}
+	kind := "additive"
+	if d.IsBreaking() {
+		kind = "breaking"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s.%s: %d %s change(s) (schema version %d -> %d)",
+		d.DatabaseName, d.TableName, len(d.Changes), kind, d.OldVersion, d.NewVersion)
+	if d.ArchiveTableName != "" {
+		fmt.Fprintf(&b, ", previous schema archived as %s.%s", d.DatabaseName, d.ArchiveTableName)
+	}
+	for i := range d.Changes {
+		b.WriteString("\n  ")
+		b.WriteString(d.Changes[i].String())
+	}
+	return b.String()
+}
+
+// SchemaChangeError is returned when a table update is refused because of breaking schema changes
+type SchemaChangeError struct {
+	Diff *SchemaDiff
+}
+
+func (e *SchemaChangeError) Error() string {
+	return "refusing breaking schema change for " + e.Diff.String()
+}
+
+// Widening conversions of primitive types that can read all values of the old type
+var compatiblePrimitives = map[string][]string{
+	"tinyint":  {"smallint", "int", "bigint", "float", "double"},
+	"smallint": {"int", "bigint", "float", "double"},
+	"int":      {"bigint", "double"},
+	"bigint":   {"double"},
+	"float":    {"double"},
+}
+
+// IsCompatibleType checks if data written with a Glue type can be read using another Glue type.
+// Struct types are compatible if all old fields exist in the new type with compatible types.
+func IsCompatibleType(oldType, newType string) bool {
+	oldNode, err := parseGlueType(oldType)
+	if err != nil {
+		return false
+	}
+	newNode, err := parseGlueType(newType)
+	if err != nil {
+		return false
+	}
+	return oldNode.compatibleWith(newNode)
+}
+
+type glueTypeNode struct {
+	name   string // lowercase base name (struct, array, map or a primitive)
+	params []*glueTypeNode
+	fields []string // struct field names, same length as params for structs
+}
+
+func (n *glueTypeNode) compatibleWith(other *glueTypeNode) bool {
+	if n.name != other.name {
+		for _, wider := range compatiblePrimitives[n.name] {
+			if wider == other.name {
+				return true
+			}
+		}
+		return false
+	}
+	switch n.name {
+	case "struct":
+		otherFields := make(map[string]*glueTypeNode, len(other.fields))
+		for i, name := range other.fields {
+			otherFields[strings.ToLower(name)] = other.params[i]
+		}
+		for i, name := range n.fields {
+			otherField, ok := otherFields[strings.ToLower(name)]
+			if !ok || !n.params[i].compatibleWith(otherField) {
+				return false
+			}
+		}
+		return true
+	case "array", "map":
+		if len(n.params) != len(other.params) {
+			return false
+		}
+		for i := range n.params {
+			if !n.params[i].compatibleWith(other.params[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		// Primitive types with parameters (ie decimal(10,2)) must match exactly
+		if len(n.params) != len(other.params) {
+			return false
+		}
+		for i := range n.params {
+			if n.params[i].name != other.params[i].name {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func parseGlueType(typ string) (*glueTypeNode, error) {
+	node, tail, err := parseGlueTypeNode(strings.TrimSpace(typ))
+	if err != nil {
+		return nil, err
+	}
+	if tail != "" {
+		return nil, fmt.Errorf("invalid glue type %q", typ)
+	}
+	return node, nil
+}
+
+func parseGlueTypeNode(typ string) (node *glueTypeNode, tail string, err error) {
+	end := strings.IndexAny(typ, "<>,(")
+	if end == -1 {
+		end = len(typ)
+	}
+	node = &glueTypeNode{
+		name: strings.ToLower(strings.TrimSpace(typ[:end])),
+	}
+	if node.name == "" {
+		return nil, "", fmt.Errorf("invalid glue type %q", typ)
+	}
+	tail = typ[end:]
+	switch {
+	case strings.HasPrefix(tail, "("):
+		// Skip type parameters like decimal(10,2) as an opaque parameter
+		closing := strings.IndexByte(tail, ')')
+		if closing == -1 {
+			return nil, "", fmt.Errorf("invalid glue type %q", typ)
+		}
+		node.params = append(node.params, &glueTypeNode{name: tail[:closing+1]})
+		return node, tail[closing+1:], nil
+	case strings.HasPrefix(tail, "<"):
+		tail = tail[1:]
+		for {
+			if node.name == "struct" {
+				colon := strings.IndexByte(tail, ':')
+				if colon == -1 {
+					return nil, "", fmt.Errorf("invalid glue struct type %q", typ)
+				}
+				node.fields = append(node.fields, strings.TrimSpace(tail[:colon]))
+				tail = tail[colon+1:]
+			}
+			var param *glueTypeNode
+			param, tail, err = parseGlueTypeNode(tail)
+			if err != nil {
+				return nil, "", err
+			}
+			node.params = append(node.params, param)
+			if strings.HasPrefix(tail, ",") {
+				tail = tail[1:]
+				continue
+			}
+			if strings.HasPrefix(tail, ">") {
+				return node, tail[1:], nil
+			}
+			return nil, "", fmt.Errorf("invalid glue type %q", typ)
+		}
+	default:
+		return node, tail, nil
+	}
+}
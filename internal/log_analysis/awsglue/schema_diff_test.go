@@ -0,0 +1,113 @@
+package awsglue
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffColumns(t *testing.T) {
+	oldColumns := []*glue.Column{
+		{Name: aws.String("same"), Type: aws.String("string")},
+		{Name: aws.String("count"), Type: aws.String("int")},
+		{Name: aws.String("ip"), Type: aws.String("string")},
+		{Name: aws.String("removed"), Type: aws.String("string")},
+		{Name: aws.String("nested"), Type: aws.String("struct<a:string>")},
+	}
+	newColumns := []*glue.Column{
+		{Name: aws.String("same"), Type: aws.String("string")},
+		{Name: aws.String("count"), Type: aws.String("bigint")},
+		{Name: aws.String("ip"), Type: aws.String("array<string>")},
+		{Name: aws.String("nested"), Type: aws.String("struct<a:string,b:int>")},
+		{Name: aws.String("added"), Type: aws.String("timestamp")},
+	}
+	changes := DiffColumns(oldColumns, newColumns)
+	require.Equal(t, []ColumnChange{
+		{Name: "count", Kind: ColumnWidened, OldType: "int", NewType: "bigint"},
+		{Name: "ip", Kind: ColumnTypeChanged, OldType: "string", NewType: "array<string>"},
+		{Name: "nested", Kind: ColumnWidened, OldType: "struct<a:string>", NewType: "struct<a:string,b:int>"},
+		{Name: "added", Kind: ColumnAdded, NewType: "timestamp"},
+		{Name: "removed", Kind: ColumnRemoved, OldType: "string"},
+	}, changes)
+
+	diff := &SchemaDiff{Changes: changes}
+	require.True(t, diff.HasChanges())
+	require.True(t, diff.IsBreaking())
+
+	diff = &SchemaDiff{Changes: DiffColumns(oldColumns[:2], newColumns[:2])}
+	require.True(t, diff.HasChanges())
+	require.False(t, diff.IsBreaking())
+
+	diff = &SchemaDiff{Changes: DiffColumns(oldColumns, oldColumns)}
+	require.False(t, diff.HasChanges())
+	require.False(t, diff.IsBreaking())
+}
+
+func TestIsCompatibleType(t *testing.T) {
+	for _, tc := range []struct {
+		Old, New   string
+		Compatible bool
+	}{
+		{"string", "string", true},
+		{"tinyint", "bigint", true},
+		{"bigint", "int", false},
+		{"float", "double", true},
+		{"string", "bigint", false},
+		{"array<int>", "array<bigint>", true},
+		{"array<string>", "array<bigint>", false},
+		{"map<string,string>", "map<string,string>", true},
+		{"map<string,int>", "map<string,string>", false},
+		{"struct<a:string,b:struct<c:int>>", "struct<b:struct<c:bigint,d:string>,a:string,e:int>", true},
+		{"struct<a:string,b:int>", "struct<a:string>", false},
+		{"struct<A:string>", "struct<a:string>", true},
+		{"decimal(10,2)", "decimal(10,2)", true},
+		{"decimal(10,2)", "decimal(12,2)", false},
+		{"array<string>", "string", false},
+		{"struct<a:string", "struct<a:string>", false},
+		{"struct<a>", "struct<a:string>", false},
+	} {
+		require.Equal(t, tc.Compatible, IsCompatibleType(tc.Old, tc.New), "%s -> %s", tc.Old, tc.New)
+	}
+}
+
+func TestSchemaDiffString(t *testing.T) {
+	diff := &SchemaDiff{
+		DatabaseName: "panther_logs",
+		TableName:    "test_logs",
+		OldVersion:   1,
+		NewVersion:   2,
+		Changes: []ColumnChange{
+			{Name: "added", Kind: ColumnAdded, NewType: "string"},
+			{Name: "ip", Kind: ColumnTypeChanged, OldType: "string", NewType: "array<string>"},
+		},
+		ArchiveTableName: "test_logs_v1",
+	}
+	expect := `panther_logs.test_logs: 2 breaking change(s) (schema version 1 -> 2), previous schema archived as panther_logs.test_logs_v1
+  added added (string)
+  type changed ip (string -> array<string>)`
+	require.Equal(t, expect, diff.String())
+	require.Equal(t, "refusing breaking schema change for "+expect, (&SchemaChangeError{Diff: diff}).Error())
+
+	diff = &SchemaDiff{DatabaseName: "panther_logs", TableName: "test_logs"}
+	require.Equal(t, "panther_logs.test_logs: no changes", diff.String())
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -53,6 +54,9 @@ type GlueTableMetadata struct {
 	prefix       string
 	timebin      GlueTableTimebin // at what time resolution is this table partitioned
 	eventStruct  interface{}
+	// Athena partition projection settings (see WithPartitionProjection)
+	projectionMode     partitionProjectionMode
+	projectionFromYear int
 }
 
 // Creates a new GlueTableMetadata object for Panther log sources
@@ -131,7 +135,18 @@ func (gm *GlueTableMetadata) RuleTable() *GlueTableMetadata {
 		return gm
 	}
 	// the corresponding rule table shares the same structure as the log table + some columns
-	return NewGlueTableMetadata(models.RuleData, gm.LogType(), gm.Description(), GlueTableHourly, gm.EventStruct())
+	ruleTable := NewGlueTableMetadata(models.RuleData, gm.LogType(), gm.Description(), GlueTableHourly, gm.EventStruct())
+	ruleTable.projectionMode = gm.projectionMode
+	ruleTable.projectionFromYear = gm.projectionFromYear
+	return ruleTable
+}
+
+// ArchiveTable returns the table holding the partitions of a previous schema version (see ArchiveBreakingChanges).
+// It stores its data in the same S3 location as the live table.
+func (gm *GlueTableMetadata) ArchiveTable(version int) *GlueTableMetadata {
+	archive := *gm
+	archive.tableName = fmt.Sprintf("%s_v%d", gm.tableName, version)
+	return &archive
 }
 
 func (gm *GlueTableMetadata) glueTableInput(bucketName string) *glue.TableInput {
@@ -204,28 +219,215 @@ func (gm *GlueTableMetadata) Signature() (string, error) {
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// SchemaVersionParameter is the table parameter holding the schema version of a Panther table
+const SchemaVersionParameter = "panther_schema_version"
+
+// SchemaUpdatedAtParameter is the table parameter holding the time (RFC3339) of the last breaking schema change.
+// Partitions before the one containing this time were moved to the archive table and must not be added back to the live table.
+// The archive and the live table share the same S3 location, so the partition containing the change is in both tables:
+// it holds events written with either schema and is read with the new schema by the live table.
+const SchemaUpdatedAtParameter = "panther_schema_updated_at"
+
+// SchemaUpdatePolicy decides how breaking schema changes are handled when updating a deployed table
+type SchemaUpdatePolicy int
+
+const (
+	// ArchiveBreakingChanges moves the partitions of the deployed table to a versioned table (`<table>_v<version>`)
+	// before updating the schema, so data written with the previous schema can still be queried.
+	ArchiveBreakingChanges SchemaUpdatePolicy = iota
+	// RefuseBreakingChanges leaves the deployed table untouched and returns a *SchemaChangeError
+	RefuseBreakingChanges
+)
+
+// CreateOrUpdateTable creates the table or updates the schema of the deployed table.
+// Breaking schema changes are refused with a *SchemaChangeError.
 func (gm *GlueTableMetadata) CreateOrUpdateTable(glueClient glueiface.GlueAPI, bucketName string) error {
+	_, err := gm.UpdateTableSchema(glueClient, bucketName, RefuseBreakingChanges)
+	return err
+}
+
+// UpdateTableSchema creates the table or updates the schema of the deployed table.
+// It returns the column changes from the deployed schema (nil if the table was created).
+// Breaking changes are handled according to policy, additive changes are propagated to partitions by SyncPartitions.
+func (gm *GlueTableMetadata) UpdateTableSchema(glueClient glueiface.GlueAPI, bucketName string,
+	policy SchemaUpdatePolicy) (*SchemaDiff, error) {
+
 	tableInput := gm.glueTableInput(bucketName)
+	gm.setPartitionProjection(tableInput, bucketName, nil)
+	setSchemaVersion(tableInput, 1)
 
 	createTableInput := &glue.CreateTableInput{
 		DatabaseName: &gm.databaseName,
 		TableInput:   tableInput,
 	}
 	_, err := glueClient.CreateTable(createTableInput)
+	if err == nil {
+		return nil, nil
+	}
+	if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != glue.ErrCodeAlreadyExistsException {
+		return nil, errors.Wrapf(err, "failed to create table %s.%s", gm.databaseName, gm.tableName)
+	}
+
+	// need to do an update
+	tableOutput, err := GetTable(glueClient, gm.databaseName, gm.tableName)
 	if err != nil {
-		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == glue.ErrCodeAlreadyExistsException {
-			// need to do an update
-			updateTableInput := &glue.UpdateTableInput{
-				DatabaseName: &gm.databaseName,
-				TableInput:   tableInput,
-			}
-			_, err := glueClient.UpdateTable(updateTableInput)
-			return errors.Wrapf(err, "failed to update table %s.%s", gm.databaseName, gm.tableName)
+		return nil, errors.Wrapf(err, "failed to get table %s.%s", gm.databaseName, gm.tableName)
+	}
+	diff := gm.diffSchema(tableOutput.Table, tableInput)
+	if diff.IsBreaking() {
+		if policy == RefuseBreakingChanges {
+			return diff, &SchemaChangeError{Diff: diff}
+		}
+		archiveTableName := gm.ArchiveTable(diff.OldVersion).TableName()
+		if err := gm.archiveTable(glueClient, tableOutput.Table, archiveTableName); err != nil {
+			return diff, errors.Wrapf(err, "failed to archive table %s.%s as %s",
+				gm.databaseName, gm.tableName, archiveTableName)
 		}
-		return errors.Wrapf(err, "failed to create table %s.%s", gm.databaseName, gm.tableName)
+		diff.ArchiveTableName = archiveTableName
+	}
+	tableInput.Parameters = nil
+	gm.setPartitionProjection(tableInput, bucketName, tableOutput.Table)
+	setSchemaVersion(tableInput, diff.NewVersion)
+	if diff.ArchiveTableName != "" {
+		setSchemaUpdatedAt(tableInput, time.Now().UTC())
+	} else if updatedAt, ok := SchemaUpdatedAt(tableOutput.Table); ok {
+		setSchemaUpdatedAt(tableInput, updatedAt)
 	}
+	updateTableInput := &glue.UpdateTableInput{
+		DatabaseName: &gm.databaseName,
+		TableInput:   tableInput,
+	}
+	_, err = glueClient.UpdateTable(updateTableInput)
+	return diff, errors.Wrapf(err, "failed to update table %s.%s", gm.databaseName, gm.tableName)
+}
 
-	return nil
+// DiffSchema compares the schema of the deployed table with the current schema
+func (gm *GlueTableMetadata) DiffSchema(glueClient glueiface.GlueAPI) (*SchemaDiff, error) {
+	tableOutput, err := GetTable(glueClient, gm.databaseName, gm.tableName)
+	if err != nil {
+		return nil, err
+	}
+	return gm.diffSchema(tableOutput.Table, gm.glueTableInput("")), nil
+}
+
+func (gm *GlueTableMetadata) diffSchema(deployed *glue.TableData, tableInput *glue.TableInput) *SchemaDiff {
+	version := TableSchemaVersion(deployed)
+	diff := &SchemaDiff{
+		DatabaseName: gm.databaseName,
+		TableName:    gm.tableName,
+		Changes:      DiffColumns(deployed.StorageDescriptor.Columns, tableInput.StorageDescriptor.Columns),
+		OldVersion:   version,
+		NewVersion:   version,
+	}
+	if diff.IsBreaking() {
+		diff.NewVersion++
+	}
+	return diff
+}
+
+// TableSchemaVersion returns the schema version of a deployed table.
+// Tables deployed before schema versioning are at version 1.
+func TableSchemaVersion(table *glue.TableData) int {
+	if version, err := strconv.Atoi(aws.StringValue(table.Parameters[SchemaVersionParameter])); err == nil && version > 0 {
+		return version
+	}
+	return 1
+}
+
+func setSchemaVersion(tableInput *glue.TableInput, version int) {
+	if tableInput.Parameters == nil {
+		tableInput.Parameters = make(map[string]*string)
+	}
+	tableInput.Parameters[SchemaVersionParameter] = aws.String(strconv.Itoa(version))
+}
+
+// SchemaUpdatedAt returns the time of the last breaking schema change of a deployed table, if any.
+// Tables updated before the exact time was recorded have the start of the hour of the change.
+func SchemaUpdatedAt(table *glue.TableData) (time.Time, bool) {
+	updatedAt, err := time.Parse(time.RFC3339, aws.StringValue(table.Parameters[SchemaUpdatedAtParameter]))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return updatedAt.UTC(), true
+}
+
+func setSchemaUpdatedAt(tableInput *glue.TableInput, t time.Time) {
+	if tableInput.Parameters == nil {
+		tableInput.Parameters = make(map[string]*string)
+	}
+	tableInput.Parameters[SchemaUpdatedAtParameter] = aws.String(t.Format(time.RFC3339))
+}
+
+// archiveTable moves the partitions of the deployed table to a copy of it in the same database, so that
+// data written with the previous schema is never read with the new one.
+// It can be retried if a previous attempt failed midway.
+func (gm *GlueTableMetadata) archiveTable(glueClient glueiface.GlueAPI, table *glue.TableData, archiveTableName string) error {
+	description := fmt.Sprintf("%s (schema version %d)", aws.StringValue(table.Description), TableSchemaVersion(table))
+	_, err := glueClient.CreateTable(&glue.CreateTableInput{
+		DatabaseName: &gm.databaseName,
+		TableInput: &glue.TableInput{
+			Name:              aws.String(archiveTableName),
+			Description:       aws.String(description),
+			PartitionKeys:     table.PartitionKeys,
+			StorageDescriptor: table.StorageDescriptor,
+			TableType:         table.TableType,
+			Parameters:        table.Parameters,
+		},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != glue.ErrCodeAlreadyExistsException {
+			return err
+		}
+	}
+
+	const maxBatchSize = 100 // Glue API limit for BatchCreatePartition
+	input := &glue.GetPartitionsInput{
+		DatabaseName: &gm.databaseName,
+		TableName:    &gm.tableName,
+		MaxResults:   aws.Int64(maxBatchSize),
+	}
+	var archived []time.Time
+	for {
+		output, err := glueClient.GetPartitions(input)
+		if err != nil {
+			return err
+		}
+		if len(output.Partitions) > 0 {
+			batch := make([]*glue.PartitionInput, len(output.Partitions))
+			for i, p := range output.Partitions {
+				partitionTime, err := gm.timebin.PartitionTimeFromValues(p.Values)
+				if err != nil {
+					return err
+				}
+				archived = append(archived, partitionTime)
+				batch[i] = &glue.PartitionInput{
+					Values:            p.Values,
+					StorageDescriptor: p.StorageDescriptor,
+					Parameters:        p.Parameters,
+				}
+			}
+			batchOutput, err := glueClient.BatchCreatePartition(&glue.BatchCreatePartitionInput{
+				DatabaseName:       &gm.databaseName,
+				TableName:          aws.String(archiveTableName),
+				PartitionInputList: batch,
+			})
+			if err != nil {
+				return err
+			}
+			for _, e := range batchOutput.Errors {
+				if e.ErrorDetail != nil && aws.StringValue(e.ErrorDetail.ErrorCode) != glue.ErrCodeAlreadyExistsException {
+					return errors.Errorf("failed to copy partition %v: %s",
+						aws.StringValueSlice(e.PartitionValues), aws.StringValue(e.ErrorDetail.ErrorMessage))
+				}
+			}
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	// delete only after all partitions are copied, deleting while paging would invalidate the next token
+	return gm.DeletePartitions(glueClient, archived)
 }
 
 // Based on Timebin(), return an S3 prefix for objects of this table
@@ -246,11 +448,20 @@ func (gm *GlueTableMetadata) SyncPartitions(glueClient glueiface.GlueAPI, s3Clie
 		return nil, err
 	}
 
+	// partitions of projected tables are not registered in the catalog
+	if IsPartitionProjectionEnabled(tableOutput.Table) {
+		return nil, nil
+	}
+
 	columns := tableOutput.Table.StorageDescriptor.Columns
 	if startDate.IsZero() {
 		startDate = *tableOutput.Table.CreateTime
 	}
 	startDate = startDate.Truncate(time.Hour * 24) // clip to beginning of day
+	// partitions before the last breaking schema change were moved to the archive table
+	if updatedAt, ok := SchemaUpdatedAt(tableOutput.Table); ok && startDate.Before(updatedAt) {
+		startDate = updatedAt.Truncate(time.Hour) // the partition containing the change is still written to
+	}
 	// update to current day at last hour
 	endDay := time.Now().UTC().Truncate(time.Hour * 24).Add(time.Hour * 23)
 
@@ -347,6 +558,12 @@ func (gm *GlueTableMetadata) CreateJSONPartition(client glueiface.GlueAPI, t tim
 		return false, errors.Errorf("not a JSON table: %#v", *tableOutput.Table.StorageDescriptor)
 	}
 
+	// Athena computes the partitions of projected tables, we only need to make sure the time is in the projected range
+	if IsPartitionProjectionEnabled(tableOutput.Table) {
+		_, err := gm.ExtendPartitionProjection(client, tableOutput.Table, t)
+		return false, err
+	}
+
 	return gm.createPartition(client, t, tableOutput)
 }
 
@@ -384,6 +601,89 @@ func (gm *GlueTableMetadata) GetPartition(client glueiface.GlueAPI, t time.Time)
 	return output, err
 }
 
+// ExpiredPartitions returns the start times of the partitions in the catalog that ended before the partition containing t.
+// Projected tables have no partitions in the catalog.
+func (gm *GlueTableMetadata) ExpiredPartitions(client glueiface.GlueAPI, t time.Time) (expired []time.Time, err error) {
+	input := &glue.GetPartitionsInput{
+		DatabaseName: aws.String(gm.databaseName),
+		TableName:    aws.String(gm.tableName),
+		Expression:   aws.String(gm.partitionsBeforeExpression(t)),
+	}
+	for {
+		output, err := client.GetPartitions(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, partition := range output.Partitions {
+			partitionTime, err := gm.timebin.PartitionTimeFromValues(partition.Values)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "invalid partition in %s.%s", gm.databaseName, gm.tableName)
+			}
+			expired = append(expired, partitionTime)
+		}
+		if output.NextToken == nil {
+			return expired, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// partitionsBeforeExpression returns a Glue expression selecting the partitions before the partition containing t
+func (gm *GlueTableMetadata) partitionsBeforeExpression(t time.Time) string {
+	keys := gm.PartitionKeys()
+	values := gm.timebin.PartitionValuesFromTime(t.UTC())
+	// partition keys are ints, compare without the zero padding of the values
+	ints := make([]int, len(values))
+	for i, value := range values {
+		ints[i], _ = strconv.Atoi(*value)
+	}
+	terms := make([]string, len(keys))
+	for i := range keys {
+		var conditions []string
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, fmt.Sprintf("%s = %d", keys[j].Name, ints[j]))
+		}
+		conditions = append(conditions, fmt.Sprintf("%s < %d", keys[i].Name, ints[i]))
+		terms[i] = "(" + strings.Join(conditions, " AND ") + ")"
+	}
+	return strings.Join(terms, " OR ")
+}
+
+// DeletePartitions removes the partitions starting at times from the catalog, missing partitions are ignored
+func (gm *GlueTableMetadata) DeletePartitions(client glueiface.GlueAPI, times []time.Time) error {
+	const maxBatchSize = 25 // the limit of the BatchDeletePartition API
+	for len(times) > 0 {
+		n := len(times)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		input := &glue.BatchDeletePartitionInput{
+			DatabaseName:       aws.String(gm.databaseName),
+			TableName:          aws.String(gm.tableName),
+			PartitionsToDelete: make([]*glue.PartitionValueList, n),
+		}
+		for i, t := range times[:n] {
+			input.PartitionsToDelete[i] = &glue.PartitionValueList{
+				Values: gm.timebin.PartitionValuesFromTime(t),
+			}
+		}
+		output, err := client.BatchDeletePartition(input)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete partitions of %s.%s", gm.databaseName, gm.tableName)
+		}
+		for _, partitionErr := range output.Errors {
+			if aws.StringValue(partitionErr.ErrorDetail.ErrorCode) == glue.ErrCodeEntityNotFoundException {
+				continue
+			}
+			return errors.Errorf("failed to delete partition %s of %s.%s: %s",
+				strings.Join(aws.StringValueSlice(partitionErr.PartitionValues), "/"), gm.databaseName, gm.tableName,
+				aws.StringValue(partitionErr.ErrorDetail.ErrorMessage))
+		}
+		times = times[n:]
+	}
+	return nil
+}
+
 func (gm *GlueTableMetadata) deletePartition(client glueiface.GlueAPI, t time.Time) (output *glue.DeletePartitionOutput, err error) {
 	return DeletePartition(client, gm.databaseName, gm.tableName, gm.timebin.PartitionValuesFromTime(t))
 }
@@ -328,3 +328,242 @@ func TestSyncPartitionsDeadline(t *testing.T) {
 	glueClient.AssertExpectations(t)
 	s3Client.AssertExpectations(t)
 }
+
+func TestSyncPartitionsAfterSchemaUpdate(t *testing.T) {
+	var startDate time.Time // default unset
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	updatedAt := time.Now().UTC().Truncate(time.Second)
+	getTableOutput := &glue.GetTableOutput{
+		Table: &glue.TableData{
+			CreateTime:        aws.Time(refTime),
+			StorageDescriptor: testStorageDescriptor,
+			Parameters: map[string]*string{
+				SchemaUpdatedAtParameter: aws.String(updatedAt.Format(time.RFC3339)),
+			},
+		},
+	}
+
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("GetTable", mock.Anything).Return(getTableOutput, nil).Once()
+	s3Client := &testutils.S3Mock{}
+	deadline := time.Now().UTC().Add(-time.Second) // 1 second in the past, so no work should be done
+	nextPartition, err := gm.SyncPartitions(glueClient, s3Client, startDate, &deadline)
+	require.NoError(t, err)
+	require.NotNil(t, nextPartition)
+	// archived partitions before the schema update are skipped, the partition containing it is still synced
+	assert.Equal(t, updatedAt.Truncate(time.Hour), *nextPartition)
+	glueClient.AssertExpectations(t)
+	s3Client.AssertExpectations(t)
+}
+
+type schemaTestEvent struct {
+	Col  int32  `json:"col" description:"test field"`
+	Name string `json:"name" description:"test field"`
+}
+
+func TestUpdateTableSchemaCreate(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, schemaTestEvent{})
+
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, nil).Once()
+	diff, err := gm.UpdateTableSchema(glueClient, metadataTestBucket, RefuseBreakingChanges)
+	require.NoError(t, err)
+	require.Nil(t, diff)
+	glueClient.AssertExpectations(t)
+
+	createInput := glueClient.Calls[0].Arguments.Get(0).(*glue.CreateTableInput)
+	require.Equal(t, "1", aws.StringValue(createInput.TableInput.Parameters[SchemaVersionParameter]))
+}
+
+func TestUpdateTableSchemaAdditive(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, schemaTestEvent{})
+
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, entityExistsError).Once()
+	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
+	glueClient.On("UpdateTable", mock.Anything).Return(&glue.UpdateTableOutput{}, nil).Once()
+	diff, err := gm.UpdateTableSchema(glueClient, metadataTestBucket, RefuseBreakingChanges)
+	require.NoError(t, err)
+	glueClient.AssertExpectations(t)
+
+	require.False(t, diff.IsBreaking())
+	require.Equal(t, []ColumnChange{
+		{Name: "name", Kind: ColumnAdded, NewType: "string"},
+	}, diff.Changes)
+	require.Equal(t, 1, diff.OldVersion)
+	require.Equal(t, 1, diff.NewVersion)
+	require.Empty(t, diff.ArchiveTableName)
+}
+
+func TestUpdateTableSchemaRefuseBreaking(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, entityExistsError).Once()
+	glueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
+	diff, err := gm.UpdateTableSchema(glueClient, metadataTestBucket, RefuseBreakingChanges)
+	require.Error(t, err)
+	require.IsType(t, &SchemaChangeError{}, err)
+	glueClient.AssertExpectations(t)
+
+	require.True(t, diff.IsBreaking())
+	require.Equal(t, []ColumnChange{
+		{Name: "col", Kind: ColumnRemoved, OldType: "int"},
+	}, diff.Changes)
+	require.Equal(t, 2, diff.NewVersion)
+}
+
+func TestUpdateTableSchemaArchiveBreaking(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+
+	getTableOutput := &glue.GetTableOutput{
+		Table: &glue.TableData{
+			Name:              aws.String(gm.TableName()),
+			CreateTime:        aws.Time(refTime),
+			StorageDescriptor: testStorageDescriptor,
+			Parameters: map[string]*string{
+				SchemaVersionParameter: aws.String("3"),
+			},
+		},
+	}
+	partition := &glue.Partition{
+		Values:            aws.StringSlice([]string{"2020", "01", "03", "01"}),
+		StorageDescriptor: testStorageDescriptor,
+	}
+
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, entityExistsError).Once()
+	glueClient.On("GetTable", mock.Anything).Return(getTableOutput, nil).Once()
+	glueClient.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{}, nil).Once()
+	glueClient.On("GetPartitions", mock.Anything).Return(&glue.GetPartitionsOutput{
+		Partitions: []*glue.Partition{partition},
+		NextToken:  aws.String("next"),
+	}, nil).Once()
+	glueClient.On("GetPartitions", mock.Anything).Return(&glue.GetPartitionsOutput{
+		Partitions: []*glue.Partition{partition},
+	}, nil).Once()
+	glueClient.On("BatchCreatePartition", mock.Anything).Return(&glue.BatchCreatePartitionOutput{}, nil).Twice()
+	glueClient.On("BatchDeletePartition", mock.Anything).Return(&glue.BatchDeletePartitionOutput{}, nil).Once()
+	glueClient.On("UpdateTable", mock.Anything).Return(&glue.UpdateTableOutput{}, nil).Once()
+	diff, err := gm.UpdateTableSchema(glueClient, metadataTestBucket, ArchiveBreakingChanges)
+	require.NoError(t, err)
+	glueClient.AssertExpectations(t)
+
+	require.True(t, diff.IsBreaking())
+	require.Equal(t, 3, diff.OldVersion)
+	require.Equal(t, 4, diff.NewVersion)
+	require.Equal(t, gm.TableName()+"_v3", diff.ArchiveTableName)
+
+	for _, call := range glueClient.Calls {
+		switch input := call.Arguments.Get(0).(type) {
+		case *glue.BatchCreatePartitionInput:
+			require.Equal(t, diff.ArchiveTableName, aws.StringValue(input.TableName))
+			require.Equal(t, partition.Values, input.PartitionInputList[0].Values)
+		case *glue.BatchDeletePartitionInput:
+			// archived partitions are removed from the live table so they are never updated to the new schema
+			require.Equal(t, gm.TableName(), aws.StringValue(input.TableName))
+			require.Len(t, input.PartitionsToDelete, 2)
+			require.Equal(t, partition.Values, input.PartitionsToDelete[0].Values)
+		case *glue.UpdateTableInput:
+			require.Equal(t, "4", aws.StringValue(input.TableInput.Parameters[SchemaVersionParameter]))
+			updatedAt, ok := SchemaUpdatedAt(&glue.TableData{Parameters: input.TableInput.Parameters})
+			require.True(t, ok)
+			// the exact time of the change is recorded
+			require.WithinDuration(t, time.Now(), updatedAt, time.Minute)
+		}
+	}
+	archiveInput := glueClient.Calls[2].Arguments.Get(0).(*glue.CreateTableInput)
+	require.Equal(t, diff.ArchiveTableName, aws.StringValue(archiveInput.TableInput.Name))
+	require.Equal(t, testColumns, archiveInput.TableInput.StorageDescriptor.Columns)
+}
+
+func TestArchiveTable(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "Test.Logs", "Description", GlueTableHourly, partitionTestEvent{})
+	archive := gm.ArchiveTable(2)
+	require.Equal(t, "test_logs_v2", archive.TableName())
+	require.Equal(t, gm.DatabaseName(), archive.DatabaseName())
+	// archives share the S3 location of the live table
+	require.Equal(t, gm.Prefix(), archive.Prefix())
+	require.Equal(t, "test_logs", gm.TableName())
+}
+
+func TestPartitionsBeforeExpression(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "My.Logs.Type", "description", GlueTableHourly, partitionTestEvent{})
+	assert.Equal(t, "(year < 2020) OR (year = 2020 AND month < 1) OR (year = 2020 AND month = 1 AND day < 3) OR "+
+		"(year = 2020 AND month = 1 AND day = 3 AND hour < 1)", gm.partitionsBeforeExpression(refTime))
+
+	gm = NewGlueTableMetadata(models.LogData, "My.Logs.Type", "description", GlueTableDaily, partitionTestEvent{})
+	assert.Equal(t, "(year < 2020) OR (year = 2020 AND month < 1) OR (year = 2020 AND month = 1 AND day < 3)",
+		gm.partitionsBeforeExpression(refTime))
+}
+
+func TestExpiredPartitions(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "My.Logs.Type", "description", GlueTableHourly, partitionTestEvent{})
+	mockClient := &testutils.GlueMock{}
+	mockClient.On("GetPartitions", mock.Anything).Return(&glue.GetPartitionsOutput{
+		Partitions: []*glue.Partition{
+			{Values: aws.StringSlice([]string{"2019", "12", "31", "23"})},
+		},
+		NextToken: aws.String("next"),
+	}, nil).Once()
+	mockClient.On("GetPartitions", mock.Anything).Return(&glue.GetPartitionsOutput{
+		Partitions: []*glue.Partition{
+			{Values: aws.StringSlice([]string{"2020", "01", "03", "00"})},
+		},
+	}, nil).Once()
+
+	expired, err := gm.ExpiredPartitions(mockClient, refTime)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, []time.Time{
+		time.Date(2019, 12, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+	}, expired)
+
+	input := mockClient.Calls[1].Arguments.Get(0).(*glue.GetPartitionsInput)
+	assert.Equal(t, "panther_logs", aws.StringValue(input.DatabaseName))
+	assert.Equal(t, "my_logs_type", aws.StringValue(input.TableName))
+	assert.Equal(t, gm.partitionsBeforeExpression(refTime), aws.StringValue(input.Expression))
+	assert.Equal(t, "next", aws.StringValue(input.NextToken))
+}
+
+func TestDeletePartitions(t *testing.T) {
+	gm := NewGlueTableMetadata(models.LogData, "My.Logs.Type", "description", GlueTableHourly, partitionTestEvent{})
+	var times []time.Time
+	for i := 0; i < 30; i++ {
+		times = append(times, refTime.Add(time.Duration(i)*time.Hour))
+	}
+
+	mockClient := &testutils.GlueMock{}
+	mockClient.On("BatchDeletePartition", mock.Anything).Return(&glue.BatchDeletePartitionOutput{
+		Errors: []*glue.PartitionError{
+			{
+				PartitionValues: aws.StringSlice([]string{"2020", "01", "03", "01"}),
+				ErrorDetail:     &glue.ErrorDetail{ErrorCode: aws.String(glue.ErrCodeEntityNotFoundException)},
+			},
+		},
+	}, nil).Twice()
+	require.NoError(t, gm.DeletePartitions(mockClient, times))
+	mockClient.AssertExpectations(t)
+	assert.Len(t, mockClient.Calls[0].Arguments.Get(0).(*glue.BatchDeletePartitionInput).PartitionsToDelete, 25)
+	lastBatch := mockClient.Calls[1].Arguments.Get(0).(*glue.BatchDeletePartitionInput).PartitionsToDelete
+	assert.Len(t, lastBatch, 5)
+	assert.Equal(t, []string{"2020", "01", "04", "06"}, aws.StringValueSlice(lastBatch[4].Values))
+
+	mockClient = &testutils.GlueMock{}
+	mockClient.On("BatchDeletePartition", mock.Anything).Return(&glue.BatchDeletePartitionOutput{
+		Errors: []*glue.PartitionError{
+			{
+				PartitionValues: aws.StringSlice([]string{"2020", "01", "03", "01"}),
+				ErrorDetail: &glue.ErrorDetail{
+					ErrorCode:    aws.String(glue.ErrCodeInternalServiceException),
+					ErrorMessage: aws.String("failed"),
+				},
+			},
+		},
+	}, nil).Once()
+	err := gm.DeletePartitions(mockClient, times[:1])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to delete partition 2020/01/03/01")
+}
@@ -0,0 +1,342 @@
+package compactor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/pkg/box"
+)
+
+// The compactor merges the many small objects the log processor writes into a partition into a few large objects.
+// Only log tables are compacted: the alerts api locates the events of an alert in the rule tables using the
+// timestamp embedded in each object key, merging those objects would hide events from alerts.
+//
+// Merged objects are not announced on the processed data topic, the events they contain have already been
+// evaluated by the rules engine.
+
+const (
+	bytesPerMB = 1024 * 1024
+
+	// DefaultSmallObjectSizeBytes is the size below which objects are merged
+	DefaultSmallObjectSizeBytes = 64 * bytesPerMB
+	// DefaultMaxObjectSizeBytes is the largest (compressed) size we let a merged object get
+	DefaultMaxObjectSizeBytes = 512 * bytesPerMB
+	// DefaultSettleDuration is how long we wait after a partition is closed before compacting it.
+	// The log processor holds events in memory for a couple of minutes before writing them, and a lambda that
+	// was processing when the partition closed can still be running for up to 15 minutes.
+	DefaultSettleDuration = 30 * time.Minute
+
+	// jsonGzipSuffix is the suffix of objects written by the log processor, other encodings are left untouched
+	jsonGzipSuffix = ".json.gz"
+
+	// CompactedObjectsMetadata is the S3 object metadata key holding the number of objects merged into an object
+	CompactedObjectsMetadata = "panther-compacted-objects"
+	// CompactedTimeParameter is the Glue partition parameter holding the time the partition was last compacted
+	CompactedTimeParameter = "panther_compacted_time"
+	// numFilesParameter is the Glue partition parameter holding the number of objects in the partition
+	numFilesParameter = "numFiles"
+
+	maxDeleteObjects = 1000 // the limit of the DeleteObjects API
+)
+
+// Compactor merges small objects in closed partitions of log tables
+type Compactor struct {
+	S3Client   s3iface.S3API
+	S3Uploader s3manageriface.UploaderAPI
+	GlueClient glueiface.GlueAPI
+	// Bucket is the processed data bucket
+	Bucket string
+	// Objects smaller than this are merged
+	SmallObjectSizeBytes int64
+	// The largest (compressed) size of a merged object
+	MaxObjectSizeBytes int64
+	// How long after a partition is closed before it is compacted
+	SettleDuration time.Duration
+	// If true, report what would be merged but do not change anything
+	DryRun bool
+
+	now func() time.Time // so we can set in tests
+}
+
+// New creates a compactor with the default thresholds
+func New(s3Client s3iface.S3API, s3Uploader s3manageriface.UploaderAPI, glueClient glueiface.GlueAPI, bucket string) *Compactor {
+	return &Compactor{
+		S3Client:             s3Client,
+		S3Uploader:           s3Uploader,
+		GlueClient:           glueClient,
+		Bucket:               bucket,
+		SmallObjectSizeBytes: DefaultSmallObjectSizeBytes,
+		MaxObjectSizeBytes:   DefaultMaxObjectSizeBytes,
+		SettleDuration:       DefaultSettleDuration,
+	}
+}
+
+// PartitionResult reports the outcome of compacting a partition
+type PartitionResult struct {
+	DatabaseName string
+	TableName    string
+	Prefix       string
+	// The number of objects in the partition before and after compaction
+	ObjectsBefore int
+	ObjectsAfter  int
+	// The objects that were merged (or would be merged in a dry run)
+	MergedObjects []string
+	// The merged objects created
+	CreatedObjects []string
+	// The number of events in the merged objects
+	Events int64
+	DryRun bool
+}
+
+// Compacted returns true if any objects were (or would be in a dry run) merged
+func (r *PartitionResult) Compacted() bool {
+	return len(r.MergedObjects) > 0
+}
+
+// IsClosed returns true if no more data is expected for the partition of the table at time t
+func (c *Compactor) IsClosed(table *awsglue.GlueTableMetadata, t time.Time) bool {
+	return !table.Timebin().Next(t).Add(c.SettleDuration).After(c.currentTime())
+}
+
+// CompactPartitions compacts the closed partitions of a table from start to end (inclusive).
+// If deadline is non-nil, it will stop when execution time has passed the deadline and will return the
+// _next_ time period needing evaluation.
+func (c *Compactor) CompactPartitions(table *awsglue.GlueTableMetadata, start, end time.Time,
+	deadline *time.Time) (results []*PartitionResult, next *time.Time, err error) {
+
+	timebin := table.Timebin()
+	start = start.UTC().Truncate(time.Hour)
+	for t := start; !t.After(end) && c.IsClosed(table, t); t = timebin.Next(t) {
+		if deadline != nil && c.currentTime().After(*deadline) {
+			return results, box.Time(t), nil
+		}
+		result, err := c.CompactPartition(table, t)
+		if err != nil {
+			return results, nil, err
+		}
+		if result.Compacted() {
+			results = append(results, result)
+		}
+	}
+	return results, nil, nil
+}
+
+// CompactPartition merges the small objects of the closed partition of a table containing time t.
+// The merged objects are verified before the original objects are deleted.
+func (c *Compactor) CompactPartition(table *awsglue.GlueTableMetadata, t time.Time) (*PartitionResult, error) {
+	if table.DataType() != models.LogData {
+		return nil, errors.Errorf("cannot compact %s.%s: only log tables can be compacted",
+			table.DatabaseName(), table.TableName())
+	}
+	if !c.IsClosed(table, t) {
+		return nil, errors.Errorf("cannot compact %s.%s: partition %s is not closed",
+			table.DatabaseName(), table.TableName(), table.GetPartitionPrefix(t))
+	}
+
+	result := &PartitionResult{
+		DatabaseName: table.DatabaseName(),
+		TableName:    table.TableName(),
+		Prefix:       table.GetPartitionPrefix(t),
+		DryRun:       c.DryRun,
+	}
+
+	objects, err := c.listPartition(result.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	result.ObjectsBefore = len(objects)
+	result.ObjectsAfter = len(objects)
+
+	for _, batch := range c.planBatches(objects) {
+		keys := make([]string, len(batch))
+		for i, object := range batch {
+			keys[i] = aws.StringValue(object.Key)
+		}
+		result.MergedObjects = append(result.MergedObjects, keys...)
+		result.ObjectsAfter -= len(keys) - 1
+		if c.DryRun {
+			continue
+		}
+
+		key := mergedObjectKey(keys[0])
+		stats, err := c.merge(key, keys)
+		if err != nil {
+			return nil, err
+		}
+		result.CreatedObjects = append(result.CreatedObjects, key)
+		result.Events += stats.events
+
+		if err = c.deleteObjects(keys); err != nil {
+			return nil, err
+		}
+		zap.L().Debug("merged objects",
+			zap.String("key", key),
+			zap.Int("objects", len(keys)),
+			zap.Int64("events", stats.events))
+	}
+
+	if result.Compacted() && !c.DryRun {
+		if err = c.updatePartition(table, t, result.ObjectsAfter); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// listPartition returns the objects directly under the partition prefix sorted by key
+func (c *Compactor) listPartition(prefix string) (objects []*s3.Object, err error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(c.Bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+	err = c.S3Client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		objects = append(objects, page.Contents...)
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list s3://%s/%s", c.Bucket, prefix)
+	}
+	// keys start with the time the object was written, sorting by key preserves the order the events were written
+	sort.Slice(objects, func(i, j int) bool {
+		return aws.StringValue(objects[i].Key) < aws.StringValue(objects[j].Key)
+	})
+	return objects, nil
+}
+
+// planBatches groups consecutive small objects into batches to merge, batches of a single object are dropped
+func (c *Compactor) planBatches(objects []*s3.Object) (batches [][]*s3.Object) {
+	var (
+		batch     []*s3.Object
+		batchSize int64
+	)
+	flush := func() {
+		if len(batch) > 1 {
+			batches = append(batches, batch)
+		}
+		batch, batchSize = nil, 0
+	}
+	for _, object := range objects {
+		size := aws.Int64Value(object.Size)
+		if size == 0 || size >= c.SmallObjectSizeBytes || !strings.HasSuffix(aws.StringValue(object.Key), jsonGzipSuffix) {
+			continue
+		}
+		if batchSize+size > c.MaxObjectSizeBytes {
+			flush()
+		}
+		batch = append(batch, object)
+		batchSize += size
+	}
+	flush()
+	return batches
+}
+
+// mergedObjectKey returns a key for an object merging objects starting with firstKey.
+// It keeps the timestamp of the first object so the merged object sorts where its first event was written.
+func mergedObjectKey(firstKey string) string {
+	dir, name := path.Split(firstKey)
+	timestamp := strings.SplitN(name, "-", 2)[0]
+	return dir + timestamp + "-" + uuid.New().String() + jsonGzipSuffix
+}
+
+// deleteObjects deletes the merged objects
+func (c *Compactor) deleteObjects(keys []string) error {
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > maxDeleteObjects {
+			n = maxDeleteObjects
+		}
+		input := &s3.DeleteObjectsInput{
+			Bucket: aws.String(c.Bucket),
+			Delete: &s3.Delete{
+				Objects: make([]*s3.ObjectIdentifier, n),
+				Quiet:   aws.Bool(true),
+			},
+		}
+		for i, key := range keys[:n] {
+			input.Delete.Objects[i] = &s3.ObjectIdentifier{Key: aws.String(key)}
+		}
+		output, err := c.S3Client.DeleteObjects(input)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete merged objects from s3://%s", c.Bucket)
+		}
+		if len(output.Errors) > 0 {
+			failed := make([]string, len(output.Errors))
+			for i, deleteErr := range output.Errors {
+				failed[i] = aws.StringValue(deleteErr.Key)
+			}
+			// the merged objects are already in place, the remaining objects hold duplicate events
+			return errors.Errorf("failed to delete %d merged objects from s3://%s: %s (%s)",
+				len(failed), c.Bucket, strings.Join(failed, ", "), aws.StringValue(output.Errors[0].Message))
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+// updatePartition makes sure the partition is registered and records the compaction in its parameters
+func (c *Compactor) updatePartition(table *awsglue.GlueTableMetadata, t time.Time, numObjects int) error {
+	// for projected tables this only makes sure t is in the projected range
+	if _, err := table.CreateJSONPartition(c.GlueClient, t); err != nil {
+		return errors.Wrapf(err, "failed to create partition %s", table.GetPartitionPrefix(t))
+	}
+	output, err := table.GetPartition(c.GlueClient, t)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get partition %s", table.GetPartitionPrefix(t))
+	}
+	if output == nil { // projected tables have no partitions in the catalog
+		return nil
+	}
+
+	parameters := make(map[string]*string, len(output.Partition.Parameters)+2)
+	for k, v := range output.Partition.Parameters {
+		parameters[k] = v
+	}
+	parameters[CompactedTimeParameter] = aws.String(c.currentTime().Format(time.RFC3339))
+	parameters[numFilesParameter] = aws.String(strconv.Itoa(numObjects))
+	_, err = awsglue.UpdatePartition(c.GlueClient, table.DatabaseName(), table.TableName(), output.Partition.Values,
+		output.Partition.StorageDescriptor, parameters)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update partition %s", table.GetPartitionPrefix(t))
+	}
+	return nil
+}
+
+func (c *Compactor) currentTime() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now().UTC()
+}
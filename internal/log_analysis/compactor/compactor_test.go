@@ -0,0 +1,363 @@
+package compactor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const testBucket = "panther-bucket"
+
+var (
+	testTable     = awsglue.NewGlueTableMetadata(models.LogData, "Test.Logs", "Test logs", awsglue.GlueTableHourly, testEvent{})
+	testHour      = time.Date(2020, 5, 6, 7, 0, 0, 0, time.UTC)
+	testPrefix    = "logs/test_logs/year=2020/month=05/day=06/hour=07/"
+	testSourceKey = []string{
+		testPrefix + "20200506T071000Z-aaaa.json.gz",
+		testPrefix + "20200506T072000Z-bbbb.json.gz",
+		testPrefix + "20200506T073000Z-cccc.json.gz",
+	}
+)
+
+type testEvent struct {
+	Name string `json:"name" description:"test field"`
+}
+
+func gzipData(t *testing.T, data ...string) []byte {
+	var buf bytes.Buffer
+	for _, member := range data { // each string is a separate gzip member
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write([]byte(member))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	}
+	return buf.Bytes()
+}
+
+func gunzipData(t *testing.T, data []byte) string {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func testObject(key string, size int64) *s3.Object {
+	return &s3.Object{Key: aws.String(key), Size: aws.Int64(size)}
+}
+
+func objectBody(data []byte) *s3.GetObjectOutput {
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(data))}
+}
+
+func hasKey(key string) interface{} {
+	return mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+		return aws.StringValue(input.Key) == key
+	})
+}
+
+func isMergedKey(input *s3.GetObjectInput) bool {
+	key := aws.StringValue(input.Key)
+	for _, sourceKey := range testSourceKey {
+		if key == sourceKey {
+			return false
+		}
+	}
+	return true
+}
+
+func newTestCompactor(s3Client *testutils.S3Mock, uploader *testutils.S3UploaderMock,
+	glueClient *testutils.GlueMock) *Compactor {
+
+	c := New(s3Client, uploader, glueClient, testBucket)
+	c.SmallObjectSizeBytes = 100
+	c.MaxObjectSizeBytes = 250
+	c.now = func() time.Time {
+		return testHour.Add(2 * time.Hour)
+	}
+	return c
+}
+
+// mockSources sets up listing and reading the source objects of the test partition
+func mockSources(t *testing.T, s3Client *testutils.S3Mock) {
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			testObject(testSourceKey[2], 30),
+			testObject(testSourceKey[0], 10),
+			testObject(testSourceKey[1], 20),
+			testObject(testPrefix+"20200506T060000Z-dddd.json.gz", 1000), // too big
+		},
+	}, nil).Once()
+	s3Client.On("GetObject", hasKey(testSourceKey[0])).Return(objectBody(gzipData(t, "{\"name\":\"a\"}\n")), nil).Once()
+	// missing trailing new line
+	s3Client.On("GetObject", hasKey(testSourceKey[1])).Return(objectBody(gzipData(t, "{\"name\":\"b\"}")), nil).Once()
+	// multiple gzip members
+	s3Client.On("GetObject", hasKey(testSourceKey[2])).Return(objectBody(gzipData(t,
+		"{\"name\":\"c\"}\n", "{\"name\":\"d\"}\n")), nil).Once()
+}
+
+// mockUpload captures the uploaded merged object so it can be read back
+func mockUpload(uploader *testutils.S3UploaderMock, s3Client *testutils.S3Mock, corrupt bool) *bytes.Buffer {
+	var uploaded bytes.Buffer
+	readBack := &s3.GetObjectOutput{}
+	uploader.On("Upload", mock.Anything, mock.Anything).Return(&s3manager.UploadOutput{}, nil).Run(func(args mock.Arguments) {
+		input := args.Get(0).(*s3manager.UploadInput)
+		_, _ = uploaded.ReadFrom(input.Body)
+		data := uploaded.Bytes()
+		if corrupt {
+			data = data[:len(data)/2]
+		}
+		readBack.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}).Once()
+	s3Client.On("GetObject", mock.MatchedBy(isMergedKey)).Return(readBack, nil).Once()
+	return &uploaded
+}
+
+func TestCompactPartition(t *testing.T) {
+	s3Client := &testutils.S3Mock{}
+	uploader := &testutils.S3UploaderMock{}
+	glueClient := &testutils.GlueMock{}
+	c := newTestCompactor(s3Client, uploader, glueClient)
+
+	mockSources(t, s3Client)
+	uploaded := mockUpload(uploader, s3Client, false)
+	s3Client.On("DeleteObjects", mock.Anything).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+
+	glueClient.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{
+		Table: &glue.TableData{
+			StorageDescriptor: &glue.StorageDescriptor{
+				Location: aws.String("s3://" + testBucket + "/logs/test_logs"),
+				SerdeInfo: &glue.SerDeInfo{
+					SerializationLibrary: aws.String("org.openx.data.jsonserde.JsonSerDe"),
+				},
+			},
+		},
+	}, nil).Once()
+	glueClient.On("CreatePartition", mock.Anything).Return(&glue.CreatePartitionOutput{},
+		awserr.New(glue.ErrCodeAlreadyExistsException, "", nil)).Once()
+	glueClient.On("GetPartition", mock.Anything).Return(&glue.GetPartitionOutput{
+		Partition: &glue.Partition{
+			Values:            aws.StringSlice([]string{"2020", "05", "06", "07"}),
+			StorageDescriptor: &glue.StorageDescriptor{Location: aws.String("s3://" + testBucket + "/" + testPrefix)},
+			Parameters:        aws.StringMap(map[string]string{"foo": "bar"}),
+		},
+	}, nil).Once()
+	glueClient.On("UpdatePartition", mock.Anything).Return(&glue.UpdatePartitionOutput{}, nil).Once()
+
+	result, err := c.CompactPartition(testTable, testHour.Add(30*time.Minute))
+	require.NoError(t, err)
+	s3Client.AssertExpectations(t)
+	uploader.AssertExpectations(t)
+	glueClient.AssertExpectations(t)
+
+	require.Equal(t, testPrefix, result.Prefix)
+	require.Equal(t, "panther_logs", result.DatabaseName)
+	require.Equal(t, "test_logs", result.TableName)
+	require.Equal(t, 4, result.ObjectsBefore)
+	require.Equal(t, 2, result.ObjectsAfter)
+	require.Equal(t, testSourceKey, result.MergedObjects)
+	require.Equal(t, int64(4), result.Events)
+	require.Len(t, result.CreatedObjects, 1)
+
+	// events are merged in order, each on its own line
+	require.Equal(t, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n{\"name\":\"c\"}\n{\"name\":\"d\"}\n", gunzipData(t, uploaded.Bytes()))
+
+	uploadInput := uploader.Calls[0].Arguments.Get(0).(*s3manager.UploadInput)
+	require.Equal(t, testBucket, aws.StringValue(uploadInput.Bucket))
+	require.Equal(t, result.CreatedObjects[0], aws.StringValue(uploadInput.Key))
+	require.True(t, strings.HasPrefix(result.CreatedObjects[0], testPrefix+"20200506T071000Z-"))
+	require.True(t, strings.HasSuffix(result.CreatedObjects[0], ".json.gz"))
+	require.Equal(t, "3", aws.StringValue(uploadInput.Metadata[CompactedObjectsMetadata]))
+
+	var deleteInput *s3.DeleteObjectsInput
+	for _, call := range s3Client.Calls {
+		if call.Method == "DeleteObjects" {
+			deleteInput = call.Arguments.Get(0).(*s3.DeleteObjectsInput)
+		}
+	}
+	require.Len(t, deleteInput.Delete.Objects, 3)
+	for i, object := range deleteInput.Delete.Objects {
+		require.Equal(t, testSourceKey[i], aws.StringValue(object.Key))
+	}
+
+	updateInput := glueClient.Calls[3].Arguments.Get(0).(*glue.UpdatePartitionInput)
+	require.Equal(t, "bar", aws.StringValue(updateInput.PartitionInput.Parameters["foo"]))
+	require.Equal(t, "2", aws.StringValue(updateInput.PartitionInput.Parameters["numFiles"]))
+	require.Equal(t, "2020-05-06T09:00:00Z", aws.StringValue(updateInput.PartitionInput.Parameters[CompactedTimeParameter]))
+	require.Equal(t, "s3://"+testBucket+"/"+testPrefix, aws.StringValue(updateInput.PartitionInput.StorageDescriptor.Location))
+}
+
+func TestCompactPartitionVerifyFailure(t *testing.T) {
+	s3Client := &testutils.S3Mock{}
+	uploader := &testutils.S3UploaderMock{}
+	glueClient := &testutils.GlueMock{}
+	c := newTestCompactor(s3Client, uploader, glueClient)
+
+	mockSources(t, s3Client)
+	mockUpload(uploader, s3Client, true)
+	s3Client.On("DeleteObject", mock.Anything).Return(&s3.DeleteObjectOutput{}, nil).Once()
+
+	_, err := c.CompactPartition(testTable, testHour)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to verify merged object")
+	s3Client.AssertExpectations(t)
+	uploader.AssertExpectations(t)
+	glueClient.AssertExpectations(t) // no partition updates
+
+	// the unverified merged object is removed, the originals are kept
+	deleteInput := s3Client.Calls[len(s3Client.Calls)-1].Arguments.Get(0).(*s3.DeleteObjectInput)
+	uploadInput := uploader.Calls[0].Arguments.Get(0).(*s3manager.UploadInput)
+	require.Equal(t, aws.StringValue(uploadInput.Key), aws.StringValue(deleteInput.Key))
+}
+
+func TestCompactPartitionReadFailure(t *testing.T) {
+	s3Client := &testutils.S3Mock{}
+	uploader := &testutils.S3UploaderMock{}
+	glueClient := &testutils.GlueMock{}
+	c := newTestCompactor(s3Client, uploader, glueClient)
+
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			testObject(testSourceKey[0], 10),
+			testObject(testSourceKey[1], 20),
+		},
+	}, nil).Once()
+	s3Client.On("GetObject", hasKey(testSourceKey[0])).Return(objectBody([]byte("not gzip")), nil).Once()
+	uploader.On("Upload", mock.Anything, mock.Anything).Return(&s3manager.UploadOutput{},
+		awserr.New("ReadError", "read failed", nil)).Run(func(args mock.Arguments) {
+		_, _ = ioutil.ReadAll(args.Get(0).(*s3manager.UploadInput).Body)
+	}).Once()
+
+	_, err := c.CompactPartition(testTable, testHour)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read gzip s3://"+testBucket+"/"+testSourceKey[0])
+	s3Client.AssertExpectations(t)
+	uploader.AssertExpectations(t)
+}
+
+func TestCompactPartitionDryRun(t *testing.T) {
+	s3Client := &testutils.S3Mock{}
+	uploader := &testutils.S3UploaderMock{}
+	glueClient := &testutils.GlueMock{}
+	c := newTestCompactor(s3Client, uploader, glueClient)
+	c.DryRun = true
+
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			testObject(testSourceKey[0], 10),
+			testObject(testSourceKey[1], 20),
+		},
+	}, nil).Once()
+
+	result, err := c.CompactPartition(testTable, testHour)
+	require.NoError(t, err)
+	require.True(t, result.DryRun)
+	require.True(t, result.Compacted())
+	require.Equal(t, testSourceKey[:2], result.MergedObjects)
+	require.Empty(t, result.CreatedObjects)
+	require.Equal(t, 1, result.ObjectsAfter)
+	s3Client.AssertExpectations(t)
+	uploader.AssertExpectations(t)
+	glueClient.AssertExpectations(t)
+
+	listInput := s3Client.Calls[0].Arguments.Get(0).(*s3.ListObjectsV2Input)
+	require.Equal(t, testPrefix, aws.StringValue(listInput.Prefix))
+	require.Equal(t, "/", aws.StringValue(listInput.Delimiter))
+}
+
+func TestCompactPartitionRefused(t *testing.T) {
+	c := newTestCompactor(&testutils.S3Mock{}, &testutils.S3UploaderMock{}, &testutils.GlueMock{})
+
+	_, err := c.CompactPartition(testTable.RuleTable(), testHour)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "only log tables can be compacted")
+
+	_, err = c.CompactPartition(testTable, testHour.Add(time.Hour))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not closed")
+}
+
+func TestCompactPartitions(t *testing.T) {
+	s3Client := &testutils.S3Mock{}
+	c := newTestCompactor(s3Client, &testutils.S3UploaderMock{}, &testutils.GlueMock{})
+
+	// only closed partitions are listed
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{}, nil).Times(3)
+	results, next, err := c.CompactPartitions(testTable, testHour.Add(-2*time.Hour), testHour.Add(10*time.Hour), nil)
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Empty(t, results)
+	s3Client.AssertExpectations(t)
+
+	// stop at the deadline
+	deadline := c.now().Add(-time.Second)
+	results, next, err = c.CompactPartitions(testTable, testHour.Add(-2*time.Hour), testHour, &deadline)
+	require.NoError(t, err)
+	require.Equal(t, testHour.Add(-2*time.Hour), *next)
+	require.Empty(t, results)
+}
+
+func TestPlanBatches(t *testing.T) {
+	c := &Compactor{
+		SmallObjectSizeBytes: 100,
+		MaxObjectSizeBytes:   250,
+	}
+	batches := c.planBatches([]*s3.Object{
+		testObject("a.json.gz", 90),
+		testObject("b.json.gz", 90),
+		testObject("c.json.gz", 0),     // empty
+		testObject("d.json.gz", 100),   // not small
+		testObject("e.parquet", 10),    // other encoding
+		testObject("f.json.gz", 60),    // fills first batch
+		testObject("g.json.gz", 50),    // starts second batch
+		testObject("h.json.gz", 10),    // second batch
+		testObject("i.json.gz", 99),    // second batch
+		testObject("j.json.gz", 99),    // single object batch
+		testObject("k.json.gz.tmp", 1), // other encoding
+	})
+	var keys [][]string
+	for _, batch := range batches {
+		var batchKeys []string
+		for _, object := range batch {
+			batchKeys = append(batchKeys, aws.StringValue(object.Key))
+		}
+		keys = append(keys, batchKeys)
+	}
+	require.Equal(t, [][]string{
+		{"a.json.gz", "b.json.gz", "f.json.gz"},
+		{"g.json.gz", "h.json.gz", "i.json.gz"},
+	}, keys)
+}
@@ -0,0 +1,112 @@
+package main
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/kelseyhightower/envconfig"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/compactor"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/lambdalogger"
+)
+
+// The panther-log-compactor lambda runs on a schedule merging the small objects in recently closed partitions.
+
+const (
+	maxRetries = 20 // setting Max Retries to a higher number - we'd like to retry VERY hard before failing.
+
+	// partitions closed within this window are compacted, a window spanning several runs makes up for runs that
+	// stopped at the deadline
+	lookback = 6 * time.Hour
+	// stop compacting new partitions this long before the lambda deadline
+	deadlineMargin = 5 * time.Minute
+	// the rate of the schedule in the template, each run starts from the table after the one the previous run started from
+	scheduleRate = time.Hour
+)
+
+type envConfig struct {
+	ProcessedDataBucket string `required:"true" split_words:"true"`
+}
+
+var logCompactor *compactor.Compactor
+
+func handle(ctx context.Context, event events.CloudWatchEvent) (err error) {
+	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
+	operation := common.OpLogManager.Start(lc.InvokedFunctionArn, common.OpLogLambdaServiceDim).WithMemUsed(lambdacontext.MemoryLimitInMB)
+	var (
+		compacted int
+		merged    int
+	)
+	defer func() {
+		operation.Stop().Log(err,
+			zap.Int("compactedPartitions", compacted),
+			zap.Int("mergedObjects", merged))
+	}()
+
+	lambdaDeadline, _ := ctx.Deadline()
+	deadline := lambdaDeadline.Add(-deadlineMargin)
+	end := time.Now().UTC()
+	start := end.Add(-lookback)
+	// Rotate the tables so the ones late in the list are not always left over when a run stops at the deadline
+	tables := registry.AvailableTables()
+	if n := len(tables); n > 0 {
+		first := int(event.Time.Unix()/int64(scheduleRate/time.Second)) % n
+		tables = append(tables[first:], tables[:first]...)
+	}
+	for _, table := range tables {
+		results, next, err := logCompactor.CompactPartitions(table, start, end, &deadline)
+		for _, result := range results {
+			compacted++
+			merged += len(result.MergedObjects)
+		}
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			zap.L().Warn("deadline reached, remaining partitions are left for the next run",
+				zap.String("table", table.TableName()),
+				zap.Time("next", *next))
+			return nil
+		}
+	}
+	return nil
+}
+
+func main() {
+	var env envConfig
+	envconfig.MustProcess("", &env)
+
+	awsSession := session.Must(session.NewSession(aws.NewConfig().WithMaxRetries(maxRetries)))
+	logCompactor = compactor.New(s3.New(awsSession), s3manager.NewUploader(awsSession), glue.New(awsSession),
+		env.ProcessedDataBucket)
+	lambda.Start(handle)
+}
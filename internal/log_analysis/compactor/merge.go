@@ -0,0 +1,189 @@
+package compactor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var newLine = []byte("\n")
+
+// contentStats summarizes the uncompressed content of an object
+type contentStats struct {
+	events int64
+	bytes  int64
+	digest string
+}
+
+// contentWriter counts and hashes the uncompressed lines written through it
+type contentWriter struct {
+	w      io.Writer
+	hash   hash.Hash
+	events int64
+	bytes  int64
+	last   byte
+}
+
+func newContentWriter(w io.Writer) *contentWriter {
+	return &contentWriter{
+		w:    w,
+		hash: sha256.New(),
+	}
+}
+
+func (cw *contentWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.hash.Write(p[:n])
+	cw.events += int64(bytes.Count(p[:n], newLine))
+	cw.bytes += int64(n)
+	if n > 0 {
+		cw.last = p[n-1]
+	}
+	return n, err
+}
+
+// endLine terminates the last line so events of the next object do not end up on the same line
+func (cw *contentWriter) endLine() error {
+	if cw.bytes == 0 || cw.last == '\n' {
+		return nil
+	}
+	_, err := cw.Write(newLine)
+	return err
+}
+
+func (cw *contentWriter) stats() contentStats {
+	return contentStats{
+		events: cw.events,
+		bytes:  cw.bytes,
+		digest: string(cw.hash.Sum(nil)),
+	}
+}
+
+// merge streams the concatenated events of the objects at keys into a single gzip object at key and verifies it
+func (c *Compactor) merge(key string, keys []string) (stats contentStats, err error) {
+	type writeResult struct {
+		stats contentStats
+		err   error
+	}
+	reader, writer := io.Pipe()
+	writeDone := make(chan writeResult, 1)
+	go func() {
+		stats, err := c.writeMerged(writer, keys)
+		writeDone <- writeResult{stats: stats, err: err}
+		_ = writer.CloseWithError(err) // nil closes normally
+	}()
+
+	_, err = c.S3Uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(key),
+		Body:   reader,
+		Metadata: map[string]*string{
+			CompactedObjectsMetadata: aws.String(strconv.Itoa(len(keys))),
+		},
+	})
+	// make sure the writer is not blocked on a failed upload
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+	written := <-writeDone
+	stats = written.stats
+	if written.err != nil { // report the cause rather than the upload failure it triggered
+		return stats, errors.WithMessagef(written.err, "failed to write merged object s3://%s/%s", c.Bucket, key)
+	}
+	if err != nil {
+		return stats, errors.Wrapf(err, "failed to write merged object s3://%s/%s", c.Bucket, key)
+	}
+
+	if err = c.verify(key, stats); err != nil {
+		// the original objects are still in place, remove the merged object so the events are not duplicated
+		if _, deleteErr := c.S3Client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(c.Bucket),
+			Key:    aws.String(key),
+		}); deleteErr != nil {
+			zap.L().Error("failed to delete unverified merged object",
+				zap.String("bucket", c.Bucket),
+				zap.String("key", key),
+				zap.Error(deleteErr))
+		}
+		return stats, err
+	}
+	return stats, nil
+}
+
+// writeMerged writes the events of the objects at keys in order as a single gzip stream
+func (c *Compactor) writeMerged(w io.Writer, keys []string) (contentStats, error) {
+	gzipWriter := gzip.NewWriter(w)
+	cw := newContentWriter(gzipWriter)
+	for _, key := range keys {
+		if err := c.copyObject(cw, key); err != nil {
+			return cw.stats(), err
+		}
+		if err := cw.endLine(); err != nil {
+			return cw.stats(), err
+		}
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return cw.stats(), err
+	}
+	return cw.stats(), nil
+}
+
+// copyObject writes the uncompressed content of the object at key to w
+func (c *Compactor) copyObject(w io.Writer, key string) error {
+	output, err := c.S3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to read s3://%s/%s", c.Bucket, key)
+	}
+	defer output.Body.Close()
+
+	gzipReader, err := gzip.NewReader(output.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read gzip s3://%s/%s", c.Bucket, key)
+	}
+	if _, err = io.Copy(w, gzipReader); err != nil {
+		return errors.Wrapf(err, "failed to read gzip s3://%s/%s", c.Bucket, key)
+	}
+	return gzipReader.Close()
+}
+
+// verify reads back the merged object at key and compares its content with what was written
+func (c *Compactor) verify(key string, expect contentStats) error {
+	cw := newContentWriter(ioutil.Discard)
+	if err := c.copyObject(cw, key); err != nil {
+		return errors.WithMessage(err, "failed to verify merged object")
+	}
+	if actual := cw.stats(); actual != expect {
+		return errors.Errorf("failed to verify merged object s3://%s/%s: expected %d events (%d bytes), found %d events (%d bytes)",
+			c.Bucket, key, expect.events, expect.bytes, actual.events, actual.bytes)
+	}
+	return nil
+}
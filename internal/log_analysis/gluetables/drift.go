@@ -0,0 +1,173 @@
+package gluetables
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+)
+
+// maxStalePartitionsText limits the stale partitions listed per table in text reports
+const maxStalePartitionsText = 10
+
+// archiveTableName matches the versioned copies of tables archived because of breaking schema changes
+var archiveTableName = regexp.MustCompile(`^(.+)_v\d+$`)
+
+// DriftReport describes how the deployed Glue catalog differs from the tables registered in code
+type DriftReport struct {
+	Tables []*awsglue.TableDrift `json:"tables"`
+	// UnregisteredTables are deployed tables (as database.table) of log types that are no longer registered
+	UnregisteredTables []string `json:"unregisteredTables"`
+}
+
+// HasDrift returns true if any deployed table differs from the code
+func (r *DriftReport) HasDrift() bool {
+	for _, table := range r.Tables {
+		if table.HasDrift() {
+			return true
+		}
+	}
+	return len(r.UnregisteredTables) > 0
+}
+
+// DetectDrift compares the deployed log tables and their rule tables with the code without modifying anything.
+// Partitions are checked from start (the create time of each table if zero) to end (now if zero).
+func DetectDrift(glueClient glueiface.GlueAPI, s3Client s3iface.S3API, logTables []*awsglue.GlueTableMetadata,
+	start, end time.Time) (*DriftReport, error) {
+
+	report := &DriftReport{}
+	for _, logTable := range logTables {
+		for _, table := range []*awsglue.GlueTableMetadata{logTable, logTable.RuleTable()} {
+			drift, err := table.DetectDrift(glueClient, s3Client, start, end)
+			if err != nil {
+				if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == glue.ErrCodeEntityNotFoundException {
+					continue
+				}
+				return nil, errors.WithMessagef(err, "failed to detect drift of %s.%s", table.DatabaseName(), table.TableName())
+			}
+			report.Tables = append(report.Tables, drift)
+		}
+	}
+	unregistered, err := UnregisteredTables(glueClient)
+	if err != nil {
+		return nil, err
+	}
+	report.UnregisteredTables = unregistered
+	return report, nil
+}
+
+// UnregisteredTables returns the tables in the log and rule match databases that do not belong to a registered log type.
+// Archived versions of registered tables are not included.
+func UnregisteredTables(glueClient glueiface.GlueAPI) (unregistered []string, err error) {
+	registered := make(map[string]bool)
+	for _, logTable := range registry.AvailableTables() {
+		for _, table := range []*awsglue.GlueTableMetadata{logTable, logTable.RuleTable()} {
+			registered[table.DatabaseName()+"."+table.TableName()] = true
+		}
+	}
+	for _, databaseName := range []string{awsglue.LogProcessingDatabaseName, awsglue.RuleMatchDatabaseName} {
+		input := &glue.GetTablesInput{
+			DatabaseName: aws.String(databaseName),
+		}
+		for {
+			output, err := glueClient.GetTables(input)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to list tables of %s", databaseName)
+			}
+			for _, table := range output.TableList {
+				tableName := aws.StringValue(table.Name)
+				if registered[databaseName+"."+tableName] {
+					continue
+				}
+				if match := archiveTableName.FindStringSubmatch(tableName); match != nil && registered[databaseName+"."+match[1]] {
+					continue
+				}
+				unregistered = append(unregistered, databaseName+"."+tableName)
+			}
+			if output.NextToken == nil {
+				break
+			}
+			input.NextToken = output.NextToken
+		}
+	}
+	sort.Strings(unregistered)
+	return unregistered, nil
+}
+
+// WriteText writes a human readable version of the report
+func (r *DriftReport) WriteText(w io.Writer) error {
+	var b strings.Builder
+	numDrifted := 0
+	for _, table := range r.Tables {
+		name := table.DatabaseName + "." + table.TableName
+		if !table.HasDrift() {
+			fmt.Fprintf(&b, "%s: no drift\n", name)
+			continue
+		}
+		numDrifted++
+		kind := "additive"
+		if table.Breaking {
+			kind = fmt.Sprintf("breaking, schema version %d -> %d", table.SchemaVersion, table.SchemaVersion+1)
+		}
+		fmt.Fprintf(&b, "%s (%s): %s\n", name, table.LogType, kind)
+		for _, changes := range [][]awsglue.ColumnChange{table.Added, table.Removed, table.Changed} {
+			for i := range changes {
+				fmt.Fprintf(&b, "  %s\n", changes[i].String())
+			}
+		}
+		if n := len(table.StalePartitions); n > 0 {
+			fmt.Fprintf(&b, "  %d stale partition(s)\n", n)
+			for i, p := range table.StalePartitions {
+				if i == maxStalePartitionsText {
+					fmt.Fprintf(&b, "    ... %d more\n", n-i)
+					break
+				}
+				fmt.Fprintf(&b, "    %s: %s\n", p.Time.Format(time.RFC3339), strings.Join(p.Reasons, ", "))
+			}
+		}
+		for _, missing := range table.MissingPartitions {
+			fmt.Fprintf(&b, "  %d missing partition(s) from %s to %s\n", missing.Count,
+				missing.Start.Format(time.RFC3339), missing.End.Format(time.RFC3339))
+		}
+	}
+	if len(r.UnregisteredTables) > 0 {
+		b.WriteString("tables of unregistered log types:\n")
+		for _, name := range r.UnregisteredTables {
+			fmt.Fprintf(&b, "  %s\n", name)
+		}
+	}
+	fmt.Fprintf(&b, "%d of %d table(s) drifted, %d table(s) of unregistered log types\n",
+		numDrifted, len(r.Tables), len(r.UnregisteredTables))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,151 @@
+package gluetables
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+type driftTestEvent struct {
+	Foo string `json:"foo" description:"foo"`
+}
+
+func TestDetectDrift(t *testing.T) {
+	logTable := awsglue.NewGlueTableMetadata(models.LogData, "Foo.Bar", "foo", awsglue.GlueTableHourly, driftTestEvent{})
+	ruleTable := logTable.RuleTable()
+	registered := registry.AvailableTables()[0]
+
+	glueClient := &testutils.GlueMock{}
+	glueClient.On("GetTable", &glue.GetTableInput{
+		DatabaseName: aws.String(logTable.DatabaseName()),
+		Name:         aws.String(logTable.TableName()),
+	}).Return(testGetTableOutput, nil).Once()
+	glueClient.On("GetTable", &glue.GetTableInput{
+		DatabaseName: aws.String(ruleTable.DatabaseName()),
+		Name:         aws.String(ruleTable.TableName()),
+	}).Return(&glue.GetTableOutput{}, awserr.New(glue.ErrCodeEntityNotFoundException, "not found", nil)).Once()
+	glueClient.On("GetPartitions", mock.Anything).Return(&glue.GetPartitionsOutput{}, nil).Once()
+	glueClient.On("GetTables", &glue.GetTablesInput{DatabaseName: aws.String(awsglue.LogProcessingDatabaseName)}).
+		Return(&glue.GetTablesOutput{
+			TableList: []*glue.TableData{
+				{Name: aws.String(registered.TableName())},
+				{Name: aws.String(registered.TableName() + "_v2")},
+				{Name: aws.String(logTable.TableName())},
+			},
+			NextToken: aws.String("next"),
+		}, nil).Once()
+	glueClient.On("GetTables", &glue.GetTablesInput{
+		DatabaseName: aws.String(awsglue.LogProcessingDatabaseName),
+		NextToken:    aws.String("next"),
+	}).Return(&glue.GetTablesOutput{
+		TableList: []*glue.TableData{
+			{Name: aws.String(logTable.TableName() + "_v1")},
+		},
+	}, nil).Once()
+	glueClient.On("GetTables", &glue.GetTablesInput{DatabaseName: aws.String(awsglue.RuleMatchDatabaseName)}).
+		Return(&glue.GetTablesOutput{
+			TableList: []*glue.TableData{
+				{Name: aws.String(registered.TableName())},
+			},
+		}, nil).Once()
+	s3Client := &testutils.S3Mock{}
+	s3Client.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{}, nil).Once()
+
+	start := time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)
+	report, err := DetectDrift(glueClient, s3Client, []*awsglue.GlueTableMetadata{logTable}, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	glueClient.AssertExpectations(t)
+	s3Client.AssertExpectations(t)
+
+	require.True(t, report.HasDrift())
+	require.Len(t, report.Tables, 1)
+	drift := report.Tables[0]
+	require.Equal(t, "Foo.Bar", drift.LogType)
+	require.True(t, drift.Breaking)
+	require.Equal(t, []awsglue.ColumnChange{{Name: "foo", Kind: awsglue.ColumnAdded, NewType: "string"}}, drift.Added)
+	require.Equal(t, []awsglue.ColumnChange{{Name: "col1", Kind: awsglue.ColumnRemoved, OldType: "int"}}, drift.Removed)
+	require.Equal(t, []string{"panther_logs.foo_bar", "panther_logs.foo_bar_v1"}, report.UnregisteredTables)
+
+	var text bytes.Buffer
+	require.NoError(t, report.WriteText(&text))
+	require.Equal(t, `panther_logs.foo_bar (Foo.Bar): breaking, schema version 1 -> 2
+  added foo (string)
+  removed col1 (int)
+tables of unregistered log types:
+  panther_logs.foo_bar
+  panther_logs.foo_bar_v1
+1 of 1 table(s) drifted, 2 table(s) of unregistered log types
+`, text.String())
+}
+
+func TestDriftReportText(t *testing.T) {
+	start := time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)
+	stale := make([]awsglue.StalePartition, maxStalePartitionsText+2)
+	for i := range stale {
+		stale[i] = awsglue.StalePartition{
+			Time:    start.Add(time.Duration(i) * time.Hour),
+			Reasons: []string{"serde info differs"},
+		}
+	}
+	report := &DriftReport{
+		Tables: []*awsglue.TableDrift{
+			{DatabaseName: "panther_logs", TableName: "foo", LogType: "Foo"},
+			{
+				DatabaseName:    "panther_rule_matches",
+				TableName:       "bar",
+				LogType:         "Bar",
+				Changed:         []awsglue.ColumnChange{{Name: "x", Kind: awsglue.ColumnWidened, OldType: "int", NewType: "bigint"}},
+				StalePartitions: stale,
+				MissingPartitions: []awsglue.PartitionRange{
+					{Start: start, End: start.Add(2 * time.Hour), Count: 2},
+				},
+			},
+		},
+	}
+	require.True(t, report.HasDrift())
+	var text bytes.Buffer
+	require.NoError(t, report.WriteText(&text))
+	expect := "panther_logs.foo: no drift\n" +
+		"panther_rule_matches.bar (Bar): additive\n" +
+		"  widened x (int -> bigint)\n" +
+		"  12 stale partition(s)\n"
+	for i := 0; i < maxStalePartitionsText; i++ {
+		expect += "    " + stale[i].Time.Format(time.RFC3339) + ": serde info differs\n"
+	}
+	expect += "    ... 2 more\n" +
+		"  2 missing partition(s) from 2020-01-03T00:00:00Z to 2020-01-03T02:00:00Z\n" +
+		"1 of 2 table(s) drifted, 0 table(s) of unregistered log types\n"
+	require.Equal(t, expect, text.String())
+
+	require.False(t, (&DriftReport{Tables: report.Tables[:1]}).HasDrift())
+}
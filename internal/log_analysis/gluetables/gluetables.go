@@ -90,23 +90,40 @@ func CreateOrUpdateGlueTablesForLogType(glueClient glueiface.GlueAPI, logType,
 	return logTable, ruleTable, err
 }
 
-// CreateOrUpdateGlueTables, given a log meta data table, creates a log and rule table in the glue catalog
+// CreateOrUpdateGlueTables, given a log meta data table, creates a log and rule table in the glue catalog.
+// Breaking schema changes of deployed tables are refused with a *awsglue.SchemaChangeError holding the diff,
+// they can only be applied by archiving the deployed partitions with `gluesync -breaking archive`.
 func CreateOrUpdateGlueTables(glueClient glueiface.GlueAPI, bucket string,
 	logTable *awsglue.GlueTableMetadata) (ruleTable *awsglue.GlueTableMetadata, err error) {
 
-	err = logTable.CreateOrUpdateTable(glueClient, bucket)
+	ruleTable, _, err = UpdateGlueTables(glueClient, bucket, logTable, awsglue.RefuseBreakingChanges)
+	return ruleTable, err
+}
+
+// UpdateGlueTables, given a log meta data table, creates or updates a log and rule table in the glue catalog.
+// Breaking schema changes are handled according to policy. It returns the schema changes of the deployed tables.
+func UpdateGlueTables(glueClient glueiface.GlueAPI, bucket string, logTable *awsglue.GlueTableMetadata,
+	policy awsglue.SchemaUpdatePolicy) (ruleTable *awsglue.GlueTableMetadata, diffs []*awsglue.SchemaDiff, err error) {
+
+	diff, err := logTable.UpdateTableSchema(glueClient, bucket, policy)
+	if diff != nil {
+		diffs = append(diffs, diff)
+	}
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not create glue log table for %s.%s",
+		return nil, diffs, errors.Wrapf(err, "could not create glue log table for %s.%s",
 			logTable.DatabaseName(), logTable.TableName())
 	}
 
 	// the corresponding rule table shares the same structure as the log table + some columns
 	ruleTable = logTable.RuleTable()
-	err = ruleTable.CreateOrUpdateTable(glueClient, bucket)
+	diff, err = ruleTable.UpdateTableSchema(glueClient, bucket, policy)
+	if diff != nil {
+		diffs = append(diffs, diff)
+	}
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not create glue log table for %s.%s",
+		return nil, diffs, errors.Wrapf(err, "could not create glue log table for %s.%s",
 			ruleTable.DatabaseName(), ruleTable.TableName())
 	}
 
-	return ruleTable, nil
+	return ruleTable, diffs, nil
 }
@@ -23,11 +23,14 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
@@ -100,3 +103,22 @@ func TestDeployedTablesSignature(t *testing.T) {
 
 	mockGlueClient.AssertExpectations(t)
 }
+
+func TestCreateOrUpdateGlueTablesRefusesBreakingChanges(t *testing.T) {
+	logTable := registry.AvailableTables()[0]
+
+	mockGlueClient := &testutils.GlueMock{}
+	mockGlueClient.On("CreateTable", mock.Anything).Return(&glue.CreateTableOutput{},
+		awserr.New(glue.ErrCodeAlreadyExistsException, "exists", nil)).Once()
+	// the deployed columns are not in the schema of the table so they would be removed
+	mockGlueClient.On("GetTable", mock.Anything).Return(testGetTableOutput, nil).Once()
+	_, err := CreateOrUpdateGlueTables(mockGlueClient, "testbucket", logTable)
+	require.Error(t, err)
+	schemaErr, ok := errors.Cause(err).(*awsglue.SchemaChangeError)
+	require.True(t, ok)
+	require.True(t, schemaErr.Diff.IsBreaking())
+	// the deployed table is left untouched
+	mockGlueClient.AssertExpectations(t)
+	mockGlueClient.AssertNotCalled(t, "UpdateTable", mock.Anything)
+	mockGlueClient.AssertNotCalled(t, "BatchCreatePartition", mock.Anything)
+}
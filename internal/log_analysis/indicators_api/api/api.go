@@ -0,0 +1,47 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/athena/athenaiface"
+)
+
+const (
+	// Searches running longer than this return a query id to retrieve the results later
+	maxQueryWait = 45 * time.Second
+	pollDelay    = time.Second
+)
+
+// API has all of the handlers as receiver methods.
+type API struct{}
+
+var (
+	awsSession   *session.Session
+	athenaClient athenaiface.AthenaAPI
+)
+
+// Setup - parses the environment and builds the AWS clients.
+func Setup() {
+	awsSession = session.Must(session.NewSession())
+	athenaClient = athena.New(awsSession)
+}
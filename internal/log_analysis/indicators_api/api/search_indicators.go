@@ -0,0 +1,199 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/indicators/models"
+	"github.com/panther-labs/panther/internal/log_analysis/athenaviews"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/pkg/awsathena"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	defaultPageSize = 25
+	// Searches scan all log tables, the time range bounds the partitions read
+	maxSearchRange = 31 * 24 * time.Hour
+)
+
+// All searches select these columns from the indicators view, the results of other queries are not returned
+var searchSQLPrefix = fmt.Sprintf("select indicator_type,value,log_type,p_event_time,p_row_id from %s.%s where ",
+	awsglue.ViewsDatabaseName, athenaviews.IndicatorsViewName)
+
+var searchInternalError = &genericapi.InternalError{Message: "Failed to search indicators. Please try again later"}
+
+// SearchIndicators starts an indicator search and returns the first page of hits if it completes in time
+func (API) SearchIndicators(input *models.SearchIndicatorsInput) (*models.SearchIndicatorsOutput, error) {
+	if input.EndTime.Sub(input.StartTime) > maxSearchRange {
+		return nil, &genericapi.InvalidInputError{
+			Message: fmt.Sprintf("search time range cannot exceed %d days", maxSearchRange/(24*time.Hour))}
+	}
+
+	sql := searchIndicatorsSQL(input)
+	zap.L().Debug("searching indicators", zap.String("sql", sql))
+	startOutput, err := awsathena.StartQuery(athenaClient, awsglue.ViewsDatabaseName, sql, nil) // use default bucket
+	if err != nil {
+		zap.L().Error("failed to start indicator search", zap.Error(err))
+		return nil, searchInternalError
+	}
+	return getResults(aws.StringValue(startOutput.QueryExecutionId), nil, input.PageSize)
+}
+
+// GetIndicatorSearchResults returns a page of hits of a search
+func (API) GetIndicatorSearchResults(input *models.GetIndicatorSearchResultsInput) (
+	*models.GetIndicatorSearchResultsOutput, error) {
+
+	return getResults(input.QueryID, input.PaginationToken, input.PageSize)
+}
+
+// getResults waits for a search to complete and returns a page of hits
+func getResults(queryID string, paginationToken *string, pageSize int) (*models.IndicatorSearchResults, error) {
+	output := &models.IndicatorSearchResults{
+		QueryID: queryID,
+		Status:  models.SearchStatusRunning,
+		Hits:    []models.IndicatorHit{},
+	}
+
+	done, err := waitForQuery(queryID, maxQueryWait)
+	if err != nil {
+		return nil, err
+	}
+	if !done {
+		return output, nil
+	}
+
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	firstPage := paginationToken == nil
+	if firstPage {
+		pageSize++ // the first page includes the header row
+	}
+	results, err := awsathena.Results(athenaClient, queryID, paginationToken, aws.Int64(int64(pageSize)))
+	if err != nil {
+		zap.L().Error("failed to read indicator search results", zap.String("queryId", queryID), zap.Error(err))
+		return nil, searchInternalError
+	}
+
+	rows := results.ResultSet.Rows
+	if firstPage && len(rows) > 0 {
+		rows = rows[1:]
+	}
+	for _, row := range rows {
+		hit, err := parseHit(row)
+		if err != nil {
+			zap.L().Error("failed to parse indicator search result", zap.String("queryId", queryID), zap.Error(err))
+			return nil, searchInternalError
+		}
+		output.Hits = append(output.Hits, *hit)
+	}
+	output.Status = models.SearchStatusSucceeded
+	output.PaginationToken = results.NextToken
+	return output, nil
+}
+
+// waitForQuery polls the status of a query until it completes or maxWait passes.
+// Queries that are not indicator searches are reported as not existing.
+func waitForQuery(queryID string, maxWait time.Duration) (done bool, err error) {
+	deadline := time.Now().Add(maxWait)
+	for {
+		executionOutput, err := awsathena.Status(athenaClient, queryID)
+		if err != nil {
+			if awsErr, ok := errors.Cause(err).(awserr.Error); ok && awsErr.Code() == athena.ErrCodeInvalidRequestException {
+				return false, &genericapi.DoesNotExistError{Message: "search " + queryID + " does not exist"}
+			}
+			zap.L().Error("failed to get indicator search status", zap.String("queryId", queryID), zap.Error(err))
+			return false, searchInternalError
+		}
+		if !strings.HasPrefix(aws.StringValue(executionOutput.QueryExecution.Query), searchSQLPrefix) {
+			zap.L().Warn("query is not an indicator search", zap.String("queryId", queryID))
+			return false, &genericapi.DoesNotExistError{Message: "search " + queryID + " does not exist"}
+		}
+		status := executionOutput.QueryExecution.Status
+		switch aws.StringValue(status.State) {
+		case athena.QueryExecutionStateSucceeded:
+			return true, nil
+		case athena.QueryExecutionStateFailed, athena.QueryExecutionStateCancelled:
+			zap.L().Error("indicator search failed",
+				zap.String("queryId", queryID),
+				zap.String("reason", aws.StringValue(status.StateChangeReason)))
+			return false, searchInternalError
+		}
+		if time.Now().Add(pollDelay).After(deadline) {
+			return false, nil
+		}
+		time.Sleep(pollDelay)
+	}
+}
+
+// searchIndicatorsSQL builds the query for the hits of an indicator in the indicators view
+func searchIndicatorsSQL(input *models.SearchIndicatorsInput) string {
+	startTime, endTime := input.StartTime.UTC(), input.EndTime.UTC()
+	conditions := []string{
+		"value = " + awsathena.QuoteString(input.Value),
+		// restrict the partitions read, the view has the partition columns of the tables
+		fmt.Sprintf("year*1000000+month*10000+day*100+hour BETWEEN %s AND %s",
+			partitionNumber(startTime), partitionNumber(endTime)),
+		fmt.Sprintf("p_event_time >= timestamp %s", awsathena.QuoteString(startTime.Format(awsathena.TimestampLayout))),
+		fmt.Sprintf("p_event_time < timestamp %s", awsathena.QuoteString(endTime.Format(awsathena.TimestampLayout))),
+	}
+	if input.IndicatorType != "" {
+		conditions = append(conditions, "indicator_type = "+awsathena.QuoteString(input.IndicatorType))
+	}
+	if len(input.LogTypes) > 0 {
+		logTypes := make([]string, len(input.LogTypes))
+		for i, logType := range input.LogTypes {
+			logTypes[i] = awsathena.QuoteString(logType)
+		}
+		conditions = append(conditions, fmt.Sprintf("log_type IN (%s)", strings.Join(logTypes, ",")))
+	}
+	return searchSQLPrefix + strings.Join(conditions, " AND ") + " order by p_event_time desc"
+}
+
+// partitionNumber returns the hourly partition of a time as a number (e.g. 2020061705)
+func partitionNumber(t time.Time) string {
+	return t.Format("2006010215")
+}
+
+func parseHit(row *athena.Row) (*models.IndicatorHit, error) {
+	if len(row.Data) != 5 {
+		return nil, fmt.Errorf("unexpected number of columns %d", len(row.Data))
+	}
+	eventTime, err := time.Parse(awsathena.TimestampLayout, aws.StringValue(row.Data[3].VarCharValue))
+	if err != nil {
+		return nil, err
+	}
+	return &models.IndicatorHit{
+		IndicatorType: aws.StringValue(row.Data[0].VarCharValue),
+		Value:         aws.StringValue(row.Data[1].VarCharValue),
+		LogType:       aws.StringValue(row.Data[2].VarCharValue),
+		EventTime:     eventTime,
+		RowID:         aws.StringValue(row.Data[4].VarCharValue),
+	}, nil
+}
@@ -0,0 +1,203 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/indicators/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const testQueryID = "test-query-id"
+
+var (
+	testStartTime = time.Date(2020, 6, 17, 0, 0, 0, 0, time.UTC)
+	testEndTime   = time.Date(2020, 6, 18, 5, 30, 0, 0, time.UTC)
+)
+
+func row(values ...string) *athena.Row {
+	row := &athena.Row{}
+	for _, value := range values {
+		row.Data = append(row.Data, &athena.Datum{VarCharValue: aws.String(value)})
+	}
+	return row
+}
+
+func queryStatus(state string) *athena.GetQueryExecutionOutput {
+	return &athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String(testQueryID),
+			Query:            aws.String(searchSQLPrefix + "value = '1.2.3.4'"),
+			Status: &athena.QueryExecutionStatus{
+				State:             aws.String(state),
+				StateChangeReason: aws.String("test reason"),
+			},
+		},
+	}
+}
+
+func TestSearchIndicatorsSQL(t *testing.T) {
+	sql := searchIndicatorsSQL(&models.SearchIndicatorsInput{
+		Value:         "o'reilly",
+		IndicatorType: "usernames",
+		LogTypes:      []string{"AWS.CloudTrail", "GSuite.Reports"},
+		StartTime:     testStartTime,
+		EndTime:       testEndTime,
+	})
+	// nolint (lll)
+	expected := `select indicator_type,value,log_type,p_event_time,p_row_id from panther_views.all_indicators where value = 'o''reilly' AND year*1000000+month*10000+day*100+hour BETWEEN 2020061700 AND 2020061805 AND p_event_time >= timestamp '2020-06-17 00:00:00.000' AND p_event_time < timestamp '2020-06-18 05:30:00.000' AND indicator_type = 'usernames' AND log_type IN ('AWS.CloudTrail','GSuite.Reports') order by p_event_time desc`
+	require.Equal(t, expected, sql)
+}
+
+func TestSearchIndicators(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	athenaClient = athenaMock
+
+	athenaMock.On("StartQueryExecution", mock.Anything).Return(&athena.StartQueryExecutionOutput{
+		QueryExecutionId: aws.String(testQueryID),
+	}, nil).Once()
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(queryStatus(athena.QueryExecutionStateSucceeded), nil).Once()
+	athenaMock.On("GetQueryResults", &athena.GetQueryResultsInput{
+		QueryExecutionId: aws.String(testQueryID),
+		MaxResults:       aws.Int64(3), // the header row is returned in the first page
+	}).Return(&athena.GetQueryResultsOutput{
+		ResultSet: &athena.ResultSet{
+			Rows: []*athena.Row{
+				row("indicator_type", "value", "log_type", "p_event_time", "p_row_id"),
+				row("ip_addresses", "192.168.0.1", "AWS.VPCFlow", "2020-06-17 12:00:00.000", "row1"),
+				row("ip_addresses", "192.168.0.1", "AWS.CloudTrail", "2020-06-17 11:00:00.000", "row2"),
+			},
+		},
+		NextToken: aws.String("next"),
+	}, nil).Once()
+
+	output, err := API{}.SearchIndicators(&models.SearchIndicatorsInput{
+		Value:     "192.168.0.1",
+		StartTime: testStartTime,
+		EndTime:   testEndTime,
+		PageSize:  2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &models.SearchIndicatorsOutput{
+		QueryID: testQueryID,
+		Status:  models.SearchStatusSucceeded,
+		Hits: []models.IndicatorHit{
+			{
+				IndicatorType: "ip_addresses",
+				Value:         "192.168.0.1",
+				LogType:       "AWS.VPCFlow",
+				EventTime:     time.Date(2020, 6, 17, 12, 0, 0, 0, time.UTC),
+				RowID:         "row1",
+			},
+			{
+				IndicatorType: "ip_addresses",
+				Value:         "192.168.0.1",
+				LogType:       "AWS.CloudTrail",
+				EventTime:     time.Date(2020, 6, 17, 11, 0, 0, 0, time.UTC),
+				RowID:         "row2",
+			},
+		},
+		PaginationToken: aws.String("next"),
+	}, output)
+	athenaMock.AssertExpectations(t)
+}
+
+func TestSearchIndicatorsRangeTooLarge(t *testing.T) {
+	_, err := API{}.SearchIndicators(&models.SearchIndicatorsInput{
+		Value:     "192.168.0.1",
+		StartTime: testStartTime,
+		EndTime:   testStartTime.Add(maxSearchRange + time.Hour),
+	})
+	require.Error(t, err)
+	require.IsType(t, &genericapi.InvalidInputError{}, err)
+}
+
+func TestGetIndicatorSearchResultsNextPage(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	athenaClient = athenaMock
+
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(queryStatus(athena.QueryExecutionStateSucceeded), nil).Once()
+	athenaMock.On("GetQueryResults", &athena.GetQueryResultsInput{
+		QueryExecutionId: aws.String(testQueryID),
+		NextToken:        aws.String("next"),
+		MaxResults:       aws.Int64(defaultPageSize),
+	}).Return(&athena.GetQueryResultsOutput{
+		ResultSet: &athena.ResultSet{
+			Rows: []*athena.Row{
+				row("ip_addresses", "192.168.0.1", "AWS.VPCFlow", "2020-06-17 10:00:00.000", "row3"),
+			},
+		},
+	}, nil).Once()
+
+	output, err := API{}.GetIndicatorSearchResults(&models.GetIndicatorSearchResultsInput{
+		QueryID:         testQueryID,
+		PaginationToken: aws.String("next"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.SearchStatusSucceeded, output.Status)
+	require.Len(t, output.Hits, 1)
+	require.Equal(t, "row3", output.Hits[0].RowID)
+	require.Nil(t, output.PaginationToken)
+	athenaMock.AssertExpectations(t)
+}
+
+func TestGetIndicatorSearchResultsFailed(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	athenaClient = athenaMock
+
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(queryStatus(athena.QueryExecutionStateFailed), nil).Once()
+
+	_, err := API{}.GetIndicatorSearchResults(&models.GetIndicatorSearchResultsInput{QueryID: testQueryID})
+	require.Equal(t, searchInternalError, err)
+	athenaMock.AssertExpectations(t)
+}
+
+func TestGetIndicatorSearchResultsOtherQuery(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	athenaClient = athenaMock
+	status := queryStatus(athena.QueryExecutionStateSucceeded)
+	status.QueryExecution.Query = aws.String("select * from panther_logs.aws_cloudtrail")
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(status, nil).Once()
+
+	// the results of queries other than searches are never read
+	_, err := API{}.GetIndicatorSearchResults(&models.GetIndicatorSearchResultsInput{QueryID: testQueryID})
+	require.IsType(t, &genericapi.DoesNotExistError{}, err)
+	athenaMock.AssertExpectations(t)
+}
+
+func TestWaitForQueryRunning(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	athenaClient = athenaMock
+
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(queryStatus(athena.QueryExecutionStateRunning), nil).Once()
+
+	// no time to poll again
+	done, err := waitForQuery(testQueryID, 0)
+	require.NoError(t, err)
+	require.False(t, done)
+	athenaMock.AssertExpectations(t)
+}
@@ -26,11 +26,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
-	"github.com/aws/aws-sdk-go/service/sns"
-	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
@@ -78,6 +73,22 @@ func init() {
 }
 
 func CreateS3Destination(registry *logtypes.Registry, jsonAPI jsoniter.API) Destination {
+	store := &S3ObjectStore{
+		Uploader:   common.S3Uploader,
+		BucketName: common.Config.ProcessedDataBucket,
+	}
+	notifier := &SNSNotifier{
+		Client:   common.SnsClient,
+		TopicARN: common.Config.SnsTopicARN,
+	}
+	return NewS3Destination(store, notifier, registry, jsonAPI, common.Config.AwsLambdaFunctionMemorySize)
+}
+
+// NewS3Destination creates a destination that writes the output files with the S3 partition layout to store
+// and notifies about each file using notifier. The memory size is used to size the output buffers.
+func NewS3Destination(store ObjectStore, notifier Notifier, registry *logtypes.Registry, jsonAPI jsoniter.API,
+	memorySizeMB int) Destination {
+
 	if jsonAPI == nil {
 		jsonAPI = jsoniter.ConfigDefault
 	}
@@ -85,11 +96,9 @@ func CreateS3Destination(registry *logtypes.Registry, jsonAPI jsoniter.API) Dest
 		registry = logtypes.DefaultRegistry()
 	}
 	return &S3Destination{
-		s3Uploader:          common.S3Uploader,
-		snsClient:           common.SnsClient,
-		s3Bucket:            common.Config.ProcessedDataBucket,
-		snsTopicArn:         common.Config.SnsTopicARN,
-		maxBufferedMemBytes: maxS3BufferMemUsageBytes(common.Config.AwsLambdaFunctionMemorySize),
+		store:               store,
+		notifier:            notifier,
+		maxBufferedMemBytes: maxS3BufferMemUsageBytes(memorySizeMB),
 		maxDuration:         maxDuration,
 		registry:            registry,
 		jsonAPI:             jsonAPI,
@@ -124,13 +133,10 @@ func maxS3BufferMemUsageBytes(lambdaSizeMB int) uint64 {
 
 // S3Destination sends normalized events to S3
 type S3Destination struct {
-	s3Uploader s3manageriface.UploaderAPI
-	snsClient  snsiface.SNSAPI
-	// s3Bucket is the s3Bucket where the data will be stored
-	s3Bucket string
-	// snsTopic is the SNS Topic ARN where we will send the notification
-	// when we store new data in S3
-	snsTopicArn string
+	// store is where the data will be stored
+	store ObjectStore
+	// notifier sends the notification when we store new data
+	notifier Notifier
 	// thresholds for ejection
 	maxBufferedMemBytes uint64 // max will hold in buffers before ejection
 	maxDuration         time.Duration
@@ -239,7 +245,7 @@ func (destination *S3Destination) sendData(buffer *s3EventBuffer, errChan chan e
 		operation.Log(err,
 			// s3 dim info
 			zap.Int64("contentLength", contentLength),
-			zap.String("bucket", destination.s3Bucket),
+			zap.String("bucket", destination.store.Bucket()),
 			zap.String("key", key))
 	}()
 
@@ -257,60 +263,18 @@ func (destination *S3Destination) sendData(buffer *s3EventBuffer, errChan chan e
 
 	contentLength = int64(len(payload)) // for logging above
 
-	if _, err := destination.s3Uploader.Upload(&s3manager.UploadInput{
-		Bucket: &destination.s3Bucket,
-		Key:    &key,
-		Body:   bytes.NewReader(payload),
-	}); err != nil {
-		errChan <- errors.Wrap(err, "S3Upload")
+	if err = destination.store.PutObject(key, payload); err != nil {
+		errChan <- err
 		return
 	}
 
-	err = destination.sendSNSNotification(key, buffer) // if send fails we fail whole operation
+	notification := models.NewS3ObjectPutNotification(destination.store.Bucket(), key, buffer.bytes)
+	err = destination.notifier.Notify(buffer.logType, notification) // if send fails we fail whole operation
 	if err != nil {
 		errChan <- err
 	}
 }
 
-func (destination *S3Destination) sendSNSNotification(key string, buffer *s3EventBuffer) error {
-	var err error
-	operation := common.OpLogManager.Start("sendSNSNotification", common.OpLogSNSServiceDim)
-	defer func() {
-		operation.Stop()
-		operation.Log(err,
-			zap.String("topicArn", destination.snsTopicArn))
-	}()
-
-	s3Notification := models.NewS3ObjectPutNotification(destination.s3Bucket, key, buffer.bytes)
-
-	marshalledNotification, err := jsoniter.MarshalToString(s3Notification)
-	if err != nil {
-		err = errors.Wrap(err, "failed to marshal notification")
-		return err
-	}
-
-	input := &sns.PublishInput{
-		TopicArn: aws.String(destination.snsTopicArn),
-		Message:  aws.String(marshalledNotification),
-		MessageAttributes: map[string]*sns.MessageAttributeValue{
-			logDataTypeAttributeName: {
-				StringValue: aws.String(models.LogData.String()),
-				DataType:    aws.String(messageAttributeDataType),
-			},
-			logTypeAttributeName: {
-				StringValue: aws.String(buffer.logType),
-				DataType:    aws.String(messageAttributeDataType),
-			},
-		},
-	}
-	if _, err = destination.snsClient.Publish(input); err != nil {
-		err = errors.Wrap(err, "failed to send notification to topic")
-		return err
-	}
-
-	return err
-}
-
 func (destination *S3Destination) getS3ObjectKey(logType string, timestamp time.Time) (string, error) {
 	typ := destination.registry.Get(logType)
 	if typ == nil {
@@ -163,10 +163,14 @@ func newS3Destination(logTypes ...string) *testS3Destination {
 	mockS3Uploader := &mockS3ManagerUploader{}
 	return &testS3Destination{
 		S3Destination: S3Destination{
-			snsTopicArn:         "arn:aws:sns:us-west-2:123456789012:test",
-			s3Bucket:            "testbucket",
-			snsClient:           mockSns,
-			s3Uploader:          mockS3Uploader,
+			store: &S3ObjectStore{
+				Uploader:   mockS3Uploader,
+				BucketName: "testbucket",
+			},
+			notifier: &SNSNotifier{
+				Client:   mockSns,
+				TopicARN: "arn:aws:sns:us-west-2:123456789012:test",
+			},
 			maxBufferedMemBytes: 10 * 1024 * 1024, // an arbitrary amount enough to hold default test data
 			maxDuration:         maxDuration,
 			registry:            newRegistry(logTypes...),
@@ -246,7 +250,7 @@ func TestSendDataToS3BeforeTerminating(t *testing.T) {
 
 	// Verifying Sns Publish payload
 	publishInput := destination.mockSns.Calls[0].Arguments.Get(0).(*sns.PublishInput)
-	expectedS3Notification := models.NewS3ObjectPutNotification(destination.s3Bucket, *uploadInput.Key,
+	expectedS3Notification := models.NewS3ObjectPutNotification(destination.store.Bucket(), *uploadInput.Key,
 		len(expectedBytes))
 
 	marshaledExpectedS3Notification, _ := jsoniter.MarshalToString(expectedS3Notification)
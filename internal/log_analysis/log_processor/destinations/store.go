@@ -0,0 +1,190 @@
+package destinations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+)
+
+// ObjectStore stores the output files of a destination
+type ObjectStore interface {
+	// Bucket returns the bucket name to use in notifications for stored objects
+	Bucket() string
+	PutObject(key string, payload []byte) error
+}
+
+// Notifier notifies downstream consumers about new output files
+type Notifier interface {
+	Notify(logType string, notification *models.S3Notification) error
+}
+
+// S3ObjectStore stores objects in an S3 bucket.
+// Any S3 compatible service (ie MinIO) can be used by configuring the endpoint of the uploader's client.
+type S3ObjectStore struct {
+	Uploader   s3manageriface.UploaderAPI
+	BucketName string
+}
+
+var _ ObjectStore = (*S3ObjectStore)(nil)
+
+// Bucket implements ObjectStore interface
+func (s *S3ObjectStore) Bucket() string {
+	return s.BucketName
+}
+
+// PutObject implements ObjectStore interface
+func (s *S3ObjectStore) PutObject(key string, payload []byte) error {
+	if _, err := s.Uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(s.BucketName),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(payload),
+	}); err != nil {
+		return errors.Wrap(err, "S3Upload")
+	}
+	return nil
+}
+
+// FileObjectStore stores objects in a local directory using the object key as relative path.
+type FileObjectStore struct {
+	Dir string
+	// BucketName is used in notifications, defaults to the base name of Dir
+	BucketName string
+}
+
+var _ ObjectStore = (*FileObjectStore)(nil)
+
+// Bucket implements ObjectStore interface
+func (s *FileObjectStore) Bucket() string {
+	if s.BucketName != "" {
+		return s.BucketName
+	}
+	return filepath.Base(s.Dir)
+}
+
+// PutObject implements ObjectStore interface
+func (s *FileObjectStore) PutObject(key string, payload []byte) error {
+	path := filepath.Join(s.Dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory for %q", key)
+	}
+	if err := ioutil.WriteFile(path, payload, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %q", key)
+	}
+	return nil
+}
+
+// SNSNotifier publishes notifications to an SNS topic
+type SNSNotifier struct {
+	Client   snsiface.SNSAPI
+	TopicARN string
+}
+
+var _ Notifier = (*SNSNotifier)(nil)
+
+// Notify implements Notifier interface
+func (n *SNSNotifier) Notify(logType string, notification *models.S3Notification) (err error) {
+	operation := common.OpLogManager.Start("sendSNSNotification", common.OpLogSNSServiceDim)
+	defer func() {
+		operation.Stop()
+		operation.Log(err,
+			zap.String("topicArn", n.TopicARN))
+	}()
+
+	marshalledNotification, err := jsoniter.MarshalToString(notification)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+
+	input := &sns.PublishInput{
+		TopicArn: aws.String(n.TopicARN),
+		Message:  aws.String(marshalledNotification),
+		MessageAttributes: map[string]*sns.MessageAttributeValue{
+			logDataTypeAttributeName: {
+				StringValue: aws.String(models.LogData.String()),
+				DataType:    aws.String(messageAttributeDataType),
+			},
+			logTypeAttributeName: {
+				StringValue: aws.String(logType),
+				DataType:    aws.String(messageAttributeDataType),
+			},
+		},
+	}
+	if _, err = n.Client.Publish(input); err != nil {
+		return errors.Wrap(err, "failed to send notification to topic")
+	}
+	return nil
+}
+
+// FileNotifier writes each notification as a numbered JSON file in a local directory
+type FileNotifier struct {
+	Dir string
+
+	mu    sync.Mutex
+	count int
+}
+
+var _ Notifier = (*FileNotifier)(nil)
+
+// fileNotification is the JSON written by FileNotifier, it mirrors the SNS message and its attributes
+type fileNotification struct {
+	Type    string                 `json:"type"`
+	ID      string                 `json:"id"`
+	Message *models.S3Notification `json:"message"`
+}
+
+// Notify implements Notifier interface
+func (n *FileNotifier) Notify(logType string, notification *models.S3Notification) error {
+	data, err := jsoniter.MarshalIndent(&fileNotification{
+		Type:    models.LogData.String(),
+		ID:      logType,
+		Message: notification,
+	}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+	if err := os.MkdirAll(n.Dir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create notifications directory")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.count++
+	path := filepath.Join(n.Dir, fmt.Sprintf("%06d.json", n.count))
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write notification %q", path)
+	}
+	return nil
+}
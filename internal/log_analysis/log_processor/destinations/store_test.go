@@ -0,0 +1,92 @@
+package destinations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+func TestLocalS3Destination(t *testing.T) {
+	initTest()
+	assert := require.New(t)
+	dir, err := ioutil.TempDir("", "destinations")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	store := &FileObjectStore{
+		Dir:        filepath.Join(dir, "data"),
+		BucketName: "testbucket",
+	}
+	notifier := &FileNotifier{
+		Dir: filepath.Join(dir, "notifications"),
+	}
+	destination := NewS3Destination(store, notifier, newRegistry(), common.BuildJSON(), 1024)
+	eventChannel := make(chan *parsers.Result, 1)
+	eventChannel <- newTestResult(nil)
+	runSendEvents(t, destination, eventChannel, false)
+
+	var keys []string
+	err = filepath.Walk(store.Dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		key, err := filepath.Rel(store.Dir, path)
+		keys = append(keys, filepath.ToSlash(key))
+		return err
+	})
+	assert.NoError(err)
+	assert.Len(keys, 1)
+	assert.True(strings.HasPrefix(keys[0], expectedS3Prefix), keys[0])
+
+	f, err := os.Open(filepath.Join(store.Dir, keys[0]))
+	assert.NoError(err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	assert.NoError(err)
+	data, err := ioutil.ReadAll(r)
+	assert.NoError(err)
+	assert.Contains(string(data), `"p_log_type":"testLogType"`)
+
+	notification, err := ioutil.ReadFile(filepath.Join(notifier.Dir, "000001.json"))
+	assert.NoError(err)
+	var actual fileNotification
+	assert.NoError(jsoniter.Unmarshal(notification, &actual))
+	assert.Equal("LogData", actual.Type)
+	assert.Equal(testLogType, actual.ID)
+	assert.Len(actual.Message.Records, 1)
+	assert.Equal("testbucket", actual.Message.Records[0].S3.Bucket.Name)
+	assert.Equal(keys[0], actual.Message.Records[0].S3.Object.Key)
+}
+
+func TestFileObjectStoreBucket(t *testing.T) {
+	assert := require.New(t)
+	assert.Equal("output", (&FileObjectStore{Dir: "/tmp/output"}).Bucket())
+	assert.Equal("bucket", (&FileObjectStore{Dir: "/tmp/output", BucketName: "bucket"}).Bucket())
+}
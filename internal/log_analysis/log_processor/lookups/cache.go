@@ -0,0 +1,151 @@
+package lookups
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+// DefaultRefreshInterval is the default minimum time between reloads of the lookup tables
+const DefaultRefreshInterval = 5 * time.Minute
+
+// TableLister lists the lookup tables, it is implemented by logtypesapi.LogTypesAPILambdaClient
+type TableLister interface {
+	ListLookupTables(ctx context.Context) (*logtypesapi.LookupTables, error)
+}
+
+// Cache keeps the lookup tables in memory, reloading them periodically.
+// Table data are only downloaded again if the S3 object or the table settings have changed.
+type Cache struct {
+	Tables TableLister
+	S3     s3iface.S3API
+	// Minimum time between reloads, if zero DefaultRefreshInterval is used
+	RefreshInterval time.Duration
+
+	mu          sync.Mutex
+	entries     map[string]*cacheEntry
+	enricher    *Enricher
+	refreshedAt time.Time
+}
+
+type cacheEntry struct {
+	spec  logtypesapi.LookupTable
+	etag  string
+	table *Table
+}
+
+// Enricher returns an enricher using the current lookup tables, reloading the tables if the refresh interval has passed.
+// Failures to reload are logged and the previously loaded tables are kept.
+func (c *Cache) Enricher(ctx context.Context) *Enricher {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	interval := c.RefreshInterval
+	if interval == 0 {
+		interval = DefaultRefreshInterval
+	}
+	if c.enricher != nil && time.Since(c.refreshedAt) < interval {
+		return c.enricher
+	}
+	if err := c.refresh(ctx); err != nil {
+		zap.L().Warn("failed to refresh lookup tables", zap.Error(err))
+	}
+	// Do not retry on each call if the refresh failed
+	c.refreshedAt = time.Now()
+	if c.enricher == nil {
+		c.enricher = NewEnricher()
+	}
+	return c.enricher
+}
+
+func (c *Cache) refresh(ctx context.Context) error {
+	list, err := c.Tables.ListLookupTables(ctx)
+	if err != nil {
+		return err
+	}
+	entries := make(map[string]*cacheEntry, len(list.Tables))
+	tables := make([]*Table, 0, len(list.Tables))
+	for i := range list.Tables {
+		spec := &list.Tables[i]
+		prev := c.entries[spec.Name]
+		entry, err := c.load(ctx, spec, prev)
+		if err != nil {
+			zap.L().Warn("failed to load lookup table",
+				zap.String("table", spec.Name),
+				zap.String("s3URL", spec.S3URL),
+				zap.Error(err))
+			if prev == nil {
+				continue
+			}
+			entry = prev
+		}
+		entries[spec.Name] = entry
+		tables = append(tables, entry.table)
+	}
+	c.entries = entries
+	c.enricher = NewEnricher(tables...)
+	return nil
+}
+
+func (c *Cache) load(ctx context.Context, spec *logtypesapi.LookupTable, prev *cacheEntry) (*cacheEntry, error) {
+	bucket, key, err := awsglue.ParseS3URL(spec.S3URL)
+	if err != nil {
+		return nil, err
+	}
+	input := s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	unchanged := prev != nil && reflect.DeepEqual(&prev.spec, spec)
+	if unchanged && prev.etag != "" {
+		input.IfNoneMatch = aws.String(prev.etag)
+	}
+	output, err := c.S3.GetObjectWithContext(ctx, &input)
+	if err != nil {
+		if reqErr, ok := err.(awserr.RequestFailure); ok && unchanged && reqErr.StatusCode() == http.StatusNotModified {
+			return prev, nil
+		}
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	table, err := LoadTable(spec, output.Body)
+	if err != nil {
+		return nil, err
+	}
+	zap.L().Debug("loaded lookup table", zap.String("table", spec.Name), zap.Int("rows", table.Len()))
+	return &cacheEntry{
+		spec:  *spec,
+		etag:  aws.StringValue(output.ETag),
+		table: table,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package lookups
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+type testLister []logtypesapi.LookupTable
+
+func (l testLister) ListLookupTables(_ context.Context) (*logtypesapi.LookupTables, error) {
+	return &logtypesapi.LookupTables{Tables: l}, nil
+}
+
+func TestCache(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	spec := testTableSpec(logtypesapi.LookupFormatCSV)
+	s3Mock := &testutils.S3Mock{}
+	cache := Cache{
+		Tables:          testLister{*spec},
+		S3:              s3Mock,
+		RefreshInterval: time.Hour,
+	}
+
+	s3Mock.On("GetObjectWithContext", ctx, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("hosts"),
+	}, mock.Anything).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(strings.NewReader("ip,owner,port,score,managed\n10.0.0.1,alice,,,\n")),
+		ETag: aws.String(`"etag"`),
+	}, nil).Once()
+
+	e := cache.Enricher(ctx)
+	assert.JSONEq(`{"hosts":{"ip":"10.0.0.1","owner":"alice"}}`, string(e.Enrich("AWS.VPCFlow", []byte(`{"srcAddr":"10.0.0.1"}`))))
+	// Within the refresh interval the tables are not reloaded
+	assert.Same(e, cache.Enricher(ctx))
+	s3Mock.AssertExpectations(t)
+
+	// Unmodified objects are not downloaded again
+	cache.refreshedAt = time.Time{}
+	s3Mock.On("GetObjectWithContext", ctx, &s3.GetObjectInput{
+		Bucket:      aws.String("bucket"),
+		Key:         aws.String("hosts"),
+		IfNoneMatch: aws.String(`"etag"`),
+	}, mock.Anything).Return((*s3.GetObjectOutput)(nil),
+		awserr.NewRequestFailure(awserr.New("NotModified", "Not Modified", nil), http.StatusNotModified, "")).Once()
+	e = cache.Enricher(ctx)
+	assert.JSONEq(`{"hosts":{"ip":"10.0.0.1","owner":"alice"}}`, string(e.Enrich("AWS.VPCFlow", []byte(`{"srcAddr":"10.0.0.1"}`))))
+	s3Mock.AssertExpectations(t)
+
+	// Tables that fail to load keep their previous data
+	cache.refreshedAt = time.Time{}
+	s3Mock.On("GetObjectWithContext", ctx, &s3.GetObjectInput{
+		Bucket:      aws.String("bucket"),
+		Key:         aws.String("hosts"),
+		IfNoneMatch: aws.String(`"etag"`),
+	}, mock.Anything).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(strings.NewReader("ip,owner\n")),
+		ETag: aws.String(`"etag2"`),
+	}, nil).Once()
+	e = cache.Enricher(ctx)
+	assert.NotNil(e.Enrich("AWS.VPCFlow", []byte(`{"srcAddr":"10.0.0.1"}`)))
+	s3Mock.AssertExpectations(t)
+
+	// Removed tables are dropped
+	cache.refreshedAt = time.Time{}
+	cache.Tables = testLister{}
+	e = cache.Enricher(ctx)
+	assert.Nil(e.Enrich("AWS.VPCFlow", []byte(`{"srcAddr":"10.0.0.1"}`)))
+	s3Mock.AssertExpectations(t)
+}
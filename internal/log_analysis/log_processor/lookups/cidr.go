@@ -0,0 +1,113 @@
+package lookups
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// prefixTrie is a binary trie of IP networks used to find the most specific network containing an address
+type prefixTrie struct {
+	v4   trieNode
+	v6   trieNode
+	size int
+}
+
+type trieNode struct {
+	children [2]*trieNode
+	row      []byte
+}
+
+// Insert sets the row of a network, replacing the row of the same network if it exists
+func (t *prefixTrie) Insert(network *net.IPNet, row []byte) {
+	ip, node := t.root(network.IP)
+	if node == nil {
+		return
+	}
+	ones, _ := network.Mask.Size()
+	for i := 0; i < ones; i++ {
+		bit := ipBit(ip, i)
+		if node.children[bit] == nil {
+			node.children[bit] = &trieNode{}
+		}
+		node = node.children[bit]
+	}
+	if node.row == nil {
+		t.size++
+	}
+	node.row = row
+}
+
+// Lookup returns the row of the most specific network containing ip or nil if no network contains it
+func (t *prefixTrie) Lookup(ip net.IP) []byte {
+	ip, node := t.root(ip)
+	var row []byte
+	for i := 0; node != nil; i++ {
+		if node.row != nil {
+			row = node.row
+		}
+		if i == len(ip)*8 {
+			break
+		}
+		node = node.children[ipBit(ip, i)]
+	}
+	return row
+}
+
+// Len returns the number of networks in the trie
+func (t *prefixTrie) Len() int {
+	return t.size
+}
+
+func (t *prefixTrie) root(ip net.IP) (net.IP, *trieNode) {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4, &t.v4
+	}
+	if ip16 := ip.To16(); ip16 != nil {
+		return ip16, &t.v6
+	}
+	return nil, nil
+}
+
+func ipBit(ip net.IP, i int) byte {
+	return (ip[i/8] >> (7 - uint(i%8))) & 1
+}
+
+// parseNetwork parses a CIDR network, a single IP address is parsed as a network holding only that address
+func parseNetwork(value string) (*net.IPNet, error) {
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		return network, err
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, errors.Errorf("invalid IP address %q", value)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	bits := len(ip) * 8
+	return &net.IPNet{
+		IP:   ip,
+		Mask: net.CIDRMask(bits, bits),
+	}, nil
+}
@@ -0,0 +1,68 @@
+package lookups
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixTrie(t *testing.T) {
+	assert := require.New(t)
+	trie := prefixTrie{}
+	for _, cidr := range []string{"10.0.0.0/8", "10.1.0.0/16", "10.1.2.3", "0.0.0.0/0", "2001:db8::/32"} {
+		network, err := parseNetwork(cidr)
+		assert.NoError(err)
+		trie.Insert(network, []byte(network.String()))
+	}
+	assert.Equal(5, trie.Len())
+
+	// the most specific network wins
+	assert.Equal("10.1.2.3/32", string(trie.Lookup(net.ParseIP("10.1.2.3"))))
+	assert.Equal("10.1.0.0/16", string(trie.Lookup(net.ParseIP("10.1.2.4"))))
+	assert.Equal("10.0.0.0/8", string(trie.Lookup(net.ParseIP("10.2.0.1"))))
+	assert.Equal("0.0.0.0/0", string(trie.Lookup(net.ParseIP("192.168.1.1"))))
+	assert.Equal("2001:db8::/32", string(trie.Lookup(net.ParseIP("2001:db8::1"))))
+	// IPv4 networks do not match IPv6 addresses
+	assert.Nil(trie.Lookup(net.ParseIP("2001:db9::1")))
+	assert.Nil(trie.Lookup(nil))
+
+	// inserting the same network replaces its row
+	network, err := parseNetwork("10.0.0.0/8")
+	assert.NoError(err)
+	trie.Insert(network, []byte("corp"))
+	assert.Equal(5, trie.Len())
+	assert.Equal("corp", string(trie.Lookup(net.ParseIP("10.2.0.1"))))
+}
+
+func TestParseNetwork(t *testing.T) {
+	assert := require.New(t)
+	network, err := parseNetwork("10.1.2.3/8")
+	assert.NoError(err)
+	assert.Equal("10.0.0.0/8", network.String())
+	network, err = parseNetwork("::1")
+	assert.NoError(err)
+	assert.Equal("::1/128", network.String())
+	_, err = parseNetwork("10.0.0.0/33")
+	assert.Error(err)
+	_, err = parseNetwork("corp")
+	assert.Error(err)
+}
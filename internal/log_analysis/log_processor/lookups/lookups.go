@@ -0,0 +1,347 @@
+package lookups
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Table is a lookup table loaded in memory
+type Table struct {
+	Name string
+	// JSON path of the selected field by log type
+	selectors map[string][]interface{}
+	// JSON object of each row by key
+	rows map[string][]byte
+	// JSON object of each row by network if the key column is a CIDR column
+	networks *prefixTrie
+}
+
+// Len returns the number of rows in the table
+func (t *Table) Len() int {
+	if t.networks != nil {
+		return t.networks.Len()
+	}
+	return len(t.rows)
+}
+
+// Lookup returns the JSON object of the row matching key or nil if there is no such row.
+// If the key column is a CIDR column, key is an IP address matched to the most specific network containing it.
+func (t *Table) Lookup(key string) []byte {
+	if t.networks != nil {
+		ip := net.ParseIP(key)
+		if ip == nil {
+			return nil
+		}
+		return t.networks.Lookup(ip)
+	}
+	return t.rows[key]
+}
+
+// LoadTable reads the data of a lookup table.
+// Column values are converted to the column type, empty non-string values are omitted from the row.
+func LoadTable(spec *logtypesapi.LookupTable, r io.Reader) (*Table, error) {
+	table := Table{
+		Name:      spec.Name,
+		selectors: make(map[string][]interface{}, len(spec.Selectors)),
+		rows:      make(map[string][]byte),
+	}
+	for _, selector := range spec.Selectors {
+		var path []interface{}
+		for _, key := range strings.Split(selector.Field, ".") {
+			path = append(path, key)
+		}
+		table.selectors[selector.LogType] = path
+	}
+
+	var read func(r io.Reader, columns []logtypesapi.LookupColumn, row func(values []string) error) error
+	switch spec.Format {
+	case logtypesapi.LookupFormatCSV:
+		read = readCSV
+	case logtypesapi.LookupFormatJSON:
+		read = readJSON
+	default:
+		return nil, errors.Errorf("invalid lookup table format %q", spec.Format)
+	}
+
+	keyIndex := -1
+	for i, col := range spec.Columns {
+		if col.Name == spec.KeyColumn {
+			keyIndex = i
+		}
+	}
+	if keyIndex == -1 {
+		return nil, errors.Errorf("key column %q is not defined in the lookup table columns", spec.KeyColumn)
+	}
+	if spec.Columns[keyIndex].Type == logtypesapi.LookupColumnCIDR {
+		table.networks = &prefixTrie{}
+	}
+
+	stream := jsoniter.ConfigDefault.BorrowStream(nil)
+	defer jsoniter.ConfigDefault.ReturnStream(stream)
+	numRows := 0
+	err := read(r, spec.Columns, func(values []string) error {
+		numRows++
+		key, err := convertValue(spec.Columns[keyIndex].Type, values[keyIndex])
+		if err != nil {
+			return errors.WithMessagef(err, "row %d: invalid key", numRows)
+		}
+		if key == "" {
+			return nil
+		}
+		stream.Reset(nil)
+		stream.WriteObjectStart()
+		more := false
+		for i, col := range spec.Columns {
+			value, err := convertValue(col.Type, values[i])
+			if err != nil {
+				return errors.WithMessagef(err, "row %d: invalid column %q", numRows, col.Name)
+			}
+			if value == "" && col.Type != logtypesapi.LookupColumnString {
+				continue
+			}
+			if more {
+				stream.WriteMore()
+			}
+			more = true
+			stream.WriteObjectField(col.Name)
+			if col.Type == logtypesapi.LookupColumnString || col.Type == logtypesapi.LookupColumnCIDR {
+				stream.WriteString(value)
+			} else {
+				stream.WriteRaw(value)
+			}
+		}
+		stream.WriteObjectEnd()
+		row := append([]byte(nil), stream.Buffer()...)
+		if table.networks != nil {
+			// the key was normalized by convertValue
+			_, network, _ := net.ParseCIDR(key)
+			table.networks.Insert(network, row)
+			return nil
+		}
+		table.rows[key] = row
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read lookup table %q", spec.Name)
+	}
+	return &table, nil
+}
+
+// convertValue normalizes a value to its JSON representation for non-string column types
+func convertValue(typ, value string) (string, error) {
+	if typ == logtypesapi.LookupColumnString {
+		return value, nil
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	switch typ {
+	case logtypesapi.LookupColumnBigInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(n, 10), nil
+	case logtypesapi.LookupColumnDouble:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), nil
+	case logtypesapi.LookupColumnBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(b), nil
+	case logtypesapi.LookupColumnCIDR:
+		network, err := parseNetwork(value)
+		if err != nil {
+			return "", err
+		}
+		return network.String(), nil
+	default:
+		return "", errors.Errorf("invalid column type %q", typ)
+	}
+}
+
+// readCSV reads CSV data with a header row
+func readCSV(r io.Reader, columns []logtypesapi.LookupColumn, row func(values []string) error) error {
+	rd := csv.NewReader(r)
+	rd.ReuseRecord = true
+	header, err := rd.Read()
+	if err != nil {
+		return errors.Wrap(err, "failed to read CSV header")
+	}
+	indexes := make([]int, len(columns))
+	for i, col := range columns {
+		indexes[i] = -1
+		for j, name := range header {
+			if strings.TrimSpace(name) == col.Name {
+				indexes[i] = j
+				break
+			}
+		}
+		if indexes[i] == -1 {
+			return errors.Errorf("column %q not found in CSV header", col.Name)
+		}
+	}
+	values := make([]string, len(columns))
+	for {
+		record, err := rd.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for i, j := range indexes {
+			values[i] = record[j]
+		}
+		if err := row(values); err != nil {
+			return err
+		}
+	}
+}
+
+// readJSON reads JSON data with one object per line
+func readJSON(r io.Reader, columns []logtypesapi.LookupColumn, row func(values []string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	values := make([]string, len(columns))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		obj := jsoniter.Get(line)
+		if obj.ValueType() != jsoniter.ObjectValue {
+			return errors.New("invalid JSON object")
+		}
+		for i, col := range columns {
+			switch value := obj.Get(col.Name); value.ValueType() {
+			case jsoniter.InvalidValue, jsoniter.NilValue:
+				values[i] = ""
+			default:
+				values[i] = value.ToString()
+			}
+		}
+		if err := row(values); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+const maxLineSize = 1024 * 1024
+
+// Enricher enriches log events with the matching rows of lookup tables.
+// The matching rows are added to the `p_enrichment` field of the event under the table name.
+// If the selected field is an array (ie `p_any_ip_addresses`) the enrichment holds an array with all matching rows.
+type Enricher struct {
+	tables map[string][]*Table
+}
+
+var _ pantherlog.Enricher = (*Enricher)(nil)
+
+// NewEnricher creates an enricher for the lookup tables
+func NewEnricher(tables ...*Table) *Enricher {
+	e := Enricher{
+		tables: make(map[string][]*Table),
+	}
+	for _, table := range tables {
+		for logType := range table.selectors {
+			e.tables[logType] = append(e.tables[logType], table)
+		}
+	}
+	return &e
+}
+
+// Enrich implements pantherlog.Enricher interface
+func (e *Enricher) Enrich(logType string, event []byte) []byte {
+	if e == nil {
+		return nil
+	}
+	var out []byte
+	for _, table := range e.tables[logType] {
+		match := table.match(jsoniter.Get(event, table.selectors[logType]...))
+		if match == nil {
+			continue
+		}
+		if out == nil {
+			out = append(out, '{')
+		} else {
+			out = append(out, ',')
+		}
+		// Table names are validated to not require escaping
+		out = append(out, '"')
+		out = append(out, table.Name...)
+		out = append(out, '"', ':')
+		out = append(out, match...)
+	}
+	if out == nil {
+		return nil
+	}
+	return append(out, '}')
+}
+
+func (t *Table) match(value jsoniter.Any) []byte {
+	switch value.ValueType() {
+	case jsoniter.StringValue, jsoniter.NumberValue, jsoniter.BoolValue:
+		return t.Lookup(value.ToString())
+	case jsoniter.ArrayValue:
+		var out []byte
+		for i := 0; i < value.Size(); i++ {
+			var row []byte
+			switch el := value.Get(i); el.ValueType() {
+			case jsoniter.StringValue, jsoniter.NumberValue, jsoniter.BoolValue:
+				row = t.Lookup(el.ToString())
+			}
+			if row == nil {
+				continue
+			}
+			if out == nil {
+				out = append(out, '[')
+			} else {
+				out = append(out, ',')
+			}
+			out = append(out, row...)
+		}
+		if out == nil {
+			return nil
+		}
+		return append(out, ']')
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package lookups
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+)
+
+func testTableSpec(format string) *logtypesapi.LookupTable {
+	return &logtypesapi.LookupTable{
+		Name:   "hosts",
+		S3URL:  "s3://bucket/hosts",
+		Format: format,
+		Columns: []logtypesapi.LookupColumn{
+			{Name: "ip", Type: logtypesapi.LookupColumnString},
+			{Name: "owner", Type: logtypesapi.LookupColumnString},
+			{Name: "port", Type: logtypesapi.LookupColumnBigInt},
+			{Name: "score", Type: logtypesapi.LookupColumnDouble},
+			{Name: "managed", Type: logtypesapi.LookupColumnBoolean},
+		},
+		KeyColumn: "ip",
+		Selectors: []logtypesapi.LookupSelector{
+			{LogType: "AWS.VPCFlow", Field: "srcAddr"},
+			{LogType: "AWS.CloudTrail", Field: "p_any_ip_addresses"},
+			{LogType: "Nginx.Access", Field: "remote.addr"},
+		},
+	}
+}
+
+func TestLoadTableCSV(t *testing.T) {
+	assert := require.New(t)
+	data := `managed,owner,ip,port,score,extra
+true,alice,10.0.0.1,22,0.5,foo
+,bob,10.0.0.2,,,bar
+false,carol,,443,1,baz
+`
+	table, err := LoadTable(testTableSpec(logtypesapi.LookupFormatCSV), strings.NewReader(data))
+	assert.NoError(err)
+	assert.Equal("hosts", table.Name)
+	assert.Equal(2, table.Len())
+	assert.JSONEq(`{"ip":"10.0.0.1","owner":"alice","port":22,"score":0.5,"managed":true}`, string(table.Lookup("10.0.0.1")))
+	assert.JSONEq(`{"ip":"10.0.0.2","owner":"bob"}`, string(table.Lookup("10.0.0.2")))
+	assert.Nil(table.Lookup("10.0.0.3"))
+
+	_, err = LoadTable(testTableSpec(logtypesapi.LookupFormatCSV), strings.NewReader("ip,owner\n10.0.0.1,alice\n"))
+	assert.Error(err)
+	assert.Contains(err.Error(), `column "port" not found`)
+
+	_, err = LoadTable(testTableSpec(logtypesapi.LookupFormatCSV), strings.NewReader(`managed,owner,ip,port,score
+true,alice,10.0.0.1,ssh,0.5
+`))
+	assert.Error(err)
+	assert.Contains(err.Error(), `row 1: invalid column "port"`)
+}
+
+func TestLoadTableJSON(t *testing.T) {
+	assert := require.New(t)
+	data := `{"ip":"10.0.0.1","owner":"alice","port":"22","score":0.5,"managed":true,"extra":{"foo":"bar"}}
+
+{"ip":"10.0.0.2","owner":"bob","port":null}
+`
+	table, err := LoadTable(testTableSpec(logtypesapi.LookupFormatJSON), strings.NewReader(data))
+	assert.NoError(err)
+	assert.Equal(2, table.Len())
+	assert.JSONEq(`{"ip":"10.0.0.1","owner":"alice","port":22,"score":0.5,"managed":true}`, string(table.Lookup("10.0.0.1")))
+	assert.JSONEq(`{"ip":"10.0.0.2","owner":"bob"}`, string(table.Lookup("10.0.0.2")))
+
+	_, err = LoadTable(testTableSpec(logtypesapi.LookupFormatJSON), strings.NewReader(`["10.0.0.1"]`))
+	assert.Error(err)
+}
+
+func TestLoadTableCIDR(t *testing.T) {
+	assert := require.New(t)
+	spec := &logtypesapi.LookupTable{
+		Name:   "networks",
+		S3URL:  "s3://bucket/networks",
+		Format: logtypesapi.LookupFormatCSV,
+		Columns: []logtypesapi.LookupColumn{
+			{Name: "cidr", Type: logtypesapi.LookupColumnCIDR},
+			{Name: "name", Type: logtypesapi.LookupColumnString},
+		},
+		KeyColumn: "cidr",
+		Selectors: []logtypesapi.LookupSelector{
+			{LogType: "AWS.VPCFlow", Field: "srcAddr"},
+			{LogType: "AWS.CloudTrail", Field: "p_any_ip_addresses"},
+		},
+	}
+	data := `cidr,name
+10.0.0.0/8,corp
+10.1.0.0/16,vpn
+192.168.1.1,printer
+`
+	table, err := LoadTable(spec, strings.NewReader(data))
+	assert.NoError(err)
+	assert.Equal(3, table.Len())
+	assert.JSONEq(`{"cidr":"10.1.0.0/16","name":"vpn"}`, string(table.Lookup("10.1.2.3")))
+	assert.JSONEq(`{"cidr":"10.0.0.0/8","name":"corp"}`, string(table.Lookup("10.2.0.1")))
+	assert.JSONEq(`{"cidr":"192.168.1.1/32","name":"printer"}`, string(table.Lookup("192.168.1.1")))
+	assert.Nil(table.Lookup("192.168.1.2"))
+	assert.Nil(table.Lookup("corp"))
+
+	e := NewEnricher(table)
+	actual := e.Enrich("AWS.CloudTrail", []byte(`{"p_any_ip_addresses":["10.2.0.1","172.16.0.1","10.1.0.1"]}`))
+	assert.JSONEq(`{"networks":[{"cidr":"10.0.0.0/8","name":"corp"},{"cidr":"10.1.0.0/16","name":"vpn"}]}`, string(actual))
+
+	_, err = LoadTable(spec, strings.NewReader("cidr,name\n10.0.0.0/33,corp\n"))
+	assert.Error(err)
+	assert.Contains(err.Error(), "row 1: invalid key")
+}
+
+func TestEnricher(t *testing.T) {
+	assert := require.New(t)
+	data := `ip,owner,port,score,managed
+10.0.0.1,alice,,,
+10.0.0.2,bob,,,
+`
+	table, err := LoadTable(testTableSpec(logtypesapi.LookupFormatCSV), strings.NewReader(data))
+	assert.NoError(err)
+	e := NewEnricher(table)
+
+	actual := e.Enrich("AWS.VPCFlow", []byte(`{"srcAddr":"10.0.0.1","dstAddr":"10.0.0.2"}`))
+	assert.JSONEq(`{"hosts":{"ip":"10.0.0.1","owner":"alice"}}`, string(actual))
+
+	actual = e.Enrich("Nginx.Access", []byte(`{"remote":{"addr":"10.0.0.2"}}`))
+	assert.JSONEq(`{"hosts":{"ip":"10.0.0.2","owner":"bob"}}`, string(actual))
+
+	actual = e.Enrich("AWS.CloudTrail", []byte(`{"p_any_ip_addresses":["10.0.0.1","10.0.0.2","10.0.0.3"]}`))
+	assert.JSONEq(`{"hosts":[{"ip":"10.0.0.1","owner":"alice"},{"ip":"10.0.0.2","owner":"bob"}]}`, string(actual))
+
+	assert.Nil(e.Enrich("AWS.VPCFlow", []byte(`{"srcAddr":"10.0.0.3"}`)))
+	assert.Nil(e.Enrich("AWS.VPCFlow", []byte(`{"dstAddr":"10.0.0.1"}`)))
+	assert.Nil(e.Enrich("AWS.CloudTrail", []byte(`{"p_any_ip_addresses":["10.0.0.3"]}`)))
+	assert.Nil(e.Enrich("AWS.S3ServerAccess", []byte(`{"srcAddr":"10.0.0.1"}`)))
+	assert.Nil((*Enricher)(nil).Enrich("AWS.VPCFlow", []byte(`{"srcAddr":"10.0.0.1"}`)))
+}
@@ -25,25 +25,44 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambdacontext"
+	awslambda "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"go.uber.org/zap"
 
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/lookups"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/processor"
 	"github.com/panther-labs/panther/pkg/lambdalogger"
 )
 
+const logTypesAPIFunction = "panther-logtypes-api"
+
+// lookupTables keeps the lookup tables used to enrich events across invocations
+var lookupTables *lookups.Cache
+
 func main() {
 	common.Setup()
+	lookupTables = &lookups.Cache{
+		Tables: &logtypesapi.LogTypesAPILambdaClient{
+			LambdaName: logTypesAPIFunction,
+			LambdaAPI:  awslambda.New(common.Session),
+		},
+		S3: s3.New(common.Session),
+	}
 	lambda.Start(handle)
 }
 
 func handle(ctx context.Context, event events.SQSEvent) error {
 	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
 	deadline, _ := ctx.Deadline()
-	return process(lc, deadline, event)
+	return process(lc, deadline, event, lookupTables.Enricher(ctx))
 }
 
-func process(lc *lambdacontext.LambdaContext, deadline time.Time, event events.SQSEvent) (err error) {
+func process(lc *lambdacontext.LambdaContext, deadline time.Time, event events.SQSEvent,
+	enricher pantherlog.Enricher) (err error) {
+
 	operation := common.OpLogManager.Start(lc.InvokedFunctionArn, common.OpLogLambdaServiceDim).WithMemUsed(lambdacontext.MemoryLimitInMB)
 
 	var sqsMessageCount int
@@ -52,6 +71,6 @@ func process(lc *lambdacontext.LambdaContext, deadline time.Time, event events.S
 		operation.Stop().Log(err, zap.Int("sqsMessageCount", sqsMessageCount))
 	}()
 
-	sqsMessageCount, err = processor.StreamEvents(common.SqsClient, deadline, event)
+	sqsMessageCount, err = processor.StreamEvents(common.SqsClient, deadline, event, enricher)
 	return err
 }
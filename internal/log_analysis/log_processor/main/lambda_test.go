@@ -49,7 +49,7 @@ func TestProcessOpLog(t *testing.T) {
 	}
 	err := process(&lc, time.Now(), events.SQSEvent{
 		Records: []events.SQSMessage{}, // empty, should do no work
-	})
+	}, nil)
 	require.NoError(t, err)
 	message := common.OpLogNamespace + ":" + common.OpLogComponent + ":" + functionName
 	require.Equal(t, 1, len(logs.FilterMessage(message).All())) // should be just one like this
@@ -88,10 +88,13 @@ func (*resultEncoder) IsEmpty(ptr unsafe.Pointer) bool {
 // Encode implements jsoniter.ValEncoder interface
 func (e *resultEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
 	result := (*Result)(ptr)
+	// Keep the start of the event JSON object in the stream buffer for enrichment
+	start := len(stream.Buffer())
 	// Hack around events with embedded parsers.PantherLog.
 	// TODO: Remove this once all parsers are ported to not use parsers.PantherLog
 	if result.EventIncludesPantherFields {
 		stream.WriteVal(result.Event)
+		e.writeEnrichment(result, stream, start)
 		return
 	}
 
@@ -115,6 +118,30 @@ func (e *resultEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
 		result.values.Recycle()
 	}
 	result.values = values
+
+	e.writeEnrichment(result, stream, start)
+}
+
+// writeEnrichment extends the JSON object of the event in the stream buffer with the enrichment data of the event.
+func (*resultEncoder) writeEnrichment(r *Result, stream *jsoniter.Stream, start int) {
+	if r.Enricher == nil || stream.Error != nil {
+		return
+	}
+	buf := stream.Buffer()
+	// The stream was flushed while writing the event
+	if start > len(buf) {
+		return
+	}
+	data := r.Enricher.Enrich(r.PantherLogType, buf[start:])
+	if data == nil {
+		return
+	}
+	if !extendJSON(stream.Buffer()) {
+		return
+	}
+	stream.WriteObjectField(FieldEnrichmentJSON)
+	stream.WriteRaw(string(data))
+	stream.WriteObjectEnd()
 }
 
 // writePantherFields extends the JSON object buffer with all required Panther fields.
@@ -324,6 +351,12 @@ func (*pantherExt) updateStringBinding(b *jsoniter.Binding, tag *structtag.Tag,
 			scanner: scanner,
 		}
 		return true
+	case typ.ConvertibleTo(typStringSlice):
+		b.Encoder = &scanStringSliceEncoder{
+			parent:  b.Encoder,
+			scanner: scanner,
+		}
+		return true
 	case reflect.PtrTo(typ).Implements(typStringer):
 		b.Encoder = &scanStringerEncoder{
 			parent:  b.Encoder,
@@ -451,3 +484,30 @@ func (enc *scanStringEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream
 		enc.scanner.ScanValues(values, input)
 	}
 }
+
+type scanStringSliceEncoder struct {
+	parent  jsoniter.ValEncoder
+	scanner ValueScanner
+}
+
+// IsEmpty implements jsoniter.ValEncoder interface
+func (enc *scanStringSliceEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return enc.parent.IsEmpty(ptr)
+}
+
+// Encode implements jsoniter.ValEncoder interface
+func (enc *scanStringSliceEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	enc.parent.Encode(ptr, stream)
+	if stream.Error != nil {
+		return
+	}
+	values, ok := stream.Attachment.(ValueWriter)
+	if !ok {
+		return
+	}
+	for _, input := range *((*[]string)(ptr)) {
+		if input != "" {
+			enc.scanner.ScanValues(values, input)
+		}
+	}
+}
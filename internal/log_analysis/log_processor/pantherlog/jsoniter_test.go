@@ -21,6 +21,7 @@ package pantherlog
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -121,6 +122,26 @@ func TestPantherExt_DecorateEncoder(t *testing.T) {
 	require.Equal(t, `{"foo":"ok","bar":"ok","baz":"ok","qux":"ok","quux":"ok"}`, actual)
 }
 
+func TestPantherExt_StringSlice(t *testing.T) {
+	type T struct {
+		Foo []string `json:"foo" panther:"foo"`
+		Bar []string `json:"bar,omitempty" panther:"bar"`
+	}
+	v := T{
+		Foo: []string{"foo", "", "baz"},
+	}
+	require.Equal(t, FieldSet{kindFoo, kindBar}, FieldSetFromType(reflect.TypeOf(v)))
+	result := Result{
+		values: new(ValueBuffer),
+	}
+	stream := jsoniter.ConfigDefault.BorrowStream(nil)
+	stream.Attachment = &result
+	stream.WriteVal(&v)
+	require.Equal(t, []string{"baz", "foo"}, result.values.Get(kindFoo), "foo")
+	require.Empty(t, result.values.Get(kindBar), "bar")
+	require.Equal(t, `{"foo":["foo","","baz"]}`, string(stream.Buffer()))
+}
+
 func TestResultEncoder(t *testing.T) {
 	now := time.Now()
 	tm := now.Add(-1 * time.Minute)
@@ -159,3 +180,49 @@ func TestResultEncoder(t *testing.T) {
 	}`, tm.In(loc).Format(time.RFC3339Nano), tm.UTC().Format(time.RFC3339Nano), now.UTC().Format(time.RFC3339Nano))
 	assert.JSONEq(expect, actual)
 }
+
+type testEnricher map[string]string
+
+func (e testEnricher) Enrich(_ string, event []byte) []byte {
+	owner, ok := e[jsoniter.Get(event, "remote_ip").ToString()]
+	if !ok {
+		return nil
+	}
+	return []byte(fmt.Sprintf(`{"owners":{"owner":%q}}`, owner))
+}
+
+func TestResultEncoderEnrichment(t *testing.T) {
+	assert := require.New(t)
+	now := time.Now().UTC()
+	type T struct {
+		RemoteIP string `json:"remote_ip" panther:"ip"`
+	}
+	enricher := testEnricher{"2.2.2.2": "alice"}
+	result := Result{
+		CoreFields: CoreFields{
+			PantherLogType:   "Foo.Bar",
+			PantherRowID:     "id",
+			PantherParseTime: now,
+		},
+		Event:    &T{RemoteIP: "2.2.2.2"},
+		Enricher: enricher,
+	}
+	actual, err := jsoniter.MarshalToString(&result)
+	assert.NoError(err)
+	expect := fmt.Sprintf(`{
+		"remote_ip":"2.2.2.2",
+		"p_row_id": "id",
+		"p_event_time": "%s",
+		"p_parse_time": "%s",
+		"p_any_ip_addresses": ["2.2.2.2"],
+		"p_log_type": "Foo.Bar",
+		"p_enrichment": {"owners":{"owner":"alice"}}
+	}`, now.Format(time.RFC3339Nano), now.Format(time.RFC3339Nano))
+	assert.JSONEq(expect, actual)
+
+	// No match
+	result.Event = &T{RemoteIP: "1.1.1.1"}
+	actual, err = jsoniter.MarshalToString(&result)
+	assert.NoError(err)
+	assert.NotContains(actual, FieldEnrichmentJSON)
+}
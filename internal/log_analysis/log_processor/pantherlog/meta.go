@@ -70,6 +70,8 @@ const (
 	FieldAWSInstanceID
 	FieldAWSARN
 	FieldAWSTag
+	FieldEmail
+	FieldUsername
 )
 
 // ScanValues implements ValueScanner interface
@@ -88,6 +90,12 @@ type CoreFields struct {
 	PantherSourceLabel string    `json:"p_source_label,omitempty" description:"Panther added field with the source label"`
 }
 
+// EnrichmentFields are the fields Panther adds to events matched to enrichment data.
+// The fields are written by the Result encoder using the Enricher of the result.
+type EnrichmentFields struct {
+	PantherEnrichment jsoniter.RawMessage `json:"p_enrichment,omitempty" description:"Panther added field with enrichment data matched to the event"`
+}
+
 const (
 	// FieldPrefixJSON is the prefix for field names injected by panther to log events.
 	FieldPrefixJSON    = "p_"
@@ -96,11 +104,14 @@ const (
 	FieldRowIDJSON     = FieldPrefixJSON + "row_id"
 	FieldEventTimeJSON = FieldPrefixJSON + "event_time"
 	FieldParseTimeJSON = FieldPrefixJSON + "parse_time"
+	// FieldEnrichmentJSON is the field holding the enrichment data added to an event, see Enricher
+	FieldEnrichmentJSON = FieldPrefixJSON + "enrichment"
 )
 
 var (
-	typCoreFields  = reflect.TypeOf(CoreFields{})
-	typStringSlice = reflect.TypeOf([]string(nil))
+	typCoreFields       = reflect.TypeOf(CoreFields{})
+	typEnrichmentFields = reflect.TypeOf(EnrichmentFields{})
+	typStringSlice      = reflect.TypeOf([]string(nil))
 	// Registered fields holds the distinct index of field ids to struct fields
 	registeredFields = map[FieldID]reflect.StructField{
 		// Reserve ids for core fields
@@ -126,6 +137,9 @@ var (
 		"PantherLogType":   FieldNone,
 		FieldRowIDJSON:     FieldNone,
 		"PantherRowID":     FieldNone,
+		// Reserve field name for enrichment data
+		FieldEnrichmentJSON: FieldNone,
+		"PantherEnrichment": FieldNone,
 	}
 )
 
@@ -196,6 +210,16 @@ func init() {
 		NameJSON:    "p_any_aws_tags",
 		Description: "Panther added field with collection of AWS Tags associated with the row",
 	})
+	MustRegisterIndicator(FieldEmail, FieldMeta{
+		Name:        "PantherAnyEmails",
+		NameJSON:    "p_any_emails",
+		Description: "Panther added field with collection of email addresses associated with the row",
+	})
+	MustRegisterIndicator(FieldUsername, FieldMeta{
+		Name:        "PantherAnyUsernames",
+		NameJSON:    "p_any_usernames",
+		Description: "Panther added field with collection of usernames associated with the row",
+	})
 	MustRegisterScanner("ip", ValueScannerFunc(ScanIPAddress), FieldIPAddress)
 	MustRegisterScanner("domain", FieldDomainName, FieldDomainName)
 	MustRegisterScanner("md5", FieldMD5Hash, FieldMD5Hash)
@@ -205,6 +229,8 @@ func init() {
 	MustRegisterScanner("url", ValueScannerFunc(ScanURL), FieldDomainName, FieldIPAddress)
 	MustRegisterScanner("trace_id", FieldTraceID, FieldTraceID)
 	MustRegisterScanner("net_addr", ValueScannerFunc(ScanNetworkAddress), FieldIPAddress, FieldDomainName)
+	MustRegisterScanner("email", ValueScannerFunc(ScanEmail), FieldEmail)
+	MustRegisterScanner("username", FieldUsername, FieldUsername)
 }
 
 // MustRegisterIndicator allows modules to define their own indicator fields.
@@ -311,7 +337,8 @@ func BuildEventTypeSchema(eventType reflect.Type, indicators ...FieldID) (reflec
 	if err != nil {
 		return nil, err
 	}
-	fields, _ = extendStructFields(fields, reflect.TypeOf(CoreFields{}))
+	fields, _ = extendStructFields(fields, typCoreFields)
+	fields, _ = extendStructFields(fields, typEnrichmentFields)
 
 	// Auto-detect required field ids
 	if indicators == nil {
@@ -558,6 +585,11 @@ func appendFieldSet(fields FieldSet, field *reflect.StructField) FieldSet {
 		}
 	case reflect.Slice:
 		el := derefType(fieldType.Elem())
+		if el.Kind() == reflect.String {
+			// Scanners can be applied to all values of a string slice
+			tag := string(field.Tag)
+			return fields.Extend(FieldSetFromTag(tag)...)
+		}
 		return fields.Extend(FieldSetFromType(el)...)
 	case reflect.String:
 		tag := string(field.Tag)
@@ -47,6 +47,7 @@ func TestMetaEventStruct(t *testing.T) {
 		{"p_row_id", "string", "Panther added field with unique id (within table)", true},
 		{"p_source_id", "string", "Panther added field with the source id", false},
 		{"p_source_label", "string", "Panther added field with the source label", false},
+		{"p_enrichment", "string", "Panther added field with enrichment data matched to the event", false},
 		{"p_any_ip_addresses", "array<string>", "Panther added field with collection of ip addresses associated with the row", false},
 	}, columns)
 }
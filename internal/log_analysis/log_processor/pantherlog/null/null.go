@@ -83,6 +83,7 @@ func RegisterValidators(validate *validator.Validate) {
 		Int64{}, Int32{}, Int16{}, Int8{},
 		Uint64{}, Uint32{}, Uint16{}, Uint8{},
 	)
+	validate.RegisterCustomTypeFunc(ValidateNullBool, Bool{})
 }
 
 func ValidateNullType(val reflect.Value) interface{} {
@@ -92,6 +93,15 @@ func ValidateNullType(val reflect.Value) interface{} {
 	return nil
 }
 
+// ValidateNullBool returns a pointer to the value of a Bool so that `required` checks that the value is set.
+// Unlike other types, the zero value (false) of a boolean field is a valid value.
+func ValidateNullBool(val reflect.Value) interface{} {
+	if b := val.Interface().(Bool); b.Exists {
+		return &b.Value
+	}
+	return nil
+}
+
 func unquoteJSON(data []byte) []byte {
 	if len(data) > 1 && data[0] == '"' {
 		data = data[1:]
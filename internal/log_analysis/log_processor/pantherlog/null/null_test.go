@@ -53,6 +53,18 @@ func TestRegisterValidators(t *testing.T) {
 	}))
 }
 
+func TestRegisterValidatorsBool(t *testing.T) {
+	v := validator.New()
+	RegisterValidators(v)
+	type T struct {
+		Required Bool `validate:"required"`
+	}
+	require.Error(t, v.Struct(T{}))
+	// false is a valid value
+	require.NoError(t, v.Struct(T{Required: FromBool(false)}))
+	require.NoError(t, v.Struct(T{Required: FromBool(true)}))
+}
+
 type unmarshalTest struct {
 	Name    string
 	Input   string
@@ -35,12 +35,22 @@ type Result struct {
 	// to avoid duplicate panther fields in resulting JSON.
 	// FIXME: Remove this field once all parsers are ported to the new method.
 	EventIncludesPantherFields bool
+	// Enricher adds enrichment data to the event when it is encoded to JSON.
+	// If nil, no enrichment data are added.
+	Enricher Enricher
 	// Collected indicator values for this result.
 	// This field is normally nil throughout the lifetime of results.
 	// It is populated temporarily by the custom jsoniter encoder for *Result to collect all indicator field values.
 	values *ValueBuffer
 }
 
+// Enricher matches events to enrichment data.
+type Enricher interface {
+	// Enrich returns a JSON object with the enrichment data for an event or nil if there is no data.
+	// The event argument holds the JSON object of the event including all Panther fields.
+	Enrich(logType string, event []byte) []byte
+}
+
 // WriteValues implements ValueWriter interface
 func (r *Result) WriteValues(kind FieldID, values ...string) {
 	if r.values == nil {
@@ -154,6 +154,19 @@ func checkIPAddress(addr string) bool {
 	return net.ParseIP(addr) != nil
 }
 
+// ScanEmail scans `input` for an email address value.
+// Display names are removed from addresses in the `Name <user@example.com>` form.
+func ScanEmail(w ValueWriter, input string) {
+	input = strings.TrimSpace(input)
+	if start := strings.LastIndexByte(input, '<'); start != -1 && strings.HasSuffix(input, ">") {
+		input = input[start+1 : len(input)-1]
+	}
+	if pos := strings.LastIndexByte(input, '@'); pos <= 0 || pos == len(input)-1 {
+		return
+	}
+	w.WriteValues(FieldEmail, input)
+}
+
 // Tries to split host:port address or falls back to Hostname scanning if `:` is not present in input
 func ScanNetworkAddress(w ValueWriter, input string) {
 	if host, _, err := net.SplitHostPort(input); err == nil {
@@ -17,3 +17,25 @@ package pantherlog
  * You should have received a copy of the GNU Affero General Public License
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanEmail(t *testing.T) {
+	for input, expect := range map[string][]string{
+		"jdoe@example.com":            {"jdoe@example.com"},
+		" jdoe@example.com ":          {"jdoe@example.com"},
+		"John Doe <jdoe@example.com>": {"jdoe@example.com"},
+		"jdoe":                        nil,
+		"@example.com":                nil,
+		"jdoe@":                       nil,
+		"":                            nil,
+	} {
+		b := ValueBuffer{}
+		ScanEmail(&b, input)
+		require.Equal(t, expect, b.Get(FieldEmail), input)
+	}
+}
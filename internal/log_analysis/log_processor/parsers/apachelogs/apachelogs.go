@@ -27,12 +27,14 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 const (
 	TypeAccessCombined = `Apache.AccessCombined`
 	TypeAccessCommon   = `Apache.AccessCommon`
+	TypeError          = `Apache.Error`
 )
 
 func init() {
@@ -51,10 +53,19 @@ func init() {
 			Schema:       AccessCommon{},
 			NewParser:    parsers.AdapterFactory(NewAccessCommonParser()),
 		},
+		logtypes.Config{
+			Name: TypeError,
+			Description: `Apache HTTP server error logs using the default ErrorLogFormat.
+NOTE: Apache does not log the time zone of error log entries so timestamps are assumed to be UTC.`,
+			ReferenceURL: `https://httpd.apache.org/docs/current/logs.html#errorlog`,
+			Schema:       pantherlog.MustBuildEventSchema(&Error{}),
+			NewParser:    parsers.FactoryFunc(NewErrorParser),
+		},
 	)
 }
 
-// 	[day/month/year:hour:minute:second zone]
+//	[day/month/year:hour:minute:second zone]
+//
 // day = 2*digit
 // month = 3*letter
 // year = 4*digit
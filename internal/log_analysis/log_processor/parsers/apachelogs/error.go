@@ -0,0 +1,160 @@
+package apachelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Apache does not log the time zone of error log timestamps.
+// Fractional seconds logged by Apache 2.4 are accepted by time.Parse even though the layout does not include them.
+const layoutErrorTime = `Mon Jan _2 15:04:05 2006`
+
+// Error is an Apache HTTP server error log entry using the default ErrorLogFormat
+//
+//	[Thu Oct 15 10:20:30.123456 2020] [core:error] [pid 1234:tid 140234567890] [client 192.0.2.10:51234] AH00126: Invalid URI in request GET /../.. HTTP/1.1
+//	[Thu Oct 15 10:20:30 2020] [error] [client 192.0.2.10] File does not exist: /var/www/favicon.ico, referer: http://example.com/
+//
+// nolint:lll
+type Error struct {
+	Time       time.Time   `json:"time" tcodec:"rfc3339" panther:"event_time" validate:"required" description:"The time the error was logged (UTC)."`
+	Module     null.String `json:"module" description:"The name of the module that logged the error (Apache 2.4)."`
+	Level      null.String `json:"level" validate:"required" description:"The severity level of the error."`
+	PID        null.Int32  `json:"pid" description:"The process id of the child process that logged the error."`
+	TID        null.Int64  `json:"tid" description:"The thread id of the thread that logged the error."`
+	Client     null.String `json:"client" panther:"hostname" description:"The address of the client (or its host name if HostnameLookups is On)."`
+	ClientPort null.Uint16 `json:"client_port" description:"The port of the client (Apache 2.4)."`
+	ErrorCode  null.String `json:"error_code" description:"The APR error code of the message (ie AH00126)."`
+	Message    null.String `json:"message" description:"The error message."`
+	Referer    null.String `json:"referer" panther:"url" description:"The Referer header of the client request."`
+}
+
+var rxError = regexp.MustCompile(`^\[([^\]]+)\] \[(?:([^\]:]*):)?([^\]:]+)\]` + // time and module:level
+	`(?: \[pid (\d+)(?::tid (\d+))?\])?` + // optional pid and tid
+	`(?: \[client ([^\]]+)\])?` + // optional client address
+	`\s*(?:(AH\d+): )?(.*)$`) // optional error code and message
+
+// ParseString parses an Apache error log line
+func (event *Error) ParseString(s string) error {
+	match := rxError.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return errors.New("invalid error log format")
+	}
+	// Assignment in single line right after the match avoids bounds checks on fields
+	tm, module, level, pid, tid, client, code, message := match[1], match[2], match[3], match[4], match[5], match[6], match[7], match[8]
+	ts, err := time.Parse(layoutErrorTime, tm)
+	if err != nil {
+		return err
+	}
+	*event = Error{
+		Time:      ts,
+		Module:    nonEmptyString(module),
+		Level:     null.FromString(level),
+		ErrorCode: nonEmptyString(code),
+	}
+	if pid != "" {
+		n, err := strconv.ParseInt(pid, 10, 32)
+		if err != nil {
+			return err
+		}
+		event.PID = null.FromInt32(int32(n))
+	}
+	if tid != "" {
+		n, err := strconv.ParseInt(tid, 10, 64)
+		if err != nil {
+			return err
+		}
+		event.TID = null.FromInt64(n)
+	}
+	if client != "" {
+		host, port := splitClientAddress(client)
+		event.Client = null.FromString(host)
+		if port != "" {
+			n, err := strconv.ParseUint(port, 10, 16)
+			if err != nil {
+				return err
+			}
+			event.ClientPort = null.FromUint16(uint16(n))
+		}
+	}
+	// Apache appends the referer to messages logged while processing a request
+	if pos := strings.LastIndex(message, ", referer: "); pos != -1 {
+		event.Referer = nonEmptyString(message[pos+len(", referer: "):])
+		message = message[:pos]
+	}
+	event.Message = null.FromString(message)
+	return nil
+}
+
+// splitClientAddress splits a client address in the `%a:%{c}p` form used by Apache 2.4.
+// IPv6 addresses are not enclosed in brackets so addresses that are valid IPs are returned as-is.
+func splitClientAddress(addr string) (host, port string) {
+	if net.ParseIP(addr) != nil {
+		return addr, ""
+	}
+	if pos := strings.LastIndexByte(addr, ':'); pos != -1 {
+		return addr[:pos], addr[pos+1:]
+	}
+	return addr, ""
+}
+
+func nonEmptyString(s string) null.String {
+	if s == "" {
+		return null.String{}
+	}
+	return null.FromString(s)
+}
+
+// ErrorParser parses Apache error logs
+type ErrorParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*ErrorParser)(nil)
+
+// NewErrorParser creates a new Apache error log parser
+func NewErrorParser(_ interface{}) (parsers.Interface, error) {
+	return &ErrorParser{}, nil
+}
+
+// ParseLog implements parsers.Interface
+func (p *ErrorParser) ParseLog(log string) ([]*parsers.Result, error) {
+	event := Error{}
+	if err := event.ParseString(log); err != nil {
+		return nil, err
+	}
+	if err := parsers.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeError, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
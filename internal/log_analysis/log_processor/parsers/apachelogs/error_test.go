@@ -0,0 +1,118 @@
+package apachelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestError(t *testing.T) {
+	// nolint:lll
+	input := `[Thu Oct 15 10:20:30.123456 2020] [core:error] [pid 1234:tid 140234567890] [client 192.0.2.10:51234] AH00126: Invalid URI in request GET /../../etc/passwd HTTP/1.1`
+	// nolint:lll
+	expect := `{
+	  "time": "2020-10-15T10:20:30.123456Z",
+	  "module": "core",
+	  "level": "error",
+	  "pid": 1234,
+	  "tid": 140234567890,
+	  "client": "192.0.2.10",
+	  "client_port": 51234,
+	  "error_code": "AH00126",
+	  "message": "Invalid URI in request GET /../../etc/passwd HTTP/1.1",
+	  "p_log_type": "Apache.Error",
+	  "p_event_time": "2020-10-15T10:20:30.123456Z",
+	  "p_any_ip_addresses": [
+	    "192.0.2.10"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeError, input, expect)
+}
+
+func TestErrorApache22(t *testing.T) {
+	// Apache 2.2 format without module, pid and client port
+	// nolint:lll
+	input := `[Thu Oct 15 10:20:31 2020] [error] [client 192.0.2.11] File does not exist: /var/www/html/favicon.ico, referer: http://www.example.com/index.html`
+	// nolint:lll
+	expect := `{
+	  "time": "2020-10-15T10:20:31Z",
+	  "level": "error",
+	  "client": "192.0.2.11",
+	  "message": "File does not exist: /var/www/html/favicon.ico",
+	  "referer": "http://www.example.com/index.html",
+	  "p_log_type": "Apache.Error",
+	  "p_event_time": "2020-10-15T10:20:31Z",
+	  "p_any_domain_names": [
+	    "www.example.com"
+	  ],
+	  "p_any_ip_addresses": [
+	    "192.0.2.11"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeError, input, expect)
+}
+
+func TestErrorModSecurityIPv6(t *testing.T) {
+	// nolint:lll
+	input := `[Thu Oct 15 10:20:32.000001 2020] [:error] [pid 2048] [client 2001:db8::10:51235] [client 2001:db8::10] ModSecurity: Access denied with code 403 (phase 2). Pattern match "(?i:union\\s+select)" at ARGS:q. [file "/etc/modsecurity/rules/sqli.conf"] [line "12"] [id "942100"] [msg "SQL Injection Attack Detected"] [severity "CRITICAL"] [hostname "www.example.com"] [uri "/search"] [unique_id "X4gXbn8AAQEAAAhQ"]`
+	// nolint:lll
+	expect := `{
+	  "time": "2020-10-15T10:20:32.000001Z",
+	  "level": "error",
+	  "pid": 2048,
+	  "client": "2001:db8::10",
+	  "client_port": 51235,
+	  "message": "[client 2001:db8::10] ModSecurity: Access denied with code 403 (phase 2). Pattern match \"(?i:union\\\\s+select)\" at ARGS:q. [file \"/etc/modsecurity/rules/sqli.conf\"] [line \"12\"] [id \"942100\"] [msg \"SQL Injection Attack Detected\"] [severity \"CRITICAL\"] [hostname \"www.example.com\"] [uri \"/search\"] [unique_id \"X4gXbn8AAQEAAAhQ\"]",
+	  "p_log_type": "Apache.Error",
+	  "p_event_time": "2020-10-15T10:20:32.000001Z",
+	  "p_any_ip_addresses": [
+	    "2001:db8::10"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeError, input, expect)
+}
+
+func TestErrorWithoutClient(t *testing.T) {
+	// nolint:lll
+	input := `[Thu Oct  5 10:20:33 2020] [mpm_event:notice] [pid 1:tid 140001] AH00489: Apache/2.4.46 (Unix) configured -- resuming normal operations`
+	// nolint:lll
+	expect := `{
+	  "time": "2020-10-05T10:20:33Z",
+	  "module": "mpm_event",
+	  "level": "notice",
+	  "pid": 1,
+	  "tid": 140001,
+	  "error_code": "AH00489",
+	  "message": "Apache/2.4.46 (Unix) configured -- resuming normal operations",
+	  "p_log_type": "Apache.Error",
+	  "p_event_time": "2020-10-05T10:20:33Z"
+	}`
+	testutil.CheckRegisteredParser(t, TypeError, input, expect)
+}
+
+func TestErrorInvalid(t *testing.T) {
+	p, err := NewErrorParser(nil)
+	require.NoError(t, err)
+	_, err = p.ParseLog(`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`)
+	require.Error(t, err)
+}
@@ -21,19 +21,25 @@ package awslogs
 
 import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 const (
-	TypeALB               = "AWS.ALB"
-	TypeAuroraMySQLAudit  = `AWS.AuroraMySQLAudit`
-	TypeCloudTrail        = `AWS.CloudTrail`
-	TypeCloudTrailDigest  = "AWS.CloudTrailDigest"
-	TypeCloudTrailInsight = "AWS.CloudTrailInsight"
-	TypeCloudWatchEvents  = "AWS.CloudWatchEvents"
-	TypeGuardDuty         = "AWS.GuardDuty"
-	TypeS3ServerAccess    = "AWS.S3ServerAccess"
-	TypeVPCFlow           = "AWS.VPCFlow"
+	TypeALB                     = "AWS.ALB"
+	TypeAuroraMySQLAudit        = `AWS.AuroraMySQLAudit`
+	TypeCloudTrail              = `AWS.CloudTrail`
+	TypeCloudTrailDigest        = "AWS.CloudTrailDigest"
+	TypeCloudTrailInsight       = "AWS.CloudTrailInsight"
+	TypeCloudWatchEvents        = "AWS.CloudWatchEvents"
+	TypeGuardDuty               = "AWS.GuardDuty"
+	TypeNetworkFirewallAlert    = "AWS.NetworkFirewallAlert"
+	TypeNetworkFirewallFlow     = "AWS.NetworkFirewallFlow"
+	TypeRoute53ResolverQueryLog = "AWS.Route53ResolverQueryLog"
+	TypeS3ServerAccess          = "AWS.S3ServerAccess"
+	TypeSecurityHubFinding      = "AWS.SecurityHubFinding"
+	TypeVPCFlow                 = "AWS.VPCFlow"
+	TypeWAFWebACL               = "AWS.WAFWebACL"
 )
 
 // nolint:lll
@@ -88,6 +94,27 @@ func init() {
 			Schema:       GuardDuty{},
 			NewParser:    parsers.AdapterFactory(&GuardDutyParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeNetworkFirewallAlert,
+			Description:  `AWS Network Firewall alert logs report traffic that matches stateful rules with an alert or drop action.`,
+			ReferenceURL: `https://docs.aws.amazon.com/network-firewall/latest/developerguide/firewall-logging.html`,
+			Schema:       NetworkFirewallAlert{},
+			NewParser:    parsers.AdapterFactory(&NetworkFirewallAlertParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeNetworkFirewallFlow,
+			Description:  `AWS Network Firewall flow logs report the network traffic flows forwarded to the stateful rules engine.`,
+			ReferenceURL: `https://docs.aws.amazon.com/network-firewall/latest/developerguide/firewall-logging.html`,
+			Schema:       NetworkFirewallFlow{},
+			NewParser:    parsers.AdapterFactory(&NetworkFirewallFlowParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeRoute53ResolverQueryLog,
+			Description:  `Route 53 Resolver query logs contain the DNS queries made by resources within your VPCs.`,
+			ReferenceURL: `https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/resolver-query-logs-format.html`,
+			Schema:       Route53ResolverQueryLog{},
+			NewParser:    parsers.AdapterFactory(&Route53ResolverQueryLogParser{}),
+		},
 		logtypes.Config{
 			Name:         TypeS3ServerAccess,
 			Description:  `S3ServerAccess is an AWS S3 Access Log.`,
@@ -95,6 +122,14 @@ func init() {
 			Schema:       S3ServerAccess{},
 			NewParser:    parsers.AdapterFactory(&S3ServerAccessParser{}),
 		},
+		logtypes.Config{
+			Name: TypeSecurityHubFinding,
+			Description: `AWS Security Hub findings in the AWS Security Finding Format (ASFF).
+NOTE: Findings wrapped in the EventBridge (CloudWatch Events) events emitted by Security Hub are also supported.`,
+			ReferenceURL: `https://docs.aws.amazon.com/securityhub/latest/userguide/securityhub-findings-format.html`,
+			Schema:       pantherlog.MustBuildEventSchema(&SecurityHubFinding{}, securityHubIndicators...),
+			NewParser:    parsers.FactoryFunc(NewSecurityHubFindingParser),
+		},
 		logtypes.Config{
 			Name:         TypeVPCFlow,
 			Description:  `VPCFlow is a VPC NetFlow log, which is a layer 3 representation of network traffic in EC2.`,
@@ -102,5 +137,12 @@ func init() {
 			Schema:       VPCFlow{},
 			NewParser:    parsers.AdapterFactory(&VPCFlowParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeWAFWebACL,
+			Description:  `AWS WAF web ACL traffic logs contain information about the web requests inspected by a web ACL.`,
+			ReferenceURL: `https://docs.aws.amazon.com/waf/latest/developerguide/logging-fields.html`,
+			Schema:       WAFWebACL{},
+			NewParser:    parsers.AdapterFactory(&WAFWebACLParser{}),
+		},
 	)
 }
@@ -0,0 +1,95 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+func TestScanARN(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  string
+		Expect map[pantherlog.FieldID][]string
+	}
+	for _, tc := range []testCase{
+		{
+			// CloudTrail userIdentity.arn
+			Name:  "AssumedRole",
+			Input: "arn:aws:sts::888888888888:assumed-role/panther-log-processing-role/1567022003962",
+			Expect: map[pantherlog.FieldID][]string{
+				pantherlog.FieldAWSARN:       {"arn:aws:sts::888888888888:assumed-role/panther-log-processing-role/1567022003962"},
+				pantherlog.FieldAWSAccountID: {"888888888888"},
+			},
+		},
+		{
+			// CloudTrail resources[].ARN
+			Name:  "EC2Instance",
+			Input: "arn:aws:ec2:us-west-2:888888888888:instance/i-081de1d7604b11e4a",
+			Expect: map[pantherlog.FieldID][]string{
+				pantherlog.FieldAWSARN:        {"arn:aws:ec2:us-west-2:888888888888:instance/i-081de1d7604b11e4a"},
+				pantherlog.FieldAWSAccountID:  {"888888888888"},
+				pantherlog.FieldAWSInstanceID: {"i-081de1d7604b11e4a"},
+			},
+		},
+		{
+			// GuardDuty resource.instanceDetails.iamInstanceProfile.arn
+			Name:  "InstanceProfile",
+			Input: "arn:aws:iam::123456789012:instance-profile/EC2Role",
+			Expect: map[pantherlog.FieldID][]string{
+				pantherlog.FieldAWSARN:       {"arn:aws:iam::123456789012:instance-profile/EC2Role"},
+				pantherlog.FieldAWSAccountID: {"123456789012"},
+			},
+		},
+		{
+			// GuardDuty arn
+			Name:  "GuardDutyFinding",
+			Input: "arn:aws:guardduty:us-west-2:123456789012:detector/b2b7c4e8df224d2b74bae14ad5d8b4a2/finding/44b7c4e9781822beb75d3fd2b6e9f33b",
+			Expect: map[pantherlog.FieldID][]string{
+				pantherlog.FieldAWSARN: {
+					"arn:aws:guardduty:us-west-2:123456789012:detector/b2b7c4e8df224d2b74bae14ad5d8b4a2/finding/44b7c4e9781822beb75d3fd2b6e9f33b",
+				},
+				pantherlog.FieldAWSAccountID: {"123456789012"},
+			},
+		},
+		{
+			Name:  "EC2InstanceTrailingSlash",
+			Input: "arn:aws:ec2:us-west-2:888888888888:instance/",
+			Expect: map[pantherlog.FieldID][]string{
+				pantherlog.FieldAWSARN:       {"arn:aws:ec2:us-west-2:888888888888:instance/"},
+				pantherlog.FieldAWSAccountID: {"888888888888"},
+			},
+		},
+		{
+			Name:  "NotAnARN",
+			Input: "instance/i-081de1d7604b11e4a",
+		},
+	} {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			values := pantherlog.ValueBuffer{}
+			ScanARN(&values, tc.Input)
+			require.Equal(t, tc.Expect, values.Inspect())
+		})
+	}
+}
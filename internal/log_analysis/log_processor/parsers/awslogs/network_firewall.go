@@ -0,0 +1,221 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// NetworkFirewallAlert is an AWS Network Firewall alert log record.
+// The event field holds a Suricata EVE JSON alert event produced by the stateful rules engine.
+// nolint:lll
+type NetworkFirewallAlert struct {
+	FirewallName     *string                    `json:"firewall_name" validate:"required" description:"The name of the firewall associated with the log."`
+	AvailabilityZone *string                    `json:"availability_zone" validate:"required" description:"The Availability Zone of the firewall endpoint that generated the log."`
+	EventTimestamp   *numerics.Int64            `json:"event_timestamp" validate:"required" description:"The time the log was created, in seconds since the epoch."`
+	Event            *NetworkFirewallAlertEvent `json:"event" validate:"required" description:"The Suricata EVE alert event."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// nolint:lll
+type NetworkFirewallAlertEvent struct {
+	NetworkFirewallEventHeader
+	EventType *string                     `json:"event_type" validate:"required,eq=alert" description:"The Suricata event type."`
+	Alert     *NetworkFirewallAlertDetail `json:"alert" validate:"required" description:"The details of the rule that matched the traffic."`
+	AppProto  *string                     `json:"app_proto,omitempty" description:"The application layer protocol."`
+	HTTP      *NetworkFirewallHTTP        `json:"http,omitempty" description:"The HTTP request that triggered the alert."`
+	TLS       *NetworkFirewallTLS         `json:"tls,omitempty" description:"The TLS handshake that triggered the alert."`
+}
+
+// nolint:lll
+type NetworkFirewallAlertDetail struct {
+	Action      *string `json:"action,omitempty" description:"The action taken by the rule (allowed or blocked)."`
+	SignatureID *int64  `json:"signature_id,omitempty" description:"The signature id of the rule."`
+	Rev         *int    `json:"rev,omitempty" description:"The revision of the rule."`
+	Signature   *string `json:"signature,omitempty" description:"The message of the rule."`
+	Category    *string `json:"category,omitempty" description:"The classification of the rule."`
+	Severity    *int    `json:"severity,omitempty" description:"The severity of the rule."`
+}
+
+// nolint:lll
+type NetworkFirewallHTTP struct {
+	Hostname  *string `json:"hostname,omitempty" description:"The value of the Host header."`
+	URL       *string `json:"url,omitempty" description:"The request URL."`
+	UserAgent *string `json:"http_user_agent,omitempty" description:"The value of the User-Agent header."`
+	Method    *string `json:"http_method,omitempty" description:"The request method."`
+	Protocol  *string `json:"protocol,omitempty" description:"The protocol version of the request."`
+	Length    *int64  `json:"length,omitempty" description:"The size of the response body in bytes."`
+}
+
+// nolint:lll
+type NetworkFirewallTLS struct {
+	SNI     *string `json:"sni,omitempty" description:"The Server Name Indication sent by the client."`
+	Subject *string `json:"subject,omitempty" description:"The subject of the server certificate."`
+	Version *string `json:"version,omitempty" description:"The TLS version."`
+}
+
+// NetworkFirewallFlow is an AWS Network Firewall flow log record.
+// The event field holds a Suricata EVE JSON netflow event produced by the stateful rules engine.
+// nolint:lll
+type NetworkFirewallFlow struct {
+	FirewallName     *string                   `json:"firewall_name" validate:"required" description:"The name of the firewall associated with the log."`
+	AvailabilityZone *string                   `json:"availability_zone" validate:"required" description:"The Availability Zone of the firewall endpoint that generated the log."`
+	EventTimestamp   *numerics.Int64           `json:"event_timestamp" validate:"required" description:"The time the log was created, in seconds since the epoch."`
+	Event            *NetworkFirewallFlowEvent `json:"event" validate:"required" description:"The Suricata EVE netflow event."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// nolint:lll
+type NetworkFirewallFlowEvent struct {
+	NetworkFirewallEventHeader
+	EventType *string                    `json:"event_type" validate:"required,eq=netflow" description:"The Suricata event type."`
+	Netflow   *NetworkFirewallNetflow    `json:"netflow" validate:"required" description:"The flow counters."`
+	TCP       *NetworkFirewallTCPDetails `json:"tcp,omitempty" description:"The TCP flags seen in the flow."`
+	AppProto  *string                    `json:"app_proto,omitempty" description:"The application layer protocol."`
+}
+
+// nolint:lll
+type NetworkFirewallNetflow struct {
+	Pkts   *int64                       `json:"pkts,omitempty" description:"The number of packets in the flow."`
+	Bytes  *int64                       `json:"bytes,omitempty" description:"The number of bytes in the flow."`
+	Start  *timestamp.SuricataTimestamp `json:"start,omitempty" description:"The time of the first packet of the flow."`
+	End    *timestamp.SuricataTimestamp `json:"end,omitempty" description:"The time of the last packet of the flow."`
+	Age    *int64                       `json:"age,omitempty" description:"The duration of the flow in seconds."`
+	MinTTL *int                         `json:"min_ttl,omitempty" description:"The minimum TTL of the packets in the flow."`
+	MaxTTL *int                         `json:"max_ttl,omitempty" description:"The maximum TTL of the packets in the flow."`
+}
+
+// nolint:lll
+type NetworkFirewallTCPDetails struct {
+	TCPFlags *string `json:"tcp_flags,omitempty" description:"The TCP flags seen in the flow (hex)."`
+	SYN      *bool   `json:"syn,omitempty" description:"Whether the SYN flag was seen."`
+	FIN      *bool   `json:"fin,omitempty" description:"Whether the FIN flag was seen."`
+	RST      *bool   `json:"rst,omitempty" description:"Whether the RST flag was seen."`
+	PSH      *bool   `json:"psh,omitempty" description:"Whether the PSH flag was seen."`
+	ACK      *bool   `json:"ack,omitempty" description:"Whether the ACK flag was seen."`
+	URG      *bool   `json:"urg,omitempty" description:"Whether the URG flag was seen."`
+}
+
+// NetworkFirewallEventHeader holds the Suricata EVE fields common to alert and flow events
+// nolint:lll
+type NetworkFirewallEventHeader struct {
+	Timestamp *timestamp.SuricataTimestamp `json:"timestamp" validate:"required" description:"The time the event was generated by the rules engine."`
+	FlowID    *int64                       `json:"flow_id,omitempty" description:"The id of the flow. Use it to correlate alert and flow logs."`
+	SrcIP     *string                      `json:"src_ip,omitempty" description:"The source IP address."`
+	SrcPort   *uint16                      `json:"src_port,omitempty" description:"The source port."`
+	DestIP    *string                      `json:"dest_ip,omitempty" description:"The destination IP address."`
+	DestPort  *uint16                      `json:"dest_port,omitempty" description:"The destination port."`
+	Proto     *string                      `json:"proto,omitempty" description:"The transport protocol."`
+}
+
+// NetworkFirewallAlertParser parses AWS Network Firewall alert logs
+type NetworkFirewallAlertParser struct{}
+
+var _ parsers.LogParser = (*NetworkFirewallAlertParser)(nil)
+
+func (p *NetworkFirewallAlertParser) New() parsers.LogParser {
+	return &NetworkFirewallAlertParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *NetworkFirewallAlertParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &NetworkFirewallAlert{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *NetworkFirewallAlertParser) LogType() string {
+	return TypeNetworkFirewallAlert
+}
+
+func (event *NetworkFirewallAlert) updatePantherFields(p *NetworkFirewallAlertParser) {
+	if event.Event == nil {
+		event.SetCoreFields(p.LogType(), nil, event)
+		return
+	}
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Event.Timestamp), event)
+	event.AppendAnyIPAddressPtr(event.Event.SrcIP)
+	event.AppendAnyIPAddressPtr(event.Event.DestIP)
+	if event.Event.HTTP != nil && event.Event.HTTP.Hostname != nil {
+		if !event.AppendAnyIPAddress(*event.Event.HTTP.Hostname) {
+			event.AppendAnyDomainNamePtrs(event.Event.HTTP.Hostname)
+		}
+	}
+	if event.Event.TLS != nil {
+		event.AppendAnyDomainNamePtrs(event.Event.TLS.SNI)
+	}
+}
+
+// NetworkFirewallFlowParser parses AWS Network Firewall flow logs
+type NetworkFirewallFlowParser struct{}
+
+var _ parsers.LogParser = (*NetworkFirewallFlowParser)(nil)
+
+func (p *NetworkFirewallFlowParser) New() parsers.LogParser {
+	return &NetworkFirewallFlowParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *NetworkFirewallFlowParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &NetworkFirewallFlow{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *NetworkFirewallFlowParser) LogType() string {
+	return TypeNetworkFirewallFlow
+}
+
+func (event *NetworkFirewallFlow) updatePantherFields(p *NetworkFirewallFlowParser) {
+	if event.Event == nil {
+		event.SetCoreFields(p.LogType(), nil, event)
+		return
+	}
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.Event.Timestamp), event)
+	event.AppendAnyIPAddressPtr(event.Event.SrcIP)
+	event.AppendAnyIPAddressPtr(event.Event.DestIP)
+}
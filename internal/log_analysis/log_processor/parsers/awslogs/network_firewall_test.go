@@ -0,0 +1,154 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestNetworkFirewallAlertLog(t *testing.T) {
+	//nolint:lll
+	log := `{"firewall_name":"test-firewall","availability_zone":"us-east-1b","event_timestamp":"1602627001","event":{"timestamp":"2020-10-13T22:10:01.006481+0000","flow_id":1582438383425873,"event_type":"alert","src_ip":"203.0.113.4","src_port":55555,"dest_ip":"192.0.2.16","dest_port":443,"proto":"TCP","alert":{"action":"blocked","signature_id":5,"rev":0,"signature":"matching TLS denylisted FQDNs","category":"","severity":1},"app_proto":"tls","tls":{"sni":"evil.example.com","version":"UNDETERMINED"}}}`
+
+	expectedTime := time.Date(2020, 10, 13, 22, 10, 1, 6481000, time.UTC)
+	expectedEvent := &NetworkFirewallAlert{
+		FirewallName:     aws.String("test-firewall"),
+		AvailabilityZone: aws.String("us-east-1b"),
+		EventTimestamp:   (*numerics.Int64)(aws.Int64(1602627001)),
+		Event: &NetworkFirewallAlertEvent{
+			NetworkFirewallEventHeader: NetworkFirewallEventHeader{
+				Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+				FlowID:    aws.Int64(1582438383425873),
+				SrcIP:     aws.String("203.0.113.4"),
+				SrcPort:   aws.Uint16(55555),
+				DestIP:    aws.String("192.0.2.16"),
+				DestPort:  aws.Uint16(443),
+				Proto:     aws.String("TCP"),
+			},
+			EventType: aws.String("alert"),
+			Alert: &NetworkFirewallAlertDetail{
+				Action:      aws.String("blocked"),
+				SignatureID: aws.Int64(5),
+				Rev:         aws.Int(0),
+				Signature:   aws.String("matching TLS denylisted FQDNs"),
+				Category:    aws.String(""),
+				Severity:    aws.Int(1),
+			},
+			AppProto: aws.String("tls"),
+			TLS: &NetworkFirewallTLS{
+				SNI:     aws.String("evil.example.com"),
+				Version: aws.String("UNDETERMINED"),
+			},
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.NetworkFirewallAlert")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("203.0.113.4")
+	expectedEvent.AppendAnyIPAddress("192.0.2.16")
+	expectedEvent.AppendAnyDomainNames("evil.example.com")
+
+	checkNetworkFirewallAlertLog(t, log, expectedEvent)
+}
+
+func TestNetworkFirewallAlertLogType(t *testing.T) {
+	parser := &NetworkFirewallAlertParser{}
+	require.Equal(t, "AWS.NetworkFirewallAlert", parser.LogType())
+}
+
+func TestNetworkFirewallFlowLog(t *testing.T) {
+	//nolint:lll
+	log := `{"firewall_name":"test-firewall","availability_zone":"us-east-1b","event_timestamp":"1602624500","event":{"timestamp":"2020-10-13T21:28:20.000000+0000","flow_id":1662341345762012,"event_type":"netflow","src_ip":"203.0.113.4","src_port":55555,"dest_ip":"192.0.2.16","dest_port":111,"proto":"TCP","netflow":{"pkts":1,"bytes":60,"start":"2020-10-13T21:27:19.880120+0000","end":"2020-10-13T21:27:19.880120+0000","age":0,"min_ttl":59,"max_ttl":59},"tcp":{"tcp_flags":"02","syn":true}}}`
+
+	expectedTime := time.Date(2020, 10, 13, 21, 28, 20, 0, time.UTC)
+	flowTime := time.Date(2020, 10, 13, 21, 27, 19, 880120000, time.UTC)
+	expectedEvent := &NetworkFirewallFlow{
+		FirewallName:     aws.String("test-firewall"),
+		AvailabilityZone: aws.String("us-east-1b"),
+		EventTimestamp:   (*numerics.Int64)(aws.Int64(1602624500)),
+		Event: &NetworkFirewallFlowEvent{
+			NetworkFirewallEventHeader: NetworkFirewallEventHeader{
+				Timestamp: (*timestamp.SuricataTimestamp)(&expectedTime),
+				FlowID:    aws.Int64(1662341345762012),
+				SrcIP:     aws.String("203.0.113.4"),
+				SrcPort:   aws.Uint16(55555),
+				DestIP:    aws.String("192.0.2.16"),
+				DestPort:  aws.Uint16(111),
+				Proto:     aws.String("TCP"),
+			},
+			EventType: aws.String("netflow"),
+			Netflow: &NetworkFirewallNetflow{
+				Pkts:   aws.Int64(1),
+				Bytes:  aws.Int64(60),
+				Start:  (*timestamp.SuricataTimestamp)(&flowTime),
+				End:    (*timestamp.SuricataTimestamp)(&flowTime),
+				Age:    aws.Int64(0),
+				MinTTL: aws.Int(59),
+				MaxTTL: aws.Int(59),
+			},
+			TCP: &NetworkFirewallTCPDetails{
+				TCPFlags: aws.String("02"),
+				SYN:      aws.Bool(true),
+			},
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.NetworkFirewallFlow")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("203.0.113.4")
+	expectedEvent.AppendAnyIPAddress("192.0.2.16")
+
+	checkNetworkFirewallFlowLog(t, log, expectedEvent)
+}
+
+func TestNetworkFirewallFlowLogType(t *testing.T) {
+	parser := &NetworkFirewallFlowParser{}
+	require.Equal(t, "AWS.NetworkFirewallFlow", parser.LogType())
+}
+
+func TestNetworkFirewallEventTypes(t *testing.T) {
+	//nolint:lll
+	log := `{"firewall_name":"test-firewall","availability_zone":"us-east-1b","event_timestamp":"1602624500","event":{"timestamp":"2020-10-13T21:28:20.000000+0000","event_type":"netflow","src_ip":"203.0.113.4","dest_ip":"192.0.2.16","netflow":{"pkts":1}}}`
+	_, err := (&NetworkFirewallAlertParser{}).Parse(log)
+	require.Error(t, err)
+}
+
+func checkNetworkFirewallAlertLog(t *testing.T, log string, expectedEvent *NetworkFirewallAlert) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &NetworkFirewallAlertParser{}
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
+
+func checkNetworkFirewallFlowLog(t *testing.T, log string, expectedEvent *NetworkFirewallFlow) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &NetworkFirewallFlowParser{}
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
@@ -0,0 +1,119 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type Route53ResolverQueryLog struct {
+	Version        *string                      `json:"version" validate:"required" description:"The version number of the query log format."`
+	AccountID      *string                      `json:"account_id" validate:"len=12,numeric" description:"The ID of the AWS account that created the VPC."`
+	Region         *string                      `json:"region" validate:"required" description:"The AWS Region that you created the VPC in."`
+	VPCID          *string                      `json:"vpc_id" validate:"required" description:"The ID of the VPC that the query originated in."`
+	QueryTimestamp *timestamp.RFC3339           `json:"query_timestamp" validate:"required" description:"The date and time that the query was submitted, in ISO 8601 format and Coordinated Universal Time (UTC)."`
+	QueryName      *string                      `json:"query_name" validate:"required" description:"The domain name (example.com) or subdomain name (www.example.com) that was specified in the query."`
+	QueryType      *string                      `json:"query_type,omitempty" description:"Either the DNS record type that was specified in the request, or ANY."`
+	QueryClass     *string                      `json:"query_class,omitempty" description:"The class of the query."`
+	Rcode          *string                      `json:"rcode,omitempty" description:"The DNS response code that Resolver returned in response to the DNS query."`
+	Answers        []Route53ResolverQueryAnswer `json:"answers,omitempty" description:"The answers that Resolver returned in response to the DNS query."`
+	SrcAddr        *string                      `json:"srcaddr,omitempty" description:"The IP address of the instance that the query originated from."`
+	SrcPort        *numerics.Integer            `json:"srcport,omitempty" description:"The port on the instance that the query originated from."`
+	Transport      *string                      `json:"transport,omitempty" description:"The protocol used to submit the DNS query."`
+	SrcIDs         *Route53ResolverQuerySrcIDs  `json:"srcids,omitempty" description:"The IDs of the instance or resolver endpoint that the DNS query originated from."`
+
+	// NOTE: added to end of struct to allow expansion later
+	AWSPantherLog
+}
+
+// nolint:lll
+type Route53ResolverQueryAnswer struct {
+	Rdata *string `json:"Rdata,omitempty" description:"The value that Resolver returned in response to the query. For example, for A records, this is an IP address in IPv4 format. For CNAME records, this is the domain name in the CNAME record."`
+	Type  *string `json:"Type,omitempty" description:"The DNS record type (such as MX, AAAA, or TXT) and the value that Resolver returned in response to the query."`
+	Class *string `json:"Class,omitempty" description:"The class of the Resolver response to the query."`
+}
+
+// nolint:lll
+type Route53ResolverQuerySrcIDs struct {
+	Instance                 *string `json:"instance,omitempty" description:"The ID of the instance that the query originated from."`
+	ResolverEndpoint         *string `json:"resolver_endpoint,omitempty" description:"The ID of the resolver endpoint that passes the DNS query to on-premises DNS servers."`
+	ResolverNetworkInterface *string `json:"resolver_network_interface,omitempty" description:"The ID of the resolver network interface that passes the DNS query to on-premises DNS servers."`
+}
+
+// Route53ResolverQueryLogParser parses AWS Route 53 Resolver query logs
+type Route53ResolverQueryLogParser struct{}
+
+var _ parsers.LogParser = (*Route53ResolverQueryLogParser)(nil)
+
+func (p *Route53ResolverQueryLogParser) New() parsers.LogParser {
+	return &Route53ResolverQueryLogParser{}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *Route53ResolverQueryLogParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := &Route53ResolverQueryLog{}
+	err := jsoniter.UnmarshalFromString(log, event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.updatePantherFields(p)
+
+	if err := parsers.Validator.Struct(event); err != nil {
+		return nil, err
+	}
+	return event.Logs(), nil
+}
+
+// LogType returns the log type supported by this parser
+func (p *Route53ResolverQueryLogParser) LogType() string {
+	return TypeRoute53ResolverQueryLog
+}
+
+func (event *Route53ResolverQueryLog) updatePantherFields(p *Route53ResolverQueryLogParser) {
+	event.SetCoreFields(p.LogType(), event.QueryTimestamp, event)
+
+	event.AppendAnyAWSAccountIdPtrs(event.AccountID)
+	event.AppendAnyIPAddressPtr(event.SrcAddr)
+	if event.QueryName != nil {
+		// Query names are fully qualified and end with a dot
+		event.AppendAnyDomainNames(strings.TrimSuffix(*event.QueryName, "."))
+	}
+	for _, answer := range event.Answers {
+		if answer.Rdata == nil {
+			continue
+		}
+		if !event.AppendAnyIPAddress(*answer.Rdata) && answer.Type != nil {
+			switch *answer.Type {
+			case "CNAME", "NS", "PTR":
+				event.AppendAnyDomainNames(strings.TrimSuffix(*answer.Rdata, "."))
+			}
+		}
+	}
+	if event.SrcIDs != nil {
+		event.AppendAnyAWSInstanceIdPtrs(event.SrcIDs.Instance)
+	}
+}
@@ -0,0 +1,82 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestRoute53ResolverQueryLog(t *testing.T) {
+	//nolint:lll
+	log := `{"version":"1.100000","account_id":"111122223333","region":"us-west-2","vpc_id":"vpc-0a1b2c3d","query_timestamp":"2020-09-01T20:12:34Z","query_name":"www.example.com.","query_type":"A","query_class":"IN","rcode":"NOERROR","answers":[{"Rdata":"example.com.","Type":"CNAME","Class":"IN"},{"Rdata":"93.184.216.34","Type":"A","Class":"IN"}],"srcaddr":"10.0.0.12","srcport":"45938","transport":"UDP","srcids":{"instance":"i-0d15cd0d3ddbb5151"}}`
+
+	expectedTime := time.Date(2020, 9, 1, 20, 12, 34, 0, time.UTC)
+	expectedEvent := &Route53ResolverQueryLog{
+		Version:        aws.String("1.100000"),
+		AccountID:      aws.String("111122223333"),
+		Region:         aws.String("us-west-2"),
+		VPCID:          aws.String("vpc-0a1b2c3d"),
+		QueryTimestamp: (*timestamp.RFC3339)(&expectedTime),
+		QueryName:      aws.String("www.example.com."),
+		QueryType:      aws.String("A"),
+		QueryClass:     aws.String("IN"),
+		Rcode:          aws.String("NOERROR"),
+		Answers: []Route53ResolverQueryAnswer{
+			{Rdata: aws.String("example.com."), Type: aws.String("CNAME"), Class: aws.String("IN")},
+			{Rdata: aws.String("93.184.216.34"), Type: aws.String("A"), Class: aws.String("IN")},
+		},
+		SrcAddr:   aws.String("10.0.0.12"),
+		SrcPort:   (*numerics.Integer)(aws.Int(45938)),
+		Transport: aws.String("UDP"),
+		SrcIDs: &Route53ResolverQuerySrcIDs{
+			Instance: aws.String("i-0d15cd0d3ddbb5151"),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.Route53ResolverQueryLog")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("10.0.0.12")
+	expectedEvent.AppendAnyIPAddress("93.184.216.34")
+	expectedEvent.AppendAnyDomainNames("www.example.com", "example.com")
+	expectedEvent.AppendAnyAWSAccountIds("111122223333")
+	expectedEvent.AppendAnyAWSInstanceIds("i-0d15cd0d3ddbb5151")
+
+	checkRoute53ResolverQueryLog(t, log, expectedEvent)
+}
+
+func TestRoute53ResolverQueryLogType(t *testing.T) {
+	parser := &Route53ResolverQueryLogParser{}
+	require.Equal(t, "AWS.Route53ResolverQueryLog", parser.LogType())
+}
+
+func checkRoute53ResolverQueryLog(t *testing.T, log string, expectedEvent *Route53ResolverQueryLog) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &Route53ResolverQueryLogParser{}
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
@@ -0,0 +1,355 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/pkg/extract"
+)
+
+// SecurityHubFinding is a finding in the AWS Security Finding Format (ASFF)
+// nolint:lll
+type SecurityHubFinding struct {
+	SchemaVersion         null.String                       `json:"SchemaVersion" validate:"required" description:"The schema version that a finding is formatted for."`
+	ID                    null.String                       `json:"Id" validate:"required" description:"The security findings provider-specific identifier for a finding."`
+	ProductARN            null.String                       `json:"ProductArn" panther:"aws_arn" validate:"required" description:"The ARN generated by Security Hub that uniquely identifies a product that generates findings."`
+	ProductName           null.String                       `json:"ProductName" description:"The name of the product that generated the finding."`
+	CompanyName           null.String                       `json:"CompanyName" description:"The name of the company for the product that generated the finding."`
+	Region                null.String                       `json:"Region" description:"The Region from which the finding was generated."`
+	GeneratorID           null.String                       `json:"GeneratorId" validate:"required" description:"The identifier for the solution-specific component (a discrete unit of logic) that generated a finding."`
+	AWSAccountID          null.String                       `json:"AwsAccountId" panther:"aws_account_id" validate:"required" description:"The AWS account ID that a finding is generated in."`
+	Types                 []string                          `json:"Types" description:"One or more finding types in the format of namespace/category/classifier that classify a finding."`
+	FirstObservedAt       time.Time                         `json:"FirstObservedAt" tcodec:"rfc3339" description:"Indicates when the security-findings provider first observed the potential security issue that a finding captured."`
+	LastObservedAt        time.Time                         `json:"LastObservedAt" tcodec:"rfc3339" description:"Indicates when the security-findings provider most recently observed the potential security issue that a finding captured."`
+	CreatedAt             time.Time                         `json:"CreatedAt" tcodec:"rfc3339" validate:"required" description:"Indicates when the security-findings provider created the potential security issue that a finding captured."`
+	UpdatedAt             time.Time                         `json:"UpdatedAt" tcodec:"rfc3339" panther:"event_time" validate:"required" description:"Indicates when the security-findings provider last updated the finding record."`
+	Severity              *SecurityHubSeverity              `json:"Severity" validate:"required" description:"A finding's severity."`
+	Confidence            null.Int32                        `json:"Confidence" description:"A finding's confidence. Confidence is defined as the likelihood that a finding accurately identifies the behavior or issue that it was intended to identify."`
+	Criticality           null.Int32                        `json:"Criticality" description:"The level of importance assigned to the resources associated with the finding."`
+	Title                 null.String                       `json:"Title" validate:"required" description:"A finding's title."`
+	Description           null.String                       `json:"Description" validate:"required" description:"A finding's description."`
+	Remediation           *SecurityHubRemediation           `json:"Remediation" description:"A data type that describes the remediation options for a finding."`
+	SourceURL             null.String                       `json:"SourceUrl" panther:"url" description:"A URL that links to a page about the current finding in the security-findings provider's solution."`
+	ProductFields         map[string]string                 `json:"ProductFields" description:"A data type where security-findings providers can include additional solution-specific details that aren't part of the defined AwsSecurityFinding format."`
+	UserDefinedFields     map[string]string                 `json:"UserDefinedFields" description:"A list of name/value string pairs associated with the finding."`
+	Malware               []SecurityHubMalware              `json:"Malware" description:"A list of malware related to a finding."`
+	Network               *SecurityHubNetwork               `json:"Network" description:"The details of network-related information about a finding."`
+	NetworkPath           *jsoniter.RawMessage              `json:"NetworkPath" description:"Provides information about a network path that is relevant to a finding."`
+	Process               *SecurityHubProcess               `json:"Process" description:"The details of process-related information about a finding."`
+	Threats               []SecurityHubThreat               `json:"Threats" description:"Details about the threat detected in a security finding and the file paths that were affected by the threat."`
+	ThreatIntelIndicators []SecurityHubThreatIntelIndicator `json:"ThreatIntelIndicators" description:"Threat intelligence details related to a finding."`
+	Resources             []SecurityHubResource             `json:"Resources" validate:"required,min=1" description:"A set of resource data types that describe the resources that the finding refers to."`
+	Compliance            *SecurityHubCompliance            `json:"Compliance" description:"This data type is exclusive to findings that are generated as the result of a check run against a specific rule in a supported security standard."`
+	VerificationState     null.String                       `json:"VerificationState" description:"Indicates the veracity of a finding."`
+	WorkflowState         null.String                       `json:"WorkflowState" description:"The workflow state of a finding (deprecated, replaced by Workflow)."`
+	Workflow              *SecurityHubWorkflow              `json:"Workflow" description:"Provides information about the status of the investigation into a finding."`
+	RecordState           null.String                       `json:"RecordState" description:"The record state of a finding."`
+	RelatedFindings       []SecurityHubRelatedFinding       `json:"RelatedFindings" description:"A list of related findings."`
+	Note                  *SecurityHubNote                  `json:"Note" description:"A user-defined note added to a finding."`
+	Vulnerabilities       *jsoniter.RawMessage              `json:"Vulnerabilities" description:"Provides a list of vulnerabilities associated with the findings."`
+	PatchSummary          *jsoniter.RawMessage              `json:"PatchSummary" description:"Provides an overview of the patch compliance status for an instance against a selected compliance standard."`
+	Action                *jsoniter.RawMessage              `json:"Action" description:"Provides details about an action that affects or that was taken on a resource."`
+	FindingProviderFields *jsoniter.RawMessage              `json:"FindingProviderFields" description:"The details that are only updatable by the finding provider."`
+}
+
+// nolint:lll
+type SecurityHubSeverity struct {
+	Label      null.String  `json:"Label" description:"The severity value of the finding (INFORMATIONAL, LOW, MEDIUM, HIGH, CRITICAL)."`
+	Normalized null.Int32   `json:"Normalized" description:"The normalized severity of a finding (deprecated, replaced by Label)."`
+	Original   null.String  `json:"Original" description:"The native severity from the finding product that generated the finding."`
+	Product    null.Float64 `json:"Product" description:"The native severity as defined by the AWS service or integrated partner product that generated the finding (deprecated, replaced by Original)."`
+}
+
+// nolint:lll
+type SecurityHubRemediation struct {
+	Recommendation *SecurityHubRecommendation `json:"Recommendation" description:"A recommendation on the steps to take to remediate the issue identified by a finding."`
+}
+
+// nolint:lll
+type SecurityHubRecommendation struct {
+	Text null.String `json:"Text" description:"Describes the recommended steps to take to remediate an issue identified in a finding."`
+	URL  null.String `json:"Url" panther:"url" description:"A URL to a page or site that contains information about how to remediate a finding."`
+}
+
+// nolint:lll
+type SecurityHubMalware struct {
+	Name  null.String `json:"Name" description:"The name of the malware that was observed."`
+	Path  null.String `json:"Path" description:"The file system path of the malware that was observed."`
+	State null.String `json:"State" description:"The state of the malware that was observed."`
+	Type  null.String `json:"Type" description:"The type of the malware that was observed."`
+}
+
+// nolint:lll
+type SecurityHubNetwork struct {
+	Direction         null.String           `json:"Direction" description:"The direction of network traffic associated with a finding (IN, OUT)."`
+	Protocol          null.String           `json:"Protocol" description:"The protocol of network-related information about a finding."`
+	OpenPortRange     *SecurityHubPortRange `json:"OpenPortRange" description:"The range of open ports that is present on the network."`
+	SourceIPV4        null.String           `json:"SourceIpV4" panther:"ip" description:"The source IPv4 address of network-related information about a finding."`
+	SourceIPV6        null.String           `json:"SourceIpV6" panther:"ip" description:"The source IPv6 address of network-related information about a finding."`
+	SourcePort        null.Uint16           `json:"SourcePort" description:"The source port of network-related information about a finding."`
+	SourceDomain      null.String           `json:"SourceDomain" panther:"domain" description:"The source domain of network-related information about a finding."`
+	SourceMAC         null.String           `json:"SourceMac" description:"The source media access control (MAC) address of network-related information about a finding."`
+	DestinationIPV4   null.String           `json:"DestinationIpV4" panther:"ip" description:"The destination IPv4 address of network-related information about a finding."`
+	DestinationIPV6   null.String           `json:"DestinationIpV6" panther:"ip" description:"The destination IPv6 address of network-related information about a finding."`
+	DestinationPort   null.Uint16           `json:"DestinationPort" description:"The destination port of network-related information about a finding."`
+	DestinationDomain null.String           `json:"DestinationDomain" panther:"domain" description:"The destination domain of network-related information about a finding."`
+}
+
+type SecurityHubPortRange struct {
+	Begin null.Uint16 `json:"Begin" description:"The first port in the port range."`
+	End   null.Uint16 `json:"End" description:"The last port in the port range."`
+}
+
+// nolint:lll
+type SecurityHubProcess struct {
+	Name         null.String `json:"Name" description:"The name of the process."`
+	Path         null.String `json:"Path" description:"The path to the process executable."`
+	PID          null.Int64  `json:"Pid" description:"The process ID."`
+	ParentPID    null.Int64  `json:"ParentPid" description:"The parent process ID."`
+	LaunchedAt   time.Time   `json:"LaunchedAt" tcodec:"rfc3339" description:"Indicates when the process was launched."`
+	TerminatedAt time.Time   `json:"TerminatedAt" tcodec:"rfc3339" description:"Indicates when the process was terminated."`
+}
+
+// nolint:lll
+type SecurityHubThreat struct {
+	Name      null.String                 `json:"Name" description:"The name of the threat."`
+	Severity  null.String                 `json:"Severity" description:"The severity of the threat."`
+	ItemCount null.Int64                  `json:"ItemCount" description:"This total number of items in which the threat has been detected."`
+	FilePaths []SecurityHubThreatFilePath `json:"FilePaths" description:"Provides information about the file paths that were affected by the threat."`
+}
+
+// nolint:lll
+type SecurityHubThreatFilePath struct {
+	FilePath   null.String `json:"FilePath" description:"Path to the infected or suspicious file on the resource it was detected on."`
+	FileName   null.String `json:"FileName" description:"The name of the infected or suspicious file corresponding to the hash."`
+	ResourceID null.String `json:"ResourceId" panther:"aws_arn" description:"The Amazon Resource Name (ARN) of the resource on which the threat was detected."`
+	Hash       null.String `json:"Hash" description:"The hash value for the infected or suspicious file."`
+}
+
+var _ pantherlog.ValueWriterTo = (*SecurityHubThreatFilePath)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (p *SecurityHubThreatFilePath) WriteValuesTo(w pantherlog.ValueWriter) {
+	// The hash algorithm is not specified so we use the length of the hex digest to detect it
+	switch hash := p.Hash.Value; len(hash) {
+	case 32:
+		w.WriteValues(pantherlog.FieldMD5Hash, hash)
+	case 40:
+		w.WriteValues(pantherlog.FieldSHA1Hash, hash)
+	case 64:
+		w.WriteValues(pantherlog.FieldSHA256Hash, hash)
+	}
+}
+
+// nolint:lll
+type SecurityHubThreatIntelIndicator struct {
+	Type           null.String `json:"Type" description:"The type of threat intelligence indicator."`
+	Value          null.String `json:"Value" description:"The value of a threat intelligence indicator."`
+	Category       null.String `json:"Category" description:"The category of a threat intelligence indicator."`
+	LastObservedAt time.Time   `json:"LastObservedAt" tcodec:"rfc3339" description:"Indicates when the most recent instance of a threat intelligence indicator was observed."`
+	Source         null.String `json:"Source" description:"The source of the threat intelligence indicator."`
+	SourceURL      null.String `json:"SourceUrl" description:"The URL to the page or site where you can get more information about the threat intelligence indicator."`
+}
+
+var _ pantherlog.ValueWriterTo = (*SecurityHubThreatIntelIndicator)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (i *SecurityHubThreatIntelIndicator) WriteValuesTo(w pantherlog.ValueWriter) {
+	value := i.Value.Value
+	if value == "" {
+		return
+	}
+	switch i.Type.Value {
+	case "IPV4_ADDRESS", "IPV6_ADDRESS":
+		pantherlog.ScanIPAddress(w, value)
+	case "DOMAIN":
+		w.WriteValues(pantherlog.FieldDomainName, value)
+	case "URL":
+		pantherlog.ScanURL(w, value)
+	case "HASH_MD5":
+		w.WriteValues(pantherlog.FieldMD5Hash, value)
+	case "HASH_SHA1":
+		w.WriteValues(pantherlog.FieldSHA1Hash, value)
+	case "HASH_SHA256":
+		w.WriteValues(pantherlog.FieldSHA256Hash, value)
+	}
+}
+
+// nolint:lll
+type SecurityHubResource struct {
+	Type               null.String          `json:"Type" validate:"required" description:"The type of the resource that details are provided for (ie AwsEc2Instance)."`
+	ID                 null.String          `json:"Id" panther:"aws_arn" validate:"required" description:"The canonical identifier for the given resource type."`
+	Partition          null.String          `json:"Partition" description:"The canonical AWS partition name that the Region is assigned to."`
+	Region             null.String          `json:"Region" description:"The canonical AWS external Region name where this resource is located."`
+	ResourceRole       null.String          `json:"ResourceRole" description:"Identifies the role of the resource in the finding."`
+	Tags               map[string]string    `json:"Tags" description:"A list of AWS tags associated with a resource at the time the finding was processed."`
+	DataClassification *jsoniter.RawMessage `json:"DataClassification" description:"Contains information about sensitive data that was detected on the resource."`
+	Details            *jsoniter.RawMessage `json:"Details" description:"Additional details about the resource related to a finding."`
+}
+
+var _ pantherlog.ValueWriterTo = (*SecurityHubResource)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (r *SecurityHubResource) WriteValuesTo(w pantherlog.ValueWriter) {
+	for key, value := range r.Tags {
+		ScanTag(w, key+":"+value)
+	}
+	extract.Extract(r.Details, &securityHubDetailsExtractor{w: w})
+}
+
+// securityHubDetailsExtractor extracts indicators from the resource details of a finding.
+// The details have a different structure for each resource type so we look for well known keys.
+type securityHubDetailsExtractor struct {
+	w pantherlog.ValueWriter
+}
+
+func (e *securityHubDetailsExtractor) Extract(key, value gjson.Result) {
+	if strings.HasPrefix(value.Str, "arn:") {
+		ScanARN(e.w, value.Str)
+		return
+	}
+	switch {
+	case strings.HasSuffix(key.Str, "InstanceId"):
+		ScanInstanceID(e.w, value.Str)
+	case strings.HasSuffix(key.Str, "AccountId"):
+		ScanAccountID(e.w, value.Str)
+	case key.Str == "IpV4Addresses" || key.Str == "IpV6Addresses": // found in AwsEc2Instance details
+		value.ForEach(func(_, addr gjson.Result) bool {
+			pantherlog.ScanIPAddress(e.w, addr.Str)
+			return true
+		})
+	case key.Str == "PublicIp" || key.Str == "PrivateIpAddress" || key.Str == "IpAddress":
+		pantherlog.ScanIPAddress(e.w, value.Str)
+	case key.Str == "PublicDnsName" || key.Str == "PrivateDnsName":
+		if value.Str != "" {
+			e.w.WriteValues(pantherlog.FieldDomainName, value.Str)
+		}
+	}
+}
+
+// nolint:lll
+type SecurityHubCompliance struct {
+	Status              null.String                         `json:"Status" description:"The result of a standards check (PASSED, WARNING, FAILED, NOT_AVAILABLE)."`
+	RelatedRequirements []string                            `json:"RelatedRequirements" description:"For a control, the industry or regulatory framework requirements that are related to the control."`
+	StatusReasons       []SecurityHubComplianceStatusReason `json:"StatusReasons" description:"For findings generated from controls, a list of reasons behind the value of Status."`
+}
+
+// nolint:lll
+type SecurityHubComplianceStatusReason struct {
+	ReasonCode  null.String `json:"ReasonCode" description:"A code that represents a reason for the control status."`
+	Description null.String `json:"Description" description:"The corresponding description for the status reason code."`
+}
+
+type SecurityHubWorkflow struct {
+	Status null.String `json:"Status" description:"The status of the investigation into the finding."`
+}
+
+// nolint:lll
+type SecurityHubRelatedFinding struct {
+	ProductARN null.String `json:"ProductArn" panther:"aws_arn" description:"The ARN of the product that generated a related finding."`
+	ID         null.String `json:"Id" description:"The product-generated identifier for a related finding."`
+}
+
+type SecurityHubNote struct {
+	Text      null.String `json:"Text" description:"The text of a note."`
+	UpdatedBy null.String `json:"UpdatedBy" description:"The principal that created a note."`
+	UpdatedAt time.Time   `json:"UpdatedAt" tcodec:"rfc3339" description:"The timestamp of when the note was updated."`
+}
+
+// securityHubIndicators are the indicator fields of AWS.SecurityHubFinding.
+// Some of them are only written by types implementing pantherlog.ValueWriterTo so they cannot be detected from tags.
+var securityHubIndicators = []pantherlog.FieldID{
+	pantherlog.FieldIPAddress,
+	pantherlog.FieldDomainName,
+	pantherlog.FieldMD5Hash,
+	pantherlog.FieldSHA1Hash,
+	pantherlog.FieldSHA256Hash,
+	pantherlog.FieldAWSARN,
+	pantherlog.FieldAWSAccountID,
+	pantherlog.FieldAWSInstanceID,
+	pantherlog.FieldAWSTag,
+}
+
+// securityHubEvent is the EventBridge (CloudWatch Events) event Security Hub emits for findings
+type securityHubEvent struct {
+	Source null.String `json:"source"`
+	Detail *struct {
+		Findings []jsoniter.RawMessage `json:"findings"`
+	} `json:"detail"`
+}
+
+var securityHubJSON = common.BuildJSON()
+
+// SecurityHubFindingParser parses Security Hub findings.
+// Findings are either plain ASFF objects or wrapped in the EventBridge event Security Hub emits for findings.
+// An EventBridge event can contain multiple findings, each one produces a separate result.
+type SecurityHubFindingParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*SecurityHubFindingParser)(nil)
+
+// NewSecurityHubFindingParser implements parsers.FactoryFunc
+func NewSecurityHubFindingParser(_ interface{}) (parsers.Interface, error) {
+	return &SecurityHubFindingParser{}, nil
+}
+
+// ParseLog implements parsers.Interface
+func (p *SecurityHubFindingParser) ParseLog(log string) ([]*parsers.Result, error) {
+	event := securityHubEvent{}
+	if err := securityHubJSON.UnmarshalFromString(log, &event); err != nil {
+		return nil, err
+	}
+	findings := []jsoniter.RawMessage{jsoniter.RawMessage(log)}
+	if event.Detail != nil {
+		if source := event.Source.Value; source != "aws.securityhub" {
+			return nil, errors.Errorf("invalid Security Hub event source %q", source)
+		}
+		if len(event.Detail.Findings) == 0 {
+			return nil, errors.New("no findings in Security Hub event")
+		}
+		findings = event.Detail.Findings
+	}
+	results := make([]*parsers.Result, 0, len(findings))
+	for _, data := range findings {
+		finding := SecurityHubFinding{}
+		if err := securityHubJSON.Unmarshal(data, &finding); err != nil {
+			return nil, err
+		}
+		if err := parsers.ValidateStruct(&finding); err != nil {
+			return nil, err
+		}
+		result, err := p.builder.BuildResult(TypeSecurityHubFinding, &finding)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,276 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestSecurityHubFinding(t *testing.T) {
+	// nolint:lll
+	input := `{"SchemaVersion":"2018-10-08","Id":"arn:aws:securityhub:us-east-1:123456789012:subscription/aws-foundational-security-best-practices/v/1.0.0/EC2.8/finding/a1b2c3d4","ProductArn":"arn:aws:securityhub:us-east-1::product/aws/securityhub","ProductName":"Security Hub","CompanyName":"AWS","Region":"us-east-1","GeneratorId":"aws-foundational-security-best-practices/v/1.0.0/EC2.8","AwsAccountId":"123456789012","Types":["Software and Configuration Checks/Industry and Regulatory Standards/AWS-Foundational-Security-Best-Practices"],"FirstObservedAt":"2020-10-01T12:00:00.000Z","LastObservedAt":"2020-10-02T12:00:00.000Z","CreatedAt":"2020-10-01T12:00:00.000Z","UpdatedAt":"2020-10-02T12:00:00.000Z","Severity":{"Label":"HIGH","Normalized":70,"Original":"HIGH"},"Title":"EC2.8 EC2 instances should use IMDSv2","Description":"This control checks whether your EC2 instance metadata version is configured with IMDSv2.","Remediation":{"Recommendation":{"Text":"For directions on how to fix this issue, please consult the AWS Security Hub Foundational Security Best Practices documentation.","Url":"https://docs.aws.amazon.com/console/securityhub/EC2.8/remediation"}},"ProductFields":{"StandardsArn":"arn:aws:securityhub:::standards/aws-foundational-security-best-practices/v/1.0.0","ControlId":"EC2.8"},"Network":{"Direction":"IN","Protocol":"TCP","SourceIpV4":"198.51.100.7","SourcePort":4444,"DestinationIpV4":"10.0.0.12","DestinationPort":22,"DestinationDomain":"ip-10-0-0-12.ec2.internal"},"Process":{"Name":"sshd","Path":"/usr/sbin/sshd","Pid":1234,"ParentPid":1,"LaunchedAt":"2020-10-01T11:59:00.000Z"},"ThreatIntelIndicators":[{"Type":"HASH_SHA256","Value":"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855","Category":"BACKDOOR","Source":"ThreatFeed"},{"Type":"IPV4_ADDRESS","Value":"198.51.100.8"}],"Threats":[{"Name":"EICAR-Test-File","Severity":"HIGH","ItemCount":1,"FilePaths":[{"FilePath":"/tmp/eicar.com","FileName":"eicar.com","ResourceId":"arn:aws:ec2:us-east-1:123456789012:instance/i-0abcdef1234567890","Hash":"44d88612fea8a8f36de82e1278abb02f"}]}],"Resources":[{"Type":"AwsEc2Instance","Id":"arn:aws:ec2:us-east-1:123456789012:instance/i-0abcdef1234567890","Partition":"aws","Region":"us-east-1","Tags":{"Name":"bastion"},"Details":{"AwsEc2Instance":{"Type":"t3.micro","ImageId":"ami-0abcdef1234567890","IpV4Addresses":["10.0.0.12","54.1.2.3"],"VpcId":"vpc-0a1b2c3d","SubnetId":"subnet-0a1b2c3d","IamInstanceProfileArn":"arn:aws:iam::123456789012:instance-profile/bastion"}}}],"Compliance":{"Status":"FAILED","RelatedRequirements":["NIST.800-53.r5 AC-3"],"StatusReasons":[{"ReasonCode":"CONFIG_EVALUATIONS_EMPTY","Description":"AWS Config evaluated your resources against the rule."}]},"WorkflowState":"NEW","Workflow":{"Status":"NEW"},"RecordState":"ACTIVE"}`
+	// nolint:lll
+	expect := `{
+	  "SchemaVersion": "2018-10-08",
+	  "Id": "arn:aws:securityhub:us-east-1:123456789012:subscription/aws-foundational-security-best-practices/v/1.0.0/EC2.8/finding/a1b2c3d4",
+	  "ProductArn": "arn:aws:securityhub:us-east-1::product/aws/securityhub",
+	  "ProductName": "Security Hub",
+	  "CompanyName": "AWS",
+	  "Region": "us-east-1",
+	  "GeneratorId": "aws-foundational-security-best-practices/v/1.0.0/EC2.8",
+	  "AwsAccountId": "123456789012",
+	  "Types": [
+	    "Software and Configuration Checks/Industry and Regulatory Standards/AWS-Foundational-Security-Best-Practices"
+	  ],
+	  "FirstObservedAt": "2020-10-01T12:00:00Z",
+	  "LastObservedAt": "2020-10-02T12:00:00Z",
+	  "CreatedAt": "2020-10-01T12:00:00Z",
+	  "UpdatedAt": "2020-10-02T12:00:00Z",
+	  "Severity": {
+	    "Label": "HIGH",
+	    "Normalized": 70,
+	    "Original": "HIGH"
+	  },
+	  "Title": "EC2.8 EC2 instances should use IMDSv2",
+	  "Description": "This control checks whether your EC2 instance metadata version is configured with IMDSv2.",
+	  "Remediation": {
+	    "Recommendation": {
+	      "Text": "For directions on how to fix this issue, please consult the AWS Security Hub Foundational Security Best Practices documentation.",
+	      "Url": "https://docs.aws.amazon.com/console/securityhub/EC2.8/remediation"
+	    }
+	  },
+	  "ProductFields": {
+	    "StandardsArn": "arn:aws:securityhub:::standards/aws-foundational-security-best-practices/v/1.0.0",
+	    "ControlId": "EC2.8"
+	  },
+	  "Network": {
+	    "Direction": "IN",
+	    "Protocol": "TCP",
+	    "SourceIpV4": "198.51.100.7",
+	    "SourcePort": 4444,
+	    "DestinationIpV4": "10.0.0.12",
+	    "DestinationPort": 22,
+	    "DestinationDomain": "ip-10-0-0-12.ec2.internal"
+	  },
+	  "Process": {
+	    "Name": "sshd",
+	    "Path": "/usr/sbin/sshd",
+	    "Pid": 1234,
+	    "ParentPid": 1,
+	    "LaunchedAt": "2020-10-01T11:59:00Z"
+	  },
+	  "Threats": [
+	    {
+	      "Name": "EICAR-Test-File",
+	      "Severity": "HIGH",
+	      "ItemCount": 1,
+	      "FilePaths": [
+	        {
+	          "FilePath": "/tmp/eicar.com",
+	          "FileName": "eicar.com",
+	          "ResourceId": "arn:aws:ec2:us-east-1:123456789012:instance/i-0abcdef1234567890",
+	          "Hash": "44d88612fea8a8f36de82e1278abb02f"
+	        }
+	      ]
+	    }
+	  ],
+	  "ThreatIntelIndicators": [
+	    {
+	      "Type": "HASH_SHA256",
+	      "Value": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	      "Category": "BACKDOOR",
+	      "Source": "ThreatFeed"
+	    },
+	    {
+	      "Type": "IPV4_ADDRESS",
+	      "Value": "198.51.100.8"
+	    }
+	  ],
+	  "Resources": [
+	    {
+	      "Type": "AwsEc2Instance",
+	      "Id": "arn:aws:ec2:us-east-1:123456789012:instance/i-0abcdef1234567890",
+	      "Partition": "aws",
+	      "Region": "us-east-1",
+	      "Tags": {
+	        "Name": "bastion"
+	      },
+	      "Details": {
+	        "AwsEc2Instance": {
+	          "Type": "t3.micro",
+	          "ImageId": "ami-0abcdef1234567890",
+	          "IpV4Addresses": [
+	            "10.0.0.12",
+	            "54.1.2.3"
+	          ],
+	          "VpcId": "vpc-0a1b2c3d",
+	          "SubnetId": "subnet-0a1b2c3d",
+	          "IamInstanceProfileArn": "arn:aws:iam::123456789012:instance-profile/bastion"
+	        }
+	      }
+	    }
+	  ],
+	  "Compliance": {
+	    "Status": "FAILED",
+	    "RelatedRequirements": [
+	      "NIST.800-53.r5 AC-3"
+	    ],
+	    "StatusReasons": [
+	      {
+	        "ReasonCode": "CONFIG_EVALUATIONS_EMPTY",
+	        "Description": "AWS Config evaluated your resources against the rule."
+	      }
+	    ]
+	  },
+	  "WorkflowState": "NEW",
+	  "Workflow": {
+	    "Status": "NEW"
+	  },
+	  "RecordState": "ACTIVE",
+	  "p_log_type": "AWS.SecurityHubFinding",
+	  "p_event_time": "2020-10-02T12:00:00Z",
+	  "p_any_sha256_hashes": [
+	    "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	  ],
+	  "p_any_aws_tags": [
+	    "Name:bastion"
+	  ],
+	  "p_any_aws_arns": [
+	    "arn:aws:ec2:us-east-1:123456789012:instance/i-0abcdef1234567890",
+	    "arn:aws:iam::123456789012:instance-profile/bastion",
+	    "arn:aws:securityhub:us-east-1::product/aws/securityhub"
+	  ],
+	  "p_any_aws_account_ids": [
+	    "123456789012"
+	  ],
+	  "p_any_domain_names": [
+	    "docs.aws.amazon.com",
+	    "ip-10-0-0-12.ec2.internal"
+	  ],
+	  "p_any_ip_addresses": [
+	    "10.0.0.12",
+	    "198.51.100.7",
+	    "198.51.100.8",
+	    "54.1.2.3"
+	  ],
+	  "p_any_aws_instance_ids": [
+	    "i-0abcdef1234567890"
+	  ],
+	  "p_any_md5_hashes": [
+	    "44d88612fea8a8f36de82e1278abb02f"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeSecurityHubFinding, input, expect)
+}
+
+func TestSecurityHubFindingEvent(t *testing.T) {
+	// nolint:lll
+	input := `{"version":"0","id":"8e5622f9-d81c-4d81-612a-9319e7ee2506","detail-type":"Security Hub Findings - Imported","source":"aws.securityhub","account":"123456789012","time":"2020-10-02T12:00:01Z","region":"us-east-1","resources":["arn:aws:securityhub:us-east-1::product/aws/guardduty/arn:aws:guardduty:us-east-1:123456789012:detector/d1/finding/f1"],"detail":{"findings":[{"SchemaVersion":"2018-10-08","Id":"arn:aws:guardduty:us-east-1:123456789012:detector/d1/finding/f1","ProductArn":"arn:aws:securityhub:us-east-1::product/aws/guardduty","GeneratorId":"arn:aws:guardduty:us-east-1:123456789012:detector/d1","AwsAccountId":"123456789012","Types":["TTPs/Command and Control/Backdoor:EC2-C&CActivity.B!DNS"],"CreatedAt":"2020-10-02T11:50:00.000Z","UpdatedAt":"2020-10-02T11:55:00.000Z","Severity":{"Product":8,"Label":"HIGH","Normalized":60},"Title":"Backdoor:EC2/C&CActivity.B!DNS","Description":"EC2 instance is querying a domain name associated with a known Command & Control server.","Network":{"Direction":"OUT","Protocol":"UDP","DestinationDomain":"evil.example.com"},"Resources":[{"Type":"AwsEc2Instance","Id":"arn:aws:ec2:us-east-1:123456789012:instance/i-99999999"}],"RecordState":"ACTIVE"},{"SchemaVersion":"2018-10-08","Id":"f2","ProductArn":"arn:aws:securityhub:us-east-1::product/aws/inspector","GeneratorId":"rules/1","AwsAccountId":"123456789012","CreatedAt":"2020-10-02T11:56:00Z","UpdatedAt":"2020-10-02T11:57:00Z","Severity":{"Label":"LOW"},"Title":"t","Description":"d","Resources":[{"Type":"AwsAccount","Id":"AWS::::Account:123456789012"}]}]}}`
+	// nolint:lll
+	expectGuardDuty := `{
+	  "SchemaVersion": "2018-10-08",
+	  "Id": "arn:aws:guardduty:us-east-1:123456789012:detector/d1/finding/f1",
+	  "ProductArn": "arn:aws:securityhub:us-east-1::product/aws/guardduty",
+	  "GeneratorId": "arn:aws:guardduty:us-east-1:123456789012:detector/d1",
+	  "AwsAccountId": "123456789012",
+	  "Types": [
+	    "TTPs/Command and Control/Backdoor:EC2-C&CActivity.B!DNS"
+	  ],
+	  "CreatedAt": "2020-10-02T11:50:00Z",
+	  "UpdatedAt": "2020-10-02T11:55:00Z",
+	  "Severity": {
+	    "Label": "HIGH",
+	    "Normalized": 60,
+	    "Product": 8
+	  },
+	  "Title": "Backdoor:EC2/C&CActivity.B!DNS",
+	  "Description": "EC2 instance is querying a domain name associated with a known Command & Control server.",
+	  "Network": {
+	    "Direction": "OUT",
+	    "Protocol": "UDP",
+	    "DestinationDomain": "evil.example.com"
+	  },
+	  "Resources": [
+	    {
+	      "Type": "AwsEc2Instance",
+	      "Id": "arn:aws:ec2:us-east-1:123456789012:instance/i-99999999"
+	    }
+	  ],
+	  "RecordState": "ACTIVE",
+	  "p_log_type": "AWS.SecurityHubFinding",
+	  "p_event_time": "2020-10-02T11:55:00Z",
+	  "p_any_aws_arns": [
+	    "arn:aws:ec2:us-east-1:123456789012:instance/i-99999999",
+	    "arn:aws:securityhub:us-east-1::product/aws/guardduty"
+	  ],
+	  "p_any_aws_account_ids": [
+	    "123456789012"
+	  ],
+	  "p_any_domain_names": [
+	    "evil.example.com"
+	  ],
+	  "p_any_aws_instance_ids": [
+	    "i-99999999"
+	  ]
+	}`
+	expectInspector := `{
+	  "SchemaVersion": "2018-10-08",
+	  "Id": "f2",
+	  "ProductArn": "arn:aws:securityhub:us-east-1::product/aws/inspector",
+	  "GeneratorId": "rules/1",
+	  "AwsAccountId": "123456789012",
+	  "CreatedAt": "2020-10-02T11:56:00Z",
+	  "UpdatedAt": "2020-10-02T11:57:00Z",
+	  "Severity": {
+	    "Label": "LOW"
+	  },
+	  "Title": "t",
+	  "Description": "d",
+	  "Resources": [
+	    {
+	      "Type": "AwsAccount",
+	      "Id": "AWS::::Account:123456789012"
+	    }
+	  ],
+	  "p_log_type": "AWS.SecurityHubFinding",
+	  "p_event_time": "2020-10-02T11:57:00Z",
+	  "p_any_aws_arns": [
+	    "arn:aws:securityhub:us-east-1::product/aws/inspector"
+	  ],
+	  "p_any_aws_account_ids": [
+	    "123456789012"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeSecurityHubFinding, input, expectGuardDuty, expectInspector)
+}
+
+func TestSecurityHubFindingInvalidEvent(t *testing.T) {
+	parser, err := logtypes.DefaultRegistry().MustGet(TypeSecurityHubFinding).NewParser(nil)
+	require.NoError(t, err)
+	// nolint:lll
+	_, err = parser.ParseLog(`{"version":"0","id":"8e5622f9-d81c-4d81-612a-9319e7ee2506","detail-type":"GuardDuty Finding","source":"aws.guardduty","detail":{"findings":[]}}`)
+	require.Error(t, err)
+	_, err = parser.ParseLog(`{"version":"0","id":"8e5622f9-d81c-4d81-612a-9319e7ee2506","detail-type":"Security Hub Findings - Imported","source":"aws.securityhub","detail":{}}`)
+	require.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestWAFWebACLLog(t *testing.T) {
+	//nolint:lll
+	log := `{"timestamp":1576280412771,"formatVersion":1,"webaclId":"arn:aws:wafv2:ap-southeast-2:111122223333:regional/webacl/STMTest/1EXAMPLE-2ARN-3ARN-4ARN-123456EXAMPLE","terminatingRuleId":"STMTest_SQLi_XSS","terminatingRuleType":"REGULAR","action":"BLOCK","terminatingRuleMatchDetails":[{"conditionType":"SQL_INJECTION","location":"UNKNOWN","matchedData":["10","AND","1"]}],"httpSourceName":"ALB","httpSourceId":"111122223333-app/my-alb/1234567890abcdef","ruleGroupList":[{"ruleGroupId":"arn:aws:wafv2:ap-southeast-2:444455556666:regional/rulegroup/shared/a1b2c3d4","terminatingRule":null,"nonTerminatingMatchingRules":[],"excludedRules":null}],"rateBasedRuleList":[],"nonTerminatingMatchingRules":[],"httpRequest":{"clientIp":"1.1.1.1","country":"AU","headers":[{"name":"Host","value":"www.example.com:8080"},{"name":"User-Agent","value":"curl/7.53.1"}],"uri":"/","args":"x=%2A%2F10%20AND%201%3D1","httpVersion":"HTTP/1.1","httpMethod":"GET","requestId":"1-5df4e35c-6a1c3b2f7e2b0cb25b5fd49e"},"labels":[{"name":"awswaf:managed:aws:sql-database:SQLi_QueryArguments"}]}`
+
+	expectedTime := time.Unix(1576280412, 771000000).UTC()
+	expectedEvent := &WAFWebACL{
+		Timestamp:           (*timestamp.UnixMillisecond)(&expectedTime),
+		FormatVersion:       aws.Int(1),
+		WebACLID:            aws.String("arn:aws:wafv2:ap-southeast-2:111122223333:regional/webacl/STMTest/1EXAMPLE-2ARN-3ARN-4ARN-123456EXAMPLE"),
+		TerminatingRuleID:   aws.String("STMTest_SQLi_XSS"),
+		TerminatingRuleType: aws.String("REGULAR"),
+		Action:              aws.String("BLOCK"),
+		TerminatingRuleMatchDetails: []WAFRuleMatchDetails{
+			{
+				ConditionType: aws.String("SQL_INJECTION"),
+				Location:      aws.String("UNKNOWN"),
+				MatchedData:   []string{"10", "AND", "1"},
+			},
+		},
+		HTTPSourceName:              aws.String("ALB"),
+		HTTPSourceID:                aws.String("111122223333-app/my-alb/1234567890abcdef"),
+		RuleGroupList:               testutil.NewRawMessage(`[{"ruleGroupId":"arn:aws:wafv2:ap-southeast-2:444455556666:regional/rulegroup/shared/a1b2c3d4","terminatingRule":null,"nonTerminatingMatchingRules":[],"excludedRules":null}]`), // nolint:lll
+		RateBasedRuleList:           []WAFRateBasedRule{},
+		NonTerminatingMatchingRules: []WAFMatchingRule{},
+		HTTPRequest: &WAFHTTPRequest{
+			ClientIP: aws.String("1.1.1.1"),
+			Country:  aws.String("AU"),
+			Headers: []WAFHTTPHeader{
+				{Name: aws.String("Host"), Value: aws.String("www.example.com:8080")},
+				{Name: aws.String("User-Agent"), Value: aws.String("curl/7.53.1")},
+			},
+			URI:         aws.String("/"),
+			Args:        aws.String("x=%2A%2F10%20AND%201%3D1"),
+			HTTPVersion: aws.String("HTTP/1.1"),
+			HTTPMethod:  aws.String("GET"),
+			RequestID:   aws.String("1-5df4e35c-6a1c3b2f7e2b0cb25b5fd49e"),
+		},
+		Labels: []WAFLabel{
+			{Name: aws.String("awswaf:managed:aws:sql-database:SQLi_QueryArguments")},
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("AWS.WAFWebACL")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyIPAddress("1.1.1.1")
+	expectedEvent.AppendAnyDomainNames("www.example.com")
+	expectedEvent.AppendAnyAWSAccountIds("111122223333", "444455556666")
+	expectedEvent.AppendAnyAWSARNs(
+		"arn:aws:wafv2:ap-southeast-2:111122223333:regional/webacl/STMTest/1EXAMPLE-2ARN-3ARN-4ARN-123456EXAMPLE",
+		"arn:aws:wafv2:ap-southeast-2:444455556666:regional/rulegroup/shared/a1b2c3d4",
+	)
+
+	checkWAFWebACLLog(t, log, expectedEvent)
+}
+
+func TestWAFWebACLLogType(t *testing.T) {
+	parser := &WAFWebACLParser{}
+	require.Equal(t, "AWS.WAFWebACL", parser.LogType())
+}
+
+func checkWAFWebACLLog(t *testing.T, log string, expectedEvent *WAFWebACL) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &WAFWebACLParser{}
+	events, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), events, err)
+}
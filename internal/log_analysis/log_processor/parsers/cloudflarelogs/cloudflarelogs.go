@@ -0,0 +1,103 @@
+package cloudflarelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/tcodec"
+)
+
+const (
+	// LogTypePrefix is the prefix of all log types parsed by this package
+	LogTypePrefix = "Cloudflare"
+	// TypeHTTPRequest is the log type of Cloudflare HTTP request logs
+	TypeHTTPRequest = LogTypePrefix + ".HttpRequest"
+	// TypeFirewall is the log type of Cloudflare firewall event logs
+	TypeFirewall = LogTypePrefix + ".Firewall"
+
+	// TimestampCodec is the name of the tcodec used for Cloudflare timestamps
+	TimestampCodec = "cloudflare"
+)
+
+func init() {
+	// The codec needs to be registered before decoding any Cloudflare log
+	tcodec.MustRegister(TimestampCodec, tcodec.Join(
+		tcodec.TimeDecoderFunc(DecodeTimestamp),
+		tcodec.LayoutCodec(time.RFC3339Nano),
+	))
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeHTTPRequest,
+		Description:  `Cloudflare HTTP request logs shipped by Logpush (http_requests dataset)`,
+		ReferenceURL: `https://developers.cloudflare.com/logs/log-fields#http-requests`,
+	}, func() interface{} {
+		return &HTTPRequest{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeFirewall,
+		Description:  `Cloudflare firewall event logs shipped by Logpush (firewall_events dataset)`,
+		ReferenceURL: `https://developers.cloudflare.com/logs/log-fields#firewall-events`,
+	}, func() interface{} {
+		return &FirewallEvent{}
+	})
+}
+
+// Cloudflare timestamps in seconds will not exceed this value until year 5138.
+// Timestamps in nanoseconds have exceeded it since early 1970.
+const maxUnixSeconds = 1e11
+
+// DecodeTimestamp decodes a timestamp in any of the `timestamp_format` options of a Logpush job.
+// Timestamps are either RFC3339 strings (rfc3339) or UNIX epoch numbers in nanoseconds (unixnano) or seconds (unix).
+func DecodeTimestamp(iter *jsoniter.Iterator) time.Time {
+	switch iter.WhatIsNext() {
+	case jsoniter.NumberValue:
+		return unixTime(iter.ReadInt64())
+	case jsoniter.StringValue:
+		s := iter.ReadString()
+		if s == "" {
+			return time.Time{}
+		}
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return unixTime(n)
+		}
+		tm, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			iter.ReportError("DecodeTimestamp", err.Error())
+		}
+		return tm
+	case jsoniter.NilValue:
+		iter.ReadNil()
+		return time.Time{}
+	default:
+		iter.Skip()
+		iter.ReportError("DecodeTimestamp", `invalid JSON value`)
+		return time.Time{}
+	}
+}
+
+func unixTime(n int64) time.Time {
+	if n < maxUnixSeconds {
+		return time.Unix(n, 0).UTC()
+	}
+	return time.Unix(0, n).UTC()
+}
@@ -0,0 +1,54 @@
+package cloudflarelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/tcodec"
+)
+
+func TestDecodeTimestamp(t *testing.T) {
+	api := jsoniter.Config{}.Froze()
+	api.RegisterExtension(&tcodec.Extension{})
+	type event struct {
+		Timestamp time.Time `json:"ts" tcodec:"cloudflare"`
+	}
+	expect := time.Date(2020, 10, 16, 22, 20, 22, 94000000, time.UTC)
+	for _, input := range []string{
+		`1602886822094000000`,
+		`"1602886822094000000"`,
+		`"2020-10-16T22:20:22.094Z"`,
+		`"2020-10-16T15:20:22.094-07:00"`,
+	} {
+		e := event{}
+		require.NoError(t, api.UnmarshalFromString(`{"ts":`+input+`}`, &e), input)
+		require.Equal(t, expect, e.Timestamp.UTC(), input)
+	}
+	// Timestamps in seconds (unix timestamp_format)
+	e := event{}
+	require.NoError(t, api.UnmarshalFromString(`{"ts":1602886822}`, &e))
+	require.Equal(t, expect.Truncate(time.Second), e.Timestamp)
+	// Invalid timestamps
+	require.Error(t, api.UnmarshalFromString(`{"ts":"2020-10-16 22:20:22"}`, &e))
+}
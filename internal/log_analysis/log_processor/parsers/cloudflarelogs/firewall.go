@@ -0,0 +1,58 @@
+package cloudflarelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// FirewallEvent is a Cloudflare firewall event log record
+// nolint:lll
+type FirewallEvent struct {
+	Action                null.String       `json:"Action" validate:"required" description:"The code of the first-class action the Cloudflare Firewall took on this request."`
+	ClientASN             null.Int64        `json:"ClientASN" description:"The ASN number of the visitor."`
+	ClientASNDescription  null.String       `json:"ClientASNDescription" description:"The ASN of the visitor as string."`
+	ClientCountry         null.String       `json:"ClientCountry" description:"Country from which request originated."`
+	ClientIP              null.String       `json:"ClientIP" panther:"ip" description:"The visitor's IP address (IPv4 or IPv6)."`
+	ClientIPClass         null.String       `json:"ClientIPClass" description:"The classification of the visitor's IP address."`
+	ClientRefererHost     null.String       `json:"ClientRefererHost" panther:"hostname" description:"The referer host."`
+	ClientRefererPath     null.String       `json:"ClientRefererPath" description:"The referer path requested by visitor."`
+	ClientRefererQuery    null.String       `json:"ClientRefererQuery" description:"The referer query-string was requested by the visitor."`
+	ClientRefererScheme   null.String       `json:"ClientRefererScheme" description:"The referer URL scheme requested by the visitor."`
+	ClientRequestHost     null.String       `json:"ClientRequestHost" panther:"hostname" description:"The HTTP hostname requested by the visitor."`
+	ClientRequestMethod   null.String       `json:"ClientRequestMethod" description:"The HTTP method used by the visitor."`
+	ClientRequestPath     null.String       `json:"ClientRequestPath" description:"The path requested by visitor."`
+	ClientRequestProtocol null.String       `json:"ClientRequestProtocol" description:"The version of HTTP protocol requested by the visitor."`
+	ClientRequestQuery    null.String       `json:"ClientRequestQuery" description:"The query-string was requested by the visitor."`
+	ClientRequestScheme   null.String       `json:"ClientRequestScheme" description:"The URL scheme requested by the visitor."`
+	Datetime              time.Time         `json:"Datetime" tcodec:"cloudflare" panther:"event_time" validate:"required" description:"The date and time the event occurred at the edge."`
+	EdgeColoCode          null.String       `json:"EdgeColoCode" description:"The airport code of the Cloudflare datacenter that served this request."`
+	EdgeResponseStatus    null.Uint16       `json:"EdgeResponseStatus" description:"HTTP response status code returned to browser."`
+	Kind                  null.String       `json:"Kind" description:"The kind of event, currently only possible values are: firewall."`
+	MatchIndex            null.Int32        `json:"MatchIndex" description:"Rules match index in the chain."`
+	Metadata              map[string]string `json:"Metadata" description:"Additional product-specific information."`
+	OriginResponseStatus  null.Uint16       `json:"OriginResponseStatus" description:"HTTP origin response status code returned to browser."`
+	OriginatorRayID       null.String       `json:"OriginatorRayID" panther:"trace_id" description:"The RayID of the request that issued the challenge/jschallenge."`
+	RayID                 null.String       `json:"RayID" panther:"trace_id" validate:"required" description:"The RayID of the request."`
+	RuleID                null.String       `json:"RuleID" description:"The Cloudflare security product-specific RuleID triggered by this request."`
+	Source                null.String       `json:"Source" description:"The Cloudflare security product triggered by this request."`
+	UserAgent             null.String       `json:"UserAgent" description:"Visitor's user-agent string."`
+}
@@ -0,0 +1,70 @@
+package cloudflarelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestFirewallEvent(t *testing.T) {
+	// nolint:lll
+	input := `{"Action":"block","ClientASN":64496,"ClientASNDescription":"EXAMPLE-NET","ClientCountry":"nl","ClientIP":"192.0.2.44","ClientIPClass":"noRecord","ClientRefererHost":"","ClientRefererPath":"","ClientRefererQuery":"","ClientRefererScheme":"","ClientRequestHost":"api.example.com","ClientRequestMethod":"POST","ClientRequestPath":"/wp-login.php","ClientRequestProtocol":"HTTP/1.1","ClientRequestQuery":"","ClientRequestScheme":"https","Datetime":"2020-10-16T22:20:22Z","EdgeColoCode":"AMS","EdgeResponseStatus":403,"Kind":"firewall","MatchIndex":0,"Metadata":{"filter":"2b6a5d1e9f","type":"customer"},"OriginResponseStatus":0,"OriginatorRayID":"00","RayID":"5e3c5ca2bd4ad6f1","RuleID":"c4a2c8d1e7","Source":"firewallrules","UserAgent":"python-requests/2.24.0"}`
+	// nolint:lll
+	expect := `{
+	  "Action": "block",
+	  "ClientASN": 64496,
+	  "ClientASNDescription": "EXAMPLE-NET",
+	  "ClientCountry": "nl",
+	  "ClientIP": "192.0.2.44",
+	  "ClientIPClass": "noRecord",
+	  "ClientRefererHost": "",
+	  "ClientRefererPath": "",
+	  "ClientRefererQuery": "",
+	  "ClientRefererScheme": "",
+	  "ClientRequestHost": "api.example.com",
+	  "ClientRequestMethod": "POST",
+	  "ClientRequestPath": "/wp-login.php",
+	  "ClientRequestProtocol": "HTTP/1.1",
+	  "ClientRequestQuery": "",
+	  "ClientRequestScheme": "https",
+	  "Datetime": "2020-10-16T22:20:22Z",
+	  "EdgeColoCode": "AMS",
+	  "EdgeResponseStatus": 403,
+	  "Kind": "firewall",
+	  "MatchIndex": 0,
+	  "Metadata": {
+	    "filter": "2b6a5d1e9f",
+	    "type": "customer"
+	  },
+	  "OriginResponseStatus": 0,
+	  "OriginatorRayID": "00",
+	  "RayID": "5e3c5ca2bd4ad6f1",
+	  "RuleID": "c4a2c8d1e7",
+	  "Source": "firewallrules",
+	  "UserAgent": "python-requests/2.24.0",
+	  "p_log_type": "Cloudflare.Firewall",
+	  "p_event_time": "2020-10-16T22:20:22Z",
+	  "p_any_ip_addresses": ["192.0.2.44"],
+	  "p_any_domain_names": ["api.example.com"],
+	  "p_any_trace_ids": ["00", "5e3c5ca2bd4ad6f1"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeFirewall, input, expect)
+}
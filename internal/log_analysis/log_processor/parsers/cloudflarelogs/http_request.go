@@ -0,0 +1,101 @@
+package cloudflarelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// HTTPRequest is a Cloudflare HTTP request log record
+// nolint:lll
+type HTTPRequest struct {
+	BotScore                       null.Int32   `json:"BotScore" description:"Cloudflare Bot Score. Scores below 30 are commonly associated with automated traffic."`
+	BotScoreSrc                    null.String  `json:"BotScoreSrc" description:"Detection engine responsible for generating the Bot Score."`
+	BotTags                        []string     `json:"BotTags" description:"Type of bot traffic (if available)."`
+	CacheCacheStatus               null.String  `json:"CacheCacheStatus" description:"Cache status (ie hit, miss, expired, bypass)."`
+	CacheResponseBytes             null.Int64   `json:"CacheResponseBytes" description:"Number of bytes returned by the cache."`
+	CacheResponseStatus            null.Uint16  `json:"CacheResponseStatus" description:"HTTP status code returned by the cache to the edge."`
+	CacheTieredFill                null.Bool    `json:"CacheTieredFill" description:"Tiered Cache was used to serve this request."`
+	ClientASN                      null.Int64   `json:"ClientASN" description:"Client AS number."`
+	ClientCountry                  null.String  `json:"ClientCountry" description:"Country of the client IP address."`
+	ClientDeviceType               null.String  `json:"ClientDeviceType" description:"Client device type."`
+	ClientIP                       null.String  `json:"ClientIP" panther:"ip" description:"IP address of the client."`
+	ClientIPClass                  null.String  `json:"ClientIPClass" description:"Class of the client IP address (ie clean, badHost, searchEngine, tor)."`
+	ClientRequestBytes             null.Int64   `json:"ClientRequestBytes" description:"Number of bytes in the client request."`
+	ClientRequestHost              null.String  `json:"ClientRequestHost" panther:"hostname" description:"Host requested by the client."`
+	ClientRequestMethod            null.String  `json:"ClientRequestMethod" description:"HTTP method of the client request."`
+	ClientRequestPath              null.String  `json:"ClientRequestPath" description:"URI path requested by the client."`
+	ClientRequestProtocol          null.String  `json:"ClientRequestProtocol" description:"HTTP protocol of the client request."`
+	ClientRequestReferer           null.String  `json:"ClientRequestReferer" panther:"url" description:"HTTP request referrer."`
+	ClientRequestScheme            null.String  `json:"ClientRequestScheme" description:"The URL scheme requested by the visitor."`
+	ClientRequestURI               null.String  `json:"ClientRequestURI" description:"URI requested by the client."`
+	ClientRequestUserAgent         null.String  `json:"ClientRequestUserAgent" description:"User agent reported by the client."`
+	ClientSSLCipher                null.String  `json:"ClientSSLCipher" description:"Client SSL cipher."`
+	ClientSSLProtocol              null.String  `json:"ClientSSLProtocol" description:"Client SSL (TLS) protocol."`
+	ClientSrcPort                  null.Uint16  `json:"ClientSrcPort" description:"Client source port."`
+	ClientTCPRTTMs                 null.Int64   `json:"ClientTCPRTTMs" description:"The smoothed average of TCP round-trip time (SRTT) in milliseconds."`
+	ClientXRequestedWith           null.String  `json:"ClientXRequestedWith" description:"X-Requested-With HTTP header."`
+	EdgeCFConnectingO2O            null.Bool    `json:"EdgeCFConnectingO2O" description:"True if the request looped through multiple zones on the Cloudflare edge."`
+	EdgeColoCode                   null.String  `json:"EdgeColoCode" description:"IATA airport code of the data center that received the request."`
+	EdgeColoID                     null.Int32   `json:"EdgeColoID" description:"Cloudflare edge colo id."`
+	EdgeEndTimestamp               time.Time    `json:"EdgeEndTimestamp" tcodec:"cloudflare" description:"Timestamp at which the edge finished sending the response to the client."`
+	EdgePathingOp                  null.String  `json:"EdgePathingOp" description:"Indicates what type of response was issued for this request."`
+	EdgePathingSrc                 null.String  `json:"EdgePathingSrc" description:"Details how the request was classified based on security checks."`
+	EdgePathingStatus              null.String  `json:"EdgePathingStatus" description:"Indicates what data was used to determine the handling of this request."`
+	EdgeRateLimitAction            null.String  `json:"EdgeRateLimitAction" description:"The action taken by the blocking rule; empty if no action taken."`
+	EdgeRateLimitID                null.Int64   `json:"EdgeRateLimitID" description:"The internal rule ID of the rate-limiting rule that triggered a block (ban) or simulate action."`
+	EdgeRequestHost                null.String  `json:"EdgeRequestHost" panther:"hostname" description:"Host header on the request from the edge to the origin."`
+	EdgeResponseBodyBytes          null.Int64   `json:"EdgeResponseBodyBytes" description:"Size of the HTTP response body returned to clients."`
+	EdgeResponseBytes              null.Int64   `json:"EdgeResponseBytes" description:"Number of bytes returned by the edge to the client."`
+	EdgeResponseCompressionRatio   null.Float64 `json:"EdgeResponseCompressionRatio" description:"Edge response compression ratio."`
+	EdgeResponseContentType        null.String  `json:"EdgeResponseContentType" description:"Edge response Content-Type header value."`
+	EdgeResponseStatus             null.Uint16  `json:"EdgeResponseStatus" description:"HTTP status code returned by Cloudflare to the client."`
+	EdgeServerIP                   null.String  `json:"EdgeServerIP" panther:"ip" description:"IP of the edge server making a request to the origin."`
+	EdgeStartTimestamp             time.Time    `json:"EdgeStartTimestamp" tcodec:"cloudflare" panther:"event_time" validate:"required" description:"Timestamp at which the edge received request from the client."`
+	EdgeTimeToFirstByteMs          null.Int64   `json:"EdgeTimeToFirstByteMs" description:"Total view of Time To First Byte as measured at Cloudflare's edge in milliseconds."`
+	FirewallMatchesActions         []string     `json:"FirewallMatchesActions" description:"Array of actions the Cloudflare firewall products performed on this request."`
+	FirewallMatchesRuleIDs         []string     `json:"FirewallMatchesRuleIDs" description:"Array of RuleIDs of the firewall product that has matched the request."`
+	FirewallMatchesSources         []string     `json:"FirewallMatchesSources" description:"The firewall products that matched the request."`
+	OriginDNSResponseTimeMs        null.Int64   `json:"OriginDNSResponseTimeMs" description:"Time taken to receive a DNS response for an origin name in milliseconds."`
+	OriginIP                       null.String  `json:"OriginIP" panther:"ip" description:"IP of the origin server."`
+	OriginResponseBytes            null.Int64   `json:"OriginResponseBytes" description:"Number of bytes returned by the origin server."`
+	OriginResponseDurationMs       null.Int64   `json:"OriginResponseDurationMs" description:"Upstream response time, measured from the first data center that receives a request, in milliseconds."`
+	OriginResponseHTTPExpires      null.String  `json:"OriginResponseHTTPExpires" description:"Value of the origin 'expires' header in RFC1123 format."`
+	OriginResponseHTTPLastModified null.String  `json:"OriginResponseHTTPLastModified" description:"Value of the origin 'last-modified' header in RFC1123 format."`
+	OriginResponseStatus           null.Uint16  `json:"OriginResponseStatus" description:"Status returned by the origin server."`
+	OriginResponseTime             null.Int64   `json:"OriginResponseTime" description:"Number of nanoseconds it took the origin to return the response to edge."`
+	OriginSSLProtocol              null.String  `json:"OriginSSLProtocol" description:"SSL (TLS) protocol used to connect to the origin."`
+	ParentRayID                    null.String  `json:"ParentRayID" panther:"trace_id" description:"Ray ID of the parent request if this request was made through a Worker script."`
+	RayID                          null.String  `json:"RayID" panther:"trace_id" validate:"required" description:"ID of the request."`
+	SecurityLevel                  null.String  `json:"SecurityLevel" description:"The security level configured at the time of this request."`
+	WAFAction                      null.String  `json:"WAFAction" description:"Action taken by the WAF, if triggered."`
+	WAFFlags                       null.String  `json:"WAFFlags" description:"Additional configuration flags: simulate (0x1) | null."`
+	WAFMatchedVar                  null.String  `json:"WAFMatchedVar" description:"The full name of the most-recently matched variable."`
+	WAFProfile                     null.String  `json:"WAFProfile" description:"The WAF profile (low, med, high)."`
+	WAFRuleID                      null.String  `json:"WAFRuleID" description:"ID of the applied WAF rule."`
+	WAFRuleMessage                 null.String  `json:"WAFRuleMessage" description:"Rule message associated with the triggered rule."`
+	WorkerCPUTime                  null.Int64   `json:"WorkerCPUTime" description:"Amount of time in microseconds spent executing a worker, if any."`
+	WorkerStatus                   null.String  `json:"WorkerStatus" description:"Status returned from worker daemon."`
+	WorkerSubrequest               null.Bool    `json:"WorkerSubrequest" description:"Whether or not this request was a worker subrequest."`
+	WorkerSubrequestCount          null.Int64   `json:"WorkerSubrequestCount" description:"Number of subrequests issued by a worker when handling this request."`
+	ZoneID                         null.Int64   `json:"ZoneID" description:"Internal zone ID."`
+	ZoneName                       null.String  `json:"ZoneName" panther:"domain" description:"The human-readable name of the zone (ie 'cloudflare.com')."`
+}
@@ -0,0 +1,87 @@
+package cloudflarelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestHTTPRequest(t *testing.T) {
+	// nolint:lll
+	input := `{"BotScore":99,"BotScoreSrc":"Machine Learning","CacheCacheStatus":"hit","CacheResponseBytes":8720,"CacheResponseStatus":200,"CacheTieredFill":false,"ClientASN":15169,"ClientCountry":"us","ClientDeviceType":"desktop","ClientIP":"203.0.113.10","ClientIPClass":"noRecord","ClientRequestBytes":2402,"ClientRequestHost":"www.example.com","ClientRequestMethod":"GET","ClientRequestPath":"/index.html","ClientRequestProtocol":"HTTP/2","ClientRequestReferer":"https://search.example.org/?q=example","ClientRequestURI":"/index.html?lang=en","ClientRequestUserAgent":"Mozilla/5.0","ClientSSLCipher":"ECDHE-ECDSA-AES128-GCM-SHA256","ClientSSLProtocol":"TLSv1.3","ClientSrcPort":54321,"EdgeColoCode":"SJC","EdgeColoID":14,"EdgeEndTimestamp":1602886822119000000,"EdgePathingOp":"wl","EdgePathingSrc":"macro","EdgePathingStatus":"nr","EdgeRateLimitAction":"","EdgeRateLimitID":0,"EdgeRequestHost":"www.example.com","EdgeResponseBytes":9256,"EdgeResponseCompressionRatio":2.5,"EdgeResponseContentType":"text/html","EdgeResponseStatus":200,"EdgeServerIP":"","EdgeStartTimestamp":1602886822094000000,"FirewallMatchesActions":[],"FirewallMatchesRuleIDs":[],"FirewallMatchesSources":[],"OriginIP":"198.51.100.2","OriginResponseBytes":0,"OriginResponseStatus":0,"OriginResponseTime":0,"ParentRayID":"00","RayID":"5e3c5c9f1d6e2a3b","SecurityLevel":"med","WAFAction":"unknown","WAFProfile":"unknown","WorkerSubrequest":false,"ZoneID":123456789}`
+	// nolint:lll
+	expect := `{
+	  "BotScore": 99,
+	  "BotScoreSrc": "Machine Learning",
+	  "CacheCacheStatus": "hit",
+	  "CacheResponseBytes": 8720,
+	  "CacheResponseStatus": 200,
+	  "CacheTieredFill": false,
+	  "ClientASN": 15169,
+	  "ClientCountry": "us",
+	  "ClientDeviceType": "desktop",
+	  "ClientIP": "203.0.113.10",
+	  "ClientIPClass": "noRecord",
+	  "ClientRequestBytes": 2402,
+	  "ClientRequestHost": "www.example.com",
+	  "ClientRequestMethod": "GET",
+	  "ClientRequestPath": "/index.html",
+	  "ClientRequestProtocol": "HTTP/2",
+	  "ClientRequestReferer": "https://search.example.org/?q=example",
+	  "ClientRequestURI": "/index.html?lang=en",
+	  "ClientRequestUserAgent": "Mozilla/5.0",
+	  "ClientSSLCipher": "ECDHE-ECDSA-AES128-GCM-SHA256",
+	  "ClientSSLProtocol": "TLSv1.3",
+	  "ClientSrcPort": 54321,
+	  "EdgeColoCode": "SJC",
+	  "EdgeColoID": 14,
+	  "EdgeEndTimestamp": "2020-10-16T22:20:22.119Z",
+	  "EdgePathingOp": "wl",
+	  "EdgePathingSrc": "macro",
+	  "EdgePathingStatus": "nr",
+	  "EdgeRateLimitAction": "",
+	  "EdgeRateLimitID": 0,
+	  "EdgeRequestHost": "www.example.com",
+	  "EdgeResponseBytes": 9256,
+	  "EdgeResponseCompressionRatio": 2.5,
+	  "EdgeResponseContentType": "text/html",
+	  "EdgeResponseStatus": 200,
+	  "EdgeServerIP": "",
+	  "EdgeStartTimestamp": "2020-10-16T22:20:22.094Z",
+	  "OriginIP": "198.51.100.2",
+	  "OriginResponseBytes": 0,
+	  "OriginResponseStatus": 0,
+	  "OriginResponseTime": 0,
+	  "ParentRayID": "00",
+	  "RayID": "5e3c5c9f1d6e2a3b",
+	  "SecurityLevel": "med",
+	  "WAFAction": "unknown",
+	  "WAFProfile": "unknown",
+	  "WorkerSubrequest": false,
+	  "ZoneID": 123456789,
+	  "p_log_type": "Cloudflare.HttpRequest",
+	  "p_event_time": "2020-10-16T22:20:22.094Z",
+	  "p_any_ip_addresses": ["198.51.100.2", "203.0.113.10"],
+	  "p_any_domain_names": ["search.example.org", "www.example.com"],
+	  "p_any_trace_ids": ["00", "5e3c5c9f1d6e2a3b"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeHTTPRequest, input, expect)
+}
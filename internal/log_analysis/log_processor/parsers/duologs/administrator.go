@@ -0,0 +1,37 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Administrator is a Duo administrator log record
+// nolint:lll
+type Administrator struct {
+	ISOTimestamp time.Time   `json:"isotimestamp" tcodec:"rfc3339" panther:"event_time" validate:"required" description:"ISO8601 timestamp of the event."`
+	Timestamp    time.Time   `json:"timestamp" tcodec:"unix" panther:"event_time" validate:"required" description:"Unix timestamp of the event."`
+	Action       null.String `json:"action" validate:"required" description:"The type of change that was performed (ie admin_login, user_update)."`
+	Username     null.String `json:"username" panther:"username" validate:"required" description:"The full name of the administrator who performed the action."`
+	Object       null.String `json:"object" description:"The name of the object that was acted on."`
+	Description  null.String `json:"description" description:"JSON encoded string with additional details about the action."`
+	Host         null.String `json:"host" panther:"hostname" description:"The Duo API hostname."`
+}
@@ -0,0 +1,45 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestAdministrator(t *testing.T) {
+	// nolint:lll
+	input := `{"action":"user_update","description":"{\"notes\": \"Joe asked for their nickname to be displayed instead of Joseph.\", \"realname\": \"Joe Smith\"}","isotimestamp":"2020-10-15T15:09:42+00:00","object":"jsmith","timestamp":1602774582,"username":"admin"}`
+	// nolint:lll
+	expect := `{
+	  "isotimestamp": "2020-10-15T15:09:42Z",
+	  "timestamp": 1602774582,
+	  "action": "user_update",
+	  "username": "admin",
+	  "object": "jsmith",
+	  "description": "{\"notes\": \"Joe asked for their nickname to be displayed instead of Joseph.\", \"realname\": \"Joe Smith\"}",
+	  "p_log_type": "Duo.Administrator",
+	  "p_event_time": "2020-10-15T15:09:42Z",
+	  "p_any_usernames": [
+	    "admin"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeAdministrator, input, expect)
+}
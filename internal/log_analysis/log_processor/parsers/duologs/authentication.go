@@ -0,0 +1,96 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Authentication is a Duo authentication log record
+// nolint:lll
+type Authentication struct {
+	ISOTimestamp             time.Time            `json:"isotimestamp" tcodec:"rfc3339" panther:"event_time" description:"ISO8601 timestamp of the event."`
+	Timestamp                time.Time            `json:"timestamp" tcodec:"unix" panther:"event_time" validate:"required" description:"Unix timestamp of the event."`
+	TxID                     null.String          `json:"txid" panther:"trace_id" description:"The transaction ID of the event."`
+	EventType                null.String          `json:"event_type" description:"The type of activity logged (authentication or enrollment)."`
+	Result                   null.String          `json:"result" validate:"required" description:"The result of the authentication attempt (success, denied, fraud)."`
+	Reason                   null.String          `json:"reason" description:"The reason for the authentication attempt result."`
+	Factor                   null.String          `json:"factor" description:"The authentication factor (ie duo_push, phone_call, passcode)."`
+	Alias                    null.String          `json:"alias" panther:"username" description:"The username alias used to log in."`
+	Email                    null.String          `json:"email" panther:"email" description:"The email address of the user."`
+	User                     *DuoUser             `json:"user" description:"The authenticating user."`
+	Application              *DuoApplication      `json:"application" description:"The application the user authenticated to."`
+	AccessDevice             *DuoAccessDevice     `json:"access_device" description:"The device used to access the application."`
+	AuthDevice               *DuoAuthDevice       `json:"auth_device" description:"The device used to approve the authentication."`
+	TrustedEndpointStatus    null.String          `json:"trusted_endpoint_status" description:"The status of the trusted endpoint (trusted, not trusted, unknown)."`
+	OODSoftware              null.String          `json:"ood_software" description:"Out of date software detected on the access device."`
+	AdaptiveTrustAssessments *jsoniter.RawMessage `json:"adaptive_trust_assessments" description:"Risk-based authentication assessments of the attempt."`
+}
+
+// DuoUser is the user of a Duo authentication
+// nolint:lll
+type DuoUser struct {
+	Key    null.String `json:"key" description:"The user's key."`
+	Name   null.String `json:"name" panther:"username" description:"The user's username."`
+	Groups []string    `json:"groups" description:"The user's group memberships."`
+}
+
+// DuoApplication is the application of a Duo authentication
+type DuoApplication struct {
+	Key  null.String `json:"key" description:"The application's integration key."`
+	Name null.String `json:"name" description:"The application's name."`
+}
+
+// DuoLocation is the geolocation of a device
+type DuoLocation struct {
+	City    null.String `json:"city" description:"The city name."`
+	State   null.String `json:"state" description:"The state, county, province, or prefecture."`
+	Country null.String `json:"country" description:"The country name."`
+}
+
+// DuoAccessDevice is the device used to access an application
+// nolint:lll
+type DuoAccessDevice struct {
+	IP                  null.String          `json:"ip" panther:"ip" description:"The IP address of the access device."`
+	Hostname            null.String          `json:"hostname" panther:"hostname" description:"The hostname of the access device."`
+	Location            *DuoLocation         `json:"location" description:"The geolocation of the access device."`
+	Browser             null.String          `json:"browser" description:"The browser used to access the application."`
+	BrowserVersion      null.String          `json:"browser_version" description:"The browser version."`
+	FlashVersion        null.String          `json:"flash_version" description:"The Flash plugin version."`
+	JavaVersion         null.String          `json:"java_version" description:"The Java plugin version."`
+	OS                  null.String          `json:"os" description:"The operating system of the access device."`
+	OSVersion           null.String          `json:"os_version" description:"The operating system version of the access device."`
+	IsEncryptionEnabled *jsoniter.RawMessage `json:"is_encryption_enabled" description:"Whether disk encryption is enabled (true, false or \"unknown\")."`
+	IsFirewallEnabled   *jsoniter.RawMessage `json:"is_firewall_enabled" description:"Whether the firewall is enabled (true, false or \"unknown\")."`
+	IsPasswordSet       *jsoniter.RawMessage `json:"is_password_set" description:"Whether a password is set (true, false or \"unknown\")."`
+	SecurityAgents      *jsoniter.RawMessage `json:"security_agents" description:"The security agents detected on the access device."`
+	EPKey               null.String          `json:"epkey" description:"The endpoint key of the access device."`
+}
+
+// DuoAuthDevice is the device used to approve an authentication
+// nolint:lll
+type DuoAuthDevice struct {
+	IP       null.String  `json:"ip" panther:"ip" description:"The IP address of the authentication device."`
+	Location *DuoLocation `json:"location" description:"The geolocation of the authentication device."`
+	Name     null.String  `json:"name" description:"The name of the authentication device."`
+}
@@ -0,0 +1,98 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestAuthentication(t *testing.T) {
+	// nolint:lll
+	input := `{"access_device":{"browser":"Chrome","browser_version":"86.0.4240.80","flash_version":"uninstalled","hostname":null,"ip":"203.0.113.10","is_encryption_enabled":true,"is_firewall_enabled":"unknown","is_password_set":true,"java_version":"uninstalled","location":{"city":"Ann Arbor","country":"United States","state":"Michigan"},"os":"Mac OS X","os_version":"10.15.7","security_agents":[]},"alias":"","application":{"key":"DIY231J8BR23QK4UKBY8","name":"Microsoft Azure Active Directory"},"auth_device":{"ip":"198.51.100.22","location":{"city":"Ann Arbor","country":"United States","state":"Michigan"},"name":"My iPhone X (734-555-2342)"},"email":"narroway@example.com","event_type":"authentication","factor":"duo_push","isotimestamp":"2020-10-15T18:56:20.351346+00:00","ood_software":null,"reason":"user_approved","result":"success","timestamp":1602788180,"trusted_endpoint_status":"not trusted","txid":"340a23e3-23f3-4dd8-ad56-2a8bb5d2f1ee","user":{"groups":["Duo Users","CorpHQ Users"],"key":"DU3KC77WJ06Y5HIV7XKQ","name":"narroway"}}`
+	// nolint:lll
+	expect := `{
+	  "isotimestamp": "2020-10-15T18:56:20.351346Z",
+	  "timestamp": 1602788180,
+	  "txid": "340a23e3-23f3-4dd8-ad56-2a8bb5d2f1ee",
+	  "event_type": "authentication",
+	  "result": "success",
+	  "reason": "user_approved",
+	  "factor": "duo_push",
+	  "alias": "",
+	  "email": "narroway@example.com",
+	  "user": {
+	    "key": "DU3KC77WJ06Y5HIV7XKQ",
+	    "name": "narroway",
+	    "groups": [
+	      "Duo Users",
+	      "CorpHQ Users"
+	    ]
+	  },
+	  "application": {
+	    "key": "DIY231J8BR23QK4UKBY8",
+	    "name": "Microsoft Azure Active Directory"
+	  },
+	  "access_device": {
+	    "ip": "203.0.113.10",
+	    "location": {
+	      "city": "Ann Arbor",
+	      "state": "Michigan",
+	      "country": "United States"
+	    },
+	    "browser": "Chrome",
+	    "browser_version": "86.0.4240.80",
+	    "flash_version": "uninstalled",
+	    "java_version": "uninstalled",
+	    "os": "Mac OS X",
+	    "os_version": "10.15.7",
+	    "is_encryption_enabled": true,
+	    "is_firewall_enabled": "unknown",
+	    "is_password_set": true,
+	    "security_agents": []
+	  },
+	  "auth_device": {
+	    "ip": "198.51.100.22",
+	    "location": {
+	      "city": "Ann Arbor",
+	      "state": "Michigan",
+	      "country": "United States"
+	    },
+	    "name": "My iPhone X (734-555-2342)"
+	  },
+	  "trusted_endpoint_status": "not trusted",
+	  "p_log_type": "Duo.Authentication",
+	  "p_event_time": "2020-10-15T18:56:20.351346Z",
+	  "p_any_trace_ids": [
+	    "340a23e3-23f3-4dd8-ad56-2a8bb5d2f1ee"
+	  ],
+	  "p_any_emails": [
+	    "narroway@example.com"
+	  ],
+	  "p_any_usernames": [
+	    "narroway"
+	  ],
+	  "p_any_ip_addresses": [
+	    "198.51.100.22",
+	    "203.0.113.10"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeAuthentication, input, expect)
+}
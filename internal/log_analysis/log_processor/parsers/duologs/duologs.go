@@ -0,0 +1,49 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+const (
+	// LogTypePrefix is the prefix of all log types parsed by this package
+	LogTypePrefix = "Duo"
+	// TypeAuthentication is the log type of Duo authentication logs
+	TypeAuthentication = LogTypePrefix + ".Authentication"
+	// TypeAdministrator is the log type of Duo administrator logs
+	TypeAdministrator = LogTypePrefix + ".Administrator"
+)
+
+func init() {
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeAuthentication,
+		Description:  `Duo authentication logs as returned by the Duo Admin API v2 (/admin/v2/logs/authentication)`,
+		ReferenceURL: `https://duo.com/docs/adminapi#authentication-logs`,
+	}, func() interface{} {
+		return &Authentication{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeAdministrator,
+		Description:  `Duo administrator logs as returned by the Duo Admin API (/admin/v1/logs/administrator)`,
+		ReferenceURL: `https://duo.com/docs/adminapi#administrator-logs`,
+	}, func() interface{} {
+		return &Administrator{}
+	})
+}
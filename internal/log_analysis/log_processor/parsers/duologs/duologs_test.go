@@ -0,0 +1,53 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+// Logs with a unix timestamp and an action (ie AWS WAF logs) must not be parsed as Duo logs
+func TestDuoLogTypesAreDistinct(t *testing.T) {
+	logTypes := []string{TypeAuthentication, TypeAdministrator}
+	// nolint:lll
+	samples := map[string]string{
+		TypeAuthentication: `{"access_device":{"browser":"Chrome","browser_version":"86.0.4240.80","flash_version":"uninstalled","hostname":null,"ip":"203.0.113.10","is_encryption_enabled":true,"is_firewall_enabled":"unknown","is_password_set":true,"java_version":"uninstalled","location":{"city":"Ann Arbor","country":"United States","state":"Michigan"},"os":"Mac OS X","os_version":"10.15.7","security_agents":[]},"alias":"","application":{"key":"DIY231J8BR23QK4UKBY8","name":"Microsoft Azure Active Directory"},"auth_device":{"ip":"198.51.100.22","location":{"city":"Ann Arbor","country":"United States","state":"Michigan"},"name":"My iPhone X (734-555-2342)"},"email":"narroway@example.com","event_type":"authentication","factor":"duo_push","isotimestamp":"2020-10-15T18:56:20.351346+00:00","ood_software":null,"reason":"user_approved","result":"success","timestamp":1602788180,"trusted_endpoint_status":"not trusted","txid":"340a23e3-23f3-4dd8-ad56-2a8bb5d2f1ee","user":{"groups":["Duo Users","CorpHQ Users"],"key":"DU3KC77WJ06Y5HIV7XKQ","name":"narroway"}}`,
+		TypeAdministrator:  `{"action":"user_update","description":"{\"notes\": \"Joe asked for their nickname to be displayed instead of Joseph.\", \"realname\": \"Joe Smith\"}","isotimestamp":"2020-10-15T15:09:42+00:00","object":"jsmith","timestamp":1602774582,"username":"admin"}`,
+		"AWS.WAFWebACL":    `{"timestamp":1576280412771,"formatVersion":1,"webaclId":"arn:aws:wafv2:ap-southeast-2:111122223333:regional/webacl/STMTest/1EXAMPLE-2ARN-3ARN-4ARN-123456EXAMPLE","terminatingRuleId":"STMTest_SQLi_XSS","terminatingRuleType":"REGULAR","action":"BLOCK","terminatingRuleMatchDetails":[{"conditionType":"SQL_INJECTION","location":"UNKNOWN","matchedData":["10","AND","1"]}],"httpSourceName":"ALB","httpSourceId":"111122223333-app/my-alb/1234567890abcdef","ruleGroupList":[{"ruleGroupId":"arn:aws:wafv2:ap-southeast-2:444455556666:regional/rulegroup/shared/a1b2c3d4","terminatingRule":null,"nonTerminatingMatchingRules":[],"excludedRules":null}],"rateBasedRuleList":[],"nonTerminatingMatchingRules":[],"httpRequest":{"clientIp":"1.1.1.1","country":"AU","headers":[{"name":"Host","value":"www.example.com:8080"},{"name":"User-Agent","value":"curl/7.53.1"}],"uri":"/","args":"x=%2A%2F10%20AND%201%3D1","httpVersion":"HTTP/1.1","httpMethod":"GET","requestId":"1-5df4e35c-6a1c3b2f7e2b0cb25b5fd49e"},"labels":[{"name":"awswaf:managed:aws:sql-database:SQLi_QueryArguments"}]}`,
+	}
+	for sampleType, sample := range samples {
+		for _, logType := range logTypes {
+			entry := logtypes.DefaultRegistry().Get(logType)
+			require.NotNil(t, entry, logType)
+			parser, err := entry.NewParser(nil)
+			require.NoError(t, err)
+			_, err = parser.ParseLog(sample)
+			if logType == sampleType {
+				assert.NoError(t, err, "%s sample", sampleType)
+				continue
+			}
+			assert.Error(t, err, "%s sample parsed as %s", sampleType, logType)
+		}
+	}
+}
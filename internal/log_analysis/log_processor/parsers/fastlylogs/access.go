@@ -0,0 +1,66 @@
+package fastlylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Access is a Fastly access log record using the example JSON log format from the Fastly docs:
+//
+//	{
+//	  "timestamp": "%{strftime(\{"%Y-%m-%dT%H:%M:%S%z"\}, time.start)}V",
+//	  "client_ip": "%{req.http.Fastly-Client-IP}V",
+//	  "geo_country": "%{client.geo.country_name}V",
+//	  "geo_city": "%{client.geo.city}V",
+//	  "host": "%{if(req.http.Fastly-Orig-Host, req.http.Fastly-Orig-Host, req.http.Host)}V",
+//	  "url": "%{json.escape(req.url)}V",
+//	  "request_method": "%{json.escape(req.method)}V",
+//	  "request_protocol": "%{json.escape(req.proto)}V",
+//	  "request_referer": "%{json.escape(req.http.referer)}V",
+//	  "request_user_agent": "%{json.escape(req.http.User-Agent)}V",
+//	  "response_state": "%{json.escape(fastly_info.state)}V",
+//	  "response_status": %{resp.status}V,
+//	  "response_reason": %{if(resp.response, "%22"+json.escape(resp.response)+"%22", "null")}V,
+//	  "response_body_size": %{resp.body_bytes_written}V,
+//	  "fastly_server": "%{json.escape(server.identity)}V",
+//	  "fastly_is_edge": %{if(fastly.ff.visits_this_service == 0, "true", "false")}V
+//	}
+//
+// nolint:lll
+type Access struct {
+	Timestamp        time.Time   `json:"timestamp" tcodec:"layout=2006-01-02T15:04:05Z0700" panther:"event_time" validate:"required" description:"The time the request was received (time.start)"`
+	ClientIP         null.String `json:"client_ip" panther:"ip" validate:"required" description:"The IP address of the client (req.http.Fastly-Client-IP)"`
+	GeoCountry       null.String `json:"geo_country" description:"The country name of the client (client.geo.country_name)"`
+	GeoCity          null.String `json:"geo_city" description:"The city of the client (client.geo.city)"`
+	Host             null.String `json:"host" panther:"hostname" description:"The host requested by the client (req.http.Fastly-Orig-Host or req.http.Host)"`
+	URL              null.String `json:"url" description:"The URL path and query requested by the client (req.url)"`
+	RequestMethod    null.String `json:"request_method" description:"The HTTP method of the request (req.method)"`
+	RequestProtocol  null.String `json:"request_protocol" description:"The HTTP protocol version of the request (req.proto)"`
+	RequestReferer   null.String `json:"request_referer" panther:"url" description:"The Referer header of the request (req.http.referer)"`
+	RequestUserAgent null.String `json:"request_user_agent" description:"The User-Agent header of the request (req.http.User-Agent)"`
+	ResponseState    null.String `json:"response_state" description:"The state of the request with optional suffixes describing special cases (fastly_info.state)"`
+	ResponseStatus   null.Uint16 `json:"response_status" description:"The HTTP status code of the response (resp.status)"`
+	ResponseReason   null.String `json:"response_reason" description:"The HTTP status message of the response (resp.response)"`
+	ResponseBodySize null.Int64  `json:"response_body_size" description:"The number of bytes of the response body sent to the client (resp.body_bytes_written)"`
+	FastlyServer     null.String `json:"fastly_server" description:"The identity of the Fastly cache server that processed the request (server.identity)"`
+	FastlyIsEdge     null.Bool   `json:"fastly_is_edge" description:"Whether this is the first visit of the request to this service (edge) or a shield node"`
+}
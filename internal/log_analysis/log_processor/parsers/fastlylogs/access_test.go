@@ -0,0 +1,53 @@
+package fastlylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestAccess(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-10-16T22:20:22+0000","client_ip":"203.0.113.10","geo_country":"united states","geo_city":"san jose","host":"www.example.com","url":"/assets/app.js?v=3","request_method":"GET","request_protocol":"HTTP/2","request_referer":"https://www.example.com/","request_user_agent":"Mozilla/5.0","response_state":"HIT-CLUSTER","response_status":200,"response_reason":null,"response_body_size":48213,"fastly_server":"cache-sjc10041-SJC","fastly_is_edge":true}`
+	// nolint:lll
+	expect := `{
+	  "timestamp": "2020-10-16T22:20:22Z",
+	  "client_ip": "203.0.113.10",
+	  "geo_country": "united states",
+	  "geo_city": "san jose",
+	  "host": "www.example.com",
+	  "url": "/assets/app.js?v=3",
+	  "request_method": "GET",
+	  "request_protocol": "HTTP/2",
+	  "request_referer": "https://www.example.com/",
+	  "request_user_agent": "Mozilla/5.0",
+	  "response_state": "HIT-CLUSTER",
+	  "response_status": 200,
+	  "response_body_size": 48213,
+	  "fastly_server": "cache-sjc10041-SJC",
+	  "fastly_is_edge": true,
+	  "p_log_type": "Fastly.Access",
+	  "p_event_time": "2020-10-16T22:20:22Z",
+	  "p_any_ip_addresses": ["203.0.113.10"],
+	  "p_any_domain_names": ["www.example.com"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeAccess, input, expect)
+}
@@ -0,0 +1,41 @@
+package fastlylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+const (
+	// LogTypePrefix is the prefix of all log types parsed by this package
+	LogTypePrefix = "Fastly"
+	// TypeAccess is the log type of Fastly access logs in JSON format
+	TypeAccess = LogTypePrefix + ".Access"
+)
+
+func init() {
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name: TypeAccess,
+		Description: `Fastly real-time access logs in JSON format.
+NOTE: The log format of the logging endpoint must be set to the JSON format described in the reference URL`,
+		ReferenceURL: `https://docs.fastly.com/en/guides/custom-log-formats#example-json-format`,
+	}, func() interface{} {
+		return &Access{}
+	})
+}
@@ -0,0 +1,61 @@
+package fortinetlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Event is a FortiGate event log (system, vpn, user, ha, wireless, etc.)
+// nolint:lll
+type Event struct {
+	Header
+	LogDescription    null.String `json:"logdesc" description:"The description of the log message."`
+	Message           null.String `json:"msg" description:"The log message."`
+	Action            null.String `json:"action" description:"The action taken."`
+	Status            null.String `json:"status" description:"The status of the action."`
+	Reason            null.String `json:"reason" description:"The reason of the action."`
+	User              null.String `json:"user" description:"The name of the user."`
+	Group             null.String `json:"group" description:"The user group of the user."`
+	UserInterface     null.String `json:"ui" description:"The user interface of the action (ie GUI(192.0.2.10), ssh(192.0.2.10))."`
+	Method            null.String `json:"method" description:"The method of the action."`
+	Profile           null.String `json:"profile" description:"The name of the profile."`
+	SourceIP          null.String `json:"srcip" panther:"ip" description:"The source IP address."`
+	SourcePort        null.Uint16 `json:"srcport" description:"The source port."`
+	DestinationIP     null.String `json:"dstip" panther:"ip" description:"The destination IP address."`
+	DestinationPort   null.Uint16 `json:"dstport" description:"The destination port."`
+	RemoteIP          null.String `json:"remip" panther:"ip" description:"The remote IP address of the VPN tunnel."`
+	LocalIP           null.String `json:"locip" panther:"ip" description:"The local IP address of the VPN tunnel."`
+	RemotePort        null.Uint16 `json:"remport" description:"The remote port of the VPN tunnel."`
+	LocalPort         null.Uint16 `json:"locport" description:"The local port of the VPN tunnel."`
+	TunnelType        null.String `json:"tunneltype" description:"The type of the VPN tunnel."`
+	TunnelID          null.Int64  `json:"tunnelid" description:"The ID of the VPN tunnel."`
+	TunnelIP          null.String `json:"tunnelip" panther:"ip" description:"The IP address assigned to the VPN tunnel."`
+	VPNTunnel         null.String `json:"vpntunnel" description:"The name of the VPN tunnel."`
+	XAuthUser         null.String `json:"xauthuser" description:"The XAuth user name of the VPN tunnel."`
+	XAuthGroup        null.String `json:"xauthgroup" description:"The XAuth group name of the VPN tunnel."`
+	SentBytes         null.Int64  `json:"sentbyte" description:"The number of bytes sent."`
+	ReceivedBytes     null.Int64  `json:"rcvdbyte" description:"The number of bytes received."`
+	Duration          null.Int64  `json:"duration" description:"The duration in seconds."`
+	ConfigTransaction null.Int64  `json:"cfgtid" description:"The ID of the configuration transaction."`
+	ConfigPath        null.String `json:"cfgpath" description:"The path of the configuration change."`
+	ConfigObject      null.String `json:"cfgobj" description:"The object of the configuration change."`
+	ConfigAttributes  null.String `json:"cfgattr" description:"The attributes of the configuration change."`
+	Hostname          null.String `json:"hostname" panther:"hostname" description:"The host name associated with the event."`
+}
@@ -0,0 +1,65 @@
+package fortinetlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestEvent(t *testing.T) {
+	// nolint:lll
+	input := `<189>date=2020-10-15 time=12:20:33 devname="FGT60E-HQ" devid="FGT60E4Q17000001" logid="0100032001" type="event" subtype="system" level="information" vd="root" eventtime=1602757233000000000 tz="+0200" logdesc="Admin login successful" sn="1602757233" user="admin" ui="https(192.0.2.10)" method="https" srcip=192.0.2.10 dstip=192.0.2.1 action="login" status="success" reason="none" profile="super_admin" msg="Administrator admin logged in successfully from https(192.0.2.10)"`
+	// nolint:lll
+	expect := `{
+	  "action": "login",
+	  "date": "2020-10-15",
+	  "devid": "FGT60E4Q17000001",
+	  "devname": "FGT60E-HQ",
+	  "dstip": "192.0.2.1",
+	  "eventtime": 1602757233000000000,
+	  "extra": {
+	    "sn": "1602757233"
+	  },
+	  "level": "information",
+	  "logdesc": "Admin login successful",
+	  "logid": "0100032001",
+	  "method": "https",
+	  "msg": "Administrator admin logged in successfully from https(192.0.2.10)",
+	  "p_any_ip_addresses": [
+	    "192.0.2.1",
+	    "192.0.2.10"
+	  ],
+	  "p_event_time": "2020-10-15T10:20:33Z",
+	  "p_log_type": "FortiGate.Event",
+	  "profile": "super_admin",
+	  "reason": "none",
+	  "srcip": "192.0.2.10",
+	  "status": "success",
+	  "subtype": "system",
+	  "time": "12:20:33",
+	  "type": "event",
+	  "tz": "+0200",
+	  "ui": "https(192.0.2.10)",
+	  "user": "admin",
+	  "vd": "root"
+	}`
+	testutil.CheckRegisteredParser(t, TypeEvent, input, expect)
+}
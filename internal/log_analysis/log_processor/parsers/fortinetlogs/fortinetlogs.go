@@ -0,0 +1,286 @@
+package fortinetlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	// LogTypePrefix is the prefix of all log types parsed by this package
+	LogTypePrefix = "FortiGate"
+	// TypeTraffic is the log type of FortiGate traffic logs
+	TypeTraffic = LogTypePrefix + ".Traffic"
+	// TypeUTM is the log type of FortiGate security profile (UTM) logs
+	TypeUTM = LogTypePrefix + ".UTM"
+	// TypeEvent is the log type of FortiGate event logs
+	TypeEvent = LogTypePrefix + ".Event"
+
+	referenceURL = `https://docs.fortinet.com/document/fortigate/6.4.0/fortios-log-message-reference`
+)
+
+func init() {
+	logtypes.MustRegister(
+		logtypes.Config{
+			Name: TypeTraffic,
+			Description: `FortiGate traffic logs in the key=value syslog format.
+NOTE: Fields not defined in the schema are collected in the extra field.`,
+			ReferenceURL: referenceURL,
+			Schema:       pantherlog.MustBuildEventSchema(&Traffic{}),
+			NewParser:    newParserFactory(TypeTraffic, "traffic", func() interface{} { return &Traffic{} }),
+		},
+		logtypes.Config{
+			Name: TypeUTM,
+			Description: `FortiGate security profile (UTM) logs in the key=value syslog format.
+NOTE: Fields not defined in the schema are collected in the extra field.`,
+			ReferenceURL: referenceURL,
+			Schema:       pantherlog.MustBuildEventSchema(&UTM{}),
+			NewParser:    newParserFactory(TypeUTM, "utm", func() interface{} { return &UTM{} }),
+		},
+		logtypes.Config{
+			Name: TypeEvent,
+			Description: `FortiGate event logs in the key=value syslog format.
+NOTE: Fields not defined in the schema are collected in the extra field.`,
+			ReferenceURL: referenceURL,
+			Schema:       pantherlog.MustBuildEventSchema(&Event{}),
+			NewParser:    newParserFactory(TypeEvent, "event", func() interface{} { return &Event{} }),
+		},
+	)
+}
+
+// Header holds the fields common to all FortiGate logs
+// nolint:lll
+type Header struct {
+	Date       null.String       `json:"date" validate:"required" description:"The date the log was generated on the device."`
+	Time       null.String       `json:"time" validate:"required" description:"The time the log was generated on the device."`
+	TimeZone   null.String       `json:"tz" description:"The time zone of the device (ie +0200)."`
+	EventTime  null.Int64        `json:"eventtime" description:"The time the event was generated as a UNIX timestamp (nanoseconds since FortiOS 6.2)."`
+	DeviceName null.String       `json:"devname" description:"The host name of the device."`
+	DeviceID   null.String       `json:"devid" description:"The serial number of the device."`
+	LogID      null.String       `json:"logid" validate:"required" description:"The log message identifier."`
+	Type       null.String       `json:"type" validate:"required" description:"The log type."`
+	Subtype    null.String       `json:"subtype" description:"The log subtype."`
+	Level      null.String       `json:"level" description:"The log priority level."`
+	VDOM       null.String       `json:"vd" description:"The name of the virtual domain."`
+	Extra      map[string]string `json:"extra" description:"Fields not defined in the schema."`
+}
+
+var _ pantherlog.EventTimer = (*Header)(nil)
+
+// PantherEventTime implements pantherlog.EventTimer interface.
+// Logs without a time zone are assumed to be UTC.
+func (h *Header) PantherEventTime() time.Time {
+	const layout = "2006-01-02 15:04:05"
+	ts := h.Date.Value + " " + h.Time.Value
+	if tz := h.TimeZone.Value; tz != "" {
+		if tm, err := time.Parse(layout+" -0700", ts+" "+tz); err == nil {
+			return tm
+		}
+	}
+	tm, _ := time.Parse(layout, ts)
+	return tm
+}
+
+// FortiGate logs are shipped over syslog so lines can be prefixed by a `<PRI>` header
+var rxSyslogPriority = regexp.MustCompile(`^<\d{1,3}>`)
+
+// ScanPairs scans the key=value pairs of a FortiGate log line.
+// Values can be double quoted and use `\` to escape quotes. Tokens without `=` are skipped.
+func ScanPairs(input string, fn func(key, value string)) error {
+	input = rxSyslogPriority.ReplaceAllLiteralString(input, "")
+	for {
+		input = strings.TrimLeft(input, " \t\r\n")
+		if input == "" {
+			return nil
+		}
+		end := strings.IndexAny(input, " =")
+		if end == -1 {
+			// Trailing token without a value
+			return nil
+		}
+		if input[end] == ' ' {
+			// Skip tokens without a value, ie syslog timestamps and hosts
+			input = input[end:]
+			continue
+		}
+		key := input[:end]
+		input = input[end+1:]
+		if !strings.HasPrefix(input, `"`) {
+			end := strings.IndexByte(input, ' ')
+			if end == -1 {
+				end = len(input)
+			}
+			fn(key, input[:end])
+			input = input[end:]
+			continue
+		}
+		value, tail, err := scanQuoted(input)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for %q", key)
+		}
+		fn(key, value)
+		input = tail
+	}
+}
+
+func scanQuoted(input string) (value, tail string, err error) {
+	var b strings.Builder
+	for i := 1; i < len(input); i++ {
+		switch c := input[i]; c {
+		case '"':
+			return b.String(), input[i+1:], nil
+		case '\\':
+			if i+1 < len(input) {
+				i++
+				c = input[i]
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", errors.New("unterminated quoted value")
+}
+
+// fieldNames collects the JSON field names of a struct type including its embedded structs
+func fieldNames(typ reflect.Type, names map[string]bool) map[string]bool {
+	if names == nil {
+		names = map[string]bool{}
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fieldNames(field.Type, names)
+			continue
+		}
+		name := field.Tag.Get("json")
+		if pos := strings.IndexByte(name, ','); pos != -1 {
+			name = name[:pos]
+		}
+		names[name] = true
+	}
+	return names
+}
+
+func newParserFactory(logType, typ string, newEvent func() interface{}) parsers.Factory {
+	fields := fieldNames(reflect.TypeOf(newEvent()).Elem(), nil)
+	return parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+		return &Parser{
+			logType:  logType,
+			typ:      typ,
+			fields:   fields,
+			newEvent: newEvent,
+			stream:   jsoniter.NewStream(jsonAPI, nil, 4096),
+		}, nil
+	})
+}
+
+var jsonAPI = common.BuildJSON()
+
+// Parser parses FortiGate key=value logs of a specific type.
+// The pairs of each log are mapped to the fields of the typed event, unknown keys are collected in the extra field.
+type Parser struct {
+	logType  string
+	typ      string
+	fields   map[string]bool
+	newEvent func() interface{}
+	stream   *jsoniter.Stream
+	builder  pantherlog.ResultBuilder
+	extra    [][2]string
+}
+
+var _ parsers.Interface = (*Parser)(nil)
+
+// ParseLog implements parsers.Interface
+func (p *Parser) ParseLog(log string) ([]*parsers.Result, error) {
+	stream := p.stream
+	stream.Reset(nil)
+	stream.WriteObjectStart()
+	more := false
+	extra := p.extra[:0]
+	typ := ""
+	err := ScanPairs(strings.TrimSpace(log), func(key, value string) {
+		if key == "type" {
+			typ = value
+		}
+		// FortiGate uses empty values and N/A for fields that do not apply
+		if value == "" || value == "N/A" {
+			return
+		}
+		// The extra field name is reserved
+		if !p.fields[key] || key == "extra" {
+			extra = append(extra, [2]string{key, value})
+			return
+		}
+		if more {
+			stream.WriteMore()
+		}
+		stream.WriteObjectField(key)
+		stream.WriteString(value)
+		more = true
+	})
+	p.extra = extra
+	if err != nil {
+		return nil, err
+	}
+	if typ != p.typ {
+		return nil, errors.Errorf("invalid %s log type %q", p.logType, typ)
+	}
+	if len(extra) > 0 {
+		if more {
+			stream.WriteMore()
+		}
+		stream.WriteObjectField("extra")
+		stream.WriteObjectStart()
+		for i, pair := range extra {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			stream.WriteObjectField(pair[0])
+			stream.WriteString(pair[1])
+		}
+		stream.WriteObjectEnd()
+	}
+	stream.WriteObjectEnd()
+
+	event := p.newEvent()
+	if err := jsonAPI.Unmarshal(stream.Buffer(), event); err != nil {
+		return nil, err
+	}
+	if err := parsers.ValidateStruct(event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(p.logType, event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
@@ -0,0 +1,77 @@
+package fortinetlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+func TestScanPairs(t *testing.T) {
+	assert := require.New(t)
+	var pairs [][2]string
+	scan := func(key, value string) {
+		pairs = append(pairs, [2]string{key, value})
+	}
+	err := ScanPairs(`<189>Oct 15 10:20:30 fgt date=2020-10-15 msg="quoted \"value\" with spaces" empty="" path="C:\\Temp" n=1 trailing`, scan)
+	assert.NoError(err)
+	assert.Equal([][2]string{
+		{"date", "2020-10-15"},
+		{"msg", `quoted "value" with spaces`},
+		{"empty", ""},
+		{"path", `C:\Temp`},
+		{"n", "1"},
+	}, pairs)
+
+	err = ScanPairs(`date=2020-10-15 msg="unterminated`, scan)
+	assert.Error(err)
+}
+
+func TestHeaderEventTime(t *testing.T) {
+	assert := require.New(t)
+	h := Header{
+		Date: null.FromString("2020-10-15"),
+		Time: null.FromString("12:20:33"),
+	}
+	assert.Equal(time.Date(2020, 10, 15, 12, 20, 33, 0, time.UTC), h.PantherEventTime().UTC())
+	h.TimeZone = null.FromString("+0200")
+	assert.Equal(time.Date(2020, 10, 15, 10, 20, 33, 0, time.UTC), h.PantherEventTime().UTC())
+	h.TimeZone = null.FromString("-0700")
+	assert.Equal(time.Date(2020, 10, 15, 19, 20, 33, 0, time.UTC), h.PantherEventTime().UTC())
+}
+
+func TestParserErrors(t *testing.T) {
+	assert := require.New(t)
+	p, err := logtypes.DefaultRegistry().Get(TypeTraffic).NewParser(nil)
+	assert.NoError(err)
+	// Wrong log type
+	_, err = p.ParseLog(`date=2020-10-15 time=10:20:30 logid="0100032001" type="event" subtype="system"`)
+	assert.Error(err)
+	// Missing required fields
+	_, err = p.ParseLog(`date=2020-10-15 type="traffic" subtype="forward"`)
+	assert.Error(err)
+	// Not a FortiGate log
+	_, err = p.ParseLog(`1,2020/10/15 10:20:30,012801096514,TRAFFIC,end`)
+	assert.Error(err)
+}
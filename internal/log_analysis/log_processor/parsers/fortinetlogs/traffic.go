@@ -0,0 +1,99 @@
+package fortinetlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Traffic is a FortiGate traffic log
+// nolint:lll
+type Traffic struct {
+	Header
+	Session
+	PolicyName                null.String `json:"policyname" description:"The name of the firewall policy."`
+	PolicyType                null.String `json:"policytype" description:"The type of the firewall policy."`
+	PolicyUUID                null.String `json:"poluuid" description:"The UUID of the firewall policy."`
+	TranslationDisposition    null.String `json:"trandisp" description:"The NAT translation type (snat, dnat, snat+dnat, noop)."`
+	TranslatedSourceIP        null.String `json:"transip" description:"The translated source IP address (SNAT)."`
+	TranslatedSourcePort      null.Uint16 `json:"transport" description:"The translated source port (SNAT)."`
+	TranslatedDestinationIP   null.String `json:"tranip" description:"The translated destination IP address (DNAT)."`
+	TranslatedDestinationPort null.Uint16 `json:"tranport" description:"The translated destination port (DNAT)."`
+	Duration                  null.Int64  `json:"duration" description:"The duration of the session in seconds."`
+	SentBytes                 null.Int64  `json:"sentbyte" description:"The number of bytes sent."`
+	ReceivedBytes             null.Int64  `json:"rcvdbyte" description:"The number of bytes received."`
+	SentPackets               null.Int64  `json:"sentpkt" description:"The number of packets sent."`
+	ReceivedPackets           null.Int64  `json:"rcvdpkt" description:"The number of packets received."`
+	AppID                     null.Int64  `json:"appid" description:"The application ID."`
+	AppList                   null.String `json:"applist" description:"The application control sensor."`
+	UTMAction                 null.String `json:"utmaction" description:"The action taken by the security profiles."`
+	CountWeb                  null.Int32  `json:"countweb" description:"The number of web filter logs associated with the session."`
+	CountAV                   null.Int32  `json:"countav" description:"The number of antivirus logs associated with the session."`
+	CountIPS                  null.Int32  `json:"countips" description:"The number of IPS logs associated with the session."`
+	CountApp                  null.Int32  `json:"countapp" description:"The number of application control logs associated with the session."`
+	CountDNS                  null.Int32  `json:"countdns" description:"The number of DNS filter logs associated with the session."`
+	CountSSL                  null.Int32  `json:"countssl" description:"The number of SSL inspection logs associated with the session."`
+	SourceName                null.String `json:"srcname" panther:"hostname" description:"The host name of the source device."`
+	SourceMAC                 null.String `json:"srcmac" description:"The MAC address of the source device."`
+	MasterSourceMAC           null.String `json:"mastersrcmac" description:"The master MAC address of the source device."`
+	SourceServer              null.Int32  `json:"srcserver" description:"Whether the source is a server."`
+	DestinationServer         null.Int32  `json:"dstserver" description:"Whether the destination is a server."`
+	OSName                    null.String `json:"osname" description:"The operating system of the source device."`
+	DeviceType                null.String `json:"devtype" description:"The type of the source device."`
+	VPN                       null.String `json:"vpn" description:"The name of the VPN tunnel."`
+	VPNType                   null.String `json:"vpntype" description:"The type of the VPN tunnel."`
+	ShapingPolicyID           null.Uint32 `json:"shapingpolicyid" description:"The ID of the traffic shaping policy."`
+}
+
+var _ pantherlog.ValueWriterTo = (*Traffic)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *Traffic) WriteValuesTo(w pantherlog.ValueWriter) {
+	pantherlog.ScanIPAddress(w, event.TranslatedSourceIP.Value)
+	pantherlog.ScanIPAddress(w, event.TranslatedDestinationIP.Value)
+}
+
+// Session holds the session fields common to traffic and UTM logs
+// nolint:lll
+type Session struct {
+	SourceIP                 null.String `json:"srcip" panther:"ip" description:"The source IP address."`
+	SourcePort               null.Uint16 `json:"srcport" description:"The source port."`
+	SourceInterface          null.String `json:"srcintf" description:"The source interface."`
+	SourceInterfaceRole      null.String `json:"srcintfrole" description:"The role of the source interface."`
+	SourceCountry            null.String `json:"srccountry" description:"The country of the source IP address."`
+	DestinationIP            null.String `json:"dstip" panther:"ip" description:"The destination IP address."`
+	DestinationPort          null.Uint16 `json:"dstport" description:"The destination port."`
+	DestinationInterface     null.String `json:"dstintf" description:"The destination interface."`
+	DestinationInterfaceRole null.String `json:"dstintfrole" description:"The role of the destination interface."`
+	DestinationCountry       null.String `json:"dstcountry" description:"The country of the destination IP address."`
+	SessionID                null.Int64  `json:"sessionid" description:"The ID of the session."`
+	Protocol                 null.Uint8  `json:"proto" description:"The IP protocol number."`
+	Action                   null.String `json:"action" description:"The action taken."`
+	PolicyID                 null.Uint32 `json:"policyid" description:"The ID of the firewall policy."`
+	Service                  null.String `json:"service" description:"The name of the service."`
+	App                      null.String `json:"app" description:"The name of the application."`
+	AppCategory              null.String `json:"appcat" description:"The category of the application."`
+	AppRisk                  null.String `json:"apprisk" description:"The risk level of the application."`
+	User                     null.String `json:"user" description:"The name of the user."`
+	Group                    null.String `json:"group" description:"The user group of the user."`
+	CRScore                  null.Int32  `json:"crscore" description:"The client reputation score."`
+	CRAction                 null.Int32  `json:"craction" description:"The client reputation action."`
+	CRLevel                  null.String `json:"crlevel" description:"The client reputation level."`
+}
@@ -0,0 +1,94 @@
+package fortinetlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestTraffic(t *testing.T) {
+	// nolint:lll
+	input := `<189>date=2020-10-15 time=10:20:30 devname="FGT60E-HQ" devid="FGT60E4Q17000001" logid="0000000013" type="traffic" subtype="forward" level="notice" vd="root" eventtime=1602757230123456789 tz="+0000" srcip=10.0.0.12 srcport=51432 srcintf="internal" srcintfrole="lan" dstip=203.0.113.50 dstport=443 dstintf="wan1" dstintfrole="wan" sessionid=34521 proto=6 action="close" policyid=1 policytype="policy" poluuid="0f4e1c3a-7b2d-4d4e-9a61-2f1cbd5e8a10" service="HTTPS" dstcountry="United States" srccountry="Reserved" trandisp="snat" transip=198.51.100.7 transport=23011 appid=40568 app="HTTPS.BROWSER" appcat="Web.Client" apprisk="medium" applist="default" duration=16 sentbyte=1420 rcvdbyte=4511 sentpkt=11 rcvdpkt=11 srcname="jdoe-laptop" mastersrcmac="00:50:56:a1:2b:3c" srcmac="00:50:56:a1:2b:3c" srcserver=0 vwlid=0 wanin=4511 wanout=1420`
+	// nolint:lll
+	expect := `{
+	  "action": "close",
+	  "app": "HTTPS.BROWSER",
+	  "appcat": "Web.Client",
+	  "appid": 40568,
+	  "applist": "default",
+	  "apprisk": "medium",
+	  "date": "2020-10-15",
+	  "devid": "FGT60E4Q17000001",
+	  "devname": "FGT60E-HQ",
+	  "dstcountry": "United States",
+	  "dstintf": "wan1",
+	  "dstintfrole": "wan",
+	  "dstip": "203.0.113.50",
+	  "dstport": 443,
+	  "duration": 16,
+	  "eventtime": 1602757230123456789,
+	  "extra": {
+	    "vwlid": "0",
+	    "wanin": "4511",
+	    "wanout": "1420"
+	  },
+	  "level": "notice",
+	  "logid": "0000000013",
+	  "mastersrcmac": "00:50:56:a1:2b:3c",
+	  "p_any_domain_names": [
+	    "jdoe-laptop"
+	  ],
+	  "p_any_ip_addresses": [
+	    "10.0.0.12",
+	    "198.51.100.7",
+	    "203.0.113.50"
+	  ],
+	  "p_event_time": "2020-10-15T10:20:30Z",
+	  "p_log_type": "FortiGate.Traffic",
+	  "policyid": 1,
+	  "policytype": "policy",
+	  "poluuid": "0f4e1c3a-7b2d-4d4e-9a61-2f1cbd5e8a10",
+	  "proto": 6,
+	  "rcvdbyte": 4511,
+	  "rcvdpkt": 11,
+	  "sentbyte": 1420,
+	  "sentpkt": 11,
+	  "service": "HTTPS",
+	  "sessionid": 34521,
+	  "srccountry": "Reserved",
+	  "srcintf": "internal",
+	  "srcintfrole": "lan",
+	  "srcip": "10.0.0.12",
+	  "srcmac": "00:50:56:a1:2b:3c",
+	  "srcname": "jdoe-laptop",
+	  "srcport": 51432,
+	  "srcserver": 0,
+	  "subtype": "forward",
+	  "time": "10:20:30",
+	  "trandisp": "snat",
+	  "transip": "198.51.100.7",
+	  "transport": 23011,
+	  "type": "traffic",
+	  "tz": "+0000",
+	  "vd": "root"
+	}`
+	testutil.CheckRegisteredParser(t, TypeTraffic, input, expect)
+}
@@ -0,0 +1,82 @@
+package fortinetlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// UTM is a FortiGate security profile log (webfilter, virus, ips, app-ctrl, dns, anomaly, ssl, etc.)
+// nolint:lll
+type UTM struct {
+	Header
+	Session
+	EventType         null.String `json:"eventtype" description:"The type of the UTM event."`
+	PolicyType        null.String `json:"policytype" description:"The type of the firewall policy."`
+	PolicyUUID        null.String `json:"poluuid" description:"The UUID of the firewall policy."`
+	Profile           null.String `json:"profile" description:"The name of the security profile."`
+	Direction         null.String `json:"direction" description:"The direction of the traffic (incoming, outgoing)."`
+	Message           null.String `json:"msg" description:"The log message."`
+	Hostname          null.String `json:"hostname" panther:"hostname" description:"The host name of the request."`
+	URL               null.String `json:"url" description:"The URL path of the request."`
+	RequestType       null.String `json:"reqtype" description:"The type of the request (direct, referral)."`
+	Method            null.String `json:"method" description:"The method used to rate the URL."`
+	Agent             null.String `json:"agent" description:"The user agent of the request."`
+	ReferralURL       null.String `json:"referralurl" panther:"url" description:"The referral URL of the request."`
+	SentBytes         null.Int64  `json:"sentbyte" description:"The number of bytes sent."`
+	ReceivedBytes     null.Int64  `json:"rcvdbyte" description:"The number of bytes received."`
+	Category          null.Int32  `json:"cat" description:"The web category ID."`
+	CategoryDesc      null.String `json:"catdesc" description:"The web category description."`
+	QueryName         null.String `json:"qname" panther:"domain" description:"The DNS query name."`
+	QueryType         null.String `json:"qtype" description:"The DNS query type."`
+	QueryTypeValue    null.Int32  `json:"qtypeval" description:"The numeric DNS query type."`
+	QueryClass        null.String `json:"qclass" description:"The DNS query class."`
+	IPAddresses       null.String `json:"ipaddr" description:"The comma separated IP addresses of the DNS response."`
+	TransactionID     null.Int64  `json:"xid" description:"The DNS transaction ID."`
+	Attack            null.String `json:"attack" description:"The name of the attack."`
+	AttackID          null.Int64  `json:"attackid" description:"The ID of the attack."`
+	Severity          null.String `json:"severity" description:"The severity of the attack."`
+	Reference         null.String `json:"ref" description:"The URL of the FortiGuard reference for the attack or virus."`
+	IncidentSerialNo  null.Int64  `json:"incidentserialno" description:"The incident serial number."`
+	Virus             null.String `json:"virus" description:"The name of the virus."`
+	VirusID           null.Int64  `json:"virusid" description:"The ID of the virus."`
+	DetectionType     null.String `json:"dtype" description:"The type of the detection."`
+	FileName          null.String `json:"filename" description:"The name of the file."`
+	FileType          null.String `json:"filetype" description:"The type of the file."`
+	FileHash          null.String `json:"filehash" description:"The hash of the file."`
+	AnalyticsChecksum null.String `json:"analyticscksum" panther:"sha256" description:"The SHA-256 checksum of the file."`
+	QuarantineSkip    null.String `json:"quarskip" description:"The quarantine action."`
+}
+
+var _ pantherlog.ValueWriterTo = (*UTM)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *UTM) WriteValuesTo(w pantherlog.ValueWriter) {
+	for _, addr := range strings.Split(event.IPAddresses.Value, ",") {
+		pantherlog.ScanIPAddress(w, addr)
+	}
+	// The url field only holds the path of the request and its host is already in the hostname field.
+	// IPS and anomaly logs can have a full URL in the url field.
+	if u := event.URL.Value; strings.Contains(u, "://") {
+		pantherlog.ScanURL(w, u)
+	}
+}
@@ -0,0 +1,137 @@
+package fortinetlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestUTMWebFilter(t *testing.T) {
+	// nolint:lll
+	input := `<185>date=2020-10-15 time=10:20:31 devname="FGT60E-HQ" devid="FGT60E4Q17000001" logid="0316013056" type="utm" subtype="webfilter" eventtype="ftgd_blk" level="warning" vd="root" eventtime=1602757231000000000 tz="+0000" policyid=1 sessionid=34522 user="jdoe" srcip=10.0.0.12 srcport=51433 srcintf="internal" srcintfrole="lan" dstip=203.0.113.66 dstport=80 dstintf="wan1" dstintfrole="wan" proto=6 service="HTTP" hostname="malware.example.com" profile="default" action="blocked" reqtype="direct" url="/download/payload.exe" sentbyte=512 rcvdbyte=0 direction="outgoing" msg="URL belongs to a denied category in policy" method="domain" cat=26 catdesc="Malicious Websites" crscore=30 craction=4194304 crlevel="high"`
+	// nolint:lll
+	expect := `{
+	  "action": "blocked",
+	  "cat": 26,
+	  "catdesc": "Malicious Websites",
+	  "craction": 4194304,
+	  "crlevel": "high",
+	  "crscore": 30,
+	  "date": "2020-10-15",
+	  "devid": "FGT60E4Q17000001",
+	  "devname": "FGT60E-HQ",
+	  "direction": "outgoing",
+	  "dstintf": "wan1",
+	  "dstintfrole": "wan",
+	  "dstip": "203.0.113.66",
+	  "dstport": 80,
+	  "eventtime": 1602757231000000000,
+	  "eventtype": "ftgd_blk",
+	  "hostname": "malware.example.com",
+	  "level": "warning",
+	  "logid": "0316013056",
+	  "method": "domain",
+	  "msg": "URL belongs to a denied category in policy",
+	  "p_any_domain_names": [
+	    "malware.example.com"
+	  ],
+	  "p_any_ip_addresses": [
+	    "10.0.0.12",
+	    "203.0.113.66"
+	  ],
+	  "p_event_time": "2020-10-15T10:20:31Z",
+	  "p_log_type": "FortiGate.UTM",
+	  "policyid": 1,
+	  "profile": "default",
+	  "proto": 6,
+	  "rcvdbyte": 0,
+	  "reqtype": "direct",
+	  "sentbyte": 512,
+	  "service": "HTTP",
+	  "sessionid": 34522,
+	  "srcintf": "internal",
+	  "srcintfrole": "lan",
+	  "srcip": "10.0.0.12",
+	  "srcport": 51433,
+	  "subtype": "webfilter",
+	  "time": "10:20:31",
+	  "type": "utm",
+	  "tz": "+0000",
+	  "url": "/download/payload.exe",
+	  "user": "jdoe",
+	  "vd": "root"
+	}`
+	testutil.CheckRegisteredParser(t, TypeUTM, input, expect)
+}
+
+func TestUTMDNS(t *testing.T) {
+	// nolint:lll
+	input := `<186>date=2020-10-15 time=10:20:32 devname="FGT60E-HQ" devid="FGT60E4Q17000001" logid="1501054802" type="utm" subtype="dns" eventtype="dns-response" level="notice" vd="root" eventtime=1602757232000000000 tz="+0000" policyid=1 sessionid=34523 srcip=10.0.0.12 srcport=53112 srcintf="internal" srcintfrole="lan" dstip=192.0.2.53 dstport=53 dstintf="wan1" dstintfrole="wan" proto=17 profile="default" xid=4711 qname="www.example.com" qtype="A" qtypeval=1 qclass="IN" ipaddr="93.184.216.34, 93.184.216.35" msg="Domain is monitored" action="pass" cat=52 catdesc="Information Technology"`
+	// nolint:lll
+	expect := `{
+	  "action": "pass",
+	  "cat": 52,
+	  "catdesc": "Information Technology",
+	  "date": "2020-10-15",
+	  "devid": "FGT60E4Q17000001",
+	  "devname": "FGT60E-HQ",
+	  "dstintf": "wan1",
+	  "dstintfrole": "wan",
+	  "dstip": "192.0.2.53",
+	  "dstport": 53,
+	  "eventtime": 1602757232000000000,
+	  "eventtype": "dns-response",
+	  "ipaddr": "93.184.216.34, 93.184.216.35",
+	  "level": "notice",
+	  "logid": "1501054802",
+	  "msg": "Domain is monitored",
+	  "p_any_domain_names": [
+	    "www.example.com"
+	  ],
+	  "p_any_ip_addresses": [
+	    "10.0.0.12",
+	    "192.0.2.53",
+	    "93.184.216.34",
+	    "93.184.216.35"
+	  ],
+	  "p_event_time": "2020-10-15T10:20:32Z",
+	  "p_log_type": "FortiGate.UTM",
+	  "policyid": 1,
+	  "profile": "default",
+	  "proto": 17,
+	  "qclass": "IN",
+	  "qname": "www.example.com",
+	  "qtype": "A",
+	  "qtypeval": 1,
+	  "sessionid": 34523,
+	  "srcintf": "internal",
+	  "srcintfrole": "lan",
+	  "srcip": "10.0.0.12",
+	  "srcport": 53112,
+	  "subtype": "dns",
+	  "time": "10:20:32",
+	  "type": "utm",
+	  "tz": "+0000",
+	  "vd": "root",
+	  "xid": 4711
+	}`
+	testutil.CheckRegisteredParser(t, TypeUTM, input, expect)
+}
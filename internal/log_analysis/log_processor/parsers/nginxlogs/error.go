@@ -0,0 +1,167 @@
+package nginxlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Nginx does not log the time zone of error log timestamps
+const layoutErrorTime = "2006/01/02 15:04:05"
+
+// Error is an Nginx error log entry
+//
+//	2020/10/15 10:20:30 [error] 1234#5678: *91 connect() failed (111: Connection refused) while connecting to upstream, client: 192.0.2.10, server: example.com, request: "GET /api HTTP/1.1", upstream: "http://127.0.0.1:8080/api", host: "example.com"
+//
+// nolint:lll
+type Error struct {
+	Time         time.Time   `json:"time" tcodec:"rfc3339" panther:"event_time" validate:"required" description:"The time the error was logged (UTC)."`
+	Level        null.String `json:"level" validate:"required" description:"The severity level of the error (debug, info, notice, warn, error, crit, alert, emerg)."`
+	PID          null.Int32  `json:"pid" description:"The process id of the worker that logged the error."`
+	TID          null.Int64  `json:"tid" description:"The thread id of the worker that logged the error."`
+	ConnectionID null.Int64  `json:"connection_id" description:"The connection serial number."`
+	Message      null.String `json:"message" description:"The error message."`
+	Client       null.String `json:"client" panther:"ip" description:"The address of the client."`
+	Server       null.String `json:"server" description:"The name of the server that accepted the request."`
+	Request      null.String `json:"request" description:"The request line of the client request."`
+	Subrequest   null.String `json:"subrequest" description:"The URI of the subrequest."`
+	Upstream     null.String `json:"upstream" panther:"url" description:"The URL of the upstream server."`
+	Host         null.String `json:"host" panther:"hostname" description:"The Host header of the client request."`
+	Referrer     null.String `json:"referrer" panther:"url" description:"The Referer header of the client request."`
+}
+
+var (
+	rxError = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}) \[(\w+)\] (\d+)#(\d+): (?:\*(\d+) )?(.*)$`)
+	// Nginx appends the request context to messages logged while processing a request
+	rxErrorContext = regexp.MustCompile(`^, client: ([^,]+)(?:, server: ([^,]*))?` +
+		`(?:, request: "((?:[^"\\]|\\.)*)")?` +
+		`(?:, subrequest: "((?:[^"\\]|\\.)*)")?` +
+		`(?:, upstream: "((?:[^"\\]|\\.)*)")?` +
+		`(?:, host: "((?:[^"\\]|\\.)*)")?` +
+		`(?:, referrer: "((?:[^"\\]|\\.)*)")?$`)
+)
+
+// ParseString parses an Nginx error log line
+func (event *Error) ParseString(s string) error {
+	match := rxError.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return errors.New("invalid error log format")
+	}
+	// Assignment in single line right after the match avoids bounds checks on fields
+	tm, level, pid, tid, cid, message := match[1], match[2], match[3], match[4], match[5], match[6]
+	ts, err := time.Parse(layoutErrorTime, tm)
+	if err != nil {
+		return err
+	}
+	*event = Error{
+		Time:  ts,
+		Level: null.FromString(level),
+	}
+	if event.PID, err = parseInt32(pid); err != nil {
+		return err
+	}
+	if event.TID, err = parseInt64(tid); err != nil {
+		return err
+	}
+	if event.ConnectionID, err = parseInt64(cid); err != nil {
+		return err
+	}
+	// The message can contain `, client: ` so we try all occurrences starting from the last one
+	for pos := strings.LastIndex(message, ", client: "); pos != -1; pos = strings.LastIndex(message[:pos], ", client: ") {
+		if ctx := rxErrorContext.FindStringSubmatch(message[pos:]); ctx != nil {
+			event.Client = nonEmpty(ctx[1])
+			event.Server = nonEmpty(ctx[2])
+			event.Request = nonEmpty(ctx[3])
+			event.Subrequest = nonEmpty(ctx[4])
+			event.Upstream = nonEmpty(ctx[5])
+			event.Host = nonEmpty(ctx[6])
+			event.Referrer = nonEmpty(ctx[7])
+			message = message[:pos]
+			break
+		}
+	}
+	event.Message = null.FromString(message)
+	return nil
+}
+
+func nonEmpty(s string) null.String {
+	if s == "" {
+		return null.String{}
+	}
+	return null.FromString(s)
+}
+
+func parseInt32(s string) (null.Int32, error) {
+	if s == "" {
+		return null.Int32{}, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return null.Int32{}, err
+	}
+	return null.FromInt32(int32(n)), nil
+}
+
+func parseInt64(s string) (null.Int64, error) {
+	if s == "" {
+		return null.Int64{}, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return null.Int64{}, err
+	}
+	return null.FromInt64(n), nil
+}
+
+// ErrorParser parses Nginx error logs
+type ErrorParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*ErrorParser)(nil)
+
+// NewErrorParser creates a new Nginx error log parser
+func NewErrorParser(_ interface{}) (parsers.Interface, error) {
+	return &ErrorParser{}, nil
+}
+
+// ParseLog implements parsers.Interface
+func (p *ErrorParser) ParseLog(log string) ([]*parsers.Result, error) {
+	event := Error{}
+	if err := event.ParseString(log); err != nil {
+		return nil, err
+	}
+	if err := parsers.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeError, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
@@ -0,0 +1,105 @@
+package nginxlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestError(t *testing.T) {
+	// nolint:lll
+	input := `2020/10/15 10:20:30 [error] 1234#5678: *91 connect() failed (111: Connection refused) while connecting to upstream, client: 192.0.2.10, server: example.com, request: "GET /api/v1/users HTTP/1.1", upstream: "http://127.0.0.1:8080/api/v1/users", host: "www.example.com", referrer: "https://www.example.com/login"`
+	// nolint:lll
+	expect := `{
+	  "time": "2020-10-15T10:20:30Z",
+	  "level": "error",
+	  "pid": 1234,
+	  "tid": 5678,
+	  "connection_id": 91,
+	  "message": "connect() failed (111: Connection refused) while connecting to upstream",
+	  "client": "192.0.2.10",
+	  "server": "example.com",
+	  "request": "GET /api/v1/users HTTP/1.1",
+	  "upstream": "http://127.0.0.1:8080/api/v1/users",
+	  "host": "www.example.com",
+	  "referrer": "https://www.example.com/login",
+	  "p_log_type": "Nginx.Error",
+	  "p_event_time": "2020-10-15T10:20:30Z",
+	  "p_any_ip_addresses": [
+	    "127.0.0.1",
+	    "192.0.2.10"
+	  ],
+	  "p_any_domain_names": [
+	    "www.example.com"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeError, input, expect)
+}
+
+func TestErrorWithoutRequest(t *testing.T) {
+	// nolint:lll
+	input := `2020/10/15 10:20:31 [notice] 1#1: signal process started`
+	// nolint:lll
+	expect := `{
+	  "time": "2020-10-15T10:20:31Z",
+	  "level": "notice",
+	  "pid": 1,
+	  "tid": 1,
+	  "message": "signal process started",
+	  "p_log_type": "Nginx.Error",
+	  "p_event_time": "2020-10-15T10:20:31Z"
+	}`
+	testutil.CheckRegisteredParser(t, TypeError, input, expect)
+}
+
+func TestErrorModSecurity(t *testing.T) {
+	// nolint:lll
+	input := `2020/10/15 10:20:32 [error] 1234#1234: *12 [client 192.0.2.10] ModSecurity: Access denied with code 403 (phase 2). Matched "Operator 'Ge' with parameter '5' against variable 'TX:ANOMALY_SCORE' (Value: '5' ) [file "/etc/nginx/modsec/coreruleset/rules/REQUEST-949-BLOCKING-EVALUATION.conf"] [line "80"] [id "949110"] [msg "Inbound Anomaly Score Exceeded (Total Score: 5)"] [hostname "192.0.2.1"] [uri "/index.php"] [unique_id "160275723012.345678"], client: 192.0.2.10, server: _, request: "GET /index.php?id=1%27%20or%201=1 HTTP/1.1", host: "192.0.2.1"`
+	// nolint:lll
+	expect := `{
+	  "time": "2020-10-15T10:20:32Z",
+	  "level": "error",
+	  "pid": 1234,
+	  "tid": 1234,
+	  "connection_id": 12,
+	  "message": "[client 192.0.2.10] ModSecurity: Access denied with code 403 (phase 2). Matched \"Operator 'Ge' with parameter '5' against variable 'TX:ANOMALY_SCORE' (Value: '5' ) [file \"/etc/nginx/modsec/coreruleset/rules/REQUEST-949-BLOCKING-EVALUATION.conf\"] [line \"80\"] [id \"949110\"] [msg \"Inbound Anomaly Score Exceeded (Total Score: 5)\"] [hostname \"192.0.2.1\"] [uri \"/index.php\"] [unique_id \"160275723012.345678\"]",
+	  "client": "192.0.2.10",
+	  "server": "_",
+	  "request": "GET /index.php?id=1%27%20or%201=1 HTTP/1.1",
+	  "host": "192.0.2.1",
+	  "p_log_type": "Nginx.Error",
+	  "p_event_time": "2020-10-15T10:20:32Z",
+	  "p_any_ip_addresses": [
+	    "192.0.2.1",
+	    "192.0.2.10"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeError, input, expect)
+}
+
+func TestErrorInvalid(t *testing.T) {
+	p, err := NewErrorParser(nil)
+	require.NoError(t, err)
+	_, err = p.ParseLog(`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`)
+	require.Error(t, err)
+}
@@ -21,19 +21,31 @@ package nginxlogs
 
 import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 const (
 	TypeAccess = `Nginx.Access`
+	TypeError  = `Nginx.Error`
 )
 
 func init() {
-	logtypes.MustRegister(logtypes.Config{
-		Name:         TypeAccess,
-		Description:  `Access Logs for your Nginx server. We currently support Nginx 'combined' format.`,
-		ReferenceURL: `http://nginx.org/en/docs/http/ngx_http_log_module.html#log_format`,
-		Schema:       Access{},
-		NewParser:    parsers.AdapterFactory(&AccessParser{}),
-	})
+	logtypes.MustRegister(
+		logtypes.Config{
+			Name:         TypeAccess,
+			Description:  `Access Logs for your Nginx server. We currently support Nginx 'combined' format.`,
+			ReferenceURL: `http://nginx.org/en/docs/http/ngx_http_log_module.html#log_format`,
+			Schema:       Access{},
+			NewParser:    parsers.AdapterFactory(&AccessParser{}),
+		},
+		logtypes.Config{
+			Name: TypeError,
+			Description: `Error Logs for your Nginx server.
+NOTE: Nginx does not log the time zone of error log entries so timestamps are assumed to be UTC.`,
+			ReferenceURL: `http://nginx.org/en/docs/ngx_core_module.html#error_log`,
+			Schema:       pantherlog.MustBuildEventSchema(&Error{}),
+			NewParser:    parsers.FactoryFunc(NewErrorParser),
+		},
+	)
 }
@@ -0,0 +1,39 @@
+package onepasswordlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// ItemUsage is a 1Password item usage event
+// nolint:lll
+type ItemUsage struct {
+	UUID        null.String `json:"uuid" validate:"required" description:"The UUID of the event."`
+	Timestamp   time.Time   `json:"timestamp" tcodec:"rfc3339" panther:"event_time" validate:"required" description:"The time the item was accessed."`
+	UsedVersion null.Int32  `json:"used_version" description:"The version of the item that was accessed."`
+	VaultUUID   null.String `json:"vault_uuid" validate:"required" description:"The UUID of the vault the item is in."`
+	ItemUUID    null.String `json:"item_uuid" validate:"required" description:"The UUID of the item that was accessed."`
+	Action      null.String `json:"action" description:"The action performed on the item (ie fill, reveal, secure-copy)."`
+	User        *User       `json:"user" description:"The user who accessed the item."`
+	Client      *Client     `json:"client" description:"The client used to access the item."`
+	Location    *Location   `json:"location" description:"The geolocation of the client IP address."`
+}
@@ -0,0 +1,72 @@
+package onepasswordlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestItemUsage(t *testing.T) {
+	// nolint:lll
+	input := `{"uuid":"56YE2TYN2VFYRLNSHKPW5NVT5E","timestamp":"2020-10-15T20:05:00.5Z","used_version":3,"vault_uuid":"VZSYVT2LGHTBWBQGUJAIZVRABM","item_uuid":"SDGD3I4AJYO6RMHRK8DYVNFIDS","action":"reveal","user":{"uuid":"4HCGRGYCTRQFBMGVEGTABYDU2V","name":"Jeff Shiner","email":"jeff_shiner@example.com"},"client":{"app_name":"1Password Browser Extension","app_version":"1109","platform_name":"Chrome","platform_version":"86.0","os_name":"MacOSX","os_version":"10.15","ip_address":"203.0.113.95"},"location":{"country":"CA","region":"Ontario","city":"Toronto","latitude":43.6532,"longitude":-79.3832}}`
+	// nolint:lll
+	expect := `{
+	  "uuid": "56YE2TYN2VFYRLNSHKPW5NVT5E",
+	  "timestamp": "2020-10-15T20:05:00.5Z",
+	  "used_version": 3,
+	  "vault_uuid": "VZSYVT2LGHTBWBQGUJAIZVRABM",
+	  "item_uuid": "SDGD3I4AJYO6RMHRK8DYVNFIDS",
+	  "action": "reveal",
+	  "user": {
+	    "uuid": "4HCGRGYCTRQFBMGVEGTABYDU2V",
+	    "name": "Jeff Shiner",
+	    "email": "jeff_shiner@example.com"
+	  },
+	  "client": {
+	    "app_name": "1Password Browser Extension",
+	    "app_version": "1109",
+	    "platform_name": "Chrome",
+	    "platform_version": "86.0",
+	    "os_name": "MacOSX",
+	    "os_version": "10.15",
+	    "ip_address": "203.0.113.95"
+	  },
+	  "location": {
+	    "country": "CA",
+	    "region": "Ontario",
+	    "city": "Toronto",
+	    "latitude": 43.6532,
+	    "longitude": -79.3832
+	  },
+	  "p_log_type": "OnePassword.ItemUsage",
+	  "p_event_time": "2020-10-15T20:05:00.5Z",
+	  "p_any_ip_addresses": [
+	    "203.0.113.95"
+	  ],
+	  "p_any_emails": [
+	    "jeff_shiner@example.com"
+	  ],
+	  "p_any_usernames": [
+	    "Jeff Shiner"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeItemUsage, input, expect)
+}
@@ -0,0 +1,79 @@
+package onepasswordlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+const (
+	// LogTypePrefix is the prefix of all log types parsed by this package
+	LogTypePrefix = "OnePassword"
+	// TypeSignInAttempt is the log type of 1Password sign-in attempt events
+	TypeSignInAttempt = LogTypePrefix + ".SignInAttempt"
+	// TypeItemUsage is the log type of 1Password item usage events
+	TypeItemUsage = LogTypePrefix + ".ItemUsage"
+)
+
+func init() {
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeSignInAttempt,
+		Description:  `1Password sign-in attempts as returned by the 1Password Events API (/api/v1/signinattempts)`,
+		ReferenceURL: `https://support.1password.com/events-reporting-api/#signinattempt-object`,
+	}, func() interface{} {
+		return &SignInAttempt{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeItemUsage,
+		Description:  `1Password item usage events as returned by the 1Password Events API (/api/v1/itemusages)`,
+		ReferenceURL: `https://support.1password.com/events-reporting-api/#itemusage-object`,
+	}, func() interface{} {
+		return &ItemUsage{}
+	})
+}
+
+// User is a 1Password user
+// nolint:lll
+type User struct {
+	UUID  null.String `json:"uuid" description:"The UUID of the user."`
+	Name  null.String `json:"name" panther:"username" description:"The name of the user."`
+	Email null.String `json:"email" panther:"email" description:"The email address of the user."`
+}
+
+// Client is the 1Password client used for an event
+// nolint:lll
+type Client struct {
+	AppName         null.String `json:"app_name" description:"The name of the 1Password app."`
+	AppVersion      null.String `json:"app_version" description:"The version of the 1Password app."`
+	PlatformName    null.String `json:"platform_name" description:"The name of the platform the app runs on."`
+	PlatformVersion null.String `json:"platform_version" description:"The version of the platform the app runs on."`
+	OSName          null.String `json:"os_name" description:"The name of the operating system."`
+	OSVersion       null.String `json:"os_version" description:"The version of the operating system."`
+	IPAddress       null.String `json:"ip_address" panther:"ip" description:"The IP address of the client."`
+}
+
+// Location is the geolocation of the client IP address
+type Location struct {
+	Country   null.String  `json:"country" description:"The country code."`
+	Region    null.String  `json:"region" description:"The region."`
+	City      null.String  `json:"city" description:"The city."`
+	Latitude  null.Float64 `json:"latitude" description:"The latitude."`
+	Longitude null.Float64 `json:"longitude" description:"The longitude."`
+}
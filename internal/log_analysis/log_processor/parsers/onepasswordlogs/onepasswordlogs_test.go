@@ -0,0 +1,52 @@
+package onepasswordlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+// 1Password events share the uuid and timestamp fields so each log type must require fields that identify it
+func TestOnePasswordLogTypesAreDistinct(t *testing.T) {
+	logTypes := []string{TypeSignInAttempt, TypeItemUsage}
+	// nolint:lll
+	samples := map[string]string{
+		TypeSignInAttempt: `{"uuid":"56YE2TYN2VFYRLNSHKPW5NVT5E","session_uuid":"A5K6COGVRVEJXJW3XQZGS7VAMM","timestamp":"2020-10-15T20:00:00.123Z","category":"firewall_reported","type":"continent_blocked","country":"FR","details":{"value":"Europe"},"target_user":{"uuid":"IR7VJHJ36JHINBFAD7V2T5MP3E","name":"Jeff Shiner","email":"jeff_shiner@example.com"},"client":{"app_name":"1Password Browser Extension","app_version":"1109","platform_name":"Chrome","platform_version":"86.0","os_name":"MacOSX","os_version":"10.15","ip_address":"203.0.113.95"},"location":{"country":"FR","region":"Ile-de-France","city":"Paris","latitude":48.8582,"longitude":2.3387}}`,
+		TypeItemUsage:     `{"uuid":"56YE2TYN2VFYRLNSHKPW5NVT5E","timestamp":"2020-10-15T20:05:00.5Z","used_version":3,"vault_uuid":"VZSYVT2LGHTBWBQGUJAIZVRABM","item_uuid":"SDGD3I4AJYO6RMHRK8DYVNFIDS","action":"reveal","user":{"uuid":"4HCGRGYCTRQFBMGVEGTABYDU2V","name":"Jeff Shiner","email":"jeff_shiner@example.com"},"client":{"app_name":"1Password Browser Extension","app_version":"1109","platform_name":"Chrome","platform_version":"86.0","os_name":"MacOSX","os_version":"10.15","ip_address":"203.0.113.95"},"location":{"country":"CA","region":"Ontario","city":"Toronto","latitude":43.6532,"longitude":-79.3832}}`,
+	}
+	for sampleType, sample := range samples {
+		for _, logType := range logTypes {
+			entry := logtypes.DefaultRegistry().Get(logType)
+			require.NotNil(t, entry, logType)
+			parser, err := entry.NewParser(nil)
+			require.NoError(t, err)
+			_, err = parser.ParseLog(sample)
+			if logType == sampleType {
+				assert.NoError(t, err, "%s sample", sampleType)
+				continue
+			}
+			assert.Error(t, err, "%s sample parsed as %s", sampleType, logType)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package onepasswordlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// SignInAttempt is a 1Password sign-in attempt event
+// nolint:lll
+type SignInAttempt struct {
+	UUID        null.String           `json:"uuid" validate:"required" description:"The UUID of the event."`
+	SessionUUID null.String           `json:"session_uuid" panther:"trace_id" description:"The UUID of the session that created the event."`
+	Timestamp   time.Time             `json:"timestamp" tcodec:"rfc3339" panther:"event_time" validate:"required" description:"The time of the sign-in attempt."`
+	Category    null.String           `json:"category" validate:"required" description:"The category of the sign-in attempt (success, credentials_failed, mfa_failed, modern_version_failed, firewall_failed, firewall_reported)."`
+	Type        null.String           `json:"type" description:"Details about the sign-in attempt (ie credentials_ok, mfa_ok, password_secret_bad, continent_blocked)."`
+	Country     null.String           `json:"country" description:"The country code of the IP address of the sign-in attempt."`
+	TargetUser  *User                 `json:"target_user" description:"The user who attempted to sign in."`
+	Client      *Client               `json:"client" description:"The client used for the sign-in attempt."`
+	Location    *Location             `json:"location" description:"The geolocation of the client IP address."`
+	Details     *SignInAttemptDetails `json:"details" description:"Additional details about the sign-in attempt."`
+}
+
+// SignInAttemptDetails has additional details about a sign-in attempt
+type SignInAttemptDetails struct {
+	Value null.String `json:"value" description:"The country, continent or IP address that was blocked."`
+}
@@ -0,0 +1,78 @@
+package onepasswordlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestSignInAttempt(t *testing.T) {
+	// nolint:lll
+	input := `{"uuid":"56YE2TYN2VFYRLNSHKPW5NVT5E","session_uuid":"A5K6COGVRVEJXJW3XQZGS7VAMM","timestamp":"2020-10-15T20:00:00.123Z","category":"firewall_reported","type":"continent_blocked","country":"FR","details":{"value":"Europe"},"target_user":{"uuid":"IR7VJHJ36JHINBFAD7V2T5MP3E","name":"Jeff Shiner","email":"jeff_shiner@example.com"},"client":{"app_name":"1Password Browser Extension","app_version":"1109","platform_name":"Chrome","platform_version":"86.0","os_name":"MacOSX","os_version":"10.15","ip_address":"203.0.113.95"},"location":{"country":"FR","region":"Ile-de-France","city":"Paris","latitude":48.8582,"longitude":2.3387}}`
+	// nolint:lll
+	expect := `{
+	  "uuid": "56YE2TYN2VFYRLNSHKPW5NVT5E",
+	  "session_uuid": "A5K6COGVRVEJXJW3XQZGS7VAMM",
+	  "timestamp": "2020-10-15T20:00:00.123Z",
+	  "category": "firewall_reported",
+	  "type": "continent_blocked",
+	  "country": "FR",
+	  "target_user": {
+	    "uuid": "IR7VJHJ36JHINBFAD7V2T5MP3E",
+	    "name": "Jeff Shiner",
+	    "email": "jeff_shiner@example.com"
+	  },
+	  "client": {
+	    "app_name": "1Password Browser Extension",
+	    "app_version": "1109",
+	    "platform_name": "Chrome",
+	    "platform_version": "86.0",
+	    "os_name": "MacOSX",
+	    "os_version": "10.15",
+	    "ip_address": "203.0.113.95"
+	  },
+	  "location": {
+	    "country": "FR",
+	    "region": "Ile-de-France",
+	    "city": "Paris",
+	    "latitude": 48.8582,
+	    "longitude": 2.3387
+	  },
+	  "details": {
+	    "value": "Europe"
+	  },
+	  "p_log_type": "OnePassword.SignInAttempt",
+	  "p_event_time": "2020-10-15T20:00:00.123Z",
+	  "p_any_trace_ids": [
+	    "A5K6COGVRVEJXJW3XQZGS7VAMM"
+	  ],
+	  "p_any_emails": [
+	    "jeff_shiner@example.com"
+	  ],
+	  "p_any_usernames": [
+	    "Jeff Shiner"
+	  ],
+	  "p_any_ip_addresses": [
+	    "203.0.113.95"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeSignInAttempt, input, expect)
+}
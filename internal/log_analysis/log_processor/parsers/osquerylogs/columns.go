@@ -0,0 +1,248 @@
+package osquerylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Row is a row of osquery query results.
+// Column values are always stored as strings.
+// Rows logged with `numerics: true` (`--logger_numerics` flag) have their numeric values converted back to strings.
+type Row map[string]string
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (row *Row) UnmarshalJSON(data []byte) error {
+	iter := jsoniter.ConfigDefault.BorrowIterator(data)
+	defer jsoniter.ConfigDefault.ReturnIterator(iter)
+	switch iter.WhatIsNext() {
+	case jsoniter.NilValue:
+		*row = nil
+		return nil
+	case jsoniter.ObjectValue:
+	default:
+		iter.Skip()
+		iter.ReportError("ReadRow", "invalid JSON value")
+		return iter.Error
+	}
+	values := Row{}
+	iter.ReadMapCB(func(iter *jsoniter.Iterator, key string) bool {
+		switch iter.WhatIsNext() {
+		case jsoniter.StringValue:
+			values[key] = iter.ReadString()
+		case jsoniter.NumberValue:
+			values[key] = iter.ReadNumber().String()
+		case jsoniter.BoolValue:
+			values[key] = strconv.FormatBool(iter.ReadBool())
+		case jsoniter.NilValue:
+			iter.ReadNil()
+		default:
+			iter.Skip()
+			iter.ReportError("ReadRow", "invalid column value for "+key)
+			return false
+		}
+		return true
+	})
+	if iter.Error != nil {
+		return iter.Error
+	}
+	*row = values
+	return nil
+}
+
+// Columns holding indicator values across osquery tables
+var (
+	ipColumns     = []string{"local_address", "remote_address"}
+	md5Columns    = []string{"md5"}
+	sha1Columns   = []string{"sha1"}
+	sha256Columns = []string{"sha256"}
+)
+
+// WriteValuesTo writes the indicator values in well known columns of a row.
+func (row Row) WriteValuesTo(w pantherlog.ValueWriter) {
+	for _, name := range ipColumns {
+		if addr, ok := row[name]; ok {
+			pantherlog.ScanIPAddress(w, addr)
+		}
+	}
+	writeColumns(w, pantherlog.FieldMD5Hash, row, md5Columns)
+	writeColumns(w, pantherlog.FieldSHA1Hash, row, sha1Columns)
+	writeColumns(w, pantherlog.FieldSHA256Hash, row, sha256Columns)
+}
+
+func writeColumns(w pantherlog.ValueWriter, field pantherlog.FieldID, row Row, columns []string) {
+	for _, name := range columns {
+		if value := row[name]; value != "" {
+			w.WriteValues(field, value)
+		}
+	}
+}
+
+// TypedColumns holds the columns of results from well known osquery tables with proper types.
+// The table of a query is resolved from the query name (see QueryTable).
+// nolint:lll
+type TypedColumns struct {
+	ProcessEvents *ProcessEvents `json:"process_events,omitempty" description:"Columns of a query on the process_events table"`
+	SocketEvents  *SocketEvents  `json:"socket_events,omitempty" description:"Columns of a query on the socket_events table"`
+	FileEvents    *FileEvents    `json:"file_events,omitempty" description:"Columns of a query on the file_events table"`
+}
+
+// columnHints maps osquery table names to typed columns factories.
+var columnHints = map[string]func(c *TypedColumns) interface{}{
+	"process_events": func(c *TypedColumns) interface{} {
+		c.ProcessEvents = &ProcessEvents{}
+		return c.ProcessEvents
+	},
+	"socket_events": func(c *TypedColumns) interface{} {
+		c.SocketEvents = &SocketEvents{}
+		return c.SocketEvents
+	},
+	"file_events": func(c *TypedColumns) interface{} {
+		c.FileEvents = &FileEvents{}
+		return c.FileEvents
+	},
+}
+
+// QueryTable resolves the well known osquery table of a query from its name.
+// Query names in packs are either `pack/<pack>/<query>` or `pack_<pack>_<query>` (legacy).
+// It returns an empty string if the query name does not end with a known table name.
+func QueryTable(queryName string) string {
+	for table := range columnHints {
+		if !strings.HasSuffix(queryName, table) {
+			continue
+		}
+		prefix := strings.TrimSuffix(queryName, table)
+		if prefix == "" || strings.HasSuffix(prefix, "/") || strings.HasSuffix(prefix, "_") {
+			return table
+		}
+	}
+	return ""
+}
+
+// NewTypedColumns converts a result row to typed columns using the table hints for a query.
+// It returns nil if the query is not on a known table or the row values do not match the column types.
+func NewTypedColumns(queryName string, row Row) *TypedColumns {
+	if row == nil {
+		return nil
+	}
+	newColumns, ok := columnHints[QueryTable(queryName)]
+	if !ok {
+		return nil
+	}
+	typed := TypedColumns{}
+	dst := newColumns(&typed)
+	data, err := jsonAPI.Marshal(row)
+	if err != nil {
+		return nil
+	}
+	if err := jsonAPI.Unmarshal(data, dst); err != nil {
+		return nil
+	}
+	return &typed
+}
+
+// ProcessEvents holds the columns of the process_events table.
+// Packs often join the hash table to add the hashes of the process executable.
+// nolint:lll
+type ProcessEvents struct {
+	PID         null.Int64  `json:"pid" description:"Process (or thread) ID"`
+	Path        null.String `json:"path" description:"Path of executed file"`
+	Mode        null.String `json:"mode" description:"File mode permissions"`
+	Cmdline     null.String `json:"cmdline" description:"Command line arguments (argv)"`
+	CmdlineSize null.Int64  `json:"cmdline_size" description:"Actual size (bytes) of command line arguments"`
+	Env         null.String `json:"env" description:"Environment variables delimited by spaces"`
+	EnvCount    null.Int64  `json:"env_count" description:"Number of environment variables"`
+	EnvSize     null.Int64  `json:"env_size" description:"Actual size (bytes) of environment list"`
+	Cwd         null.String `json:"cwd" description:"The process current working directory"`
+	AUID        null.Int64  `json:"auid" description:"Audit User ID at process start"`
+	UID         null.Int64  `json:"uid" description:"User ID at process start"`
+	EUID        null.Int64  `json:"euid" description:"Effective user ID at process start"`
+	GID         null.Int64  `json:"gid" description:"Group ID at process start"`
+	EGID        null.Int64  `json:"egid" description:"Effective group ID at process start"`
+	OwnerUID    null.Int64  `json:"owner_uid" description:"File owner user ID"`
+	OwnerGID    null.Int64  `json:"owner_gid" description:"File owner group ID"`
+	ATime       time.Time   `json:"atime" tcodec:"unix" description:"File last access in UNIX time"`
+	MTime       time.Time   `json:"mtime" tcodec:"unix" description:"File modification in UNIX time"`
+	CTime       time.Time   `json:"ctime" tcodec:"unix" description:"File last metadata change in UNIX time"`
+	BTime       time.Time   `json:"btime" tcodec:"unix" description:"File creation in UNIX time"`
+	Overflows   null.String `json:"overflows" description:"List of structures that overflowed"`
+	Parent      null.Int64  `json:"parent" description:"Process parent's PID, or -1 if cannot be determined."`
+	Time        time.Time   `json:"time" tcodec:"unix" description:"Time of execution in UNIX time"`
+	Uptime      null.Int64  `json:"uptime" description:"Time of execution in system uptime"`
+	EID         null.String `json:"eid" description:"Event ID"`
+	Status      null.Int64  `json:"status" description:"OpenBSM Attribute: Status of the process"`
+	Syscall     null.String `json:"syscall" description:"Syscall name: fork, vfork, clone, execve, execveat"`
+	MD5         null.String `json:"md5" panther:"md5" description:"MD5 hash of the executed file (from a join with the hash table)"`
+	SHA1        null.String `json:"sha1" panther:"sha1" description:"SHA1 hash of the executed file (from a join with the hash table)"`
+	SHA256      null.String `json:"sha256" panther:"sha256" description:"SHA256 hash of the executed file (from a join with the hash table)"`
+}
+
+// SocketEvents holds the columns of the socket_events table.
+// nolint:lll
+type SocketEvents struct {
+	Action        null.String `json:"action" description:"The socket action (bind, listen, close)"`
+	PID           null.Int64  `json:"pid" description:"Process (or thread) ID"`
+	Path          null.String `json:"path" description:"Path of executed file"`
+	FD            null.String `json:"fd" description:"The file description for the process socket"`
+	AUID          null.Int64  `json:"auid" description:"Audit User ID"`
+	Success       null.Int32  `json:"success" description:"The socket open attempt status"`
+	Family        null.Int32  `json:"family" description:"The Internet protocol family ID"`
+	Protocol      null.Int32  `json:"protocol" description:"The network protocol ID"`
+	LocalAddress  null.String `json:"local_address" panther:"ip" description:"Local address associated with socket"`
+	RemoteAddress null.String `json:"remote_address" panther:"ip" description:"Remote address associated with socket"`
+	LocalPort     null.Uint16 `json:"local_port" description:"Local network protocol port number"`
+	RemotePort    null.Uint16 `json:"remote_port" description:"Remote network protocol port number"`
+	Socket        null.String `json:"socket" description:"The local path (UNIX domain socket only)"`
+	Time          time.Time   `json:"time" tcodec:"unix" description:"Time of execution in UNIX time"`
+	Uptime        null.Int64  `json:"uptime" description:"Time of execution in system uptime"`
+	EID           null.String `json:"eid" description:"Event ID"`
+	MD5           null.String `json:"md5" panther:"md5" description:"MD5 hash of the process executable (from a join with the hash table)"`
+	SHA1          null.String `json:"sha1" panther:"sha1" description:"SHA1 hash of the process executable (from a join with the hash table)"`
+	SHA256        null.String `json:"sha256" panther:"sha256" description:"SHA256 hash of the process executable (from a join with the hash table)"`
+}
+
+// FileEvents holds the columns of the file_events table.
+// nolint:lll
+type FileEvents struct {
+	TargetPath    null.String `json:"target_path" description:"The path associated with the event"`
+	Category      null.String `json:"category" description:"The category of the file defined in the config"`
+	Action        null.String `json:"action" description:"Change action (UPDATE, REMOVE, etc)"`
+	TransactionID null.Int64  `json:"transaction_id" description:"ID used during bulk update"`
+	Inode         null.Int64  `json:"inode" description:"Filesystem inode number"`
+	UID           null.Int64  `json:"uid" description:"Owning user ID"`
+	GID           null.Int64  `json:"gid" description:"Owning group ID"`
+	Mode          null.String `json:"mode" description:"Permission bits"`
+	Size          null.Int64  `json:"size" description:"Size of file in bytes"`
+	ATime         time.Time   `json:"atime" tcodec:"unix" description:"Last access time"`
+	MTime         time.Time   `json:"mtime" tcodec:"unix" description:"Last modification time"`
+	CTime         time.Time   `json:"ctime" tcodec:"unix" description:"Last status change time"`
+	MD5           null.String `json:"md5" panther:"md5" description:"The MD5 of the file after change"`
+	SHA1          null.String `json:"sha1" panther:"sha1" description:"The SHA1 of the file after change"`
+	SHA256        null.String `json:"sha256" panther:"sha256" description:"The SHA256 of the file after change"`
+	Hashed        null.Int32  `json:"hashed" description:"1 if the file was hashed, 0 if not, -1 if hashing failed"`
+	Time          time.Time   `json:"time" tcodec:"unix" description:"Time of file event"`
+	EID           null.String `json:"eid" description:"Event ID"`
+}
@@ -0,0 +1,81 @@
+package osquerylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTable(t *testing.T) {
+	for name, table := range map[string]string{
+		"process_events":                        "process_events",
+		"pack/incident-response/process_events": "process_events",
+		"pack_incident-response_process_events": "process_events",
+		"pack/network/socket_events":            "socket_events",
+		"pack/fim/file_events":                  "file_events",
+		"pack/fim/my_file_events_by_user":       "",
+		"pack/incident-response/mounts":         "",
+		"myprocess_events":                      "",
+		"":                                      "",
+	} {
+		require.Equal(t, table, QueryTable(name), "invalid table for %q", name)
+	}
+}
+
+func TestRowUnmarshalJSON(t *testing.T) {
+	row := Row{}
+	err := jsoniter.UnmarshalFromString(`{"pid":42,"size":1.5,"path":"/bin/ls","flag":true,"missing":null}`, &row)
+	require.NoError(t, err)
+	require.Equal(t, Row{
+		"pid":  "42",
+		"size": "1.5",
+		"path": "/bin/ls",
+		"flag": "true",
+	}, row)
+
+	row = Row{}
+	require.NoError(t, jsoniter.UnmarshalFromString(`null`, &row))
+	require.Nil(t, row)
+
+	require.Error(t, jsoniter.UnmarshalFromString(`{"pid":[42]}`, &row))
+	require.Error(t, jsoniter.UnmarshalFromString(`["pid"]`, &row))
+}
+
+func TestNewTypedColumns(t *testing.T) {
+	typed := NewTypedColumns("pack/fim/file_events", Row{
+		"target_path": "/etc/passwd",
+		"action":      "UPDATED",
+		"size":        "2048",
+		"md5":         "0cc175b9c0f1b6a831c399e269772661",
+	})
+	require.NotNil(t, typed)
+	require.Nil(t, typed.ProcessEvents)
+	require.Nil(t, typed.SocketEvents)
+	require.NotNil(t, typed.FileEvents)
+	require.Equal(t, "/etc/passwd", typed.FileEvents.TargetPath.Value)
+	require.Equal(t, int64(2048), typed.FileEvents.Size.Value)
+	require.Equal(t, "0cc175b9c0f1b6a831c399e269772661", typed.FileEvents.MD5.Value)
+
+	require.Nil(t, NewTypedColumns("pack/incident-response/mounts", Row{"blocks": "42"}))
+	require.Nil(t, NewTypedColumns("pack/fim/file_events", nil))
+	require.Nil(t, NewTypedColumns("pack/fim/file_events", Row{"size": "huge"}))
+}
@@ -19,7 +19,9 @@ package osquerylogs
  */
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
@@ -30,7 +32,7 @@ import (
 type Differential struct { // FIXME: field descriptions need updating!
 	Action               *string                `json:"action,omitempty" validate:"required" description:"Action"`
 	CalendarTime         *timestamp.ANSICwithTZ `json:"calendarTime,omitempty" validate:"required" description:"The time of the event (UTC)."`
-	Columns              map[string]string      `json:"columns,omitempty" validate:"required" description:"Columns"`
+	Columns              Row                    `json:"columns,omitempty" validate:"required" description:"Columns"`
 	Counter              *numerics.Integer      `json:"counter,omitempty" description:"Counter"`
 	Decorations          map[string]string      `json:"decorations,omitempty" description:"Decorations"`
 	Epoch                *numerics.Integer      `json:"epoch,omitempty" validate:"required" description:"Epoch"`
@@ -40,6 +42,7 @@ type Differential struct { // FIXME: field descriptions need updating!
 	Name                 *string                `json:"name,omitempty" validate:"required" description:"Name"`
 	UnixTime             *numerics.Integer      `json:"unixTime,omitempty" validate:"required" description:"UnixTime"`
 	LogNumericsAsNumbers *bool                  `json:"logNumericsAsNumbers,omitempty,string" description:"LogNumericsAsNumbers"`
+	TypedColumns         *TypedColumns          `json:"typed_columns,omitempty" description:"Columns with types resolved from the query table"`
 
 	// NOTE: added to end of struct to allow expansion later
 	parsers.PantherLog
@@ -61,12 +64,16 @@ func (p *DifferentialParser) Parse(log string) ([]*parsers.PantherLog, error) {
 	if err != nil {
 		return nil, err
 	}
+	if isNumericsResult(log) {
+		return nil, errors.New("results logged with numerics are parsed as " + TypeResult)
+	}
 
 	// Populating LogType with LogTypeInput value
 	// This is needed because we want the JSON field with key `log_type` to be marshalled
 	// with key `logtype`
 	event.LogType = event.LogUnderscoreType
 	event.LogUnderscoreType = nil
+	event.TypedColumns = NewTypedColumns(aws.StringValue(event.Name), event.Columns)
 
 	event.updatePantherFields(p)
 
@@ -86,6 +93,22 @@ func (event *Differential) updatePantherFields(p *DifferentialParser) {
 	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.CalendarTime), event)
 	event.AppendAnyDomainNamePtrs(event.HostIdentifier)
 
-	event.AppendAnyIPAddress(event.Columns["local_address"])
-	event.AppendAnyIPAddress(event.Columns["remote_address"])
+	for _, name := range ipColumns {
+		event.AppendAnyIPAddress(event.Columns[name])
+	}
+	for _, name := range md5Columns {
+		if value := event.Columns[name]; value != "" {
+			event.AppendAnyMD5Hashes(value)
+		}
+	}
+	for _, name := range sha1Columns {
+		if value := event.Columns[name]; value != "" {
+			event.AppendAnySHA1Hashes(value)
+		}
+	}
+	for _, name := range sha256Columns {
+		if value := event.Columns[name]; value != "" {
+			event.AppendAnySHA256Hashes(value)
+		}
+	}
 }
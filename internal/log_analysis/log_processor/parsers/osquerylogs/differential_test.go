@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/require"
 
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
@@ -167,6 +168,57 @@ func TestOsQueryDifferentialLogType(t *testing.T) {
 	require.Equal(t, "Osquery.Differential", parser.LogType())
 }
 
+func TestDifferentialLogTypedColumns(t *testing.T) {
+	//nolint:lll
+	log := `{"name":"pack/network/socket_events","hostIdentifier":"ubuntu-dev","calendarTime":"Tue Sep 15 10:12:01 2020 UTC","unixTime":1600164721,"epoch":0,"counter":3,"logNumericsAsNumbers":"true","columns":{"action":"connect","pid":2112,"path":"/usr/bin/curl","local_address":"10.0.2.15","remote_address":"93.184.216.34","local_port":48842,"remote_port":443,"time":1600164720,"sha256":"ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"},"action":"added"}`
+
+	expectedTime := time.Unix(1600164721, 0).UTC()
+	expectedEvent := &Differential{
+		Action:               aws.String("added"),
+		Name:                 aws.String("pack/network/socket_events"),
+		Epoch:                (*numerics.Integer)(aws.Int(0)),
+		HostIdentifier:       aws.String("ubuntu-dev"),
+		UnixTime:             (*numerics.Integer)(aws.Int(1600164721)),
+		LogNumericsAsNumbers: aws.Bool(true),
+		CalendarTime:         (*timestamp.ANSICwithTZ)(&expectedTime),
+		Columns: map[string]string{
+			"action":         "connect",
+			"pid":            "2112",
+			"path":           "/usr/bin/curl",
+			"local_address":  "10.0.2.15",
+			"remote_address": "93.184.216.34",
+			"local_port":     "48842",
+			"remote_port":    "443",
+			"time":           "1600164720",
+			"sha256":         "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb",
+		},
+		Counter: (*numerics.Integer)(aws.Int(3)),
+		TypedColumns: &TypedColumns{
+			SocketEvents: &SocketEvents{
+				Action:        null.FromString("connect"),
+				PID:           null.FromInt64(2112),
+				Path:          null.FromString("/usr/bin/curl"),
+				LocalAddress:  null.FromString("10.0.2.15"),
+				RemoteAddress: null.FromString("93.184.216.34"),
+				LocalPort:     null.FromUint16(48842),
+				RemotePort:    null.FromUint16(443),
+				Time:          time.Unix(1600164720, 0),
+				SHA256:        null.FromString("ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"),
+			},
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Osquery.Differential")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	expectedEvent.AppendAnyDomainNames("ubuntu-dev")
+	expectedEvent.AppendAnyIPAddress("10.0.2.15")
+	expectedEvent.AppendAnyIPAddress("93.184.216.34")
+	expectedEvent.AppendAnySHA256Hashes("ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb")
+
+	checkOsQueryDifferentialLog(t, log, expectedEvent)
+}
+
 func checkOsQueryDifferentialLog(t *testing.T, log string, expectedEvent *Differential) {
 	expectedEvent.SetEvent(expectedEvent)
 	parser := &DifferentialParser{}
@@ -0,0 +1,57 @@
+package osquerylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// DistributedQueryResult is the result of a distributed (live) query on a single host as streamed by Fleet.
+// nolint:lll
+type DistributedQueryResult struct {
+	DistributedQueryExecutionID null.Int64            `json:"distributed_query_execution_id" validate:"required" description:"The id of the distributed query campaign execution."`
+	QueryName                   null.String           `json:"query_name" description:"The name of the query (if a saved query was used)."`
+	Query                       null.String           `json:"query" description:"The SQL of the query."`
+	Timestamp                   time.Time             `json:"timestamp" tcodec:"rfc3339" panther:"event_time" description:"The time the results were received."`
+	Host                        *DistributedQueryHost `json:"host" validate:"required" description:"The host that ran the query."`
+	Rows                        []Row                 `json:"rows" description:"The rows returned by the query."`
+	Status                      null.Int32            `json:"status" description:"The osquery status code of the query (0 on success)."`
+	Error                       null.String           `json:"error" description:"The error message if the query failed."`
+}
+
+// DistributedQueryHost is the host of a distributed query result
+// nolint:lll
+type DistributedQueryHost struct {
+	ID          null.Int64  `json:"id" description:"The Fleet id of the host."`
+	UUID        null.String `json:"uuid" description:"The UUID of the host."`
+	Hostname    null.String `json:"hostname" panther:"hostname" description:"The hostname of the host."`
+	DisplayName null.String `json:"display_name" description:"The display name of the host."`
+	Platform    null.String `json:"platform" description:"The platform of the host (darwin, ubuntu, windows)."`
+	PrimaryIP   null.String `json:"primary_ip" panther:"ip" description:"The primary IP address of the host."`
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *DistributedQueryResult) WriteValuesTo(w pantherlog.ValueWriter) {
+	for _, row := range event.Rows {
+		row.WriteValuesTo(w)
+	}
+}
@@ -0,0 +1,92 @@
+package osquerylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestDistributedQueryResult(t *testing.T) {
+	// nolint:lll
+	input := `{"distributed_query_execution_id":12,"query_name":"open_sockets","query":"SELECT pid, local_address, remote_address FROM process_open_sockets","timestamp":"2020-09-16T08:01:02Z","host":{"id":3,"uuid":"4740D59F-699E-4B4E-A4F6-5C5D3E1A2B3C","hostname":"ubuntu-dev.example.com","display_name":"ubuntu-dev","platform":"ubuntu","primary_ip":"10.0.2.15"},"rows":[{"pid":"2112","local_address":"10.0.2.15","remote_address":"93.184.216.34"},{"pid":"1","local_address":"::","remote_address":""}],"status":0,"error":null}`
+	// nolint:lll
+	expect := `{
+	  "distributed_query_execution_id": 12,
+	  "query_name": "open_sockets",
+	  "query": "SELECT pid, local_address, remote_address FROM process_open_sockets",
+	  "timestamp": "2020-09-16T08:01:02Z",
+	  "host": {
+	    "id": 3,
+	    "uuid": "4740D59F-699E-4B4E-A4F6-5C5D3E1A2B3C",
+	    "hostname": "ubuntu-dev.example.com",
+	    "display_name": "ubuntu-dev",
+	    "platform": "ubuntu",
+	    "primary_ip": "10.0.2.15"
+	  },
+	  "rows": [
+	    {
+	      "pid": "2112",
+	      "local_address": "10.0.2.15",
+	      "remote_address": "93.184.216.34"
+	    },
+	    {
+	      "pid": "1",
+	      "local_address": "::",
+	      "remote_address": ""
+	    }
+	  ],
+	  "status": 0,
+	  "p_log_type": "Osquery.DistributedQueryResult",
+	  "p_event_time": "2020-09-16T08:01:02Z",
+	  "p_any_domain_names": [
+	    "ubuntu-dev.example.com"
+	  ],
+	  "p_any_ip_addresses": [
+	    "10.0.2.15",
+	    "93.184.216.34",
+	    "::"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeDistributedQueryResult, input, expect)
+}
+
+func TestDistributedQueryResultError(t *testing.T) {
+	// nolint:lll
+	input := `{"distributed_query_execution_id":13,"query":"SELECT * FROM nope","timestamp":"2020-09-16T08:05:00Z","host":{"id":3,"hostname":"ubuntu-dev.example.com"},"rows":[],"status":1,"error":"no such table: nope"}`
+	// nolint:lll
+	expect := `{
+	  "distributed_query_execution_id": 13,
+	  "query": "SELECT * FROM nope",
+	  "timestamp": "2020-09-16T08:05:00Z",
+	  "host": {
+	    "id": 3,
+	    "hostname": "ubuntu-dev.example.com"
+	  },
+	  "status": 1,
+	  "error": "no such table: nope",
+	  "p_log_type": "Osquery.DistributedQueryResult",
+	  "p_event_time": "2020-09-16T08:05:00Z",
+	  "p_any_domain_names": [
+	    "ubuntu-dev.example.com"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeDistributedQueryResult, input, expect)
+}
@@ -19,17 +19,29 @@ package osquerylogs
  */
 
 import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 const (
-	TypeBatch        = "Osquery.Batch"
-	TypeDifferential = "Osquery.Differential"
-	TypeSnapshot     = "Osquery.Snapshot"
-	TypeStatus       = "Osquery.Status"
+	TypeBatch                  = "Osquery.Batch"
+	TypeDifferential           = "Osquery.Differential"
+	TypeSnapshot               = "Osquery.Snapshot"
+	TypeStatus                 = "Osquery.Status"
+	TypeResult                 = "Osquery.Result"
+	TypeDistributedQueryResult = "Osquery.DistributedQueryResult"
 )
 
+var jsonAPI = common.BuildJSON()
+
+// isNumericsResult reports whether a log is a result logged with `numerics: true` as Fleet and Kolide launcher do.
+// These are only parsed as TypeResult so that a log is not classified as more than one osquery log type.
+func isNumericsResult(log string) bool {
+	return jsonAPI.Get([]byte(log), "numerics").ToBool()
+}
+
 func init() {
 	logtypes.MustRegister(
 		logtypes.Config{
@@ -60,5 +72,19 @@ func init() {
 			Schema:       Status{},
 			NewParser:    parsers.AdapterFactory(&StatusParser{}),
 		},
+		logtypes.Config{
+			Name:         TypeResult,
+			Description:  `Result is an osquery result log (event or snapshot format) logged with numerics as forwarded by Fleet or Kolide launcher.`,
+			ReferenceURL: `https://osquery.readthedocs.io/en/stable/deployment/logging/`,
+			Schema:       pantherlog.MustBuildEventSchema(&Result{}),
+			NewParser:    parsers.FactoryFunc(NewResultParser),
+		},
 	)
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeDistributedQueryResult,
+		Description:  `DistributedQueryResult is the result of a distributed (live) query on a host as streamed by Fleet.`,
+		ReferenceURL: `https://osquery.readthedocs.io/en/stable/deployment/remote/#distributed-queries`,
+	}, func() interface{} {
+		return &DistributedQueryResult{}
+	})
 }
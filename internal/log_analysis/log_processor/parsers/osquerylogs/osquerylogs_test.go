@@ -0,0 +1,64 @@
+package osquerylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+// Osquery result logs share most fields so each log type must reject the logs of the others
+func TestOsqueryLogTypesAreDistinct(t *testing.T) {
+	// nolint:lll
+	samples := map[string]string{
+		TypeBatch:                  `{"diffResults": {"added": [ { "name": "osqueryd", "path": "/usr/local/bin/osqueryd", "pid": "97830" } ],"removed": [ { "name": "osqueryd", "path": "/usr/local/bin/osqueryd", "pid": "97650" } ] },"name": "processes", "hostname": "hostname.local", "calendarTime": "Tue Nov 5 06:08:26 2018 UTC","unixTime": "1412123850", "epoch": "314159265", "counter": "1" }`,
+		TypeDifferential:           `{"name":"pack/incident-response/listening_ports","hostIdentifier":"jaguar.local","calendarTime":"Tue Nov 5 06:08:26 2018 UTC","unixTime":"1536682461","epoch":0,"counter":33,"numerics":false,"decorations":{"host_uuid":"97D8254F-7D98-56AE-91DB-924545EFXXXX","hostname":"jaguar.local"},"columns":{"address":"0.0.0.0","family":"2","fd":"20","path":"","pid":"75165","port":"55596","protocol":"17","socket":"3276877798114717479"},"action":"added"}`,
+		TypeSnapshot:               `{"action": "snapshot","snapshot": [{"parent": "0","path": "/sbin/launchd","pid": "1"}],"name": "process_snapshot","hostIdentifier": "hostname.local","calendarTime": "Tue Nov 5 06:08:26 2018 UTC","unixTime": "1462228052","epoch": "314159265","counter": "1","numerics": false}`,
+		TypeStatus:                 `{"hostIdentifier":"jaguar.local","calendarTime":"Tue Nov 5 06:08:26 2018 UTC","unixTime":"1535731040","severity":"0","filename":"tls.cpp","line":"253","message":"TLS/HTTPS POST request to URI: https://fleet.runpanther.tools:443/api/v1/osquery/log","version":"4.1.2","decorations":{"host_uuid":"97D8254F-7D98-56AE-91DB-924545EFXXXX","hostname":"jaguar.local"}}`,
+		TypeResult:                 `{"name":"pack/network/socket_events","hostIdentifier":"ubuntu-dev","calendarTime":"Tue Sep 15 10:12:01 2020 UTC","unixTime":1600164721,"epoch":0,"counter":3,"numerics":true,"decorations":{"host_uuid":"4740D59F-699E-4B4E-A4F6-5C5D3E1A2B3C","hostname":"ubuntu-dev.example.com"},"columns":{"action":"connect","pid":2112,"path":"/usr/bin/curl","local_address":"10.0.2.15","remote_address":"93.184.216.34","local_port":48842,"remote_port":443},"action":"added"}`,
+		TypeDistributedQueryResult: `{"distributed_query_execution_id":12,"query_name":"open_sockets","query":"SELECT pid, local_address, remote_address FROM process_open_sockets","timestamp":"2020-09-16T08:01:02Z","host":{"id":3,"uuid":"4740D59F-699E-4B4E-A4F6-5C5D3E1A2B3C","hostname":"ubuntu-dev.example.com"},"rows":[{"pid":"2112","local_address":"10.0.2.15","remote_address":"93.184.216.34"}],"status":0,"error":null}`,
+	}
+	// a snapshot result with numerics is only an Osquery.Result
+	// nolint:lll
+	numericsSnapshot := `{"name":"pack/hashes/binaries","hostIdentifier":"C02XK1ABJG5H","calendarTime":"Wed Sep 16 08:00:00 2020 UTC","unixTime":1600243200,"epoch":0,"counter":0,"numerics":true,"decorations":{"hostname":"macbook.local"},"snapshot":[{"path":"/bin/ls","size":"38704"}],"action":"snapshot"}`
+	for sampleType, sample := range samples {
+		for logType := range samples {
+			entry := logtypes.DefaultRegistry().Get(logType)
+			require.NotNil(t, entry, logType)
+			parser, err := entry.NewParser(nil)
+			require.NoError(t, err)
+			_, err = parser.ParseLog(sample)
+			if logType == sampleType {
+				assert.NoError(t, err, "%s sample", sampleType)
+			} else {
+				assert.Error(t, err, "%s sample parsed as %s", sampleType, logType)
+			}
+			_, err = parser.ParseLog(numericsSnapshot)
+			if logType == TypeResult {
+				assert.NoError(t, err, "numerics snapshot")
+			} else {
+				assert.Error(t, err, "numerics snapshot parsed as %s", logType)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package osquerylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Result is an osquery result log as forwarded by Fleet or Kolide launcher.
+// It handles both the event (differential) and snapshot formats, results must be logged with `numerics: true`
+// and have decorations, other results are parsed as Osquery.Differential or Osquery.Snapshot.
+// Columns of queries on well known tables are also available with proper types in `typed_columns`.
+// nolint:lll
+type Result struct {
+	Name           null.String       `json:"name" validate:"required" description:"The name of the query that generated the result."`
+	HostIdentifier null.String       `json:"hostIdentifier" panther:"hostname" description:"The identifier of the host (as set by the --host_identifier flag)."`
+	CalendarTime   time.Time         `json:"calendarTime" tcodec:"layout=Mon Jan _2 15:04:05 2006 MST" panther:"event_time" description:"The time of the event (UTC)."`
+	UnixTime       time.Time         `json:"unixTime" tcodec:"unix" panther:"event_time" validate:"required" description:"The time of the event in UNIX time."`
+	Epoch          null.Int64        `json:"epoch" description:"The epoch of the query results."`
+	Counter        null.Int64        `json:"counter" description:"The number of times the query results were logged for the same epoch."`
+	Numerics       null.Bool         `json:"numerics" description:"True if numeric column values are logged as JSON numbers."`
+	Decorations    map[string]string `json:"decorations" validate:"required" description:"Decorations added to the result by the osquery config."`
+	Action         null.String       `json:"action" validate:"required" description:"The action of the result (added, removed, snapshot)."`
+	Columns        Row               `json:"columns" description:"The columns of an added or removed row."`
+	Snapshot       []Row             `json:"snapshot" description:"The rows of a snapshot query."`
+	TypedColumns   *TypedColumns     `json:"typed_columns" description:"The columns of an added or removed row with types resolved from the query table."`
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *Result) WriteValuesTo(w pantherlog.ValueWriter) {
+	if hostname := event.Decorations["hostname"]; hostname != "" {
+		pantherlog.ScanHostname(w, hostname)
+	}
+	event.Columns.WriteValuesTo(w)
+	for _, row := range event.Snapshot {
+		row.WriteValuesTo(w)
+	}
+}
+
+// ResultParser parses osquery result logs
+type ResultParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*ResultParser)(nil)
+
+// NewResultParser creates a new osquery result log parser
+func NewResultParser(_ interface{}) (parsers.Interface, error) {
+	return &ResultParser{}, nil
+}
+
+// ParseLog implements parsers.Interface
+func (p *ResultParser) ParseLog(log string) ([]*parsers.Result, error) {
+	event := Result{}
+	if err := jsonAPI.UnmarshalFromString(log, &event); err != nil {
+		return nil, err
+	}
+	if err := parsers.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	if !event.Numerics.Value {
+		return nil, errors.New("result is not logged with numerics")
+	}
+	event.TypedColumns = NewTypedColumns(event.Name.Value, event.Columns)
+	result, err := p.builder.BuildResult(TypeResult, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
@@ -0,0 +1,246 @@
+package osquerylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestResultNumerics(t *testing.T) {
+	// nolint:lll
+	input := `{"name":"pack/network/socket_events","hostIdentifier":"ubuntu-dev","calendarTime":"Tue Sep 15 10:12:01 2020 UTC","unixTime":1600164721,"epoch":0,"counter":3,"numerics":true,"decorations":{"host_uuid":"4740D59F-699E-4B4E-A4F6-5C5D3E1A2B3C","hostname":"ubuntu-dev.example.com"},"columns":{"action":"connect","pid":2112,"path":"/usr/bin/curl","fd":"3","auid":1000,"success":1,"family":2,"protocol":6,"local_address":"10.0.2.15","remote_address":"93.184.216.34","local_port":48842,"remote_port":443,"socket":"","time":1600164720,"uptime":8121,"eid":"2931"},"action":"added"}`
+	// nolint:lll
+	expect := `{
+	  "name": "pack/network/socket_events",
+	  "hostIdentifier": "ubuntu-dev",
+	  "calendarTime": "Tue Sep 15 10:12:01 2020 UTC",
+	  "unixTime": 1600164721,
+	  "epoch": 0,
+	  "counter": 3,
+	  "numerics": true,
+	  "decorations": {
+	    "host_uuid": "4740D59F-699E-4B4E-A4F6-5C5D3E1A2B3C",
+	    "hostname": "ubuntu-dev.example.com"
+	  },
+	  "action": "added",
+	  "columns": {
+	    "action": "connect",
+	    "pid": "2112",
+	    "fd": "3",
+	    "protocol": "6",
+	    "uptime": "8121",
+	    "remote_address": "93.184.216.34",
+	    "remote_port": "443",
+	    "time": "1600164720",
+	    "path": "/usr/bin/curl",
+	    "socket": "",
+	    "eid": "2931",
+	    "auid": "1000",
+	    "success": "1",
+	    "family": "2",
+	    "local_address": "10.0.2.15",
+	    "local_port": "48842"
+	  },
+	  "typed_columns": {
+	    "socket_events": {
+	      "action": "connect",
+	      "pid": 2112,
+	      "path": "/usr/bin/curl",
+	      "fd": "3",
+	      "auid": 1000,
+	      "success": 1,
+	      "family": 2,
+	      "protocol": 6,
+	      "local_address": "10.0.2.15",
+	      "remote_address": "93.184.216.34",
+	      "local_port": 48842,
+	      "remote_port": 443,
+	      "socket": "",
+	      "time": 1600164720,
+	      "uptime": 8121,
+	      "eid": "2931"
+	    }
+	  },
+	  "p_log_type": "Osquery.Result",
+	  "p_event_time": "2020-09-15T10:12:01Z",
+	  "p_any_domain_names": [
+	    "ubuntu-dev",
+	    "ubuntu-dev.example.com"
+	  ],
+	  "p_any_ip_addresses": [
+	    "10.0.2.15",
+	    "93.184.216.34"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeResult, input, expect)
+}
+
+func TestResultLegacyPackName(t *testing.T) {
+	// nolint:lll
+	input := `{"name":"pack_incident-response_process_events","hostIdentifier":"ubuntu-dev","calendarTime":"Tue Sep  8 10:03:21 2020 UTC","unixTime":"1599559401","epoch":"0","counter":"0","numerics":true,"decorations":{"host_uuid":"4740D59F-699E-4B4E-A4F6-5C5D3E1A2B3C"},"columns":{"pid":"4242","parent":"4100","path":"/tmp/payload","cmdline":"/tmp/payload -c 10.0.2.2","cwd":"/tmp","uid":"1000","euid":"0","gid":"1000","egid":"0","btime":"1599559300","time":"1599559400","uptime":"5000","syscall":"execve","md5":"0cc175b9c0f1b6a831c399e269772661","sha256":"ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"},"action":"added"}`
+	// nolint:lll
+	expect := `{
+	  "name": "pack_incident-response_process_events",
+	  "hostIdentifier": "ubuntu-dev",
+	  "calendarTime": "Tue Sep  8 10:03:21 2020 UTC",
+	  "unixTime": 1599559401,
+	  "epoch": 0,
+	  "counter": 0,
+	  "numerics": true,
+	  "decorations": {
+	    "host_uuid": "4740D59F-699E-4B4E-A4F6-5C5D3E1A2B3C"
+	  },
+	  "action": "added",
+	  "columns": {
+	    "uptime": "5000",
+	    "sha256": "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb",
+	    "cmdline": "/tmp/payload -c 10.0.2.2",
+	    "syscall": "execve",
+	    "pid": "4242",
+	    "cwd": "/tmp",
+	    "euid": "0",
+	    "gid": "1000",
+	    "egid": "0",
+	    "btime": "1599559300",
+	    "time": "1599559400",
+	    "path": "/tmp/payload",
+	    "uid": "1000",
+	    "md5": "0cc175b9c0f1b6a831c399e269772661",
+	    "parent": "4100"
+	  },
+	  "typed_columns": {
+	    "process_events": {
+	      "pid": 4242,
+	      "path": "/tmp/payload",
+	      "cmdline": "/tmp/payload -c 10.0.2.2",
+	      "cwd": "/tmp",
+	      "uid": 1000,
+	      "euid": 0,
+	      "gid": 1000,
+	      "egid": 0,
+	      "btime": 1599559300,
+	      "parent": 4100,
+	      "time": 1599559400,
+	      "uptime": 5000,
+	      "syscall": "execve",
+	      "md5": "0cc175b9c0f1b6a831c399e269772661",
+	      "sha256": "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"
+	    }
+	  },
+	  "p_log_type": "Osquery.Result",
+	  "p_event_time": "2020-09-08T10:03:21Z",
+	  "p_any_md5_hashes": [
+	    "0cc175b9c0f1b6a831c399e269772661"
+	  ],
+	  "p_any_sha256_hashes": [
+	    "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"
+	  ],
+	  "p_any_domain_names": [
+	    "ubuntu-dev"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeResult, input, expect)
+}
+
+func TestResultSnapshot(t *testing.T) {
+	// nolint:lll
+	input := `{"name":"pack/hashes/binaries","hostIdentifier":"C02XK1ABJG5H","calendarTime":"Wed Sep 16 08:00:00 2020 UTC","unixTime":1600243200,"epoch":0,"counter":0,"numerics":true,"decorations":{"hostname":"macbook.local"},"snapshot":[{"path":"/bin/ls","md5":"0cc175b9c0f1b6a831c399e269772661","sha1":"86f7e437faa5a7fce15d1ddcb9eaeaea377667b8","size":38704},{"path":"/bin/cat","md5":"92eb5ffee6ae2fec3ad71c777531578f","sha1":"e9d71f5ee7c92d6dc9e92ffdad17b8bd49418f98","size":null}],"action":"snapshot"}`
+	// nolint:lll
+	expect := `{
+	  "name": "pack/hashes/binaries",
+	  "hostIdentifier": "C02XK1ABJG5H",
+	  "calendarTime": "Wed Sep 16 08:00:00 2020 UTC",
+	  "unixTime": 1600243200,
+	  "epoch": 0,
+	  "counter": 0,
+	  "numerics": true,
+	  "decorations": {
+	    "hostname": "macbook.local"
+	  },
+	  "action": "snapshot",
+	  "snapshot": [
+	    {
+	      "path": "/bin/ls",
+	      "md5": "0cc175b9c0f1b6a831c399e269772661",
+	      "sha1": "86f7e437faa5a7fce15d1ddcb9eaeaea377667b8",
+	      "size": "38704"
+	    },
+	    {
+	      "path": "/bin/cat",
+	      "md5": "92eb5ffee6ae2fec3ad71c777531578f",
+	      "sha1": "e9d71f5ee7c92d6dc9e92ffdad17b8bd49418f98"
+	    }
+	  ],
+	  "p_log_type": "Osquery.Result",
+	  "p_event_time": "2020-09-16T08:00:00Z",
+	  "p_any_domain_names": [
+	    "C02XK1ABJG5H",
+	    "macbook.local"
+	  ],
+	  "p_any_md5_hashes": [
+	    "0cc175b9c0f1b6a831c399e269772661",
+	    "92eb5ffee6ae2fec3ad71c777531578f"
+	  ],
+	  "p_any_sha1_hashes": [
+	    "86f7e437faa5a7fce15d1ddcb9eaeaea377667b8",
+	    "e9d71f5ee7c92d6dc9e92ffdad17b8bd49418f98"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeResult, input, expect)
+}
+
+func TestResultInvalidTypedColumns(t *testing.T) {
+	// nolint:lll
+	input := `{"name":"pack/network/socket_events","hostIdentifier":"ubuntu-dev","unixTime":1600164721,"numerics":true,"decorations":{},"columns":{"pid":"not-a-number"},"action":"added"}`
+	// nolint:lll
+	expect := `{
+	  "name": "pack/network/socket_events",
+	  "hostIdentifier": "ubuntu-dev",
+	  "unixTime": 1600164721,
+	  "numerics": true,
+	  "action": "added",
+	  "columns": {
+	    "pid": "not-a-number"
+	  },
+	  "p_log_type": "Osquery.Result",
+	  "p_event_time": "2020-09-15T10:12:01Z",
+	  "p_any_domain_names": [
+	    "ubuntu-dev"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeResult, input, expect)
+}
+
+func TestResultInvalid(t *testing.T) {
+	p, err := NewResultParser(nil)
+	require.NoError(t, err)
+	// Missing query name
+	_, err = p.ParseLog(`{"hostIdentifier":"ubuntu-dev","unixTime":1600164721,"columns":{},"action":"added"}`)
+	require.Error(t, err)
+	// Column values must be scalar
+	_, err = p.ParseLog(`{"name":"x","hostIdentifier":"ubuntu-dev","unixTime":1600164721,"numerics":true,"decorations":{},"columns":{"a":[1]},"action":"added"}`)
+	require.Error(t, err)
+	// Results without numerics are parsed as Osquery.Differential
+	_, err = p.ParseLog(`{"name":"x","hostIdentifier":"ubuntu-dev","unixTime":1600164721,"numerics":false,"decorations":{},"columns":{},"action":"added"}`)
+	require.Error(t, err)
+}
@@ -20,6 +20,7 @@ package osquerylogs
 
 import (
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
@@ -58,6 +59,9 @@ func (p *SnapshotParser) Parse(log string) ([]*parsers.PantherLog, error) {
 	if err != nil {
 		return nil, err
 	}
+	if isNumericsResult(log) {
+		return nil, errors.New("results logged with numerics are parsed as " + TypeResult)
+	}
 
 	event.updatePantherFields(p)
 
@@ -0,0 +1,103 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Header holds the columns common to all PAN-OS logs
+// nolint:lll
+type Header struct {
+	ReceiveTime   time.Time   `json:"receive_time" tcodec:"layout=2006/01/02 15:04:05" validate:"required" description:"Time the log was received at the management plane."`
+	SerialNumber  null.String `json:"serial" description:"Serial number of the firewall that generated the log."`
+	Type          null.String `json:"type" validate:"required" description:"Type of log (TRAFFIC, THREAT, SYSTEM)."`
+	Subtype       null.String `json:"subtype" description:"Subtype of the log."`
+	TimeGenerated time.Time   `json:"time_generated" tcodec:"layout=2006/01/02 15:04:05" panther:"event_time" validate:"required" description:"Time the log was generated on the dataplane."`
+}
+
+// Session holds the session columns common to traffic and threat logs
+// nolint:lll
+type Session struct {
+	SourceAddress         null.String `json:"src" panther:"ip" description:"Original session source IP address."`
+	DestinationAddress    null.String `json:"dst" panther:"ip" description:"Original session destination IP address."`
+	NATSourceAddress      null.String `json:"natsrc" description:"If Source NAT performed, the post-NAT Source IP address."`
+	NATDestinationAddress null.String `json:"natdst" description:"If Destination NAT performed, the post-NAT Destination IP address."`
+	Rule                  null.String `json:"rule" description:"Name of the rule that the session matched."`
+	SourceUser            null.String `json:"srcuser" description:"Username of the user who initiated the session."`
+	DestinationUser       null.String `json:"dstuser" description:"Username of the user to which the session was destined."`
+	Application           null.String `json:"app" description:"Application associated with the session."`
+	VirtualSystem         null.String `json:"vsys" description:"Virtual System associated with the session."`
+	SourceZone            null.String `json:"from" description:"Zone the session was sourced from."`
+	DestinationZone       null.String `json:"to" description:"Zone the session was destined to."`
+	InboundInterface      null.String `json:"inbound_if" description:"Interface that the session was sourced from."`
+	OutboundInterface     null.String `json:"outbound_if" description:"Interface that the session was destined to."`
+	LogAction             null.String `json:"logset" description:"Log Forwarding Profile that was applied to the session."`
+	SessionID             null.Int64  `json:"sessionid" description:"An internal numerical identifier applied to each session."`
+	RepeatCount           null.Int32  `json:"repeatcnt" description:"Number of sessions with same Source IP, Destination IP, Application, and Subtype seen within 5 seconds."`
+	SourcePort            null.Uint16 `json:"sport" description:"Source port utilized by the session."`
+	DestinationPort       null.Uint16 `json:"dport" description:"Destination port utilized by the session."`
+	NATSourcePort         null.Uint16 `json:"natsport" description:"Post-NAT source port."`
+	NATDestinationPort    null.Uint16 `json:"natdport" description:"Post-NAT destination port."`
+	Flags                 null.String `json:"flags" description:"32-bit field that provides details on session (hex)."`
+	Protocol              null.String `json:"proto" description:"IP protocol associated with the session."`
+	Action                null.String `json:"action" description:"Action taken for the session."`
+}
+
+// DeviceGroup holds the device group and device name columns common to all PAN-OS logs
+// nolint:lll
+type DeviceGroup struct {
+	DeviceGroupHierarchyLevel1 null.String `json:"dg_hier_level_1" description:"Device group hierarchy level 1 ID."`
+	DeviceGroupHierarchyLevel2 null.String `json:"dg_hier_level_2" description:"Device group hierarchy level 2 ID."`
+	DeviceGroupHierarchyLevel3 null.String `json:"dg_hier_level_3" description:"Device group hierarchy level 3 ID."`
+	DeviceGroupHierarchyLevel4 null.String `json:"dg_hier_level_4" description:"Device group hierarchy level 4 ID."`
+	VirtualSystemName          null.String `json:"vsys_name" description:"The name of the virtual system associated with the session."`
+	DeviceName                 null.String `json:"device_name" description:"The hostname of the firewall on which the session was logged."`
+}
+
+// DeviceID holds the Device-ID and container columns added to traffic and threat logs in PAN-OS 9.1
+// nolint:lll
+type DeviceID struct {
+	XFFAddress                     null.String `json:"xff_ip" panther:"ip" description:"The IP address of the user who requested the webpage or the IP address of the next to last device that the request traversed."`
+	SourceDeviceCategory           null.String `json:"src_category" description:"Category of the source device."`
+	SourceDeviceProfile            null.String `json:"src_profile" description:"Profile of the source device."`
+	SourceDeviceModel              null.String `json:"src_model" description:"Model of the source device."`
+	SourceDeviceVendor             null.String `json:"src_vendor" description:"Vendor of the source device."`
+	SourceDeviceOSFamily           null.String `json:"src_osfamily" description:"Operating system of the source device."`
+	SourceDeviceOSVersion          null.String `json:"src_osversion" description:"Operating system version of the source device."`
+	SourceHostname                 null.String `json:"src_host" panther:"hostname" description:"Hostname of the source device."`
+	SourceMACAddress               null.String `json:"src_mac" description:"MAC address of the source device."`
+	DestinationDeviceCategory      null.String `json:"dst_category" description:"Category of the destination device."`
+	DestinationDeviceProfile       null.String `json:"dst_profile" description:"Profile of the destination device."`
+	DestinationDeviceModel         null.String `json:"dst_model" description:"Model of the destination device."`
+	DestinationDeviceVendor        null.String `json:"dst_vendor" description:"Vendor of the destination device."`
+	DestinationDeviceOSFamily      null.String `json:"dst_osfamily" description:"Operating system of the destination device."`
+	DestinationDeviceOSVersion     null.String `json:"dst_osversion" description:"Operating system version of the destination device."`
+	DestinationHostname            null.String `json:"dst_host" panther:"hostname" description:"Hostname of the destination device."`
+	DestinationMACAddress          null.String `json:"dst_mac" description:"MAC address of the destination device."`
+	ContainerID                    null.String `json:"container_id" description:"Container ID of the pod on the Kubernetes node."`
+	PodNamespace                   null.String `json:"pod_namespace" description:"Namespace of the pod on the Kubernetes node."`
+	PodName                        null.String `json:"pod_name" description:"Name of the pod on the Kubernetes node."`
+	SourceExternalDynamicList      null.String `json:"src_edl" description:"The name of the external dynamic list that contains the source IP address of the traffic."`
+	DestinationExternalDynamicList null.String `json:"dst_edl" description:"The name of the external dynamic list that contains the destination IP address of the traffic."`
+	HostID                         null.String `json:"hostid" description:"Unique ID GlobalProtect assigns to identify the host."`
+	UserDeviceSerialNumber         null.String `json:"serialnumber" description:"Serial number of the user's machine or device."`
+}
@@ -0,0 +1,204 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/csvstream"
+)
+
+const (
+	// LogTypePrefix is the prefix of all log types parsed by this package
+	LogTypePrefix = "PaloAlto"
+	// TypeTraffic is the log type of PAN-OS traffic logs
+	TypeTraffic = LogTypePrefix + ".Traffic"
+	// TypeThreat is the log type of PAN-OS threat logs
+	TypeThreat = LogTypePrefix + ".Threat"
+	// TypeSystem is the log type of PAN-OS system logs
+	TypeSystem = LogTypePrefix + ".System"
+)
+
+func init() {
+	// nolint:lll
+	logtypes.MustRegister(
+		logtypes.Config{
+			Name: TypeTraffic,
+			Description: `PAN-OS traffic logs in the default CSV syslog format.
+NOTE: Timestamps are assumed to be UTC. Columns added in PAN-OS versions newer than 10.0 are ignored.`,
+			ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/10-0/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/traffic-log-fields.html`,
+			Schema:       pantherlog.MustBuildEventSchema(&Traffic{}),
+			NewParser:    newParserFactory(TypeTraffic, "TRAFFIC", trafficLayouts, func() interface{} { return &Traffic{} }),
+		},
+		logtypes.Config{
+			Name: TypeThreat,
+			Description: `PAN-OS threat logs in the default CSV syslog format.
+NOTE: Timestamps are assumed to be UTC. Columns added in PAN-OS versions newer than 10.0 are ignored.`,
+			ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/10-0/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/threat-log-fields.html`,
+			Schema:       pantherlog.MustBuildEventSchema(&Threat{}),
+			NewParser:    newParserFactory(TypeThreat, "THREAT", threatLayouts, func() interface{} { return &Threat{} }),
+		},
+		logtypes.Config{
+			Name: TypeSystem,
+			Description: `PAN-OS system logs in the default CSV syslog format.
+NOTE: Timestamps are assumed to be UTC.`,
+			ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/10-0/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/system-log-fields.html`,
+			Schema:       pantherlog.MustBuildEventSchema(&System{}),
+			NewParser:    newParserFactory(TypeSystem, "SYSTEM", systemLayouts, func() interface{} { return &System{} }),
+		},
+	)
+}
+
+// Layout describes the positional columns of a log type for a PAN-OS version.
+// Columns are named after the PAN-OS field names. FUTURE_USE columns have an empty name.
+type Layout struct {
+	Version string
+	Columns []string
+}
+
+// Layouts holds the layouts of a log type ordered by version.
+// Newer PAN-OS versions only append columns so each layout extends the previous one.
+type Layouts []Layout
+
+// Select finds the layout of the newest PAN-OS version that fits in a record with `numColumns` columns.
+// Records with more columns than the newest layout come from newer PAN-OS versions and their extra columns are ignored.
+func (layouts Layouts) Select(numColumns int) *Layout {
+	var selected *Layout
+	for i := range layouts {
+		layout := &layouts[i]
+		if len(layout.Columns) > numColumns {
+			break
+		}
+		selected = layout
+	}
+	return selected
+}
+
+// extend creates the layout of a newer PAN-OS version that adds `columns` to the last layout
+func (layouts Layouts) extend(version string, columns ...string) Layouts {
+	last := layouts[len(layouts)-1].Columns
+	extended := make([]string, 0, len(last)+len(columns))
+	extended = append(extended, last...)
+	extended = append(extended, columns...)
+	return append(layouts, Layout{
+		Version: version,
+		Columns: extended,
+	})
+}
+
+// PAN-OS logs are shipped over syslog so lines can be prefixed by an RFC3164 or RFC5424 header
+var rxSyslogHeader = regexp.MustCompile(`^<\d{1,3}>(?:1 \S+ \S+ \S+ \S+ \S+ (?:-|\[.*?\]) |[A-Z][a-z]{2} {1,2}\d{1,2} \d{2}:\d{2}:\d{2} \S+ )?`)
+
+// Index of the type column in all PAN-OS logs
+const columnType = 3
+
+func newParserFactory(logType, typ string, layouts Layouts, newEvent func() interface{}) parsers.Factory {
+	return parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+		return &Parser{
+			logType:  logType,
+			typ:      typ,
+			layouts:  layouts,
+			newEvent: newEvent,
+			csv:      csvstream.NewStreamingCSVReader(),
+			stream:   jsoniter.NewStream(jsonAPI, nil, 4096),
+		}, nil
+	})
+}
+
+var jsonAPI = common.BuildJSON()
+
+// Parser parses PAN-OS CSV logs of a specific type.
+// The columns of each record are mapped to fields using the layout of the PAN-OS version that produced it.
+type Parser struct {
+	logType  string
+	typ      string
+	layouts  Layouts
+	newEvent func() interface{}
+	csv      *csvstream.StreamingCSVReader
+	stream   *jsoniter.Stream
+	builder  pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*Parser)(nil)
+
+// ParseLog implements parsers.Interface
+func (p *Parser) ParseLog(log string) ([]*parsers.Result, error) {
+	log = rxSyslogHeader.ReplaceAllLiteralString(strings.TrimSpace(log), "")
+	record, err := p.csv.Parse(log)
+	if err != nil {
+		return nil, err
+	}
+	if len(record) <= columnType || record[columnType] != p.typ {
+		return nil, errors.Errorf("invalid %s log type", p.logType)
+	}
+	layout := p.layouts.Select(len(record))
+	if layout == nil {
+		return nil, errors.Errorf("invalid number of columns %d for %s", len(record), p.logType)
+	}
+
+	// Map the record columns to a JSON object and decode the typed event from it
+	stream := p.stream
+	stream.Reset(nil)
+	stream.WriteObjectStart()
+	more := false
+	for i, name := range layout.Columns {
+		// Skip FUTURE_USE and empty columns so that missing values are null
+		if name == "" || record[i] == "" {
+			continue
+		}
+		if more {
+			stream.WriteMore()
+		}
+		stream.WriteObjectField(name)
+		stream.WriteString(record[i])
+		more = true
+	}
+	stream.WriteObjectEnd()
+
+	event := p.newEvent()
+	if err := jsonAPI.Unmarshal(stream.Buffer(), event); err != nil {
+		return nil, err
+	}
+	if err := parsers.ValidateStruct(event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(p.logType, event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+// scanNAT writes the NAT addresses of a session.
+// PAN-OS sets the NAT addresses to 0.0.0.0 for sessions without NAT so we skip it.
+func scanNAT(w pantherlog.ValueWriter, addrs ...string) {
+	for _, addr := range addrs {
+		if addr != "0.0.0.0" {
+			pantherlog.ScanIPAddress(w, addr)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+func TestLayoutsSelect(t *testing.T) {
+	assert := require.New(t)
+	assert.Nil(trafficLayouts.Select(10))
+	assert.Equal("8.1", trafficLayouts.Select(67).Version)
+	assert.Equal("8.1", trafficLayouts.Select(70).Version)
+	assert.Equal("9.0", trafficLayouts.Select(75).Version)
+	assert.Equal("9.1", trafficLayouts.Select(103).Version)
+	assert.Equal("10.0", trafficLayouts.Select(105).Version)
+	// Records from newer versions use the newest layout
+	assert.Equal("10.0", trafficLayouts.Select(120).Version)
+	assert.Equal("8.1", threatLayouts.Select(78).Version)
+	assert.Equal("9.0", threatLayouts.Select(79).Version)
+	assert.Equal("9.1", threatLayouts.Select(110).Version)
+	assert.Equal("8.1", systemLayouts.Select(23).Version)
+	assert.Equal("9.1", systemLayouts.Select(26).Version)
+}
+
+func TestLayoutsExtend(t *testing.T) {
+	assert := require.New(t)
+	layouts := Layouts{{Version: "1", Columns: []string{"a", "b"}}}.extend("2", "c")
+	assert.Equal(Layouts{
+		{Version: "1", Columns: []string{"a", "b"}},
+		{Version: "2", Columns: []string{"a", "b", "c"}},
+	}, layouts)
+}
+
+func TestSyslogHeader(t *testing.T) {
+	assert := require.New(t)
+	for _, header := range []string{
+		"",
+		"<14>",
+		"<14>Oct 15 10:20:30 PA-VM-01 ",
+		"<14>Oct  5 10:20:30 PA-VM-01 ",
+		"<14>1 2020-10-15T10:20:30+00:00 PA-VM-01 - - - - ",
+		"<14>1 2020-10-15T10:20:30+00:00 PA-VM-01 - - - [meta sequenceId=\"1\"] ",
+	} {
+		assert.Equal("1,2020/10/15 10:20:30", rxSyslogHeader.ReplaceAllLiteralString(header+"1,2020/10/15 10:20:30", ""), header)
+	}
+}
+
+func TestParserErrors(t *testing.T) {
+	assert := require.New(t)
+	p, err := logtypes.DefaultRegistry().Get(TypeTraffic).NewParser(nil)
+	assert.NoError(err)
+	// nolint:lll
+	threat := `1,2020/10/15 10:20:30,012801096514,THREAT,url,2049,2020/10/15 10:20:29,10.0.0.12,203.0.113.50,198.51.100.7,0.0.0.0,allow-outbound,corp\jdoe,,web-browsing,vsys1,trust,untrust,ethernet1/2,ethernet1/1,default,,34521,1,51432,80,23011,0,0x40001c,tcp,block-url`
+	_, err = p.ParseLog(threat)
+	assert.Error(err)
+	_, err = p.ParseLog(`1,2020/10/15 10:20:30,012801096514,TRAFFIC,end,2049,2020/10/15 10:20:29`)
+	assert.Error(err)
+	_, err = p.ParseLog(`{"type":"TRAFFIC"}`)
+	assert.Error(err)
+}
@@ -0,0 +1,54 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// System is a PAN-OS system log
+// nolint:lll
+type System struct {
+	Header
+	VirtualSystem  null.String `json:"vsys" description:"Virtual System associated with the event."`
+	EventID        null.String `json:"eventid" description:"Name of the event."`
+	Object         null.String `json:"object" description:"Name of the object associated with the system event."`
+	Module         null.String `json:"module" description:"The module of the event, only used for the general subtype."`
+	Severity       null.String `json:"severity" description:"Severity associated with the event (informational, low, medium, high, critical)."`
+	Description    null.String `json:"opaque" description:"Detailed description of the event."`
+	SequenceNumber null.Int64  `json:"seqno" description:"A 64-bit log entry identifier incremented sequentially; each log type has a unique number space."`
+	ActionFlags    null.String `json:"actionflags" description:"A bit field indicating if the log was forwarded to Panorama."`
+	DeviceGroup
+	HighResolutionTimestamp time.Time `json:"high_res_timestamp" tcodec:"rfc3339" description:"Time the log was generated with millisecond precision (added in PAN-OS 9.1)."`
+}
+
+var systemLayouts = Layouts{
+	{
+		Version: "8.1",
+		Columns: []string{
+			"", "receive_time", "serial", "type", "subtype", "", "time_generated",
+			"vsys", "eventid", "object", "", "", "module", "severity", "opaque", "seqno", "actionflags",
+			"dg_hier_level_1", "dg_hier_level_2", "dg_hier_level_3", "dg_hier_level_4", "vsys_name", "device_name",
+		},
+	},
+}.extend("9.1",
+	"", "", "high_res_timestamp",
+)
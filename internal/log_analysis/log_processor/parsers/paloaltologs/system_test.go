@@ -0,0 +1,52 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestSystem(t *testing.T) {
+	// nolint:lll
+	input := `1,2020/10/15 10:20:30,012801096514,SYSTEM,auth,2049,2020/10/15 10:20:29,,auth-fail,,,,,medium,failed authentication for user 'admin'. Reason: Invalid username/password. From: 192.0.2.10.,2045,0x0,12,0,0,0,,PA-VM-01,,,2020-10-15T10:20:29.000+00:00`
+	// nolint:lll
+	expect := `{
+	  "actionflags": "0x0",
+	  "device_name": "PA-VM-01",
+	  "dg_hier_level_1": "12",
+	  "dg_hier_level_2": "0",
+	  "dg_hier_level_3": "0",
+	  "dg_hier_level_4": "0",
+	  "eventid": "auth-fail",
+	  "high_res_timestamp": "2020-10-15T10:20:29Z",
+	  "opaque": "failed authentication for user 'admin'. Reason: Invalid username/password. From: 192.0.2.10.",
+	  "p_event_time": "2020-10-15T10:20:29Z",
+	  "p_log_type": "PaloAlto.System",
+	  "receive_time": "2020/10/15 10:20:30",
+	  "seqno": 2045,
+	  "serial": "012801096514",
+	  "severity": "medium",
+	  "subtype": "auth",
+	  "time_generated": "2020/10/15 10:20:29",
+	  "type": "SYSTEM"
+	}`
+	testutil.CheckRegisteredParser(t, TypeSystem, input, expect)
+}
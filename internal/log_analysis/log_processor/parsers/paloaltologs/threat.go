@@ -0,0 +1,136 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Threat is a PAN-OS threat log
+// nolint:lll
+type Threat struct {
+	Header
+	Session
+	Miscellaneous       null.String `json:"misc" description:"The URL for URL filtering logs, the file name for file and virus logs or the domain for DNS logs."`
+	ThreatID            null.String `json:"threatid" description:"Palo Alto Networks identifier for the threat and its description."`
+	Category            null.String `json:"category" description:"The URL category for URL filtering logs, the verdict for WildFire logs."`
+	Severity            null.String `json:"severity" description:"Severity associated with the threat (informational, low, medium, high, critical)."`
+	Direction           null.String `json:"direction" description:"Indicates the direction of the attack, client-to-server or server-to-client."`
+	SequenceNumber      null.Int64  `json:"seqno" description:"A 64-bit log entry identifier incremented sequentially; each log type has a unique number space."`
+	ActionFlags         null.String `json:"actionflags" description:"A bit field indicating if the log was forwarded to Panorama."`
+	SourceLocation      null.String `json:"srcloc" description:"Source country or Internal region for private addresses."`
+	DestinationLocation null.String `json:"dstloc" description:"Destination country or Internal region for private addresses."`
+	ContentType         null.String `json:"contenttype" description:"Content type of the HTTP response data (URL filtering logs only)."`
+	PCAPID              null.String `json:"pcap_id" description:"The packet capture ID of the threat."`
+	FileDigest          null.String `json:"filedigest" panther:"sha256" description:"The SHA-256 hash of the file submitted to WildFire."`
+	Cloud               null.String `json:"cloud" description:"The FQDN of the WildFire appliance or cloud the file was uploaded to."`
+	URLIndex            null.Int32  `json:"url_idx" description:"Used with URL filtering and vulnerability logs to correlate them."`
+	UserAgent           null.String `json:"user_agent" description:"The User Agent field of HTTP requests (URL filtering logs only)."`
+	FileType            null.String `json:"filetype" description:"The type of file submitted to WildFire."`
+	XFF                 null.String `json:"xff" description:"The X-Forwarded-For field of HTTP requests (URL filtering logs only)."`
+	Referer             null.String `json:"referer" panther:"url" description:"The Referer field of HTTP requests (URL filtering logs only)."`
+	Sender              null.String `json:"sender" description:"The sender of an email submitted to WildFire."`
+	Subject             null.String `json:"subject" description:"The subject of an email submitted to WildFire."`
+	Recipient           null.String `json:"recipient" description:"The recipient of an email submitted to WildFire."`
+	ReportID            null.String `json:"reportid" description:"The ID of the WildFire report."`
+	DeviceGroup
+	SourceVMUUID         null.String `json:"src_uuid" description:"Identifies the source universal unique identifier for a guest virtual machine in the VMware NSX environment."`
+	DestinationVMUUID    null.String `json:"dst_uuid" description:"Identifies the destination universal unique identifier for a guest virtual machine in the VMware NSX environment."`
+	HTTPMethod           null.String `json:"http_method" description:"The HTTP method of the request (URL filtering logs only)."`
+	TunnelID             null.String `json:"tunnelid" description:"ID of the tunnel being inspected or the International Mobile Subscriber Identity (IMSI) ID of the mobile user."`
+	MonitorTag           null.String `json:"monitortag" description:"Monitor name or International Mobile Equipment Identity (IMEI) ID of the mobile device."`
+	ParentSessionID      null.Int64  `json:"parent_session_id" description:"ID of the session in which this session is tunneled."`
+	ParentStartTime      time.Time   `json:"parent_start_time" tcodec:"layout=2006/01/02 15:04:05" description:"Year/month/day hours:minutes:seconds that the parent tunnel session began."`
+	TunnelType           null.String `json:"tunnel" description:"Type of tunnel, such as either GRE or IPSec."`
+	ThreatCategory       null.String `json:"thr_category" description:"Describes threat categories used to classify different types of threat signatures."`
+	ContentVersion       null.String `json:"contentver" description:"Applications and Threats version on the firewall when the log was generated."`
+	SCTPAssociationID    null.String `json:"assoc_id" description:"Number that identifies all connections for an association between two SCTP endpoints."`
+	PayloadProtocolID    null.String `json:"ppid" description:"Identifies the protocol of the SCTP payload data."`
+	HTTPHeaders          null.String `json:"http_headers" description:"The HTTP headers inserted into the request."`
+	URLCategoryList      null.String `json:"url_category_list" description:"Lists the URL filtering categories the firewall used to enforce policy."`
+	RuleUUID             null.String `json:"rule_uuid" description:"The UUID that permanently identifies the rule."`
+	HTTP2Connection      null.Int64  `json:"http2_connection" description:"Parent session ID for an HTTP/2 connection, 0 if the session is not an HTTP/2 connection."`
+	DynamicUserGroupName null.String `json:"dynusergroup_name" description:"The dynamic user group of the user who initiated the session (added in PAN-OS 9.0)."`
+	DeviceID
+	DomainExternalDynamicList      null.String `json:"domain_edl" description:"The name of the external dynamic list that contains the domain (added in PAN-OS 9.1)."`
+	SourceDynamicAddressGroup      null.String `json:"src_dag" description:"Dynamic address group of the source IP address (added in PAN-OS 9.1)."`
+	DestinationDynamicAddressGroup null.String `json:"dst_dag" description:"Dynamic address group of the destination IP address (added in PAN-OS 9.1)."`
+	PartialHash                    null.String `json:"partial_hash" description:"Machine learning partial hash (added in PAN-OS 9.1)."`
+	HighResolutionTimestamp        time.Time   `json:"high_res_timestamp" tcodec:"rfc3339" description:"Time the log was generated with millisecond precision (added in PAN-OS 9.1)."`
+	Reason                         null.String `json:"reason" description:"Reason for the data filtering action (added in PAN-OS 9.1)."`
+	Justification                  null.String `json:"justification" description:"Justification for the data filtering action (added in PAN-OS 9.1)."`
+	NSSAISST                       null.String `json:"nssai_sst" description:"The Network Slice Selection Assistance Information Slice/Service Type of a 5G session (added in PAN-OS 10.0)."`
+}
+
+var _ pantherlog.ValueWriterTo = (*Threat)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *Threat) WriteValuesTo(w pantherlog.ValueWriter) {
+	scanNAT(w, event.NATSourceAddress.Value, event.NATDestinationAddress.Value)
+	if event.Miscellaneous.Value == "" {
+		return
+	}
+	switch event.Subtype.Value {
+	case "url":
+		// PAN-OS logs URLs without a scheme
+		u := event.Miscellaneous.Value
+		if !strings.Contains(u, "://") {
+			u = "http://" + u
+		}
+		pantherlog.ScanURL(w, u)
+	case "spyware":
+		// DNS sinkhole and anti-spyware DNS signatures log the queried domain
+		if event.Category.Value == "dns" || strings.HasPrefix(event.ThreatCategory.Value, "dns") {
+			pantherlog.ScanHostname(w, event.Miscellaneous.Value)
+		}
+	}
+}
+
+var threatLayouts = Layouts{
+	{
+		Version: "8.1",
+		Columns: []string{
+			"", "receive_time", "serial", "type", "subtype", "", "time_generated",
+			"src", "dst", "natsrc", "natdst", "rule", "srcuser", "dstuser", "app", "vsys", "from", "to",
+			"inbound_if", "outbound_if", "logset", "", "sessionid", "repeatcnt", "sport", "dport", "natsport", "natdport",
+			"flags", "proto", "action",
+			"misc", "threatid", "category", "severity", "direction", "seqno", "actionflags", "srcloc", "dstloc", "",
+			"contenttype", "pcap_id", "filedigest", "cloud", "url_idx", "user_agent", "filetype", "xff", "referer",
+			"sender", "subject", "recipient", "reportid",
+			"dg_hier_level_1", "dg_hier_level_2", "dg_hier_level_3", "dg_hier_level_4", "vsys_name", "device_name",
+			"", "src_uuid", "dst_uuid", "http_method", "tunnelid", "monitortag", "parent_session_id", "parent_start_time",
+			"tunnel", "thr_category", "contentver", "", "assoc_id", "ppid", "http_headers", "url_category_list",
+			"rule_uuid", "http2_connection",
+		},
+	},
+}.extend("9.0",
+	"dynusergroup_name",
+).extend("9.1",
+	"xff_ip",
+	"src_category", "src_profile", "src_model", "src_vendor", "src_osfamily", "src_osversion", "src_host", "src_mac",
+	"dst_category", "dst_profile", "dst_model", "dst_vendor", "dst_osfamily", "dst_osversion", "dst_host", "dst_mac",
+	"container_id", "pod_namespace", "pod_name", "src_edl", "dst_edl", "hostid", "serialnumber",
+	"domain_edl", "src_dag", "dst_dag", "partial_hash", "high_res_timestamp", "reason", "justification",
+).extend("10.0",
+	"nssai_sst",
+)
@@ -0,0 +1,102 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestThreatURL(t *testing.T) {
+	// nolint:lll
+	input := `1,2020/10/15 10:20:30,012801096514,THREAT,url,2049,2020/10/15 10:20:29,10.0.0.12,203.0.113.50,198.51.100.7,0.0.0.0,allow-outbound,corp\jdoe,,web-browsing,vsys1,trust,untrust,ethernet1/2,ethernet1/1,default,,34521,1,51432,80,23011,0,0x40001c,tcp,block-url,malware.example.com/download/payload.exe,(9999),malware,informational,client-to-server,6890120100,0x8000000000000000,10.0.0.0-10.255.255.255,United States,,text/html,0,,,1,Mozilla/5.0,,,http://www.example.org/,,,,,12,0,0,0,,PA-VM-01,,,,get,0,,0,,N/A,unknown,8338-6420,,0,0,,"malware,low-risk",0f4e1c3a-7b2d-4d4e-9a61-2f1cbd5e8a10,0,`
+	// nolint:lll
+	expect := `{
+	  "action": "block-url",
+	  "actionflags": "0x8000000000000000",
+	  "app": "web-browsing",
+	  "assoc_id": "0",
+	  "category": "malware",
+	  "contenttype": "text/html",
+	  "contentver": "8338-6420",
+	  "device_name": "PA-VM-01",
+	  "dg_hier_level_1": "12",
+	  "dg_hier_level_2": "0",
+	  "dg_hier_level_3": "0",
+	  "dg_hier_level_4": "0",
+	  "direction": "client-to-server",
+	  "dport": 80,
+	  "dst": "203.0.113.50",
+	  "dstloc": "United States",
+	  "flags": "0x40001c",
+	  "from": "trust",
+	  "http2_connection": 0,
+	  "http_method": "get",
+	  "inbound_if": "ethernet1/2",
+	  "logset": "default",
+	  "misc": "malware.example.com/download/payload.exe",
+	  "natdport": 0,
+	  "natdst": "0.0.0.0",
+	  "natsport": 23011,
+	  "natsrc": "198.51.100.7",
+	  "outbound_if": "ethernet1/1",
+	  "p_any_domain_names": [
+	    "malware.example.com",
+	    "www.example.org"
+	  ],
+	  "p_any_ip_addresses": [
+	    "10.0.0.12",
+	    "198.51.100.7",
+	    "203.0.113.50"
+	  ],
+	  "p_event_time": "2020-10-15T10:20:29Z",
+	  "p_log_type": "PaloAlto.Threat",
+	  "parent_session_id": 0,
+	  "pcap_id": "0",
+	  "ppid": "0",
+	  "proto": "tcp",
+	  "receive_time": "2020/10/15 10:20:30",
+	  "referer": "http://www.example.org/",
+	  "repeatcnt": 1,
+	  "rule": "allow-outbound",
+	  "rule_uuid": "0f4e1c3a-7b2d-4d4e-9a61-2f1cbd5e8a10",
+	  "seqno": 6890120100,
+	  "serial": "012801096514",
+	  "sessionid": 34521,
+	  "severity": "informational",
+	  "sport": 51432,
+	  "src": "10.0.0.12",
+	  "srcloc": "10.0.0.0-10.255.255.255",
+	  "srcuser": "corp\\jdoe",
+	  "subtype": "url",
+	  "thr_category": "unknown",
+	  "threatid": "(9999)",
+	  "time_generated": "2020/10/15 10:20:29",
+	  "to": "untrust",
+	  "tunnel": "N/A",
+	  "tunnelid": "0",
+	  "type": "THREAT",
+	  "url_category_list": "malware,low-risk",
+	  "url_idx": 1,
+	  "user_agent": "Mozilla/5.0",
+	  "vsys": "vsys1"
+	}`
+	testutil.CheckRegisteredParser(t, TypeThreat, input, expect)
+}
@@ -0,0 +1,112 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Traffic is a PAN-OS traffic log
+// nolint:lll
+type Traffic struct {
+	Header
+	Session
+	Bytes               null.Int64  `json:"bytes" description:"Number of total bytes (transmit and receive) for the session."`
+	BytesSent           null.Int64  `json:"bytes_sent" description:"Number of bytes in the client-to-server direction of the session."`
+	BytesReceived       null.Int64  `json:"bytes_received" description:"Number of bytes in the server-to-client direction of the session."`
+	Packets             null.Int64  `json:"packets" description:"Number of total packets (transmit and receive) for the session."`
+	StartTime           time.Time   `json:"start" tcodec:"layout=2006/01/02 15:04:05" description:"Time of session start."`
+	ElapsedTime         null.Int64  `json:"elapsed" description:"Elapsed time of the session in seconds."`
+	Category            null.String `json:"category" description:"URL category associated with the session (if applicable)."`
+	SequenceNumber      null.Int64  `json:"seqno" description:"A 64-bit log entry identifier incremented sequentially; each log type has a unique number space."`
+	ActionFlags         null.String `json:"actionflags" description:"A bit field indicating if the log was forwarded to Panorama."`
+	SourceLocation      null.String `json:"srcloc" description:"Source country or Internal region for private addresses."`
+	DestinationLocation null.String `json:"dstloc" description:"Destination country or Internal region for private addresses."`
+	PacketsSent         null.Int64  `json:"pkts_sent" description:"Number of client-to-server packets for the session."`
+	PacketsReceived     null.Int64  `json:"pkts_received" description:"Number of server-to-client packets for the session."`
+	SessionEndReason    null.String `json:"session_end_reason" description:"The reason a session terminated."`
+	DeviceGroup
+	ActionSource         null.String `json:"action_source" description:"Specifies whether the action taken to allow or block an application was defined in the application or in policy."`
+	SourceVMUUID         null.String `json:"src_uuid" description:"Identifies the source universal unique identifier for a guest virtual machine in the VMware NSX environment."`
+	DestinationVMUUID    null.String `json:"dst_uuid" description:"Identifies the destination universal unique identifier for a guest virtual machine in the VMware NSX environment."`
+	TunnelID             null.String `json:"tunnelid" description:"ID of the tunnel being inspected or the International Mobile Subscriber Identity (IMSI) ID of the mobile user."`
+	MonitorTag           null.String `json:"monitortag" description:"Monitor name or International Mobile Equipment Identity (IMEI) ID of the mobile device."`
+	ParentSessionID      null.Int64  `json:"parent_session_id" description:"ID of the session in which this session is tunneled."`
+	ParentStartTime      time.Time   `json:"parent_start_time" tcodec:"layout=2006/01/02 15:04:05" description:"Year/month/day hours:minutes:seconds that the parent tunnel session began."`
+	TunnelType           null.String `json:"tunnel" description:"Type of tunnel, such as either GRE or IPSec."`
+	SCTPAssociationID    null.String `json:"assoc_id" description:"Number that identifies all connections for an association between two SCTP endpoints."`
+	SCTPChunks           null.Int64  `json:"chunks" description:"Sum of SCTP chunks sent and received for an association."`
+	SCTPChunksSent       null.Int64  `json:"chunks_sent" description:"Number of SCTP chunks sent for an association."`
+	SCTPChunksReceived   null.Int64  `json:"chunks_received" description:"Number of SCTP chunks received for an association."`
+	RuleUUID             null.String `json:"rule_uuid" description:"The UUID that permanently identifies the rule."`
+	HTTP2Connection      null.Int64  `json:"http2_connection" description:"Parent session ID for an HTTP/2 connection, 0 if the session is not an HTTP/2 connection."`
+	LinkChangeCount      null.Int32  `json:"link_change_count" description:"Number of link changes the session underwent (added in PAN-OS 9.0)."`
+	PolicyID             null.String `json:"policy_id" description:"The ID of the policy-based forwarding policy that controls the session (added in PAN-OS 9.0)."`
+	LinkSwitches         null.String `json:"link_switches" description:"Details of the link switches of an SD-WAN session (added in PAN-OS 9.0)."`
+	SDWANCluster         null.String `json:"sdwan_cluster" description:"Name of the SD-WAN cluster (added in PAN-OS 9.0)."`
+	SDWANDeviceType      null.String `json:"sdwan_device_type" description:"Type of the SD-WAN device, hub or branch (added in PAN-OS 9.0)."`
+	SDWANClusterType     null.String `json:"sdwan_cluster_type" description:"Type of the SD-WAN cluster, mesh or hub-spoke (added in PAN-OS 9.0)."`
+	SDWANSite            null.String `json:"sdwan_site" description:"Name of the SD-WAN site (added in PAN-OS 9.0)."`
+	DynamicUserGroupName null.String `json:"dynusergroup_name" description:"The dynamic user group of the user who initiated the session (added in PAN-OS 9.0)."`
+	DeviceID
+	SourceDynamicAddressGroup      null.String `json:"src_dag" description:"Dynamic address group of the source IP address (added in PAN-OS 9.1)."`
+	DestinationDynamicAddressGroup null.String `json:"dst_dag" description:"Dynamic address group of the destination IP address (added in PAN-OS 9.1)."`
+	SessionOwner                   null.String `json:"session_owner" description:"The HA peer that owns the session (added in PAN-OS 9.1)."`
+	HighResolutionTimestamp        time.Time   `json:"high_res_timestamp" tcodec:"rfc3339" description:"Time the log was generated with millisecond precision (added in PAN-OS 9.1)."`
+	NSSAISST                       null.String `json:"nssai_sst" description:"The Network Slice Selection Assistance Information Slice/Service Type of a 5G session (added in PAN-OS 10.0)."`
+	NSSAISD                        null.String `json:"nssai_sd" description:"The Network Slice Selection Assistance Information Slice Differentiator of a 5G session (added in PAN-OS 10.0)."`
+}
+
+var _ pantherlog.ValueWriterTo = (*Traffic)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *Traffic) WriteValuesTo(w pantherlog.ValueWriter) {
+	scanNAT(w, event.NATSourceAddress.Value, event.NATDestinationAddress.Value)
+}
+
+var trafficLayouts = Layouts{
+	{
+		Version: "8.1",
+		Columns: []string{
+			"", "receive_time", "serial", "type", "subtype", "", "time_generated",
+			"src", "dst", "natsrc", "natdst", "rule", "srcuser", "dstuser", "app", "vsys", "from", "to",
+			"inbound_if", "outbound_if", "logset", "", "sessionid", "repeatcnt", "sport", "dport", "natsport", "natdport",
+			"flags", "proto", "action",
+			"bytes", "bytes_sent", "bytes_received", "packets", "start", "elapsed", "category", "",
+			"seqno", "actionflags", "srcloc", "dstloc", "", "pkts_sent", "pkts_received", "session_end_reason",
+			"dg_hier_level_1", "dg_hier_level_2", "dg_hier_level_3", "dg_hier_level_4", "vsys_name", "device_name",
+			"action_source", "src_uuid", "dst_uuid", "tunnelid", "monitortag", "parent_session_id", "parent_start_time",
+			"tunnel", "assoc_id", "chunks", "chunks_sent", "chunks_received", "rule_uuid", "http2_connection",
+		},
+	},
+}.extend("9.0",
+	"link_change_count", "policy_id", "link_switches",
+	"sdwan_cluster", "sdwan_device_type", "sdwan_cluster_type", "sdwan_site", "dynusergroup_name",
+).extend("9.1",
+	"xff_ip",
+	"src_category", "src_profile", "src_model", "src_vendor", "src_osfamily", "src_osversion", "src_host", "src_mac",
+	"dst_category", "dst_profile", "dst_model", "dst_vendor", "dst_osfamily", "dst_osversion", "dst_host", "dst_mac",
+	"container_id", "pod_namespace", "pod_name", "src_edl", "dst_edl", "hostid", "serialnumber",
+	"src_dag", "dst_dag", "session_owner", "high_res_timestamp",
+).extend("10.0",
+	"nssai_sst", "nssai_sd",
+)
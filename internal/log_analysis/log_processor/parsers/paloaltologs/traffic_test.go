@@ -0,0 +1,176 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestTraffic(t *testing.T) {
+	// nolint:lll
+	input := `<14>Oct 15 10:20:30 PA-VM-01 1,2020/10/15 10:20:30,012801096514,TRAFFIC,end,2049,2020/10/15 10:20:29,10.0.0.12,203.0.113.50,198.51.100.7,0.0.0.0,allow-outbound,corp\jdoe,,ssl,vsys1,trust,untrust,ethernet1/2,ethernet1/1,default,,34521,1,51432,443,23011,0,0x40001c,tcp,allow,5931,1420,4511,22,2020/10/15 10:20:12,16,computer-and-internet-info,,6890120044,0x8000000000000000,10.0.0.0-10.255.255.255,United States,,11,11,tcp-fin,12,0,0,0,,PA-VM-01,from-policy,,,0,,0,,N/A,0,0,0,0,0f4e1c3a-7b2d-4d4e-9a61-2f1cbd5e8a10,0,0,,,,,,,,,,,,,,,jdoe-laptop,00:50:56:a1:2b:3c,,,,,,,,,,,,,,,,,,,2020-10-15T10:20:29.512+00:00`
+	// nolint:lll
+	expect := `{
+	  "action": "allow",
+	  "action_source": "from-policy",
+	  "actionflags": "0x8000000000000000",
+	  "app": "ssl",
+	  "assoc_id": "0",
+	  "bytes": 5931,
+	  "bytes_received": 4511,
+	  "bytes_sent": 1420,
+	  "category": "computer-and-internet-info",
+	  "chunks": 0,
+	  "chunks_received": 0,
+	  "chunks_sent": 0,
+	  "device_name": "PA-VM-01",
+	  "dg_hier_level_1": "12",
+	  "dg_hier_level_2": "0",
+	  "dg_hier_level_3": "0",
+	  "dg_hier_level_4": "0",
+	  "dport": 443,
+	  "dst": "203.0.113.50",
+	  "dstloc": "United States",
+	  "elapsed": 16,
+	  "flags": "0x40001c",
+	  "from": "trust",
+	  "high_res_timestamp": "2020-10-15T10:20:29.512Z",
+	  "http2_connection": 0,
+	  "inbound_if": "ethernet1/2",
+	  "link_change_count": 0,
+	  "logset": "default",
+	  "natdport": 0,
+	  "natdst": "0.0.0.0",
+	  "natsport": 23011,
+	  "natsrc": "198.51.100.7",
+	  "outbound_if": "ethernet1/1",
+	  "p_any_domain_names": [
+	    "jdoe-laptop"
+	  ],
+	  "p_any_ip_addresses": [
+	    "10.0.0.12",
+	    "198.51.100.7",
+	    "203.0.113.50"
+	  ],
+	  "p_event_time": "2020-10-15T10:20:29Z",
+	  "p_log_type": "PaloAlto.Traffic",
+	  "packets": 22,
+	  "parent_session_id": 0,
+	  "pkts_received": 11,
+	  "pkts_sent": 11,
+	  "proto": "tcp",
+	  "receive_time": "2020/10/15 10:20:30",
+	  "repeatcnt": 1,
+	  "rule": "allow-outbound",
+	  "rule_uuid": "0f4e1c3a-7b2d-4d4e-9a61-2f1cbd5e8a10",
+	  "seqno": 6890120044,
+	  "serial": "012801096514",
+	  "session_end_reason": "tcp-fin",
+	  "sessionid": 34521,
+	  "sport": 51432,
+	  "src": "10.0.0.12",
+	  "src_host": "jdoe-laptop",
+	  "src_mac": "00:50:56:a1:2b:3c",
+	  "srcloc": "10.0.0.0-10.255.255.255",
+	  "srcuser": "corp\\jdoe",
+	  "start": "2020/10/15 10:20:12",
+	  "subtype": "end",
+	  "time_generated": "2020/10/15 10:20:29",
+	  "to": "untrust",
+	  "tunnel": "N/A",
+	  "tunnelid": "0",
+	  "type": "TRAFFIC",
+	  "vsys": "vsys1"
+	}`
+	testutil.CheckRegisteredParser(t, TypeTraffic, input, expect)
+}
+
+func TestTrafficPANOS81(t *testing.T) {
+	// nolint:lll
+	input := `1,2020/10/15 10:20:30,012801096514,TRAFFIC,end,2049,2020/10/15 10:20:29,10.0.0.12,203.0.113.50,198.51.100.7,0.0.0.0,allow-outbound,corp\jdoe,,ssl,vsys1,trust,untrust,ethernet1/2,ethernet1/1,default,,34521,1,51432,443,23011,0,0x40001c,tcp,allow,5931,1420,4511,22,2020/10/15 10:20:12,16,computer-and-internet-info,,6890120044,0x8000000000000000,10.0.0.0-10.255.255.255,United States,,11,11,tcp-fin,12,0,0,0,,PA-VM-01,from-policy,,,0,,0,,N/A,0,0,0,0,0f4e1c3a-7b2d-4d4e-9a61-2f1cbd5e8a10,0`
+	// nolint:lll
+	expect := `{
+	  "action": "allow",
+	  "action_source": "from-policy",
+	  "actionflags": "0x8000000000000000",
+	  "app": "ssl",
+	  "assoc_id": "0",
+	  "bytes": 5931,
+	  "bytes_received": 4511,
+	  "bytes_sent": 1420,
+	  "category": "computer-and-internet-info",
+	  "chunks": 0,
+	  "chunks_received": 0,
+	  "chunks_sent": 0,
+	  "device_name": "PA-VM-01",
+	  "dg_hier_level_1": "12",
+	  "dg_hier_level_2": "0",
+	  "dg_hier_level_3": "0",
+	  "dg_hier_level_4": "0",
+	  "dport": 443,
+	  "dst": "203.0.113.50",
+	  "dstloc": "United States",
+	  "elapsed": 16,
+	  "flags": "0x40001c",
+	  "from": "trust",
+	  "http2_connection": 0,
+	  "inbound_if": "ethernet1/2",
+	  "logset": "default",
+	  "natdport": 0,
+	  "natdst": "0.0.0.0",
+	  "natsport": 23011,
+	  "natsrc": "198.51.100.7",
+	  "outbound_if": "ethernet1/1",
+	  "p_any_ip_addresses": [
+	    "10.0.0.12",
+	    "198.51.100.7",
+	    "203.0.113.50"
+	  ],
+	  "p_event_time": "2020-10-15T10:20:29Z",
+	  "p_log_type": "PaloAlto.Traffic",
+	  "packets": 22,
+	  "parent_session_id": 0,
+	  "pkts_received": 11,
+	  "pkts_sent": 11,
+	  "proto": "tcp",
+	  "receive_time": "2020/10/15 10:20:30",
+	  "repeatcnt": 1,
+	  "rule": "allow-outbound",
+	  "rule_uuid": "0f4e1c3a-7b2d-4d4e-9a61-2f1cbd5e8a10",
+	  "seqno": 6890120044,
+	  "serial": "012801096514",
+	  "session_end_reason": "tcp-fin",
+	  "sessionid": 34521,
+	  "sport": 51432,
+	  "src": "10.0.0.12",
+	  "srcloc": "10.0.0.0-10.255.255.255",
+	  "srcuser": "corp\\jdoe",
+	  "start": "2020/10/15 10:20:12",
+	  "subtype": "end",
+	  "time_generated": "2020/10/15 10:20:29",
+	  "to": "untrust",
+	  "tunnel": "N/A",
+	  "tunnelid": "0",
+	  "type": "TRAFFIC",
+	  "vsys": "vsys1"
+	}`
+	testutil.CheckRegisteredParser(t, TypeTraffic, input, expect)
+}
@@ -65,6 +65,9 @@ type PantherLog struct {
 	PantherAnySHA1Hashes   *PantherAnyString `json:"p_any_sha1_hashes,omitempty" description:"Panther added field with collection of SHA1 hashes associated with the row"`
 	PantherAnyMD5Hashes    *PantherAnyString `json:"p_any_md5_hashes,omitempty" description:"Panther added field with collection of MD5 hashes associated with the row"`
 	PantherAnySHA256Hashes *PantherAnyString `json:"p_any_sha256_hashes,omitempty" description:"Panther added field with collection of SHA256 hashes of any algorithm associated with the row"`
+
+	// enrichment, never set on the event, it is written by the result encoder (see pantherlog.Enricher)
+	PantherEnrichment jsoniter.RawMessage `json:"p_enrichment,omitempty" description:"Panther added field with enrichment data matched to the event"`
 }
 
 type PantherAnyString struct { // needed to declare as struct (rather than map) for CF generation
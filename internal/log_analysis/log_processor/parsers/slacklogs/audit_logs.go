@@ -0,0 +1,124 @@
+package slacklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// AuditLog is a Slack audit log entry
+// nolint:lll
+type AuditLog struct {
+	ID         null.String          `json:"id" validate:"required" description:"The ID of the audit log entry."`
+	DateCreate time.Time            `json:"date_create" tcodec:"unix" panther:"event_time" validate:"required" description:"The time the action occurred."`
+	Action     null.String          `json:"action" validate:"required" description:"The action that occurred (ie user_login, file_downloaded, app_installed)."`
+	Actor      *Actor               `json:"actor" description:"The user who performed the action."`
+	Entity     *Entity              `json:"entity" description:"The entity the action was performed on."`
+	Context    *Context             `json:"context" description:"The location and session of the action."`
+	Details    *jsoniter.RawMessage `json:"details" description:"Additional details about the action. The fields depend on the action."`
+}
+
+// Actor is the actor of a Slack audit log entry
+type Actor struct {
+	Type null.String `json:"type" description:"The type of the actor (user)."`
+	User *User       `json:"user" description:"The user who performed the action."`
+}
+
+// User is a Slack user
+// nolint:lll
+type User struct {
+	ID    null.String `json:"id" description:"The ID of the user."`
+	Name  null.String `json:"name" panther:"username" description:"The name of the user."`
+	Email null.String `json:"email" panther:"email" description:"The email address of the user."`
+	Team  null.String `json:"team" description:"The ID of the workspace of the user."`
+}
+
+// Entity is the entity of a Slack audit log entry.
+// Only the field matching the entity type is set.
+// nolint:lll
+type Entity struct {
+	Type       null.String          `json:"type" description:"The type of the entity (ie user, workspace, enterprise, channel, file, app, workflow, usergroup, barrier, message)."`
+	User       *User                `json:"user" description:"The user entity."`
+	Workspace  *Location            `json:"workspace" description:"The workspace entity."`
+	Enterprise *Location            `json:"enterprise" description:"The enterprise entity."`
+	Channel    *Channel             `json:"channel" description:"The channel entity."`
+	File       *File                `json:"file" description:"The file entity."`
+	App        *App                 `json:"app" description:"The app entity."`
+	Workflow   *NamedEntity         `json:"workflow" description:"The workflow entity."`
+	Usergroup  *NamedEntity         `json:"usergroup" description:"The user group entity."`
+	Barrier    *jsoniter.RawMessage `json:"barrier" description:"The information barrier entity."`
+	Message    *jsoniter.RawMessage `json:"message" description:"The message entity."`
+}
+
+// Location is a Slack workspace or enterprise
+type Location struct {
+	Type   null.String `json:"type" description:"The type of the location (workspace, enterprise)."`
+	ID     null.String `json:"id" description:"The ID of the location."`
+	Name   null.String `json:"name" description:"The name of the location."`
+	Domain null.String `json:"domain" description:"The Slack domain of the location."`
+}
+
+// Channel is a Slack channel
+// nolint:lll
+type Channel struct {
+	ID              null.String `json:"id" description:"The ID of the channel."`
+	Name            null.String `json:"name" description:"The name of the channel."`
+	Privacy         null.String `json:"privacy" description:"The privacy of the channel (public, private)."`
+	IsShared        null.Bool   `json:"is_shared" description:"Whether the channel is shared with other organizations."`
+	IsOrgShared     null.Bool   `json:"is_org_shared" description:"Whether the channel is shared between workspaces of the organization."`
+	TeamsSharedWith []string    `json:"teams_shared_with" description:"The IDs of the workspaces the channel is shared with."`
+}
+
+// File is a Slack file
+type File struct {
+	ID       null.String `json:"id" description:"The ID of the file."`
+	Name     null.String `json:"name" description:"The name of the file."`
+	Filetype null.String `json:"filetype" description:"The type of the file."`
+	Title    null.String `json:"title" description:"The title of the file."`
+}
+
+// App is a Slack app
+// nolint:lll
+type App struct {
+	ID                  null.String `json:"id" description:"The ID of the app."`
+	Name                null.String `json:"name" description:"The name of the app."`
+	IsDistributed       null.Bool   `json:"is_distributed" description:"Whether the app is distributed."`
+	IsDirectoryApproved null.Bool   `json:"is_directory_approved" description:"Whether the app is approved in the Slack app directory."`
+	IsWorkflowApp       null.Bool   `json:"is_workflow_app" description:"Whether the app is a workflow app."`
+	Scopes              []string    `json:"scopes" description:"The OAuth scopes granted to the app."`
+}
+
+// NamedEntity is a Slack entity with an ID and a name
+type NamedEntity struct {
+	ID   null.String `json:"id" description:"The ID of the entity."`
+	Name null.String `json:"name" description:"The name of the entity."`
+}
+
+// Context is the context of a Slack audit log entry
+// nolint:lll
+type Context struct {
+	Location  *Location   `json:"location" description:"The workspace or enterprise the action occurred in."`
+	UserAgent null.String `json:"ua" description:"The user agent of the client."`
+	SessionID null.String `json:"session_id" panther:"trace_id" description:"The ID of the session of the actor."`
+	IPAddress null.String `json:"ip_address" panther:"ip" description:"The IP address of the client."`
+}
@@ -0,0 +1,136 @@
+package slacklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestAuditLogUserLogin(t *testing.T) {
+	// nolint:lll
+	input := `{"id":"0123a45b-6c7d-8900-e12f-3456789gh0i1","date_create":1602788180,"action":"user_login","actor":{"type":"user","user":{"id":"W123AB456","name":"Charlie Parker","email":"bird@example.com"}},"entity":{"type":"user","user":{"id":"W123AB456","name":"Charlie Parker","email":"bird@example.com"}},"context":{"location":{"type":"enterprise","id":"E1701NCCA","name":"Birdland","domain":"birdland"},"ua":"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/86.0.4240.80 Safari/537.36","session_id":"847288190092","ip_address":"203.0.113.45"}}`
+	// nolint:lll
+	expect := `{
+	  "id": "0123a45b-6c7d-8900-e12f-3456789gh0i1",
+	  "date_create": 1602788180,
+	  "action": "user_login",
+	  "actor": {
+	    "type": "user",
+	    "user": {
+	      "id": "W123AB456",
+	      "name": "Charlie Parker",
+	      "email": "bird@example.com"
+	    }
+	  },
+	  "entity": {
+	    "type": "user",
+	    "user": {
+	      "id": "W123AB456",
+	      "name": "Charlie Parker",
+	      "email": "bird@example.com"
+	    }
+	  },
+	  "context": {
+	    "location": {
+	      "type": "enterprise",
+	      "id": "E1701NCCA",
+	      "name": "Birdland",
+	      "domain": "birdland"
+	    },
+	    "ua": "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/86.0.4240.80 Safari/537.36",
+	    "session_id": "847288190092",
+	    "ip_address": "203.0.113.45"
+	  },
+	  "p_log_type": "Slack.AuditLogs",
+	  "p_event_time": "2020-10-15T18:56:20Z",
+	  "p_any_ip_addresses": [
+	    "203.0.113.45"
+	  ],
+	  "p_any_trace_ids": [
+	    "847288190092"
+	  ],
+	  "p_any_emails": [
+	    "bird@example.com"
+	  ],
+	  "p_any_usernames": [
+	    "Charlie Parker"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeAuditLogs, input, expect)
+}
+
+func TestAuditLogFileDownloaded(t *testing.T) {
+	// nolint:lll
+	input := `{"id":"4a82e7a1-1b1f-4d3c-9e2b-5a6f7d8c9e0f","date_create":1602788280,"action":"file_downloaded","actor":{"type":"user","user":{"id":"W123AB456","name":"Charlie Parker","email":"bird@example.com","team":"T013B14EP"}},"entity":{"type":"file","file":{"id":"F123JK7X2","name":"salaries.xlsx","filetype":"xlsx","title":"Salaries"}},"context":{"location":{"type":"workspace","id":"T013B14EP","name":"Birdland HQ","domain":"birdland-hq"},"ua":"Slack/4.10.3","session_id":"847288190093","ip_address":"203.0.113.45"},"details":{"type":"app"}}`
+	// nolint:lll
+	expect := `{
+	  "id": "4a82e7a1-1b1f-4d3c-9e2b-5a6f7d8c9e0f",
+	  "date_create": 1602788280,
+	  "action": "file_downloaded",
+	  "actor": {
+	    "type": "user",
+	    "user": {
+	      "id": "W123AB456",
+	      "name": "Charlie Parker",
+	      "email": "bird@example.com",
+	      "team": "T013B14EP"
+	    }
+	  },
+	  "entity": {
+	    "type": "file",
+	    "file": {
+	      "id": "F123JK7X2",
+	      "name": "salaries.xlsx",
+	      "filetype": "xlsx",
+	      "title": "Salaries"
+	    }
+	  },
+	  "context": {
+	    "location": {
+	      "type": "workspace",
+	      "id": "T013B14EP",
+	      "name": "Birdland HQ",
+	      "domain": "birdland-hq"
+	    },
+	    "ua": "Slack/4.10.3",
+	    "session_id": "847288190093",
+	    "ip_address": "203.0.113.45"
+	  },
+	  "details": {
+	    "type": "app"
+	  },
+	  "p_log_type": "Slack.AuditLogs",
+	  "p_event_time": "2020-10-15T18:58:00Z",
+	  "p_any_emails": [
+	    "bird@example.com"
+	  ],
+	  "p_any_usernames": [
+	    "Charlie Parker"
+	  ],
+	  "p_any_ip_addresses": [
+	    "203.0.113.45"
+	  ],
+	  "p_any_trace_ids": [
+	    "847288190093"
+	  ]
+	}`
+	testutil.CheckRegisteredParser(t, TypeAuditLogs, input, expect)
+}
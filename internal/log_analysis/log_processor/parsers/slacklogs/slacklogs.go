@@ -0,0 +1,40 @@
+package slacklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+const (
+	// LogTypePrefix is the prefix of all log types parsed by this package
+	LogTypePrefix = "Slack"
+	// TypeAuditLogs is the log type of Slack Enterprise Grid audit logs
+	TypeAuditLogs = LogTypePrefix + ".AuditLogs"
+)
+
+func init() {
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeAuditLogs,
+		Description:  `Slack Enterprise Grid audit logs as returned by the Audit Logs API (/audit/v1/logs)`,
+		ReferenceURL: `https://api.slack.com/enterprise/audit-logs`,
+	}, func() interface{} {
+		return &AuditLog{}
+	})
+}
@@ -0,0 +1,69 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Alert is an alert event in the EVE JSON output.
+// Suricata adds the application layer details of the flow that triggered the alert when they are available.
+// nolint:lll
+type Alert struct {
+	EventHeader
+	EventType        null.String      `json:"event_type" validate:"required,eq=alert" description:"The event type"`
+	Alert            AlertDetails     `json:"alert" description:"The alert details"`
+	Flow             *FlowDetails     `json:"flow" description:"The flow that triggered the alert"`
+	HTTP             *HTTPDetails     `json:"http" description:"The HTTP transaction that triggered the alert"`
+	TLS              *TLSDetails      `json:"tls" description:"The TLS handshake that triggered the alert"`
+	SSH              *SSHDetails      `json:"ssh" description:"The SSH handshake that triggered the alert"`
+	SMTP             *SMTPDetails     `json:"smtp" description:"The SMTP transaction that triggered the alert"`
+	Email            *EmailDetails    `json:"email" description:"The email message that triggered the alert"`
+	FileInfo         *FileInfoDetails `json:"fileinfo" description:"The file that triggered the alert"`
+	Payload          null.String      `json:"payload" description:"The base64 encoded payload of the packet that triggered the alert"`
+	PayloadPrintable null.String      `json:"payload_printable" description:"The printable payload of the packet that triggered the alert"`
+	Stream           null.Uint8       `json:"stream" description:"Set to 1 if the payload is from a reassembled stream"`
+	Packet           null.String      `json:"packet" description:"The base64 encoded packet that triggered the alert"`
+	PacketInfo       *PacketInfo      `json:"packet_info" description:"Information about the packet that triggered the alert"`
+}
+
+// nolint:lll
+type AlertDetails struct {
+	Action      null.String          `json:"action" description:"The action taken (allowed or blocked)"`
+	GID         null.Uint32          `json:"gid" description:"The generator id of the rule"`
+	SignatureID null.Uint64          `json:"signature_id" validate:"required" description:"The signature id of the rule"`
+	Rev         null.Uint32          `json:"rev" description:"The revision of the rule"`
+	Signature   null.String          `json:"signature" description:"The message of the rule"`
+	Category    null.String          `json:"category" description:"The classification of the rule"`
+	Severity    null.Uint8           `json:"severity" description:"The priority of the rule (1 is the highest)"`
+	Metadata    *jsoniter.RawMessage `json:"metadata" description:"The metadata keywords of the rule"`
+	Source      *AlertEndpoint       `json:"source" description:"The source of the attack as defined by the rule target keyword"`
+	Target      *AlertEndpoint       `json:"target" description:"The target of the attack as defined by the rule target keyword"`
+}
+
+type AlertEndpoint struct {
+	IP   null.String `json:"ip" panther:"ip" description:"The IP address"`
+	Port null.Uint16 `json:"port" description:"The port"`
+}
+
+type PacketInfo struct {
+	Linktype null.Int32 `json:"linktype" description:"The link type of the packet"`
+}
@@ -0,0 +1,78 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestAlert(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-06-05T14:40:01.602367+0000","flow_id":1424873432853562,"in_iface":"eth0","event_type":"alert","src_ip":"192.168.4.76","src_port":46378,"dest_ip":"31.3.245.133","dest_port":80,"proto":"TCP","tx_id":0,"alert":{"action":"allowed","gid":1,"signature_id":2100498,"rev":7,"signature":"GPL ATTACK_RESPONSE id check returned root","category":"Potentially Bad Traffic","severity":2,"metadata":{"created_at":["2010_09_23"],"updated_at":["2019_07_26"]}},"http":{"hostname":"testmyids.com","url":"/","http_user_agent":"curl/7.47.0","http_content_type":"text/html","http_method":"GET","protocol":"HTTP/1.1","status":200,"length":39},"app_proto":"http","flow":{"pkts_toserver":4,"pkts_toclient":3,"bytes_toserver":347,"bytes_toclient":486,"start":"2020-06-05T14:40:01.515398+0000"},"payload_printable":"uid=0(root) gid=0(root) groups=0(root)\n","stream":1}`
+	// nolint:lll
+	expect := `{
+	  "timestamp": "2020-06-05T14:40:01.602367Z",
+	  "flow_id": 1424873432853562,
+	  "in_iface": "eth0",
+	  "event_type": "alert",
+	  "src_ip": "192.168.4.76",
+	  "src_port": 46378,
+	  "dest_ip": "31.3.245.133",
+	  "dest_port": 80,
+	  "proto": "TCP",
+	  "tx_id": 0,
+	  "alert": {
+	    "action": "allowed",
+	    "gid": 1,
+	    "signature_id": 2100498,
+	    "rev": 7,
+	    "signature": "GPL ATTACK_RESPONSE id check returned root",
+	    "category": "Potentially Bad Traffic",
+	    "severity": 2,
+	    "metadata": {"created_at": ["2010_09_23"], "updated_at": ["2019_07_26"]}
+	  },
+	  "http": {
+	    "hostname": "testmyids.com",
+	    "url": "/",
+	    "http_user_agent": "curl/7.47.0",
+	    "http_content_type": "text/html",
+	    "http_method": "GET",
+	    "protocol": "HTTP/1.1",
+	    "status": 200,
+	    "length": 39
+	  },
+	  "app_proto": "http",
+	  "flow": {
+	    "pkts_toserver": 4,
+	    "pkts_toclient": 3,
+	    "bytes_toserver": 347,
+	    "bytes_toclient": 486,
+	    "start": "2020-06-05T14:40:01.515398Z"
+	  },
+	  "payload_printable": "uid=0(root) gid=0(root) groups=0(root)\n",
+	  "stream": 1,
+	  "p_log_type": "Suricata.Alert",
+	  "p_event_time": "2020-06-05T14:40:01.602367Z",
+	  "p_any_ip_addresses": ["192.168.4.76", "31.3.245.133"],
+	  "p_any_domain_names": ["testmyids.com"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeAlert, input, expect)
+}
@@ -0,0 +1,169 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// EventHeader holds the fields common to all event types in the EVE JSON output.
+// Event types that are not tied to a flow (ie stats) only set the timestamp and event type.
+// nolint:lll
+type EventHeader struct {
+	Timestamp    time.Time   `json:"timestamp" tcodec:"layout=2006-01-02T15:04:05.999999999Z0700" panther:"event_time" validate:"required" description:"The time the event was generated"`
+	FlowID       null.Uint64 `json:"flow_id" description:"The id of the flow the event belongs to. Use it to correlate events of the same flow."`
+	ParentID     null.Uint64 `json:"parent_id" description:"The flow id of the parent flow for tunneled traffic"`
+	InIface      null.String `json:"in_iface" description:"The interface the packet was captured on"`
+	SensorName   null.String `json:"host" description:"The sensor name set in the Suricata configuration"`
+	Vlan         []uint16    `json:"vlan" description:"The VLAN ids of the packet"`
+	SrcIP        null.String `json:"src_ip" panther:"ip" description:"The source IP address"`
+	SrcPort      null.Uint16 `json:"src_port" description:"The source port"`
+	DestIP       null.String `json:"dest_ip" panther:"ip" description:"The destination IP address"`
+	DestPort     null.Uint16 `json:"dest_port" description:"The destination port"`
+	Proto        null.String `json:"proto" description:"The transport protocol (ie TCP, UDP)"`
+	IcmpType     null.Uint8  `json:"icmp_type" description:"The ICMP type"`
+	IcmpCode     null.Uint8  `json:"icmp_code" description:"The ICMP code"`
+	AppProto     null.String `json:"app_proto" description:"The application layer protocol detected for the flow"`
+	CommunityID  null.String `json:"community_id" description:"The Community ID hash of the flow"`
+	TxID         null.Uint64 `json:"tx_id" description:"The id of the application layer transaction within the flow"`
+	PcapCnt      null.Uint64 `json:"pcap_cnt" description:"The number of the packet in the capture file"`
+	PcapFilename null.String `json:"pcap_filename" description:"The capture file name when reading from pcap files"`
+}
+
+// FlowDetails holds the flow counters and state
+// nolint:lll
+type FlowDetails struct {
+	PktsToServer  null.Uint64 `json:"pkts_toserver" description:"The number of packets sent to the server"`
+	PktsToClient  null.Uint64 `json:"pkts_toclient" description:"The number of packets sent to the client"`
+	BytesToServer null.Uint64 `json:"bytes_toserver" description:"The number of bytes sent to the server"`
+	BytesToClient null.Uint64 `json:"bytes_toclient" description:"The number of bytes sent to the client"`
+	Start         time.Time   `json:"start" tcodec:"layout=2006-01-02T15:04:05.999999999Z0700" description:"The time of the first packet of the flow"`
+	End           time.Time   `json:"end" tcodec:"layout=2006-01-02T15:04:05.999999999Z0700" description:"The time of the last packet of the flow"`
+	Age           null.Uint64 `json:"age" description:"The duration of the flow in seconds"`
+	State         null.String `json:"state" description:"The state of the flow (ie new, established, closed)"`
+	Reason        null.String `json:"reason" description:"The reason the flow was logged (ie timeout, forced, shutdown)"`
+	Alerted       null.Bool   `json:"alerted" description:"Whether any alerts were raised for the flow"`
+	Bypass        null.String `json:"bypass" description:"The bypass mode of the flow if it was bypassed"`
+}
+
+// HTTPDetails holds the HTTP transaction fields
+// nolint:lll
+type HTTPDetails struct {
+	Hostname        null.String  `json:"hostname" panther:"hostname" description:"The value of the Host header"`
+	Port            null.Uint16  `json:"http_port" description:"The port in the Host header if it is not the default port"`
+	URL             null.String  `json:"url" description:"The request URL"`
+	UserAgent       null.String  `json:"http_user_agent" description:"The value of the User-Agent header"`
+	ContentType     null.String  `json:"http_content_type" description:"The value of the Content-Type header of the response"`
+	Referrer        null.String  `json:"http_refer" panther:"url" description:"The value of the Referer header"`
+	Method          null.String  `json:"http_method" description:"The request method"`
+	Protocol        null.String  `json:"protocol" description:"The protocol version of the request"`
+	Status          null.Uint16  `json:"status" description:"The status code of the response"`
+	Length          null.Uint64  `json:"length" description:"The size of the response body in bytes"`
+	Redirect        null.String  `json:"redirect" panther:"url" description:"The redirect location of the response"`
+	XFF             null.String  `json:"xff" panther:"ip" description:"The value of the X-Forwarded-For header"`
+	RequestHeaders  []HTTPHeader `json:"request_headers" description:"The request headers if header logging is enabled"`
+	ResponseHeaders []HTTPHeader `json:"response_headers" description:"The response headers if header logging is enabled"`
+}
+
+type HTTPHeader struct {
+	Name  null.String `json:"name" description:"The header name"`
+	Value null.String `json:"value" description:"The header value"`
+}
+
+// TLSDetails holds the TLS handshake fields
+// nolint:lll
+type TLSDetails struct {
+	Subject        null.String     `json:"subject" description:"The subject of the server certificate"`
+	IssuerDN       null.String     `json:"issuerdn" description:"The issuer of the server certificate"`
+	Serial         null.String     `json:"serial" description:"The serial number of the server certificate"`
+	Fingerprint    null.String     `json:"fingerprint" description:"The SHA1 fingerprint of the server certificate"`
+	SNI            null.String     `json:"sni" panther:"hostname" description:"The Server Name Indication sent by the client"`
+	Version        null.String     `json:"version" description:"The TLS version"`
+	NotBefore      time.Time       `json:"notbefore" tcodec:"layout=2006-01-02T15:04:05" description:"The start of the validity period of the server certificate"`
+	NotAfter       time.Time       `json:"notafter" tcodec:"layout=2006-01-02T15:04:05" description:"The end of the validity period of the server certificate"`
+	SessionResumed null.Bool       `json:"session_resumed" description:"Whether the TLS session was resumed"`
+	JA3            *JA3Fingerprint `json:"ja3" description:"The JA3 fingerprint of the client hello"`
+	JA3S           *JA3Fingerprint `json:"ja3s" description:"The JA3S fingerprint of the server hello"`
+	Certificate    null.String     `json:"certificate" description:"The base64 encoded server certificate if certificate logging is enabled"`
+	Chain          []string        `json:"chain" description:"The base64 encoded certificate chain if chain logging is enabled"`
+}
+
+type JA3Fingerprint struct {
+	Hash   null.String `json:"hash" description:"The MD5 hash of the JA3 string"`
+	String null.String `json:"string" description:"The JA3 string"`
+}
+
+// SMTPDetails holds the SMTP transaction fields
+// nolint:lll
+type SMTPDetails struct {
+	Helo     null.String `json:"helo" panther:"hostname" description:"The HELO/EHLO domain sent by the client"`
+	MailFrom null.String `json:"mail_from" description:"The MAIL FROM address"`
+	RcptTo   []string    `json:"rcpt_to" description:"The RCPT TO addresses"`
+}
+
+// EmailDetails holds the fields of an email message transferred over SMTP
+// nolint:lll
+type EmailDetails struct {
+	Status     null.String `json:"status" description:"The status of the message parsing"`
+	From       null.String `json:"from" description:"The value of the From header"`
+	To         []string    `json:"to" description:"The values of the To header"`
+	CC         []string    `json:"cc" description:"The values of the Cc header"`
+	Subject    null.String `json:"subject" description:"The value of the Subject header"`
+	MessageID  null.String `json:"message_id" description:"The value of the Message-Id header"`
+	XMailer    null.String `json:"x_mailer" description:"The value of the X-Mailer header"`
+	Attachment []string    `json:"attachment" description:"The names of the attachments"`
+	URL        []string    `json:"url" panther:"url" description:"The URLs found in the message body"`
+}
+
+// SSHDetails holds the SSH handshake fields
+type SSHDetails struct {
+	Client *SSHEndpoint `json:"client" description:"The SSH client"`
+	Server *SSHEndpoint `json:"server" description:"The SSH server"`
+}
+
+// nolint:lll
+type SSHEndpoint struct {
+	ProtoVersion    null.String `json:"proto_version" description:"The SSH protocol version"`
+	SoftwareVersion null.String `json:"software_version" description:"The software version string"`
+	HASSH           *HASSH      `json:"hassh" description:"The HASSH fingerprint of the key exchange"`
+}
+
+type HASSH struct {
+	Hash   null.String `json:"hash" description:"The MD5 hash of the HASSH string"`
+	String null.String `json:"string" description:"The HASSH string"`
+}
+
+// FileInfoDetails holds the fields of a file extracted from a flow
+// nolint:lll
+type FileInfoDetails struct {
+	Filename null.String `json:"filename" description:"The name of the file"`
+	FileID   null.Uint64 `json:"file_id" description:"The id of the stored file"`
+	Magic    null.String `json:"magic" description:"The libmagic description of the file"`
+	Gaps     null.Bool   `json:"gaps" description:"Whether there were gaps in the file data"`
+	State    null.String `json:"state" description:"The state of the file transfer (ie CLOSED, TRUNCATED)"`
+	MD5      null.String `json:"md5" panther:"md5" description:"The MD5 hash of the file"`
+	SHA1     null.String `json:"sha1" panther:"sha1" description:"The SHA1 hash of the file"`
+	SHA256   null.String `json:"sha256" panther:"sha256" description:"The SHA256 hash of the file"`
+	Stored   null.Bool   `json:"stored" description:"Whether the file was stored to disk"`
+	Size     null.Uint64 `json:"size" description:"The size of the file in bytes"`
+	TxID     null.Uint64 `json:"tx_id" description:"The id of the application layer transaction the file was transferred in"`
+	Sid      []uint64    `json:"sid" description:"The signature ids of the rules that matched the file"`
+}
@@ -0,0 +1,74 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestFileInfo(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-06-05T14:40:01.731260+0000","flow_id":1424873432853562,"in_iface":"eth0","event_type":"fileinfo","src_ip":"31.3.245.133","src_port":80,"dest_ip":"192.168.4.76","dest_port":46378,"proto":"TCP","http":{"hostname":"testmyids.com","url":"/","http_user_agent":"curl/7.47.0","http_content_type":"text/html","http_method":"GET","protocol":"HTTP/1.1","status":200,"length":39},"app_proto":"http","fileinfo":{"filename":"/","sid":[2100498],"magic":"ASCII text","gaps":false,"state":"CLOSED","md5":"2a3a4ad9ca1e9ab0b3c7b4c4a4d1e1cb","sha1":"a2bd47a3cfe1c2fa3cc94c1ff6d1c08c0b7f0b07","sha256":"d1d6ba49bf2e9ec8a7e1ce3e6c2b26a5c57a36d4d3c6b2a1f1a0c5c3b1d2e3f4","stored":false,"size":39,"tx_id":0}}`
+	// nolint:lll
+	expect := `{
+	  "timestamp": "2020-06-05T14:40:01.73126Z",
+	  "flow_id": 1424873432853562,
+	  "in_iface": "eth0",
+	  "event_type": "fileinfo",
+	  "src_ip": "31.3.245.133",
+	  "src_port": 80,
+	  "dest_ip": "192.168.4.76",
+	  "dest_port": 46378,
+	  "proto": "TCP",
+	  "http": {
+	    "hostname": "testmyids.com",
+	    "url": "/",
+	    "http_user_agent": "curl/7.47.0",
+	    "http_content_type": "text/html",
+	    "http_method": "GET",
+	    "protocol": "HTTP/1.1",
+	    "status": 200,
+	    "length": 39
+	  },
+	  "app_proto": "http",
+	  "fileinfo": {
+	    "filename": "/",
+	    "sid": [2100498],
+	    "magic": "ASCII text",
+	    "gaps": false,
+	    "state": "CLOSED",
+	    "md5": "2a3a4ad9ca1e9ab0b3c7b4c4a4d1e1cb",
+	    "sha1": "a2bd47a3cfe1c2fa3cc94c1ff6d1c08c0b7f0b07",
+	    "sha256": "d1d6ba49bf2e9ec8a7e1ce3e6c2b26a5c57a36d4d3c6b2a1f1a0c5c3b1d2e3f4",
+	    "stored": false,
+	    "size": 39,
+	    "tx_id": 0
+	  },
+	  "p_log_type": "Suricata.FileInfo",
+	  "p_event_time": "2020-06-05T14:40:01.73126Z",
+	  "p_any_ip_addresses": ["192.168.4.76", "31.3.245.133"],
+	  "p_any_domain_names": ["testmyids.com"],
+	  "p_any_md5_hashes": ["2a3a4ad9ca1e9ab0b3c7b4c4a4d1e1cb"],
+	  "p_any_sha1_hashes": ["a2bd47a3cfe1c2fa3cc94c1ff6d1c08c0b7f0b07"],
+	  "p_any_sha256_hashes": ["d1d6ba49bf2e9ec8a7e1ce3e6c2b26a5c57a36d4d3c6b2a1f1a0c5c3b1d2e3f4"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeFileInfo, input, expect)
+}
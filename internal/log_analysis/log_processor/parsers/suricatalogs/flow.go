@@ -0,0 +1,48 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Flow is a flow event in the EVE JSON output
+// nolint:lll
+type Flow struct {
+	EventHeader
+	EventType null.String `json:"event_type" validate:"required,eq=flow" description:"The event type"`
+	Flow      FlowDetails `json:"flow" description:"The flow counters and state"`
+	TCP       *TCPDetails `json:"tcp" description:"The TCP state of the flow"`
+}
+
+// nolint:lll
+type TCPDetails struct {
+	TCPFlags   null.String `json:"tcp_flags" description:"The TCP flags seen in both directions (hex)"`
+	TCPFlagsTS null.String `json:"tcp_flags_ts" description:"The TCP flags seen to the server (hex)"`
+	TCPFlagsTC null.String `json:"tcp_flags_tc" description:"The TCP flags seen to the client (hex)"`
+	SYN        null.Bool   `json:"syn" description:"Whether the SYN flag was seen"`
+	FIN        null.Bool   `json:"fin" description:"Whether the FIN flag was seen"`
+	RST        null.Bool   `json:"rst" description:"Whether the RST flag was seen"`
+	PSH        null.Bool   `json:"psh" description:"Whether the PSH flag was seen"`
+	ACK        null.Bool   `json:"ack" description:"Whether the ACK flag was seen"`
+	URG        null.Bool   `json:"urg" description:"Whether the URG flag was seen"`
+	ECN        null.Bool   `json:"ecn" description:"Whether the ECN flag was seen"`
+	CWR        null.Bool   `json:"cwr" description:"Whether the CWR flag was seen"`
+	State      null.String `json:"state" description:"The TCP state of the flow"`
+}
@@ -0,0 +1,71 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestFlow(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-06-05T14:41:02.145103+0000","flow_id":1424873432853562,"in_iface":"eth0","event_type":"flow","vlan":[110],"src_ip":"192.168.4.76","src_port":46378,"dest_ip":"31.3.245.133","dest_port":80,"proto":"TCP","app_proto":"http","community_id":"1:Tfl0PjdUy1U4xWQ/Z8tO0RvWqFg=","flow":{"pkts_toserver":6,"pkts_toclient":4,"bytes_toserver":467,"bytes_toclient":606,"start":"2020-06-05T14:40:01.515398+0000","end":"2020-06-05T14:40:01.731260+0000","age":0,"state":"closed","reason":"timeout","alerted":true},"tcp":{"tcp_flags":"1b","tcp_flags_ts":"1b","tcp_flags_tc":"1b","syn":true,"fin":true,"psh":true,"ack":true,"state":"closed"}}`
+	// nolint:lll
+	expect := `{
+	  "timestamp": "2020-06-05T14:41:02.145103Z",
+	  "flow_id": 1424873432853562,
+	  "in_iface": "eth0",
+	  "event_type": "flow",
+	  "vlan": [110],
+	  "src_ip": "192.168.4.76",
+	  "src_port": 46378,
+	  "dest_ip": "31.3.245.133",
+	  "dest_port": 80,
+	  "proto": "TCP",
+	  "app_proto": "http",
+	  "community_id": "1:Tfl0PjdUy1U4xWQ/Z8tO0RvWqFg=",
+	  "flow": {
+	    "pkts_toserver": 6,
+	    "pkts_toclient": 4,
+	    "bytes_toserver": 467,
+	    "bytes_toclient": 606,
+	    "start": "2020-06-05T14:40:01.515398Z",
+	    "end": "2020-06-05T14:40:01.73126Z",
+	    "age": 0,
+	    "state": "closed",
+	    "reason": "timeout",
+	    "alerted": true
+	  },
+	  "tcp": {
+	    "tcp_flags": "1b",
+	    "tcp_flags_ts": "1b",
+	    "tcp_flags_tc": "1b",
+	    "syn": true,
+	    "fin": true,
+	    "psh": true,
+	    "ack": true,
+	    "state": "closed"
+	  },
+	  "p_log_type": "Suricata.Flow",
+	  "p_event_time": "2020-06-05T14:41:02.145103Z",
+	  "p_any_ip_addresses": ["192.168.4.76", "31.3.245.133"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeFlow, input, expect)
+}
@@ -0,0 +1,63 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestHTTP(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-06-05T14:40:01.602367+0000","flow_id":1424873432853562,"in_iface":"eth0","event_type":"http","src_ip":"192.168.4.76","src_port":46378,"dest_ip":"31.3.245.133","dest_port":80,"proto":"TCP","tx_id":0,"http":{"hostname":"testmyids.com","http_port":8080,"url":"/index.html","http_user_agent":"curl/7.47.0","http_content_type":"text/html","http_refer":"http://www.example.com/start","http_method":"GET","protocol":"HTTP/1.1","status":301,"redirect":"https://testmyids.com/index.html","xff":"203.0.113.7","length":178,"request_headers":[{"name":"Accept","value":"*/*"}]}}`
+	// nolint:lll
+	expect := `{
+	  "timestamp": "2020-06-05T14:40:01.602367Z",
+	  "flow_id": 1424873432853562,
+	  "in_iface": "eth0",
+	  "event_type": "http",
+	  "src_ip": "192.168.4.76",
+	  "src_port": 46378,
+	  "dest_ip": "31.3.245.133",
+	  "dest_port": 80,
+	  "proto": "TCP",
+	  "tx_id": 0,
+	  "http": {
+	    "hostname": "testmyids.com",
+	    "http_port": 8080,
+	    "url": "/index.html",
+	    "http_user_agent": "curl/7.47.0",
+	    "http_content_type": "text/html",
+	    "http_refer": "http://www.example.com/start",
+	    "http_method": "GET",
+	    "protocol": "HTTP/1.1",
+	    "status": 301,
+	    "redirect": "https://testmyids.com/index.html",
+	    "xff": "203.0.113.7",
+	    "length": 178,
+	    "request_headers": [{"name": "Accept", "value": "*/*"}]
+	  },
+	  "p_log_type": "Suricata.HTTP",
+	  "p_event_time": "2020-06-05T14:40:01.602367Z",
+	  "p_any_ip_addresses": ["192.168.4.76", "203.0.113.7", "31.3.245.133"],
+	  "p_any_domain_names": ["testmyids.com", "www.example.com"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeHTTP, input, expect)
+}
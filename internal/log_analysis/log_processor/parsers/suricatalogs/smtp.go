@@ -0,0 +1,32 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// SMTP is an SMTP transaction event in the EVE JSON output
+// nolint:lll
+type SMTP struct {
+	EventHeader
+	EventType null.String   `json:"event_type" validate:"required,eq=smtp" description:"The event type"`
+	SMTP      SMTPDetails   `json:"smtp" description:"The SMTP transaction"`
+	Email     *EmailDetails `json:"email" description:"The email message"`
+}
@@ -0,0 +1,61 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestSMTP(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-06-05T14:40:42.114502+0000","flow_id":918273645546372,"in_iface":"eth0","event_type":"smtp","src_ip":"10.0.0.21","src_port":1470,"dest_ip":"192.0.2.25","dest_port":25,"proto":"TCP","tx_id":0,"smtp":{"helo":"mail.example.org","mail_from":"<alice@example.org>","rcpt_to":["<bob@example.com>"]},"email":{"status":"PARSE_DONE","from":"Alice <alice@example.org>","to":["bob@example.com"],"subject":"Quarterly report","attachment":["report.pdf"],"url":["http://phish.example.net/login"]}}`
+	// nolint:lll
+	expect := `{
+	  "timestamp": "2020-06-05T14:40:42.114502Z",
+	  "flow_id": 918273645546372,
+	  "in_iface": "eth0",
+	  "event_type": "smtp",
+	  "src_ip": "10.0.0.21",
+	  "src_port": 1470,
+	  "dest_ip": "192.0.2.25",
+	  "dest_port": 25,
+	  "proto": "TCP",
+	  "tx_id": 0,
+	  "smtp": {
+	    "helo": "mail.example.org",
+	    "mail_from": "<alice@example.org>",
+	    "rcpt_to": ["<bob@example.com>"]
+	  },
+	  "email": {
+	    "status": "PARSE_DONE",
+	    "from": "Alice <alice@example.org>",
+	    "to": ["bob@example.com"],
+	    "subject": "Quarterly report",
+	    "attachment": ["report.pdf"],
+	    "url": ["http://phish.example.net/login"]
+	  },
+	  "p_log_type": "Suricata.SMTP",
+	  "p_event_time": "2020-06-05T14:40:42.114502Z",
+	  "p_any_ip_addresses": ["10.0.0.21", "192.0.2.25"],
+	  "p_any_domain_names": ["mail.example.org", "phish.example.net"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeSMTP, input, expect)
+}
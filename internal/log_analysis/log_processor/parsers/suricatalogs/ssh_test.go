@@ -0,0 +1,54 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestSSH(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-06-05T14:41:41.873221+0000","flow_id":1357924680135792,"in_iface":"eth0","event_type":"ssh","src_ip":"198.51.100.40","src_port":52104,"dest_ip":"10.0.0.5","dest_port":22,"proto":"TCP","ssh":{"client":{"proto_version":"2.0","software_version":"libssh2_1.8.0"},"server":{"proto_version":"2.0","software_version":"OpenSSH_7.4","hassh":{"hash":"b12d2871a1189eff20364cf5333619ee","string":"curve25519-sha256,ecdh-sha2-nistp256"}}}}`
+	// nolint:lll
+	expect := `{
+	  "timestamp": "2020-06-05T14:41:41.873221Z",
+	  "flow_id": 1357924680135792,
+	  "in_iface": "eth0",
+	  "event_type": "ssh",
+	  "src_ip": "198.51.100.40",
+	  "src_port": 52104,
+	  "dest_ip": "10.0.0.5",
+	  "dest_port": 22,
+	  "proto": "TCP",
+	  "ssh": {
+	    "client": {"proto_version": "2.0", "software_version": "libssh2_1.8.0"},
+	    "server": {
+	      "proto_version": "2.0",
+	      "software_version": "OpenSSH_7.4",
+	      "hassh": {"hash": "b12d2871a1189eff20364cf5333619ee", "string": "curve25519-sha256,ecdh-sha2-nistp256"}
+	    }
+	  },
+	  "p_log_type": "Suricata.SSH",
+	  "p_event_time": "2020-06-05T14:41:41.873221Z",
+	  "p_any_ip_addresses": ["10.0.0.5", "198.51.100.40"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeSSH, input, expect)
+}
@@ -0,0 +1,87 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Stats is a periodic engine statistics event in the EVE JSON output.
+// Statistics of engine modules that change often across Suricata versions are kept as JSON objects.
+// nolint:lll
+type Stats struct {
+	EventHeader
+	EventType null.String  `json:"event_type" validate:"required,eq=stats" description:"The event type"`
+	Stats     StatsDetails `json:"stats" description:"The engine statistics"`
+}
+
+// nolint:lll
+type StatsDetails struct {
+	Uptime       null.Uint64          `json:"uptime" description:"The engine uptime in seconds"`
+	Capture      *StatsCapture        `json:"capture" description:"Packet capture statistics"`
+	Decoder      *StatsDecoder        `json:"decoder" description:"Packet decoder statistics"`
+	Detect       *StatsDetect         `json:"detect" description:"Detection engine statistics"`
+	AppLayer     *StatsAppLayer       `json:"app_layer" description:"Application layer statistics"`
+	Flow         *jsoniter.RawMessage `json:"flow" description:"Flow engine statistics"`
+	FlowBypassed *jsoniter.RawMessage `json:"flow_bypassed" description:"Bypassed flow statistics"`
+	FlowMgr      *jsoniter.RawMessage `json:"flow_mgr" description:"Flow manager statistics"`
+	TCP          *jsoniter.RawMessage `json:"tcp" description:"TCP stream engine statistics"`
+	Defrag       *jsoniter.RawMessage `json:"defrag" description:"Defragmentation statistics"`
+	DNS          *jsoniter.RawMessage `json:"dns" description:"DNS parser statistics"`
+	HTTP         *jsoniter.RawMessage `json:"http" description:"HTTP parser statistics"`
+	FTP          *jsoniter.RawMessage `json:"ftp" description:"FTP parser statistics"`
+	FileStore    *jsoniter.RawMessage `json:"file_store" description:"File store statistics"`
+}
+
+// nolint:lll
+type StatsCapture struct {
+	KernelPackets null.Uint64 `json:"kernel_packets" description:"The number of packets received by the kernel"`
+	KernelDrops   null.Uint64 `json:"kernel_drops" description:"The number of packets dropped by the kernel"`
+	KernelIfdrops null.Uint64 `json:"kernel_ifdrops" description:"The number of packets dropped by the interface"`
+	Errors        null.Uint64 `json:"errors" description:"The number of capture errors"`
+}
+
+// nolint:lll
+type StatsDecoder struct {
+	Pkts       null.Uint64 `json:"pkts" description:"The number of packets decoded"`
+	Bytes      null.Uint64 `json:"bytes" description:"The number of bytes decoded"`
+	Invalid    null.Uint64 `json:"invalid" description:"The number of invalid packets"`
+	IPv4       null.Uint64 `json:"ipv4" description:"The number of IPv4 packets"`
+	IPv6       null.Uint64 `json:"ipv6" description:"The number of IPv6 packets"`
+	Ethernet   null.Uint64 `json:"ethernet" description:"The number of ethernet packets"`
+	TCP        null.Uint64 `json:"tcp" description:"The number of TCP packets"`
+	UDP        null.Uint64 `json:"udp" description:"The number of UDP packets"`
+	ICMPv4     null.Uint64 `json:"icmpv4" description:"The number of ICMPv4 packets"`
+	ICMPv6     null.Uint64 `json:"icmpv6" description:"The number of ICMPv6 packets"`
+	VLAN       null.Uint64 `json:"vlan" description:"The number of VLAN packets"`
+	AvgPktSize null.Uint64 `json:"avg_pkt_size" description:"The average packet size"`
+	MaxPktSize null.Uint64 `json:"max_pkt_size" description:"The maximum packet size"`
+}
+
+type StatsDetect struct {
+	Alert null.Uint64 `json:"alert" description:"The number of alerts raised"`
+}
+
+// nolint:lll
+type StatsAppLayer struct {
+	Flow map[string]uint64 `json:"flow" description:"The number of flows per application layer protocol"`
+	Tx   map[string]uint64 `json:"tx" description:"The number of transactions per application layer protocol"`
+}
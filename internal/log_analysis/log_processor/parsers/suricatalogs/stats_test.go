@@ -0,0 +1,64 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestStats(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-06-05T14:42:00.000421+0000","event_type":"stats","stats":{"uptime":3608,"capture":{"kernel_packets":1824561,"kernel_drops":12,"errors":0},"decoder":{"pkts":1824549,"bytes":1359320651,"invalid":3,"ipv4":1821012,"ipv6":3414,"ethernet":1824549,"tcp":1655012,"udp":168342,"icmpv4":1072,"icmpv6":105,"vlan":0,"avg_pkt_size":745,"max_pkt_size":1514},"detect":{"alert":17},"app_layer":{"flow":{"http":1203,"tls":8830,"dns_udp":40211},"tx":{"http":2210,"dns_udp":80422}},"flow":{"memcap":0,"tcp":12033,"udp":40512},"tcp":{"sessions":11983,"reassembly_gap":4}}}`
+	// nolint:lll
+	expect := `{
+	  "timestamp": "2020-06-05T14:42:00.000421Z",
+	  "event_type": "stats",
+	  "stats": {
+	    "uptime": 3608,
+	    "capture": {"kernel_packets": 1824561, "kernel_drops": 12, "errors": 0},
+	    "decoder": {
+	      "pkts": 1824549,
+	      "bytes": 1359320651,
+	      "invalid": 3,
+	      "ipv4": 1821012,
+	      "ipv6": 3414,
+	      "ethernet": 1824549,
+	      "tcp": 1655012,
+	      "udp": 168342,
+	      "icmpv4": 1072,
+	      "icmpv6": 105,
+	      "vlan": 0,
+	      "avg_pkt_size": 745,
+	      "max_pkt_size": 1514
+	    },
+	    "detect": {"alert": 17},
+	    "app_layer": {
+	      "flow": {"http": 1203, "tls": 8830, "dns_udp": 40211},
+	      "tx": {"http": 2210, "dns_udp": 80422}
+	    },
+	    "flow": {"memcap": 0, "tcp": 12033, "udp": 40512},
+	    "tcp": {"sessions": 11983, "reassembly_gap": 4}
+	  },
+	  "p_log_type": "Suricata.Stats",
+	  "p_event_time": "2020-06-05T14:42:00.000421Z"
+	}`
+	testutil.CheckRegisteredParser(t, TypeStats, input, expect)
+}
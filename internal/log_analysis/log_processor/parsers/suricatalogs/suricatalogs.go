@@ -24,8 +24,16 @@ import (
 )
 
 const (
-	TypeDNS     = "Suricata.DNS"
-	TypeAnomaly = "Suricata.Anomaly"
+	TypeDNS      = "Suricata.DNS"
+	TypeAnomaly  = "Suricata.Anomaly"
+	TypeAlert    = "Suricata.Alert"
+	TypeFlow     = "Suricata.Flow"
+	TypeHTTP     = "Suricata.HTTP"
+	TypeTLS      = "Suricata.TLS"
+	TypeFileInfo = "Suricata.FileInfo"
+	TypeSMTP     = "Suricata.SMTP"
+	TypeSSH      = "Suricata.SSH"
+	TypeStats    = "Suricata.Stats"
 )
 
 func init() {
@@ -45,4 +53,60 @@ func init() {
 			NewParser:    parsers.AdapterFactory(&DNSParser{}),
 		},
 	)
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeAlert,
+		Description:  `Suricata parser for the Alert event type in the EVE JSON output.`,
+		ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-alert`,
+	}, func() interface{} {
+		return &Alert{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeFlow,
+		Description:  `Suricata parser for the Flow event type in the EVE JSON output.`,
+		ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-flow`,
+	}, func() interface{} {
+		return &Flow{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeHTTP,
+		Description:  `Suricata parser for the HTTP event type in the EVE JSON output.`,
+		ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-http`,
+	}, func() interface{} {
+		return &HTTP{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeTLS,
+		Description:  `Suricata parser for the TLS event type in the EVE JSON output.`,
+		ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-tls`,
+	}, func() interface{} {
+		return &TLS{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeFileInfo,
+		Description:  `Suricata parser for the FileInfo event type in the EVE JSON output.`,
+		ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-fileinfo`,
+	}, func() interface{} {
+		return &FileInfo{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeSMTP,
+		Description:  `Suricata parser for the SMTP event type in the EVE JSON output.`,
+		ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-smtp`,
+	}, func() interface{} {
+		return &SMTP{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeSSH,
+		Description:  `Suricata parser for the SSH event type in the EVE JSON output.`,
+		ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-ssh`,
+	}, func() interface{} {
+		return &SSH{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeStats,
+		Description:  `Suricata parser for the Stats event type in the EVE JSON output.`,
+		ReferenceURL: `https://suricata.readthedocs.io/en/suricata-5.0.2/output/eve/eve-json-format.html#event-type-stats`,
+	}, func() interface{} {
+		return &Stats{}
+	})
 }
@@ -0,0 +1,39 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+func TestEventTypes(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-06-05T14:40:01.602367+0000","flow_id":1424873432853562,"event_type":"http","src_ip":"192.168.4.76","src_port":46378,"dest_ip":"31.3.245.133","dest_port":80,"proto":"TCP","http":{"hostname":"testmyids.com"}}`
+	for _, logType := range []string{TypeAlert, TypeFlow, TypeTLS, TypeFileInfo, TypeSMTP, TypeSSH, TypeStats} {
+		parser, err := logtypes.DefaultRegistry().MustGet(logType).NewParser(nil)
+		require.NoError(t, err)
+		_, err = parser.ParseLog(input)
+		assert.Error(t, err, "%s parsed an http event", logType)
+	}
+}
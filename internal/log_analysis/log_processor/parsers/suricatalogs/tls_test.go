@@ -0,0 +1,59 @@
+package suricatalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestTLS(t *testing.T) {
+	// nolint:lll
+	input := `{"timestamp":"2020-06-05T14:40:00.120861+0000","flow_id":2081364532105638,"in_iface":"eth0","event_type":"tls","src_ip":"192.168.4.49","src_port":56718,"dest_ip":"13.32.202.10","dest_port":443,"proto":"TCP","tls":{"subject":"CN=www.taosecurity.com","issuerdn":"C=US, O=Amazon, OU=Server CA 1B, CN=Amazon","serial":"0C:8D:9A:0E:4B:5E:2D:1F","fingerprint":"8e:7d:31:2f:66:06:1c:3c:5c:0a:4c:7e:26:b3:71:95:c4:12:7e:e8","sni":"www.taosecurity.com","version":"TLS 1.2","notbefore":"2020-02-01T00:00:00","notafter":"2021-03-01T12:00:00","ja3":{"hash":"72a589da586844d7f0818ce684948eea","string":"771,49195-49199,0-23-65281,29-23-24,0"},"ja3s":{"hash":"d2b4e6f8b1e8a7f9d3c2b1a0e9f8d7c6","string":"771,49199,65281-0"}}}`
+	// nolint:lll
+	expect := `{
+	  "timestamp": "2020-06-05T14:40:00.120861Z",
+	  "flow_id": 2081364532105638,
+	  "in_iface": "eth0",
+	  "event_type": "tls",
+	  "src_ip": "192.168.4.49",
+	  "src_port": 56718,
+	  "dest_ip": "13.32.202.10",
+	  "dest_port": 443,
+	  "proto": "TCP",
+	  "tls": {
+	    "subject": "CN=www.taosecurity.com",
+	    "issuerdn": "C=US, O=Amazon, OU=Server CA 1B, CN=Amazon",
+	    "serial": "0C:8D:9A:0E:4B:5E:2D:1F",
+	    "fingerprint": "8e:7d:31:2f:66:06:1c:3c:5c:0a:4c:7e:26:b3:71:95:c4:12:7e:e8",
+	    "sni": "www.taosecurity.com",
+	    "version": "TLS 1.2",
+	    "notbefore": "2020-02-01T00:00:00",
+	    "notafter": "2021-03-01T12:00:00",
+	    "ja3": {"hash": "72a589da586844d7f0818ce684948eea", "string": "771,49195-49199,0-23-65281,29-23-24,0"},
+	    "ja3s": {"hash": "d2b4e6f8b1e8a7f9d3c2b1a0e9f8d7c6", "string": "771,49199,65281-0"}
+	  },
+	  "p_log_type": "Suricata.TLS",
+	  "p_event_time": "2020-06-05T14:40:00.120861Z",
+	  "p_any_ip_addresses": ["13.32.202.10", "192.168.4.49"],
+	  "p_any_domain_names": ["www.taosecurity.com"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeTLS, input, expect)
+}
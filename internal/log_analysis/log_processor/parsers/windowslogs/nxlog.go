@@ -0,0 +1,100 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// NXLog is a Windows event log record in the JSON format produced by NXLog's `to_json()`.
+// NXLog adds the event specific data as top-level fields. All fields not defined here are collected in EventData.
+// nolint:lll
+type NXLog struct {
+	EventTime         time.Time            `json:"EventTime" tcodec:"layout=2006-01-02 15:04:05" panther:"event_time" validate:"required" description:"The time the event was generated"`
+	EventReceivedTime time.Time            `json:"EventReceivedTime" tcodec:"layout=2006-01-02 15:04:05" description:"The time the event was received by NXLog"`
+	Hostname          null.String          `json:"Hostname" panther:"hostname" validate:"required" description:"The name of the computer that generated the event"`
+	EventID           null.Uint32          `json:"EventID" validate:"required" description:"The event identifier. The value is specific to the source of the event."`
+	SourceName        null.String          `json:"SourceName" validate:"required" description:"The source (provider) of the event"`
+	ProviderGUID      null.String          `json:"ProviderGuid" description:"A globally unique identifier that identifies the provider"`
+	Channel           null.String          `json:"Channel" validate:"required" description:"The name of the channel the event was logged to (ie Security)"`
+	EventType         null.String          `json:"EventType" description:"The type of the event (ie AUDIT_SUCCESS)"`
+	Severity          null.String          `json:"Severity" description:"The normalized severity of the event"`
+	SeverityValue     null.Int32           `json:"SeverityValue" description:"The numeric normalized severity of the event"`
+	Keywords          *jsoniter.RawMessage `json:"Keywords" description:"The keywords used to classify the event"`
+	Version           null.Int32           `json:"Version" description:"The version number of the event definition"`
+	Task              null.Int32           `json:"Task" description:"The task defined in the event"`
+	Category          null.String          `json:"Category" description:"The category of the event"`
+	Opcode            null.String          `json:"Opcode" description:"The opcode defined in the event"`
+	OpcodeValue       null.Int32           `json:"OpcodeValue" description:"The numeric opcode defined in the event"`
+	RecordNumber      null.Uint64          `json:"RecordNumber" description:"The record number of the event"`
+	ActivityID        null.String          `json:"ActivityID" panther:"trace_id" description:"A globally unique identifier that identifies the current activity"`
+	ProcessID         null.Uint32          `json:"ProcessID" description:"The id of the process that generated the event"`
+	ThreadID          null.Uint32          `json:"ThreadID" description:"The id of the thread that generated the event"`
+	Domain            null.String          `json:"Domain" description:"The domain of the user associated with the event"`
+	AccountName       null.String          `json:"AccountName" description:"The name of the user associated with the event"`
+	UserID            null.String          `json:"UserID" description:"The security identifier (SID) of the user associated with the event"`
+	AccountType       null.String          `json:"AccountType" description:"The type of the account associated with the event"`
+	Message           null.String          `json:"Message" description:"The rendered event message"`
+	SourceModuleName  null.String          `json:"SourceModuleName" description:"The name of the NXLog input module instance"`
+	SourceModuleType  null.String          `json:"SourceModuleType" description:"The type of the NXLog input module"`
+	EventData         EventData            `json:"EventData" description:"The event-specific data as name/value pairs"`
+}
+
+// nxlogFields holds the JSON field names defined in NXLog
+var nxlogFields = func() map[string]bool {
+	fields := map[string]bool{}
+	typ := reflect.TypeOf(NXLog{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := typ.Field(i).Tag.Get("json")
+		if pos := strings.IndexByte(name, ','); pos != -1 {
+			name = name[:pos]
+		}
+		fields[name] = true
+	}
+	return fields
+}()
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (event *NXLog) UnmarshalJSON(data []byte) error {
+	// Use a type without methods to avoid recursion
+	type nxlog NXLog
+	if err := jsoniter.Unmarshal(data, (*nxlog)(event)); err != nil {
+		return err
+	}
+	var fields map[string]interface{}
+	if err := eventDataJSON.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for name, value := range fields {
+		if nxlogFields[name] {
+			continue
+		}
+		if event.EventData == nil {
+			event.EventData = EventData{}
+		}
+		event.EventData[name] = eventDataValue(value)
+	}
+	return nil
+}
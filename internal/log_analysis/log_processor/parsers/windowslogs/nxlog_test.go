@@ -0,0 +1,69 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestNXLog(t *testing.T) {
+	// nolint:lll
+	input := `{"EventTime":"2020-06-04 14:21:42","Hostname":"WIN-DC01.corp.example.com","Keywords":-9214364837600034816,"EventType":"AUDIT_SUCCESS","SeverityValue":2,"Severity":"INFO","EventID":4625,"SourceName":"Microsoft-Windows-Security-Auditing","ProviderGuid":"{54849625-5478-4994-A5BA-3E3B0328C30D}","Version":0,"Task":12544,"OpcodeValue":0,"RecordNumber":1120378,"ProcessID":644,"ThreadID":3164,"Channel":"Security","Message":"An account failed to log on.","Category":"Logon","Opcode":"Info","SubjectUserSid":"S-1-0-0","TargetUserName":"administrator","Status":"0xc000006d","LogonType":3,"WorkstationName":"-","IpAddress":"203.0.113.7","IpPort":"0","EventReceivedTime":"2020-06-04 14:21:43","SourceModuleName":"eventlog","SourceModuleType":"im_msvistalog"}`
+	// nolint:lll
+	expect := `{
+	  "EventTime": "2020-06-04 14:21:42",
+	  "Hostname": "WIN-DC01.corp.example.com",
+	  "Keywords": -9214364837600034816,
+	  "EventType": "AUDIT_SUCCESS",
+	  "SeverityValue": 2,
+	  "Severity": "INFO",
+	  "EventID": 4625,
+	  "SourceName": "Microsoft-Windows-Security-Auditing",
+	  "ProviderGuid": "{54849625-5478-4994-A5BA-3E3B0328C30D}",
+	  "Version": 0,
+	  "Task": 12544,
+	  "OpcodeValue": 0,
+	  "RecordNumber": 1120378,
+	  "ProcessID": 644,
+	  "ThreadID": 3164,
+	  "Channel": "Security",
+	  "Message": "An account failed to log on.",
+	  "Category": "Logon",
+	  "Opcode": "Info",
+	  "EventReceivedTime": "2020-06-04 14:21:43",
+	  "SourceModuleName": "eventlog",
+	  "SourceModuleType": "im_msvistalog",
+	  "EventData": {
+	    "SubjectUserSid": "S-1-0-0",
+	    "TargetUserName": "administrator",
+	    "Status": "0xc000006d",
+	    "LogonType": "3",
+	    "WorkstationName": "-",
+	    "IpAddress": "203.0.113.7",
+	    "IpPort": "0"
+	  },
+	  "p_log_type": "Windows.NXLog",
+	  "p_event_time": "2020-06-04T14:21:42Z",
+	  "p_any_ip_addresses": ["203.0.113.7"],
+	  "p_any_domain_names": ["WIN-DC01.corp.example.com"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeNXLog, input, expect)
+}
@@ -0,0 +1,151 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Sysmon event ids with a specific log type
+const (
+	SysmonEventIDProcessCreate        = 1
+	SysmonEventIDNetworkConnect       = 3
+	SysmonEventIDFileCreateStreamHash = 15
+)
+
+// Sysmon is a Sysmon event without a specific log type
+type Sysmon struct {
+	Beat
+	Winlog SysmonInfo `json:"winlog" description:"Windows event log fields"`
+}
+
+// nolint:lll
+type SysmonInfo struct {
+	Winlog
+	Channel   null.String `json:"channel" validate:"required,eq=Microsoft-Windows-Sysmon/Operational" description:"The name of the channel from which this record was read"`
+	EventID   null.Uint32 `json:"event_id" validate:"required,ne=1,ne=3,ne=15" description:"The Sysmon event id"`
+	EventData EventData   `json:"event_data" description:"The event-specific data as name/value pairs"`
+}
+
+// SysmonProcessCreate is a Sysmon process creation event
+type SysmonProcessCreate struct {
+	Beat
+	Winlog SysmonProcessCreateInfo `json:"winlog" description:"Windows event log fields"`
+}
+
+// nolint:lll
+type SysmonProcessCreateInfo struct {
+	Winlog
+	Channel   null.String              `json:"channel" validate:"required,eq=Microsoft-Windows-Sysmon/Operational" description:"The name of the channel from which this record was read"`
+	EventID   null.Uint32              `json:"event_id" validate:"required,eq=1" description:"The Sysmon event id"`
+	EventData SysmonProcessCreateEvent `json:"event_data" description:"Process creation details"`
+}
+
+// nolint:lll
+type SysmonProcessCreateEvent struct {
+	RuleName          null.String `json:"RuleName" description:"The name of the rule that triggered the event"`
+	UtcTime           time.Time   `json:"UtcTime" tcodec:"layout=2006-01-02 15:04:05.000" validate:"required" description:"The time the process was created (UTC)"`
+	ProcessGUID       null.String `json:"ProcessGuid" panther:"trace_id" description:"The unique identifier of the process across a domain"`
+	ProcessID         null.Uint32 `json:"ProcessId" description:"The process id"`
+	Image             null.String `json:"Image" description:"The file path of the process image"`
+	FileVersion       null.String `json:"FileVersion" description:"The file version of the process image"`
+	Description       null.String `json:"Description" description:"The description of the process image"`
+	Product           null.String `json:"Product" description:"The product name of the process image"`
+	Company           null.String `json:"Company" description:"The company name of the process image"`
+	OriginalFileName  null.String `json:"OriginalFileName" description:"The original file name of the process image"`
+	CommandLine       null.String `json:"CommandLine" description:"The command line of the process"`
+	CurrentDirectory  null.String `json:"CurrentDirectory" description:"The working directory of the process"`
+	User              null.String `json:"User" description:"The account that created the process"`
+	LogonGUID         null.String `json:"LogonGuid" description:"The unique identifier of the logon session"`
+	LogonID           null.String `json:"LogonId" description:"The identifier of the logon session"`
+	TerminalSessionID null.Uint32 `json:"TerminalSessionId" description:"The terminal session id"`
+	IntegrityLevel    null.String `json:"IntegrityLevel" description:"The integrity level of the process"`
+	Hashes            null.String `json:"Hashes" panther:"sysmon_hashes" description:"The hashes of the process image"`
+	ParentProcessGUID null.String `json:"ParentProcessGuid" panther:"trace_id" description:"The unique identifier of the parent process across a domain"`
+	ParentProcessID   null.Uint32 `json:"ParentProcessId" description:"The parent process id"`
+	ParentImage       null.String `json:"ParentImage" description:"The file path of the parent process image"`
+	ParentCommandLine null.String `json:"ParentCommandLine" description:"The command line of the parent process"`
+	ParentUser        null.String `json:"ParentUser" description:"The account of the parent process"`
+}
+
+// SysmonNetworkConnect is a Sysmon network connection event
+type SysmonNetworkConnect struct {
+	Beat
+	Winlog SysmonNetworkConnectInfo `json:"winlog" description:"Windows event log fields"`
+}
+
+// nolint:lll
+type SysmonNetworkConnectInfo struct {
+	Winlog
+	Channel   null.String               `json:"channel" validate:"required,eq=Microsoft-Windows-Sysmon/Operational" description:"The name of the channel from which this record was read"`
+	EventID   null.Uint32               `json:"event_id" validate:"required,eq=3" description:"The Sysmon event id"`
+	EventData SysmonNetworkConnectEvent `json:"event_data" description:"Network connection details"`
+}
+
+// nolint:lll
+type SysmonNetworkConnectEvent struct {
+	RuleName            null.String `json:"RuleName" description:"The name of the rule that triggered the event"`
+	UtcTime             time.Time   `json:"UtcTime" tcodec:"layout=2006-01-02 15:04:05.000" validate:"required" description:"The time the connection was detected (UTC)"`
+	ProcessGUID         null.String `json:"ProcessGuid" panther:"trace_id" description:"The unique identifier of the process across a domain"`
+	ProcessID           null.Uint32 `json:"ProcessId" description:"The process id"`
+	Image               null.String `json:"Image" description:"The file path of the process image"`
+	User                null.String `json:"User" description:"The account of the process"`
+	Protocol            null.String `json:"Protocol" description:"The transport protocol of the connection"`
+	Initiated           null.Bool   `json:"Initiated" description:"Whether the process initiated the connection"`
+	SourceIsIpv6        null.Bool   `json:"SourceIsIpv6" description:"Whether the source address is IPv6"`
+	SourceIP            null.String `json:"SourceIp" panther:"ip" description:"The source IP address"`
+	SourceHostname      null.String `json:"SourceHostname" panther:"hostname" description:"The source host name"`
+	SourcePort          null.Uint16 `json:"SourcePort" description:"The source port"`
+	SourcePortName      null.String `json:"SourcePortName" description:"The source port name"`
+	DestinationIsIpv6   null.Bool   `json:"DestinationIsIpv6" description:"Whether the destination address is IPv6"`
+	DestinationIP       null.String `json:"DestinationIp" panther:"ip" description:"The destination IP address"`
+	DestinationHostname null.String `json:"DestinationHostname" panther:"hostname" description:"The destination host name"`
+	DestinationPort     null.Uint16 `json:"DestinationPort" description:"The destination port"`
+	DestinationPortName null.String `json:"DestinationPortName" description:"The destination port name"`
+}
+
+// SysmonFileCreateStreamHash is a Sysmon file stream creation event
+type SysmonFileCreateStreamHash struct {
+	Beat
+	Winlog SysmonFileCreateStreamHashInfo `json:"winlog" description:"Windows event log fields"`
+}
+
+// nolint:lll
+type SysmonFileCreateStreamHashInfo struct {
+	Winlog
+	Channel   null.String                     `json:"channel" validate:"required,eq=Microsoft-Windows-Sysmon/Operational" description:"The name of the channel from which this record was read"`
+	EventID   null.Uint32                     `json:"event_id" validate:"required,eq=15" description:"The Sysmon event id"`
+	EventData SysmonFileCreateStreamHashEvent `json:"event_data" description:"File stream creation details"`
+}
+
+// nolint:lll
+type SysmonFileCreateStreamHashEvent struct {
+	RuleName        null.String `json:"RuleName" description:"The name of the rule that triggered the event"`
+	UtcTime         time.Time   `json:"UtcTime" tcodec:"layout=2006-01-02 15:04:05.000" validate:"required" description:"The time the stream was created (UTC)"`
+	ProcessGUID     null.String `json:"ProcessGuid" panther:"trace_id" description:"The unique identifier of the process across a domain"`
+	ProcessID       null.Uint32 `json:"ProcessId" description:"The process id"`
+	Image           null.String `json:"Image" description:"The file path of the process image that created the stream"`
+	TargetFilename  null.String `json:"TargetFilename" description:"The name of the file the stream was created for"`
+	CreationUtcTime time.Time   `json:"CreationUtcTime" tcodec:"layout=2006-01-02 15:04:05.000" description:"The creation time of the file (UTC)"`
+	Hash            null.String `json:"Hash" panther:"sysmon_hashes" description:"The hashes of the stream contents"`
+	Contents        null.String `json:"Contents" description:"The contents of the stream if it is small enough"`
+	User            null.String `json:"User" description:"The account of the process"`
+}
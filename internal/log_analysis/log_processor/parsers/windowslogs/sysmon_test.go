@@ -0,0 +1,316 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestSysmon(t *testing.T) {
+	type testCase struct {
+		Name    string
+		LogType string
+		Input   string
+		Expect  string
+	}
+	for _, tc := range []testCase{
+		{
+			Name:    "ProcessCreate",
+			LogType: TypeSysmonProcessCreate,
+			Input: `{
+			  "@timestamp": "2020-06-04T14:21:42.620Z",
+			  "host": {"name": "WKS-042"},
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "computer_name": "WKS-042.corp.example.com",
+			    "event_id": 1,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "record_id": 57021,
+			    "event_data": {
+			      "RuleName": "-",
+			      "UtcTime": "2020-06-04 14:21:42.601",
+			      "ProcessGuid": "{a5ce6a59-0396-5ed9-3a01-000000002d00}",
+			      "ProcessId": "4152",
+			      "Image": "C:\\Windows\\System32\\cmd.exe",
+			      "CommandLine": "cmd.exe /c whoami",
+			      "User": "CORP\\jdoe",
+			      "LogonId": "0x3e7",
+			      "TerminalSessionId": "1",
+			      "IntegrityLevel": "High",
+			      "Hashes": "SHA1=99AE9C73E9BEE6F9C76D6F4093A9882DF06832CF,MD5=A6177D080759CF4A03EF837A38F62401,SHA256=79D1FFABDD7841D9043D4DDF1F93721BCD35D823614411FD4EAB5D2C16A86F35,IMPHASH=41E25E6A43C0A21D5B4F6C8F1EF6B7C0",
+			      "ParentProcessGuid": "{a5ce6a59-0395-5ed9-3901-000000002d00}",
+			      "ParentProcessId": "3820",
+			      "ParentImage": "C:\\Windows\\explorer.exe"
+			    }
+			  }
+			}`,
+			Expect: `{
+			  "@timestamp": "2020-06-04T14:21:42.62Z",
+			  "host": {"name": "WKS-042"},
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "computer_name": "WKS-042.corp.example.com",
+			    "event_id": 1,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "record_id": 57021,
+			    "event_data": {
+			      "RuleName": "-",
+			      "UtcTime": "2020-06-04 14:21:42.601",
+			      "ProcessGuid": "{a5ce6a59-0396-5ed9-3a01-000000002d00}",
+			      "ProcessId": 4152,
+			      "Image": "C:\\Windows\\System32\\cmd.exe",
+			      "CommandLine": "cmd.exe /c whoami",
+			      "User": "CORP\\jdoe",
+			      "LogonId": "0x3e7",
+			      "TerminalSessionId": 1,
+			      "IntegrityLevel": "High",
+			      "Hashes": "SHA1=99AE9C73E9BEE6F9C76D6F4093A9882DF06832CF,MD5=A6177D080759CF4A03EF837A38F62401,SHA256=79D1FFABDD7841D9043D4DDF1F93721BCD35D823614411FD4EAB5D2C16A86F35,IMPHASH=41E25E6A43C0A21D5B4F6C8F1EF6B7C0",
+			      "ParentProcessGuid": "{a5ce6a59-0395-5ed9-3901-000000002d00}",
+			      "ParentProcessId": 3820,
+			      "ParentImage": "C:\\Windows\\explorer.exe"
+			    }
+			  },
+			  "p_log_type": "Windows.SysmonProcessCreate",
+			  "p_event_time": "2020-06-04T14:21:42.620Z",
+			  "p_any_domain_names": ["WKS-042", "WKS-042.corp.example.com"],
+			  "p_any_md5_hashes": ["A6177D080759CF4A03EF837A38F62401"],
+			  "p_any_sha1_hashes": ["99AE9C73E9BEE6F9C76D6F4093A9882DF06832CF"],
+			  "p_any_sha256_hashes": ["79D1FFABDD7841D9043D4DDF1F93721BCD35D823614411FD4EAB5D2C16A86F35"],
+			  "p_any_trace_ids": ["{a5ce6a59-0395-5ed9-3901-000000002d00}", "{a5ce6a59-0396-5ed9-3a01-000000002d00}"]
+			}`,
+		},
+		{
+			Name:    "NetworkConnect",
+			LogType: TypeSysmonNetworkConnect,
+			Input: `{
+			  "@timestamp": "2020-06-04T14:25:10.112Z",
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "computer_name": "WKS-042.corp.example.com",
+			    "event_id": 3,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "event_data": {
+			      "UtcTime": "2020-06-04 14:25:08.935",
+			      "ProcessGuid": "{a5ce6a59-0396-5ed9-3a01-000000002d00}",
+			      "ProcessId": "4152",
+			      "Image": "C:\\Windows\\System32\\WindowsPowerShell\\v1.0\\powershell.exe",
+			      "User": "CORP\\jdoe",
+			      "Protocol": "tcp",
+			      "Initiated": "true",
+			      "SourceIsIpv6": "false",
+			      "SourceIp": "10.0.1.15",
+			      "SourceHostname": "WKS-042.corp.example.com",
+			      "SourcePort": "49712",
+			      "DestinationIsIpv6": "false",
+			      "DestinationIp": "93.184.216.34",
+			      "DestinationHostname": "example.com",
+			      "DestinationPort": "443",
+			      "DestinationPortName": "https"
+			    }
+			  }
+			}`,
+			Expect: `{
+			  "@timestamp": "2020-06-04T14:25:10.112Z",
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "computer_name": "WKS-042.corp.example.com",
+			    "event_id": 3,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "event_data": {
+			      "UtcTime": "2020-06-04 14:25:08.935",
+			      "ProcessGuid": "{a5ce6a59-0396-5ed9-3a01-000000002d00}",
+			      "ProcessId": 4152,
+			      "Image": "C:\\Windows\\System32\\WindowsPowerShell\\v1.0\\powershell.exe",
+			      "User": "CORP\\jdoe",
+			      "Protocol": "tcp",
+			      "Initiated": true,
+			      "SourceIsIpv6": false,
+			      "SourceIp": "10.0.1.15",
+			      "SourceHostname": "WKS-042.corp.example.com",
+			      "SourcePort": 49712,
+			      "DestinationIsIpv6": false,
+			      "DestinationIp": "93.184.216.34",
+			      "DestinationHostname": "example.com",
+			      "DestinationPort": 443,
+			      "DestinationPortName": "https"
+			    }
+			  },
+			  "p_log_type": "Windows.SysmonNetworkConnect",
+			  "p_event_time": "2020-06-04T14:25:10.112Z",
+			  "p_any_ip_addresses": ["10.0.1.15", "93.184.216.34"],
+			  "p_any_domain_names": ["WKS-042.corp.example.com", "example.com"],
+			  "p_any_trace_ids": ["{a5ce6a59-0396-5ed9-3a01-000000002d00}"]
+			}`,
+		},
+		{
+			Name:    "FileCreateStreamHash",
+			LogType: TypeSysmonFileCreateStreamHash,
+			Input: `{
+			  "@timestamp": "2020-06-04T14:30:01.000Z",
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "event_id": 15,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "event_data": {
+			      "UtcTime": "2020-06-04 14:30:00.511",
+			      "ProcessGuid": "{a5ce6a59-0401-5ed9-4a01-000000002d00}",
+			      "ProcessId": "5120",
+			      "Image": "C:\\Program Files\\Mozilla Firefox\\firefox.exe",
+			      "TargetFilename": "C:\\Users\\jdoe\\Downloads\\invoice.exe:Zone.Identifier",
+			      "CreationUtcTime": "2020-06-04 14:29:58.104",
+			      "Hash": "MD5=2B6E0A1F5B0C3E33E2B1AF5E0C9D1F44,SHA256=0F343B0931126A20F133D67C2B018A3B5F1B3D7A1C0B1F4E5A6D7C8B9A0E1F22"
+			    }
+			  }
+			}`,
+			Expect: `{
+			  "@timestamp": "2020-06-04T14:30:01Z",
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "event_id": 15,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "event_data": {
+			      "UtcTime": "2020-06-04 14:30:00.511",
+			      "ProcessGuid": "{a5ce6a59-0401-5ed9-4a01-000000002d00}",
+			      "ProcessId": 5120,
+			      "Image": "C:\\Program Files\\Mozilla Firefox\\firefox.exe",
+			      "TargetFilename": "C:\\Users\\jdoe\\Downloads\\invoice.exe:Zone.Identifier",
+			      "CreationUtcTime": "2020-06-04 14:29:58.104",
+			      "Hash": "MD5=2B6E0A1F5B0C3E33E2B1AF5E0C9D1F44,SHA256=0F343B0931126A20F133D67C2B018A3B5F1B3D7A1C0B1F4E5A6D7C8B9A0E1F22"
+			    }
+			  },
+			  "p_log_type": "Windows.SysmonFileCreateStreamHash",
+			  "p_event_time": "2020-06-04T14:30:01Z",
+			  "p_any_md5_hashes": ["2B6E0A1F5B0C3E33E2B1AF5E0C9D1F44"],
+			  "p_any_sha256_hashes": ["0F343B0931126A20F133D67C2B018A3B5F1B3D7A1C0B1F4E5A6D7C8B9A0E1F22"],
+			  "p_any_trace_ids": ["{a5ce6a59-0401-5ed9-4a01-000000002d00}"]
+			}`,
+		},
+		{
+			Name:    "ImageLoad",
+			LogType: TypeSysmon,
+			Input: `{
+			  "@timestamp": "2020-06-04T14:32:00.000Z",
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "event_id": 7,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "event_data": {
+			      "Image": "C:\\Windows\\System32\\svchost.exe",
+			      "ImageLoaded": "C:\\Windows\\System32\\wininet.dll",
+			      "Hashes": "MD5=5D41402ABC4B2A76B9719D911017C592,SHA256=2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824",
+			      "Signed": "true"
+			    }
+			  }
+			}`,
+			Expect: `{
+			  "@timestamp": "2020-06-04T14:32:00Z",
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "event_id": 7,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "event_data": {
+			      "Image": "C:\\Windows\\System32\\svchost.exe",
+			      "ImageLoaded": "C:\\Windows\\System32\\wininet.dll",
+			      "Hashes": "MD5=5D41402ABC4B2A76B9719D911017C592,SHA256=2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824",
+			      "Signed": "true"
+			    }
+			  },
+			  "p_log_type": "Windows.Sysmon",
+			  "p_event_time": "2020-06-04T14:32:00Z",
+			  "p_any_md5_hashes": ["5D41402ABC4B2A76B9719D911017C592"],
+			  "p_any_sha256_hashes": ["2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824"]
+			}`,
+		},
+		{
+			Name:    "DNSQuery",
+			LogType: TypeSysmon,
+			Input: `{
+			  "@timestamp": "2020-06-04T14:31:00.000Z",
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "event_id": 22,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "event_data": {
+			      "QueryName": "example.com",
+			      "QueryStatus": "0",
+			      "Image": "C:\\Windows\\System32\\svchost.exe"
+			    }
+			  }
+			}`,
+			Expect: `{
+			  "@timestamp": "2020-06-04T14:31:00Z",
+			  "winlog": {
+			    "channel": "Microsoft-Windows-Sysmon/Operational",
+			    "event_id": 22,
+			    "provider_name": "Microsoft-Windows-Sysmon",
+			    "event_data": {
+			      "QueryName": "example.com",
+			      "QueryStatus": "0",
+			      "Image": "C:\\Windows\\System32\\svchost.exe"
+			    }
+			  },
+			  "p_log_type": "Windows.Sysmon",
+			  "p_event_time": "2020-06-04T14:31:00Z"
+			}`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			testutil.CheckRegisteredParser(t, tc.LogType, tc.Input, tc.Expect)
+		})
+	}
+}
+
+func TestSysmonEventIDs(t *testing.T) {
+	input := `{
+	  "@timestamp": "2020-06-04T14:21:42.620Z",
+	  "winlog": {
+	    "channel": "Microsoft-Windows-Sysmon/Operational",
+	    "event_id": 3,
+	    "provider_name": "Microsoft-Windows-Sysmon",
+	    "event_data": {"UtcTime": "2020-06-04 14:25:08.935"}
+	  }
+	}`
+	for _, logType := range []string{TypeSysmon, TypeSysmonProcessCreate, TypeSysmonFileCreateStreamHash, TypeWinlogbeat} {
+		parser, err := logtypes.DefaultRegistry().MustGet(logType).NewParser(nil)
+		require.NoError(t, err)
+		_, err = parser.ParseLog(input)
+		assert.Error(t, err, "%s parsed a network connection event", logType)
+	}
+}
+
+func TestScanHashes(t *testing.T) {
+	values := pantherlog.ValueBuffer{}
+	ScanHashes(&values, "SHA1=AAA,md5=BBB, SHA256=CCC,IMPHASH=DDD,invalid")
+	require.Equal(t, []pantherlog.FieldID{
+		pantherlog.FieldMD5Hash,
+		pantherlog.FieldSHA1Hash,
+		pantherlog.FieldSHA256Hash,
+	}, values.Fields())
+	require.Equal(t, []string{"BBB"}, values.Get(pantherlog.FieldMD5Hash))
+	require.Equal(t, []string{"AAA"}, values.Get(pantherlog.FieldSHA1Hash))
+	require.Equal(t, []string{"CCC"}, values.Get(pantherlog.FieldSHA256Hash))
+}
@@ -0,0 +1,257 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const (
+	// LogTypePrefix is the prefix of all log types parsed by this package
+	LogTypePrefix = "Windows"
+	// TypeWinlogbeat is the log type of Windows event log records shipped by Winlogbeat
+	TypeWinlogbeat = LogTypePrefix + ".Winlogbeat"
+	// TypeNXLog is the log type of Windows event log records shipped by NXLog
+	TypeNXLog = LogTypePrefix + ".NXLog"
+	// TypeSysmon is the log type of Sysmon events without a specific log type
+	TypeSysmon = LogTypePrefix + ".Sysmon"
+	// TypeSysmonProcessCreate is the log type of Sysmon process creation events
+	TypeSysmonProcessCreate = LogTypePrefix + ".SysmonProcessCreate"
+	// TypeSysmonNetworkConnect is the log type of Sysmon network connection events
+	TypeSysmonNetworkConnect = LogTypePrefix + ".SysmonNetworkConnect"
+	// TypeSysmonFileCreateStreamHash is the log type of Sysmon file stream hash events
+	TypeSysmonFileCreateStreamHash = LogTypePrefix + ".SysmonFileCreateStreamHash"
+
+	// ChannelSysmon is the event log channel Sysmon writes its events to
+	ChannelSysmon = "Microsoft-Windows-Sysmon/Operational"
+
+	sysmonReferenceURL = `https://docs.microsoft.com/en-us/sysinternals/downloads/sysmon#events`
+)
+
+func init() {
+	// The scanner needs to be registered before building the schemas of the log types using it
+	pantherlog.MustRegisterScannerFunc("sysmon_hashes", ScanHashes,
+		pantherlog.FieldMD5Hash,
+		pantherlog.FieldSHA1Hash,
+		pantherlog.FieldSHA256Hash,
+	)
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name: TypeWinlogbeat,
+		Description: `Windows event log records shipped as JSON by Elastic Winlogbeat.
+NOTE: Sysmon events are parsed by the Windows.Sysmon* log types`,
+		ReferenceURL: `https://www.elastic.co/guide/en/beats/winlogbeat/current/exported-fields-winlog.html`,
+	}, func() interface{} {
+		return &Winlogbeat{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name: TypeNXLog,
+		Description: `Windows event log records shipped as JSON by NXLog (im_msvistalog).
+NOTE: Event specific fields are collected in the EventData field. Timestamps without a time zone are assumed to be UTC.`,
+		ReferenceURL: `https://nxlog.co/documentation/nxlog-user-guide/im_msvistalog.html#im_msvistalog_fields`,
+	}, func() interface{} {
+		return &NXLog{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name: TypeSysmon,
+		Description: `Sysmon events shipped as JSON by Elastic Winlogbeat.
+NOTE: Process creation, network connection and file stream hash events are parsed by their own log types`,
+		ReferenceURL: sysmonReferenceURL,
+	}, func() interface{} {
+		return &Sysmon{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeSysmonProcessCreate,
+		Description:  `Sysmon process creation events (event id 1) shipped as JSON by Elastic Winlogbeat`,
+		ReferenceURL: sysmonReferenceURL,
+	}, func() interface{} {
+		return &SysmonProcessCreate{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeSysmonNetworkConnect,
+		Description:  `Sysmon network connection events (event id 3) shipped as JSON by Elastic Winlogbeat`,
+		ReferenceURL: sysmonReferenceURL,
+	}, func() interface{} {
+		return &SysmonNetworkConnect{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeSysmonFileCreateStreamHash,
+		Description:  `Sysmon file stream creation events with file hashes (event id 15) shipped as JSON by Elastic Winlogbeat`,
+		ReferenceURL: sysmonReferenceURL,
+	}, func() interface{} {
+		return &SysmonFileCreateStreamHash{}
+	})
+}
+
+// ScanHashes scans a Sysmon hashes value (ie `SHA1=...,MD5=...,SHA256=...,IMPHASH=...`) for hash indicators.
+func ScanHashes(w pantherlog.ValueWriter, input string) {
+	for _, pair := range strings.Split(input, ",") {
+		pos := strings.IndexByte(pair, '=')
+		if pos == -1 {
+			continue
+		}
+		hash := strings.TrimSpace(pair[pos+1:])
+		switch algorithm := strings.TrimSpace(pair[:pos]); strings.ToUpper(algorithm) {
+		case "MD5":
+			w.WriteValues(pantherlog.FieldMD5Hash, hash)
+		case "SHA1":
+			w.WriteValues(pantherlog.FieldSHA1Hash, hash)
+		case "SHA256":
+			w.WriteValues(pantherlog.FieldSHA256Hash, hash)
+		}
+	}
+}
+
+// EventData holds the name/value pairs of the EventData or UserData section of a Windows event.
+// Shippers render this section in different shapes. All of them are flattened to a map of strings:
+//
+//	{"TargetUserName": "admin", "LogonType": 3}
+//	[{"Name": "TargetUserName", "Value": "admin"}, {"Name": "LogonType", "Value": "3"}]
+//	{"Data": [{"@Name": "TargetUserName", "#text": "admin"}, {"@Name": "LogonType", "#text": "3"}]}
+type EventData map[string]string
+
+var _ pantherlog.ValueWriterTo = (EventData)(nil)
+
+var (
+	// Event data fields holding an IP address
+	eventDataIPFields = map[string]bool{
+		"IpAddress":          true,
+		"ClientAddress":      true,
+		"SourceAddress":      true,
+		"DestAddress":        true,
+		"SourceIp":           true,
+		"DestinationIp":      true,
+		"CallerNetworkAddrs": true,
+	}
+	// Event data fields holding a host name
+	eventDataHostnameFields = map[string]bool{
+		"WorkstationName":     true,
+		"Workstation":         true,
+		"ClientName":          true,
+		"SourceHostname":      true,
+		"DestinationHostname": true,
+		"TargetServerName":    true,
+	}
+	// Event data fields holding hashes as `ALGORITHM=hash` pairs (ie Sysmon image and driver loads, file stream hashes)
+	eventDataHashesFields = map[string]bool{
+		"Hashes": true,
+		"Hash":   true,
+	}
+)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (d EventData) WriteValuesTo(w pantherlog.ValueWriter) {
+	for name, value := range d {
+		// Windows uses '-' for fields that do not apply to an event
+		if value == "" || value == "-" {
+			continue
+		}
+		switch {
+		case eventDataIPFields[name]:
+			pantherlog.ScanIPAddress(w, value)
+		case eventDataHostnameFields[name]:
+			pantherlog.ScanHostname(w, value)
+		case eventDataHashesFields[name]:
+			ScanHashes(w, value)
+		}
+	}
+}
+
+// Use json.Number to avoid losing precision on large numeric values
+var eventDataJSON = jsoniter.Config{UseNumber: true}.Froze()
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (d *EventData) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := eventDataJSON.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v == nil {
+		*d = nil
+		return nil
+	}
+	m := EventData{}
+	m.flatten(v)
+	*d = m
+	return nil
+}
+
+func (d EventData) flatten(v interface{}) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, el := range v {
+			d.flatten(el)
+		}
+	case map[string]interface{}:
+		if name, value, ok := eventDataPair(v); ok {
+			d[name] = value
+			return
+		}
+		// XML rendered as JSON wraps the pairs in a `Data` element
+		if data, ok := v["Data"]; ok && len(v) == 1 {
+			d.flatten(data)
+			return
+		}
+		for name, value := range v {
+			d[name] = eventDataValue(value)
+		}
+	}
+}
+
+// eventDataPair checks if an object is a single name/value pair
+func eventDataPair(obj map[string]interface{}) (name, value string, ok bool) {
+	if len(obj) > 2 {
+		return "", "", false
+	}
+	for key, v := range obj {
+		switch key {
+		case "Name", "@Name":
+			if name, ok = v.(string); !ok {
+				return "", "", false
+			}
+		case "Value", "#text":
+			value = eventDataValue(v)
+		default:
+			return "", "", false
+		}
+	}
+	return name, value, ok
+}
+
+func eventDataValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		s, _ := jsoniter.MarshalToString(v)
+		return s
+	}
+}
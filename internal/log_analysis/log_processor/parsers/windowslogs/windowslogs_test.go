@@ -0,0 +1,45 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDataUnmarshal(t *testing.T) {
+	expect := EventData{
+		"TargetUserName": "admin",
+		"LogonType":      "3",
+	}
+	for _, input := range []string{
+		`{"TargetUserName": "admin", "LogonType": 3}`,
+		`[{"Name": "TargetUserName", "Value": "admin"}, {"Name": "LogonType", "Value": "3"}]`,
+		`{"Data": [{"@Name": "TargetUserName", "#text": "admin"}, {"@Name": "LogonType", "#text": "3"}]}`,
+	} {
+		var actual EventData
+		require.NoError(t, jsoniter.UnmarshalFromString(input, &actual), input)
+		require.Equal(t, expect, actual, input)
+	}
+	var actual EventData
+	require.NoError(t, jsoniter.UnmarshalFromString(`null`, &actual))
+	require.Nil(t, actual)
+}
@@ -0,0 +1,138 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Winlogbeat is a Windows event log record shipped by Winlogbeat
+type Winlogbeat struct {
+	Beat
+	Winlog WinlogbeatInfo `json:"winlog" description:"Windows event log fields"`
+}
+
+// WinlogbeatInfo is the `winlog` section of a Winlogbeat record for events outside the Sysmon channel
+// nolint:lll
+type WinlogbeatInfo struct {
+	Winlog
+	Channel   null.String `json:"channel" validate:"required,ne=Microsoft-Windows-Sysmon/Operational" description:"The name of the channel from which this record was read (ie Security)"`
+	EventID   null.Uint32 `json:"event_id" validate:"required" description:"The event identifier. The value is specific to the source of the event."`
+	EventData EventData   `json:"event_data" description:"The event-specific data as name/value pairs"`
+	UserData  EventData   `json:"user_data" description:"The event specific data for events that use the UserData section"`
+}
+
+// Beat holds the common ECS fields added by Elastic Beats to all records
+// nolint:lll
+type Beat struct {
+	Timestamp time.Time   `json:"@timestamp" tcodec:"rfc3339" panther:"event_time" validate:"required" description:"The time the event was generated"`
+	Message   null.String `json:"message" description:"The rendered event message"`
+	Tags      []string    `json:"tags" description:"Tags added by the shipper"`
+	Event     *ECSEvent   `json:"event" description:"ECS event categorization fields"`
+	Log       *ECSLog     `json:"log" description:"ECS log fields"`
+	Host      *ECSHost    `json:"host" description:"The host that generated the event"`
+	Agent     *BeatAgent  `json:"agent" description:"The Beat agent that shipped the event"`
+	Source    *ECSAddress `json:"source" description:"The source of a network event as mapped by the Winlogbeat security module"`
+	User      *ECSUser    `json:"user" description:"The user associated with the event as mapped by the Winlogbeat modules"`
+}
+
+// nolint:lll
+type ECSEvent struct {
+	Action   null.String `json:"action" description:"The action captured by the event"`
+	Category []string    `json:"category" description:"The ECS categories of the event"`
+	Type     []string    `json:"type" description:"The ECS types of the event"`
+	Kind     null.String `json:"kind" description:"The ECS kind of the event"`
+	Module   null.String `json:"module" description:"The name of the module that processed the event"`
+	Outcome  null.String `json:"outcome" description:"The outcome of the event (success, failure, unknown)"`
+	Provider null.String `json:"provider" description:"The source of the event"`
+	Created  time.Time   `json:"created" tcodec:"rfc3339" description:"The time the event was read by the agent"`
+}
+
+type ECSLog struct {
+	Level null.String `json:"level" description:"The log level of the event"`
+}
+
+// nolint:lll
+type ECSHost struct {
+	Name         null.String `json:"name" panther:"hostname" description:"The name of the host"`
+	Hostname     null.String `json:"hostname" panther:"hostname" description:"The hostname of the host"`
+	ID           null.String `json:"id" description:"The unique host id"`
+	Architecture null.String `json:"architecture" description:"The operating system architecture"`
+	IP           []string    `json:"ip" description:"The IP addresses of the host"`
+	MAC          []string    `json:"mac" description:"The MAC addresses of the host"`
+}
+
+// nolint:lll
+type BeatAgent struct {
+	Type        null.String `json:"type" description:"The type of the agent"`
+	Version     null.String `json:"version" description:"The version of the agent"`
+	Name        null.String `json:"name" description:"The custom name of the agent"`
+	Hostname    null.String `json:"hostname" description:"The hostname of the agent"`
+	ID          null.String `json:"id" description:"The unique identifier of the agent"`
+	EphemeralID null.String `json:"ephemeral_id" description:"The ephemeral identifier of the agent"`
+}
+
+// nolint:lll
+type ECSAddress struct {
+	IP     null.String `json:"ip" panther:"ip" description:"The IP address"`
+	Port   null.Uint16 `json:"port" description:"The port"`
+	Domain null.String `json:"domain" panther:"hostname" description:"The domain name or hostname"`
+}
+
+type ECSUser struct {
+	ID     null.String `json:"id" description:"The unique identifier of the user (SID)"`
+	Name   null.String `json:"name" description:"The name of the user"`
+	Domain null.String `json:"domain" description:"The domain of the user"`
+}
+
+// Winlog holds the `winlog` fields common to all Winlogbeat records
+// nolint:lll
+type Winlog struct {
+	API               null.String    `json:"api" description:"The event log API used to read the record"`
+	ActivityID        null.String    `json:"activity_id" panther:"trace_id" description:"A globally unique identifier that identifies the current activity"`
+	RelatedActivityID null.String    `json:"related_activity_id" panther:"trace_id" description:"A globally unique identifier that identifies the activity to which control was transferred to"`
+	ComputerName      null.String    `json:"computer_name" panther:"hostname" description:"The name of the computer that generated the record"`
+	ProviderName      null.String    `json:"provider_name" validate:"required" description:"The source of the event log record"`
+	ProviderGUID      null.String    `json:"provider_guid" description:"A globally unique identifier that identifies the provider"`
+	RecordID          null.Uint64    `json:"record_id" description:"The record ID of the event log record"`
+	Task              null.String    `json:"task" description:"The task defined in the event"`
+	Opcode            null.String    `json:"opcode" description:"The opcode defined in the event"`
+	Keywords          []string       `json:"keywords" description:"The keywords used to classify the event"`
+	Version           null.Int32     `json:"version" description:"The version number of the event definition"`
+	Process           *WinlogProcess `json:"process" description:"The process that generated the event"`
+	User              *WinlogUser    `json:"user" description:"The user associated with the event"`
+}
+
+type WinlogProcess struct {
+	PID    null.Uint32   `json:"pid" description:"The process id"`
+	Thread *WinlogThread `json:"thread" description:"The thread that generated the event"`
+}
+
+type WinlogThread struct {
+	ID null.Uint32 `json:"id" description:"The thread id"`
+}
+
+type WinlogUser struct {
+	Identifier null.String `json:"identifier" description:"The Windows security identifier (SID) of the account"`
+	Name       null.String `json:"name" description:"The name of the account"`
+	Domain     null.String `json:"domain" description:"The domain of the account"`
+	Type       null.String `json:"type" description:"The type of the account"`
+}
@@ -0,0 +1,120 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestWinlogbeat(t *testing.T) {
+	input := `{
+	  "@timestamp": "2020-06-04T14:21:42.591Z",
+	  "agent": {"type": "winlogbeat", "version": "7.7.0", "hostname": "WIN-DC01", "id": "8a2f38c0-b4a5-4c47-9f08-b0c5f0c2e8a1"},
+	  "event": {"action": "logged-in", "category": ["authentication"], "code": 4624, "kind": "event", "module": "security", "outcome": "success", "provider": "Microsoft-Windows-Security-Auditing", "type": ["start"], "created": "2020-06-04T14:21:43.003Z"},
+	  "host": {"name": "WIN-DC01.corp.example.com"},
+	  "log": {"level": "information"},
+	  "message": "An account was successfully logged on.",
+	  "source": {"ip": "10.0.1.15", "port": 49667, "domain": "WKS-042"},
+	  "winlog": {
+	    "api": "wineventlog",
+	    "channel": "Security",
+	    "computer_name": "WIN-DC01.corp.example.com",
+	    "event_id": 4624,
+	    "provider_name": "Microsoft-Windows-Security-Auditing",
+	    "provider_guid": "{54849625-5478-4994-a5ba-3e3b0328c30d}",
+	    "record_id": 1120377,
+	    "task": "Logon",
+	    "opcode": "Info",
+	    "keywords": ["Audit Success"],
+	    "version": 2,
+	    "activity_id": "{7b7c5a1e-3a6e-0000-e25a-7c7b6e3ad601}",
+	    "process": {"pid": 644, "thread": {"id": 3164}},
+	    "event_data": {
+	      "SubjectUserSid": "S-1-0-0",
+	      "TargetUserName": "jdoe",
+	      "TargetDomainName": "CORP",
+	      "LogonType": "3",
+	      "WorkstationName": "WKS-042",
+	      "IpAddress": "10.0.1.15",
+	      "IpPort": "49667",
+	      "LogonProcessName": "NtLmSsp "
+	    }
+	  }
+	}`
+	expect := `{
+	  "@timestamp": "2020-06-04T14:21:42.591Z",
+	  "agent": {"type": "winlogbeat", "version": "7.7.0", "hostname": "WIN-DC01", "id": "8a2f38c0-b4a5-4c47-9f08-b0c5f0c2e8a1"},
+	  "event": {"action": "logged-in", "category": ["authentication"], "kind": "event", "module": "security", "outcome": "success", "provider": "Microsoft-Windows-Security-Auditing", "type": ["start"], "created": "2020-06-04T14:21:43.003Z"},
+	  "host": {"name": "WIN-DC01.corp.example.com"},
+	  "log": {"level": "information"},
+	  "message": "An account was successfully logged on.",
+	  "source": {"ip": "10.0.1.15", "port": 49667, "domain": "WKS-042"},
+	  "winlog": {
+	    "api": "wineventlog",
+	    "channel": "Security",
+	    "computer_name": "WIN-DC01.corp.example.com",
+	    "event_id": 4624,
+	    "provider_name": "Microsoft-Windows-Security-Auditing",
+	    "provider_guid": "{54849625-5478-4994-a5ba-3e3b0328c30d}",
+	    "record_id": 1120377,
+	    "task": "Logon",
+	    "opcode": "Info",
+	    "keywords": ["Audit Success"],
+	    "version": 2,
+	    "activity_id": "{7b7c5a1e-3a6e-0000-e25a-7c7b6e3ad601}",
+	    "process": {"pid": 644, "thread": {"id": 3164}},
+	    "event_data": {
+	      "SubjectUserSid": "S-1-0-0",
+	      "TargetUserName": "jdoe",
+	      "TargetDomainName": "CORP",
+	      "LogonType": "3",
+	      "WorkstationName": "WKS-042",
+	      "IpAddress": "10.0.1.15",
+	      "IpPort": "49667",
+	      "LogonProcessName": "NtLmSsp "
+	    }
+	  },
+	  "p_log_type": "Windows.Winlogbeat",
+	  "p_event_time": "2020-06-04T14:21:42.591Z",
+	  "p_any_ip_addresses": ["10.0.1.15"],
+	  "p_any_domain_names": ["WIN-DC01.corp.example.com", "WKS-042"],
+	  "p_any_trace_ids": ["{7b7c5a1e-3a6e-0000-e25a-7c7b6e3ad601}"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeWinlogbeat, input, expect)
+}
+
+func TestWinlogbeatSysmonChannel(t *testing.T) {
+	input := `{
+	  "@timestamp": "2020-06-04T14:21:42.591Z",
+	  "winlog": {
+	    "channel": "Microsoft-Windows-Sysmon/Operational",
+	    "event_id": 22,
+	    "provider_name": "Microsoft-Windows-Sysmon"
+	  }
+	}`
+	parser, err := logtypes.DefaultRegistry().MustGet(TypeWinlogbeat).NewParser(nil)
+	require.NoError(t, err)
+	_, err = parser.ParseLog(input)
+	require.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// nolint:lll
+type ZeekConn struct {
+	TS  time.Time   `json:"ts" tcodec:"unix" panther:"event_time" validate:"required" description:"This is the time of the first packet."`
+	UID null.String `json:"uid" panther:"trace_id" validate:"required" description:"A unique identifier of the connection."`
+	ConnID
+	Proto         null.String  `json:"proto" validate:"required" description:"The transport layer protocol of the connection."`
+	Service       null.String  `json:"service" description:"An identification of an application protocol being sent over the connection."`
+	Duration      null.Float64 `json:"duration" description:"How long the connection lasted in seconds."`
+	OrigBytes     null.Uint64  `json:"orig_bytes" description:"The number of payload bytes the originator sent."`
+	RespBytes     null.Uint64  `json:"resp_bytes" description:"The number of payload bytes the responder sent."`
+	ConnState     null.String  `json:"conn_state" validate:"required" description:"The state of the connection (ie S0, SF, REJ)."`
+	LocalOrig     null.Bool    `json:"local_orig" description:"If the connection is originated locally, this value will be true."`
+	LocalResp     null.Bool    `json:"local_resp" description:"If the connection is responded to locally, this value will be true."`
+	MissedBytes   null.Uint64  `json:"missed_bytes" description:"Indicates the number of bytes missed in content gaps, which is representative of packet loss."`
+	History       null.String  `json:"history" description:"Records the state history of connections as a string of letters."`
+	OrigPkts      null.Uint64  `json:"orig_pkts" description:"Number of packets that the originator sent."`
+	OrigIPBytes   null.Uint64  `json:"orig_ip_bytes" description:"Number of IP level bytes that the originator sent."`
+	RespPkts      null.Uint64  `json:"resp_pkts" description:"Number of packets that the responder sent."`
+	RespIPBytes   null.Uint64  `json:"resp_ip_bytes" description:"Number of IP level bytes that the responder sent."`
+	TunnelParents []string     `json:"tunnel_parents" panther:"trace_id" description:"If this connection was over a tunnel, indicate the uid values for any encapsulating parent connections."`
+	OrigL2Addr    null.String  `json:"orig_l2_addr" description:"Link-layer address of the originator, if available."`
+	RespL2Addr    null.String  `json:"resp_l2_addr" description:"Link-layer address of the responder, if available."`
+	VLAN          null.Int32   `json:"vlan" description:"The outer VLAN for this connection, if applicable."`
+	InnerVLAN     null.Int32   `json:"inner_vlan" description:"The inner VLAN for this connection, if applicable."`
+	CommunityID   null.String  `json:"community_id" description:"The Community ID hash of the connection."`
+}
@@ -0,0 +1,60 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestZeekConn(t *testing.T) {
+	// nolint:lll
+	input := `{"ts":1591367999.305988,"uid":"CMdzit1AMNsmfAIiQc","id.orig_h":"192.168.4.76","id.orig_p":36844,"id.resp_h":"192.168.4.1","id.resp_p":53,"proto":"udp","service":"dns","duration":0.06685185432434082,"orig_bytes":62,"resp_bytes":141,"conn_state":"SF","local_orig":true,"local_resp":true,"missed_bytes":0,"history":"Dd","orig_pkts":2,"orig_ip_bytes":118,"resp_pkts":2,"resp_ip_bytes":197,"tunnel_parents":["CZ0kqu2qTqDz2Zk2pa"],"community_id":"1:Tfl0PjdUy1U4xWQ/Z8tO0RvWqFg="}`
+	// nolint:lll
+	expect := `{
+	  "ts": 1591367999.305988,
+	  "uid": "CMdzit1AMNsmfAIiQc",
+	  "id.orig_h": "192.168.4.76",
+	  "id.orig_p": 36844,
+	  "id.resp_h": "192.168.4.1",
+	  "id.resp_p": 53,
+	  "proto": "udp",
+	  "service": "dns",
+	  "duration": 0.06685185432434082,
+	  "orig_bytes": 62,
+	  "resp_bytes": 141,
+	  "conn_state": "SF",
+	  "local_orig": true,
+	  "local_resp": true,
+	  "missed_bytes": 0,
+	  "history": "Dd",
+	  "orig_pkts": 2,
+	  "orig_ip_bytes": 118,
+	  "resp_pkts": 2,
+	  "resp_ip_bytes": 197,
+	  "tunnel_parents": ["CZ0kqu2qTqDz2Zk2pa"],
+	  "community_id": "1:Tfl0PjdUy1U4xWQ/Z8tO0RvWqFg=",
+	  "p_log_type": "Zeek.Conn",
+	  "p_event_time": "2020-06-05T14:39:59.305988Z",
+	  "p_any_ip_addresses": ["192.168.4.1", "192.168.4.76"],
+	  "p_any_trace_ids": ["CMdzit1AMNsmfAIiQc", "CZ0kqu2qTqDz2Zk2pa"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeZeekConn, input, expect)
+}
@@ -0,0 +1,59 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestZeekFiles(t *testing.T) {
+	// nolint:lll
+	input := `{"ts":1591367999.58031,"fuid":"FEEsZS1w0Z0VJIb5x4","tx_hosts":["31.3.245.133"],"rx_hosts":["192.168.4.76"],"conn_uids":["C5bLoe2Mvxqhawzqqd"],"source":"HTTP","depth":0,"analyzers":["MD5","SHA1"],"mime_type":"text/plain","duration":0.0,"local_orig":false,"is_orig":false,"seen_bytes":39,"total_bytes":39,"missing_bytes":0,"overflow_bytes":0,"timedout":false,"md5":"2a3a4ad9ca1e9ab0b3c7b4c4a4d1e1cb","sha1":"a2bd47a3cfe1c2fa3cc94c1ff6d1c08c0b7f0b07"}`
+	// nolint:lll
+	expect := `{
+	  "ts": 1591367999.58031,
+	  "fuid": "FEEsZS1w0Z0VJIb5x4",
+	  "tx_hosts": ["31.3.245.133"],
+	  "rx_hosts": ["192.168.4.76"],
+	  "conn_uids": ["C5bLoe2Mvxqhawzqqd"],
+	  "source": "HTTP",
+	  "depth": 0,
+	  "analyzers": ["MD5", "SHA1"],
+	  "mime_type": "text/plain",
+	  "duration": 0,
+	  "local_orig": false,
+	  "is_orig": false,
+	  "seen_bytes": 39,
+	  "total_bytes": 39,
+	  "missing_bytes": 0,
+	  "overflow_bytes": 0,
+	  "timedout": false,
+	  "md5": "2a3a4ad9ca1e9ab0b3c7b4c4a4d1e1cb",
+	  "sha1": "a2bd47a3cfe1c2fa3cc94c1ff6d1c08c0b7f0b07",
+	  "p_log_type": "Zeek.Files",
+	  "p_event_time": "2020-06-05T14:39:59.58031Z",
+	  "p_any_ip_addresses": ["192.168.4.76", "31.3.245.133"],
+	  "p_any_trace_ids": ["C5bLoe2Mvxqhawzqqd", "FEEsZS1w0Z0VJIb5x4"],
+	  "p_any_md5_hashes": ["2a3a4ad9ca1e9ab0b3c7b4c4a4d1e1cb"],
+	  "p_any_sha1_hashes": ["a2bd47a3cfe1c2fa3cc94c1ff6d1c08c0b7f0b07"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeZeekFiles, input, expect)
+}
@@ -0,0 +1,56 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// nolint:lll
+type ZeekHTTP struct {
+	TS  time.Time   `json:"ts" tcodec:"unix" panther:"event_time" validate:"required" description:"Timestamp for when the request happened."`
+	UID null.String `json:"uid" panther:"trace_id" validate:"required" description:"Unique ID for the connection."`
+	ConnID
+	TransDepth      null.Uint32 `json:"trans_depth" validate:"required" description:"Represents the pipelined depth into the connection of this request/response transaction."`
+	Method          null.String `json:"method" validate:"required" description:"Verb used in the HTTP request (GET, POST, HEAD, etc.)."`
+	Host            null.String `json:"host" panther:"net_addr" description:"Value of the HOST header."`
+	URI             null.String `json:"uri" description:"URI used in the request."`
+	Referrer        null.String `json:"referrer" panther:"url" description:"Value of the “referer” header."`
+	Version         null.String `json:"version" description:"Value of the version portion of the request."`
+	UserAgent       null.String `json:"user_agent" description:"Value of the User-Agent header from the client."`
+	Origin          null.String `json:"origin" panther:"url" description:"Value of the Origin header from the client."`
+	RequestBodyLen  null.Uint64 `json:"request_body_len" description:"Actual uncompressed content size of the data transferred from the client."`
+	ResponseBodyLen null.Uint64 `json:"response_body_len" description:"Actual uncompressed content size of the data transferred from the server."`
+	StatusCode      null.Uint16 `json:"status_code" description:"Status code returned by the server."`
+	StatusMsg       null.String `json:"status_msg" description:"Status message returned by the server."`
+	InfoCode        null.Uint16 `json:"info_code" description:"Last seen 1xx informational reply code returned by the server."`
+	InfoMsg         null.String `json:"info_msg" description:"Last seen 1xx informational reply message returned by the server."`
+	Tags            []string    `json:"tags" description:"A set of indicators of various attributes discovered and related to a particular request/response pair."`
+	Username        null.String `json:"username" description:"Username if basic-auth is performed for the request."`
+	Password        null.String `json:"password" description:"Password if basic-auth is performed for the request."`
+	Proxied         []string    `json:"proxied" description:"All of the headers that may indicate if the request was proxied."`
+	OrigFUIDs       []string    `json:"orig_fuids" panther:"trace_id" description:"An ordered vector of file unique IDs from the originator."`
+	OrigFilenames   []string    `json:"orig_filenames" description:"An ordered vector of filenames from the originator."`
+	OrigMIMETypes   []string    `json:"orig_mime_types" description:"An ordered vector of mime types from the originator."`
+	RespFUIDs       []string    `json:"resp_fuids" panther:"trace_id" description:"An ordered vector of file unique IDs from the responder."`
+	RespFilenames   []string    `json:"resp_filenames" description:"An ordered vector of filenames from the responder."`
+	RespMIMETypes   []string    `json:"resp_mime_types" description:"An ordered vector of mime types from the responder."`
+}
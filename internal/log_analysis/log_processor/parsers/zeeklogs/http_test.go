@@ -0,0 +1,57 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestZeekHTTP(t *testing.T) {
+	// nolint:lll
+	input := `{"ts":1591367999.512593,"uid":"C5bLoe2Mvxqhawzqqd","id.orig_h":"192.168.4.76","id.orig_p":46378,"id.resp_h":"31.3.245.133","id.resp_p":80,"trans_depth":1,"method":"GET","host":"testmyids.com","uri":"/","version":"1.1","user_agent":"curl/7.47.0","request_body_len":0,"response_body_len":39,"status_code":200,"status_msg":"OK","tags":[],"resp_fuids":["FEEsZS1w0Z0VJIb5x4"],"resp_mime_types":["text/plain"]}`
+	// nolint:lll
+	expect := `{
+	  "ts": 1591367999.512593,
+	  "uid": "C5bLoe2Mvxqhawzqqd",
+	  "id.orig_h": "192.168.4.76",
+	  "id.orig_p": 46378,
+	  "id.resp_h": "31.3.245.133",
+	  "id.resp_p": 80,
+	  "trans_depth": 1,
+	  "method": "GET",
+	  "host": "testmyids.com",
+	  "uri": "/",
+	  "version": "1.1",
+	  "user_agent": "curl/7.47.0",
+	  "request_body_len": 0,
+	  "response_body_len": 39,
+	  "status_code": 200,
+	  "status_msg": "OK",
+	  "resp_fuids": ["FEEsZS1w0Z0VJIb5x4"],
+	  "resp_mime_types": ["text/plain"],
+	  "p_log_type": "Zeek.HTTP",
+	  "p_event_time": "2020-06-05T14:39:59.512593Z",
+	  "p_any_ip_addresses": ["192.168.4.76", "31.3.245.133"],
+	  "p_any_domain_names": ["testmyids.com"],
+	  "p_any_trace_ids": ["C5bLoe2Mvxqhawzqqd", "FEEsZS1w0Z0VJIb5x4"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeZeekHTTP, input, expect)
+}
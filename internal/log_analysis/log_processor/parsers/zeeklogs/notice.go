@@ -0,0 +1,56 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// nolint:lll
+type ZeekNotice struct {
+	TS                        time.Time    `json:"ts" tcodec:"unix" panther:"event_time" validate:"required" description:"An absolute time indicating when the notice occurred."`
+	UID                       null.String  `json:"uid" panther:"trace_id" description:"A connection UID which uniquely identifies the endpoints concerned with the notice."`
+	OrigH                     null.String  `json:"id.orig_h" panther:"ip" description:"The originator’s IP address of the connection concerned with the notice."`
+	OrigP                     null.Uint16  `json:"id.orig_p" description:"The originator’s port number of the connection concerned with the notice."`
+	RespH                     null.String  `json:"id.resp_h" panther:"ip" description:"The responder’s IP address of the connection concerned with the notice."`
+	RespP                     null.Uint16  `json:"id.resp_p" description:"The responder’s port number of the connection concerned with the notice."`
+	FUID                      null.String  `json:"fuid" panther:"trace_id" description:"A file unique ID if this notice is related to a file."`
+	FileMIMEType              null.String  `json:"file_mime_type" description:"A mime type if the notice is related to a file."`
+	FileDesc                  null.String  `json:"file_desc" description:"Frequently files can be described to give a bit more context."`
+	Proto                     null.String  `json:"proto" description:"The transport protocol."`
+	Note                      null.String  `json:"note" validate:"required" description:"The type of the notice."`
+	Msg                       null.String  `json:"msg" description:"The human readable message for the notice."`
+	Sub                       null.String  `json:"sub" description:"The human readable sub-message."`
+	Src                       null.String  `json:"src" panther:"ip" description:"Source address, if we don’t have a connection."`
+	Dst                       null.String  `json:"dst" panther:"ip" description:"Destination address."`
+	P                         null.Uint16  `json:"p" description:"Associated port, if we don’t have a connection."`
+	N                         null.Uint64  `json:"n" description:"Associated count, or perhaps a status code."`
+	PeerDescr                 null.String  `json:"peer_descr" description:"Textual description for the peer that raised this notice, including name, host address and port."`
+	Actions                   []string     `json:"actions" description:"The actions which have been applied to this notice."`
+	EmailDest                 []string     `json:"email_dest" description:"The email address(es) where to send this notice."`
+	SuppressFor               null.Float64 `json:"suppress_for" description:"This field indicates the length of time in seconds that this unique notice should be suppressed."`
+	RemoteLocationCountryCode null.String  `json:"remote_location.country_code" description:"The country code of the remote host."`
+	RemoteLocationRegion      null.String  `json:"remote_location.region" description:"The region of the remote host."`
+	RemoteLocationCity        null.String  `json:"remote_location.city" description:"The city of the remote host."`
+	RemoteLocationLatitude    null.Float64 `json:"remote_location.latitude" description:"The latitude of the remote host."`
+	RemoteLocationLongitude   null.Float64 `json:"remote_location.longitude" description:"The longitude of the remote host."`
+	Dropped                   null.Bool    `json:"dropped" description:"Indicate if the source IP address was dropped and denied network access."`
+}
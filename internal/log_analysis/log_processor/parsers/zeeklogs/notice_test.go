@@ -0,0 +1,54 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestZeekNotice(t *testing.T) {
+	// nolint:lll
+	input := `{"ts":1591368010.402349,"uid":"CHhAvVGS1DHFjwGM9","id.orig_h":"10.0.0.12","id.orig_p":50236,"id.resp_h":"203.0.113.22","id.resp_p":443,"proto":"tcp","note":"SSL::Invalid_Server_Cert","msg":"SSL certificate validation failed with (unable to get local issuer certificate)","sub":"CN=evil.example.com","src":"10.0.0.12","dst":"203.0.113.22","p":443,"peer_descr":"worker-1-1","actions":["Notice::ACTION_LOG"],"suppress_for":86400.0}`
+	// nolint:lll
+	expect := `{
+	  "ts": 1591368010.402349,
+	  "uid": "CHhAvVGS1DHFjwGM9",
+	  "id.orig_h": "10.0.0.12",
+	  "id.orig_p": 50236,
+	  "id.resp_h": "203.0.113.22",
+	  "id.resp_p": 443,
+	  "proto": "tcp",
+	  "note": "SSL::Invalid_Server_Cert",
+	  "msg": "SSL certificate validation failed with (unable to get local issuer certificate)",
+	  "sub": "CN=evil.example.com",
+	  "src": "10.0.0.12",
+	  "dst": "203.0.113.22",
+	  "p": 443,
+	  "peer_descr": "worker-1-1",
+	  "actions": ["Notice::ACTION_LOG"],
+	  "suppress_for": 86400,
+	  "p_log_type": "Zeek.Notice",
+	  "p_event_time": "2020-06-05T14:40:10.402349Z",
+	  "p_any_ip_addresses": ["10.0.0.12", "203.0.113.22"],
+	  "p_any_trace_ids": ["CHhAvVGS1DHFjwGM9"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeZeekNotice, input, expect)
+}
@@ -0,0 +1,55 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// ZeekSMTP is a Zeek smtp.log record.
+// Records without headers have no path, `trans_depth` and `tls` are always logged.
+// nolint:lll
+type ZeekSMTP struct {
+	TS  time.Time   `json:"ts" tcodec:"unix" panther:"event_time" validate:"required" description:"Time when the message was first seen."`
+	UID null.String `json:"uid" panther:"trace_id" validate:"required" description:"Unique ID for the connection."`
+	ConnID
+	TransDepth     null.Uint32 `json:"trans_depth" validate:"required" description:"A count to represent the depth of this message transaction in a single connection where multiple messages were transferred."`
+	Helo           null.String `json:"helo" panther:"hostname" description:"Contents of the Helo header."`
+	MailFrom       null.String `json:"mailfrom" description:"Email addresses found in the From header."`
+	RcptTo         []string    `json:"rcptto" description:"Email addresses found in the Rcpt header."`
+	Date           null.String `json:"date" description:"Contents of the Date header."`
+	From           null.String `json:"from" description:"Contents of the From header."`
+	To             []string    `json:"to" description:"Contents of the To header."`
+	CC             []string    `json:"cc" description:"Contents of the CC header."`
+	ReplyTo        null.String `json:"reply_to" description:"Contents of the ReplyTo header."`
+	MsgID          null.String `json:"msg_id" description:"Contents of the MsgID header."`
+	InReplyTo      null.String `json:"in_reply_to" description:"Contents of the In-Reply-To header."`
+	Subject        null.String `json:"subject" description:"Contents of the Subject header."`
+	XOriginatingIP null.String `json:"x_originating_ip" panther:"ip" description:"Contents of the X-Originating-IP header."`
+	FirstReceived  null.String `json:"first_received" description:"Contents of the first Received header."`
+	SecondReceived null.String `json:"second_received" description:"Contents of the second Received header."`
+	LastReply      null.String `json:"last_reply" description:"The last message that the server sent to the client."`
+	Path           []string    `json:"path" panther:"ip" description:"The message transmission path, as extracted from the headers."`
+	UserAgent      null.String `json:"user_agent" description:"Value of the User-Agent header from the client."`
+	TLS            null.Bool   `json:"tls" validate:"required" description:"Indicates that the connection has switched to using TLS."`
+	FUIDs          []string    `json:"fuids" panther:"trace_id" description:"An ordered vector of file unique IDs seen attached to the message."`
+	IsWebmail      null.Bool   `json:"is_webmail" description:"Boolean indicator of if the message was sent through a webmail interface."`
+}
@@ -0,0 +1,61 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestZeekSMTP(t *testing.T) {
+	// nolint:lll
+	input := `{"ts":1591368042.114502,"uid":"CmES5u32sYpV7JYN","id.orig_h":"10.0.0.21","id.orig_p":1470,"id.resp_h":"192.0.2.25","id.resp_p":25,"trans_depth":1,"helo":"mail.example.org","mailfrom":"<alice@example.org>","rcptto":["<bob@example.com>"],"date":"Fri, 5 Jun 2020 14:40:41 +0000","from":"Alice <alice@example.org>","to":["Bob <bob@example.com>"],"msg_id":"<20200605144041.1234@example.org>","subject":"Quarterly report","x_originating_ip":"198.51.100.7","last_reply":"250 2.0.0 Ok: queued","path":["192.0.2.25","10.0.0.21"],"user_agent":"Thunderbird","tls":false,"fuids":["Fel9gs4OtNEV6gUJZ5"],"is_webmail":false}`
+	// nolint:lll
+	expect := `{
+	  "ts": 1591368042.114502,
+	  "uid": "CmES5u32sYpV7JYN",
+	  "id.orig_h": "10.0.0.21",
+	  "id.orig_p": 1470,
+	  "id.resp_h": "192.0.2.25",
+	  "id.resp_p": 25,
+	  "trans_depth": 1,
+	  "helo": "mail.example.org",
+	  "mailfrom": "<alice@example.org>",
+	  "rcptto": ["<bob@example.com>"],
+	  "date": "Fri, 5 Jun 2020 14:40:41 +0000",
+	  "from": "Alice <alice@example.org>",
+	  "to": ["Bob <bob@example.com>"],
+	  "msg_id": "<20200605144041.1234@example.org>",
+	  "subject": "Quarterly report",
+	  "x_originating_ip": "198.51.100.7",
+	  "last_reply": "250 2.0.0 Ok: queued",
+	  "path": ["192.0.2.25", "10.0.0.21"],
+	  "user_agent": "Thunderbird",
+	  "tls": false,
+	  "fuids": ["Fel9gs4OtNEV6gUJZ5"],
+	  "is_webmail": false,
+	  "p_log_type": "Zeek.SMTP",
+	  "p_event_time": "2020-06-05T14:40:42.114502Z",
+	  "p_any_ip_addresses": ["10.0.0.21", "192.0.2.25", "198.51.100.7"],
+	  "p_any_domain_names": ["mail.example.org"],
+	  "p_any_trace_ids": ["CmES5u32sYpV7JYN", "Fel9gs4OtNEV6gUJZ5"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeZeekSMTP, input, expect)
+}
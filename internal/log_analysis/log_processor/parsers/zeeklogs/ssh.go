@@ -0,0 +1,53 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// ZeekSSH is a Zeek ssh.log record.
+// Most fields are optional in Zeek, `auth_attempts` is always logged (it defaults to 0, `min=0` requires it to be set).
+// nolint:lll
+type ZeekSSH struct {
+	TS  time.Time   `json:"ts" tcodec:"unix" panther:"event_time" validate:"required" description:"Time when the SSH connection began."`
+	UID null.String `json:"uid" panther:"trace_id" validate:"required" description:"Unique ID for the connection."`
+	ConnID
+	Version                   null.Uint8   `json:"version" description:"SSH major version (1 or 2)."`
+	AuthSuccess               null.Bool    `json:"auth_success" description:"Authentication result (T=success, F=failure, unset=unknown)."`
+	AuthAttempts              null.Uint32  `json:"auth_attempts" validate:"min=0" description:"The number of authentication attempts observed."`
+	Direction                 null.String  `json:"direction" description:"Direction of the connection. If the client was a local host logging into an external host, this would be OUTBOUND."`
+	Client                    null.String  `json:"client" description:"The client’s version string."`
+	Server                    null.String  `json:"server" description:"The server’s version string."`
+	CipherAlg                 null.String  `json:"cipher_alg" description:"The encryption algorithm in use."`
+	MACAlg                    null.String  `json:"mac_alg" description:"The signing (MAC) algorithm in use."`
+	CompressionAlg            null.String  `json:"compression_alg" description:"The compression algorithm in use."`
+	KexAlg                    null.String  `json:"kex_alg" description:"The key exchange algorithm in use."`
+	HostKeyAlg                null.String  `json:"host_key_alg" description:"The server host key’s algorithm."`
+	HostKey                   null.String  `json:"host_key" description:"The server’s key fingerprint."`
+	RemoteLocationCountryCode null.String  `json:"remote_location.country_code" description:"The country code of the remote host."`
+	RemoteLocationRegion      null.String  `json:"remote_location.region" description:"The region of the remote host."`
+	RemoteLocationCity        null.String  `json:"remote_location.city" description:"The city of the remote host."`
+	RemoteLocationLatitude    null.Float64 `json:"remote_location.latitude" description:"The latitude of the remote host."`
+	RemoteLocationLongitude   null.Float64 `json:"remote_location.longitude" description:"The longitude of the remote host."`
+	HASSH                     null.String  `json:"hassh" description:"HASSH fingerprint of the client key exchange."`
+	HASSHServer               null.String  `json:"hasshServer" description:"HASSH fingerprint of the server key exchange."`
+}
@@ -0,0 +1,59 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestZeekSSH(t *testing.T) {
+	// nolint:lll
+	input := `{"ts":1591368101.873221,"uid":"CbOjYpkXn9LfqV51c","id.orig_h":"198.51.100.40","id.orig_p":52104,"id.resp_h":"10.0.0.5","id.resp_p":22,"version":2,"auth_success":false,"auth_attempts":3,"direction":"INBOUND","client":"SSH-2.0-libssh2_1.8.0","server":"SSH-2.0-OpenSSH_7.4","cipher_alg":"aes128-ctr","mac_alg":"hmac-sha2-256","compression_alg":"none","kex_alg":"ecdh-sha2-nistp256","host_key_alg":"ecdsa-sha2-nistp256","host_key":"e2:5f:6d:8e:11:2f:aa:b1:54:c3:2d:9c:1c:23:80:41","remote_location.country_code":"US","remote_location.latitude":37.751,"remote_location.longitude":-97.822}`
+	// nolint:lll
+	expect := `{
+	  "ts": 1591368101.873221,
+	  "uid": "CbOjYpkXn9LfqV51c",
+	  "id.orig_h": "198.51.100.40",
+	  "id.orig_p": 52104,
+	  "id.resp_h": "10.0.0.5",
+	  "id.resp_p": 22,
+	  "version": 2,
+	  "auth_success": false,
+	  "auth_attempts": 3,
+	  "direction": "INBOUND",
+	  "client": "SSH-2.0-libssh2_1.8.0",
+	  "server": "SSH-2.0-OpenSSH_7.4",
+	  "cipher_alg": "aes128-ctr",
+	  "mac_alg": "hmac-sha2-256",
+	  "compression_alg": "none",
+	  "kex_alg": "ecdh-sha2-nistp256",
+	  "host_key_alg": "ecdsa-sha2-nistp256",
+	  "host_key": "e2:5f:6d:8e:11:2f:aa:b1:54:c3:2d:9c:1c:23:80:41",
+	  "remote_location.country_code": "US",
+	  "remote_location.latitude": 37.751,
+	  "remote_location.longitude": -97.822,
+	  "p_log_type": "Zeek.SSH",
+	  "p_event_time": "2020-06-05T14:41:41.873221Z",
+	  "p_any_ip_addresses": ["10.0.0.5", "198.51.100.40"],
+	  "p_any_trace_ids": ["CbOjYpkXn9LfqV51c"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeZeekSSH, input, expect)
+}
@@ -0,0 +1,52 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// ZeekSSL is a Zeek ssl.log record.
+// Sessions without a completed handshake have no version or cipher, `resumed` and `established` are always logged.
+// nolint:lll
+type ZeekSSL struct {
+	TS  time.Time   `json:"ts" tcodec:"unix" panther:"event_time" validate:"required" description:"Time when the SSL connection was first detected."`
+	UID null.String `json:"uid" panther:"trace_id" validate:"required" description:"Unique ID for the connection."`
+	ConnID
+	Version              null.String `json:"version" description:"SSL/TLS version that the server chose."`
+	Cipher               null.String `json:"cipher" description:"SSL/TLS cipher suite that the server chose."`
+	Curve                null.String `json:"curve" description:"Elliptic curve the server chose when using ECDH/ECDHE."`
+	ServerName           null.String `json:"server_name" panther:"hostname" description:"Value of the Server Name Indicator SSL/TLS extension."`
+	Resumed              null.Bool   `json:"resumed" validate:"required" description:"Flag to indicate if the session was resumed reusing the key material exchanged in an earlier connection."`
+	LastAlert            null.String `json:"last_alert" description:"Last alert that was seen during the connection."`
+	NextProtocol         null.String `json:"next_protocol" description:"Next protocol the server chose using the application layer next protocol extension, if present."`
+	Established          null.Bool   `json:"established" validate:"required" description:"Flag to indicate if this ssl session has been established successfully, or if it was aborted during the handshake."`
+	CertChainFUIDs       []string    `json:"cert_chain_fuids" panther:"trace_id" description:"An ordered vector of all certificate file unique IDs for the certificates offered by the server."`
+	ClientCertChainFUIDs []string    `json:"client_cert_chain_fuids" panther:"trace_id" description:"An ordered vector of all certificate file unique IDs for the certificates offered by the client."`
+	Subject              null.String `json:"subject" description:"Subject of the X.509 certificate offered by the server."`
+	Issuer               null.String `json:"issuer" description:"Subject of the signer of the X.509 certificate offered by the server."`
+	ClientSubject        null.String `json:"client_subject" description:"Subject of the X.509 certificate offered by the client."`
+	ClientIssuer         null.String `json:"client_issuer" description:"Subject of the signer of the X.509 certificate offered by the client."`
+	ValidationStatus     null.String `json:"validation_status" description:"Result of certificate validation for this connection."`
+	JA3                  null.String `json:"ja3" description:"JA3 fingerprint of the client hello."`
+	JA3S                 null.String `json:"ja3s" description:"JA3S fingerprint of the server hello."`
+	SNIMatchesCert       null.Bool   `json:"sni_matches_cert" description:"Set to true if the hostname sent in the SNI matches the certificate."`
+}
@@ -0,0 +1,58 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestZeekSSL(t *testing.T) {
+	// nolint:lll
+	input := `{"ts":1591368000.120861,"uid":"CsukF91Bx9mrqdEaH9","id.orig_h":"192.168.4.49","id.orig_p":56718,"id.resp_h":"13.32.202.10","id.resp_p":443,"version":"TLSv12","cipher":"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256","curve":"secp256r1","server_name":"www.taosecurity.com","resumed":false,"next_protocol":"h2","established":true,"cert_chain_fuids":["F2XEvj1CahhdhtfvT4","FZ7ygD3ERPfEVVohG9"],"client_cert_chain_fuids":[],"subject":"CN=www.taosecurity.com","issuer":"CN=Amazon,OU=Server CA 1B,O=Amazon,C=US","validation_status":"ok","ja3":"72a589da586844d7f0818ce684948eea","ja3s":"d2b4e6f8b1e8a7f9d3c2b1a0e9f8d7c6"}`
+	// nolint:lll
+	expect := `{
+	  "ts": 1591368000.120861,
+	  "uid": "CsukF91Bx9mrqdEaH9",
+	  "id.orig_h": "192.168.4.49",
+	  "id.orig_p": 56718,
+	  "id.resp_h": "13.32.202.10",
+	  "id.resp_p": 443,
+	  "version": "TLSv12",
+	  "cipher": "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	  "curve": "secp256r1",
+	  "server_name": "www.taosecurity.com",
+	  "resumed": false,
+	  "next_protocol": "h2",
+	  "established": true,
+	  "cert_chain_fuids": ["F2XEvj1CahhdhtfvT4", "FZ7ygD3ERPfEVVohG9"],
+	  "subject": "CN=www.taosecurity.com",
+	  "issuer": "CN=Amazon,OU=Server CA 1B,O=Amazon,C=US",
+	  "validation_status": "ok",
+	  "ja3": "72a589da586844d7f0818ce684948eea",
+	  "ja3s": "d2b4e6f8b1e8a7f9d3c2b1a0e9f8d7c6",
+	  "p_log_type": "Zeek.SSL",
+	  "p_event_time": "2020-06-05T14:40:00.120861Z",
+	  "p_any_ip_addresses": ["13.32.202.10", "192.168.4.49"],
+	  "p_any_domain_names": ["www.taosecurity.com"],
+	  "p_any_trace_ids": ["CsukF91Bx9mrqdEaH9", "F2XEvj1CahhdhtfvT4", "FZ7ygD3ERPfEVVohG9"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeZeekSSL, input, expect)
+}
@@ -0,0 +1,55 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// ZeekX509 is a Zeek x509.log record.
+// Zeek writes the nested certificate, san and basic_constraints records as dotted top-level fields.
+// nolint:lll
+type ZeekX509 struct {
+	TS                        time.Time   `json:"ts" tcodec:"unix" panther:"event_time" validate:"required" description:"Current timestamp."`
+	ID                        null.String `json:"id" panther:"trace_id" validate:"required" description:"File id of this certificate."`
+	Fingerprint               null.String `json:"fingerprint" description:"Fingerprint of the certificate (SHA256 by default)."`
+	CertificateVersion        null.Uint8  `json:"certificate.version" description:"Version number."`
+	CertificateSerial         null.String `json:"certificate.serial" description:"Serial number."`
+	CertificateSubject        null.String `json:"certificate.subject" description:"Subject."`
+	CertificateIssuer         null.String `json:"certificate.issuer" description:"Issuer."`
+	CertificateCN             null.String `json:"certificate.cn" description:"Last (most specific) common name."`
+	CertificateNotValidBefore time.Time   `json:"certificate.not_valid_before" tcodec:"unix" description:"Timestamp before when certificate is not valid."`
+	CertificateNotValidAfter  time.Time   `json:"certificate.not_valid_after" tcodec:"unix" description:"Timestamp after when certificate is not valid."`
+	CertificateKeyAlg         null.String `json:"certificate.key_alg" description:"Name of the key algorithm."`
+	CertificateSigAlg         null.String `json:"certificate.sig_alg" description:"Name of the signature algorithm."`
+	CertificateKeyType        null.String `json:"certificate.key_type" description:"Key type, if key parseable by openssl (either rsa, dsa or ec)."`
+	CertificateKeyLength      null.Uint32 `json:"certificate.key_length" description:"Key length in bits."`
+	CertificateExponent       null.String `json:"certificate.exponent" description:"Exponent, if RSA-certificate."`
+	CertificateCurve          null.String `json:"certificate.curve" description:"Curve, if EC-certificate."`
+	SANDNS                    []string    `json:"san.dns" panther:"domain" description:"List of DNS entries in the Subject Alternative Name extension."`
+	SANURI                    []string    `json:"san.uri" panther:"url" description:"List of URI entries in the Subject Alternative Name extension."`
+	SANEmail                  []string    `json:"san.email" description:"List of email entries in the Subject Alternative Name extension."`
+	SANIP                     []string    `json:"san.ip" panther:"ip" description:"List of IP entries in the Subject Alternative Name extension."`
+	BasicConstraintsCA        null.Bool   `json:"basic_constraints.ca" description:"CA flag set or not."`
+	BasicConstraintsPathLen   null.Uint32 `json:"basic_constraints.path_len" description:"Maximum path length."`
+	HostCert                  null.Bool   `json:"host_cert" description:"Indicates if this certificate was a end-host certificate, or sent as part of a chain."`
+	ClientCert                null.Bool   `json:"client_cert" description:"Indicates if this certificate was sent from the client."`
+}
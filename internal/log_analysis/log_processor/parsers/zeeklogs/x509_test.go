@@ -0,0 +1,55 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+)
+
+func TestZeekX509(t *testing.T) {
+	// nolint:lll
+	input := `{"ts":1591368000.144587,"id":"F2XEvj1CahhdhtfvT4","certificate.version":3,"certificate.serial":"0C8D9A0E4B5E2D1F","certificate.subject":"CN=www.taosecurity.com","certificate.issuer":"CN=Amazon,OU=Server CA 1B,O=Amazon,C=US","certificate.not_valid_before":1580515200.0,"certificate.not_valid_after":1614600000.0,"certificate.key_alg":"rsaEncryption","certificate.sig_alg":"sha256WithRSAEncryption","certificate.key_type":"rsa","certificate.key_length":2048,"certificate.exponent":"65537","san.dns":["www.taosecurity.com","taosecurity.com"],"san.ip":["13.32.202.10"],"basic_constraints.ca":false}`
+	// nolint:lll
+	expect := `{
+	  "ts": 1591368000.144587,
+	  "id": "F2XEvj1CahhdhtfvT4",
+	  "certificate.version": 3,
+	  "certificate.serial": "0C8D9A0E4B5E2D1F",
+	  "certificate.subject": "CN=www.taosecurity.com",
+	  "certificate.issuer": "CN=Amazon,OU=Server CA 1B,O=Amazon,C=US",
+	  "certificate.not_valid_before": 1580515200,
+	  "certificate.not_valid_after": 1614600000,
+	  "certificate.key_alg": "rsaEncryption",
+	  "certificate.sig_alg": "sha256WithRSAEncryption",
+	  "certificate.key_type": "rsa",
+	  "certificate.key_length": 2048,
+	  "certificate.exponent": "65537",
+	  "san.dns": ["www.taosecurity.com", "taosecurity.com"],
+	  "san.ip": ["13.32.202.10"],
+	  "basic_constraints.ca": false,
+	  "p_log_type": "Zeek.X509",
+	  "p_event_time": "2020-06-05T14:40:00.144587Z",
+	  "p_any_ip_addresses": ["13.32.202.10"],
+	  "p_any_domain_names": ["taosecurity.com", "www.taosecurity.com"],
+	  "p_any_trace_ids": ["F2XEvj1CahhdhtfvT4"]
+	}`
+	testutil.CheckRegisteredParser(t, TypeZeekX509, input, expect)
+}
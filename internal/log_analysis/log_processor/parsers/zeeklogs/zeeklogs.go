@@ -20,11 +20,20 @@ package zeeklogs
 
 import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 const (
-	TypeZeekDNS = "Zeek.DNS"
+	TypeZeekDNS    = "Zeek.DNS"
+	TypeZeekConn   = "Zeek.Conn"
+	TypeZeekHTTP   = "Zeek.HTTP"
+	TypeZeekSSL    = "Zeek.SSL"
+	TypeZeekX509   = "Zeek.X509"
+	TypeZeekFiles  = "Zeek.Files"
+	TypeZeekNotice = "Zeek.Notice"
+	TypeZeekSMTP   = "Zeek.SMTP"
+	TypeZeekSSH    = "Zeek.SSH"
 )
 
 func init() {
@@ -36,4 +45,69 @@ func init() {
 			Schema:       &ZeekDNS{},
 			NewParser:    parsers.AdapterFactory(&ZeekDNSParser{}),
 		})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeZeekConn,
+		Description:  `Zeek TCP/UDP/ICMP connection activity`,
+		ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/conn/main.zeek.html#type-Conn::Info`,
+	}, func() interface{} {
+		return &ZeekConn{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeZeekHTTP,
+		Description:  `Zeek HTTP request/reply activity`,
+		ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/http/main.zeek.html#type-HTTP::Info`,
+	}, func() interface{} {
+		return &ZeekHTTP{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeZeekSSL,
+		Description:  `Zeek SSL/TLS handshake activity`,
+		ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/ssl/main.zeek.html#type-SSL::Info`,
+	}, func() interface{} {
+		return &ZeekSSL{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeZeekX509,
+		Description:  `Zeek X.509 certificate details`,
+		ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/files/x509/main.zeek.html#type-X509::Info`,
+	}, func() interface{} {
+		return &ZeekX509{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeZeekFiles,
+		Description:  `Zeek file analysis results`,
+		ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/files/main.zeek.html#type-Files::Info`,
+	}, func() interface{} {
+		return &ZeekFiles{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeZeekNotice,
+		Description:  `Zeek notices raised by the notice framework`,
+		ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/notice/main.zeek.html#type-Notice::Info`,
+	}, func() interface{} {
+		return &ZeekNotice{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeZeekSMTP,
+		Description:  `Zeek SMTP transaction activity`,
+		ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/smtp/main.zeek.html#type-SMTP::Info`,
+	}, func() interface{} {
+		return &ZeekSMTP{}
+	})
+	logtypes.MustRegisterJSON(logtypes.Desc{
+		Name:         TypeZeekSSH,
+		Description:  `Zeek SSH handshake activity`,
+		ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/ssh/main.zeek.html#type-SSH::Info`,
+	}, func() interface{} {
+		return &ZeekSSH{}
+	})
+}
+
+// ConnID holds the connection endpoint fields shared by Zeek logs that are tied to a single connection.
+// nolint:lll
+type ConnID struct {
+	OrigH null.String `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	OrigP null.Uint16 `json:"id.orig_p" description:"The originator’s port number."`
+	RespH null.String `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	RespP null.Uint16 `json:"id.resp_p" description:"The responder’s port number."`
 }
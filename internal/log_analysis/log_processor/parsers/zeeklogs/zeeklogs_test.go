@@ -0,0 +1,79 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+// Zeek logs share the connection fields so each log type must require fields that identify it
+func TestZeekLogTypesAreDistinct(t *testing.T) {
+	// nolint:lll
+	samples := map[string]string{
+		TypeZeekDNS:    `{"ts":1591367999.305988,"uid":"CMdzit1AMNsmfAIiQc","id.orig_h":"192.168.4.76","id.orig_p":36844,"id.resp_h":"192.168.4.1","id.resp_p":53,"proto":"udp","trans_id":62418,"rtt":0.06685185432434082,"query":"www.reddit.com","qclass":1,"qclass_name":"C_INTERNET","qtype":1,"qtype_name":"A","rcode":0,"rcode_name":"NOERROR","AA":false,"TC":false,"RD":true,"RA":true,"Z":0,"answers":["reddit.map.fastly.net","151.101.1.140"],"TTLs":[3600.0,30.0],"rejected":false}`,
+		TypeZeekConn:   `{"ts":1591367999.305988,"uid":"CMdzit1AMNsmfAIiQc","id.orig_h":"192.168.4.76","id.orig_p":36844,"id.resp_h":"192.168.4.1","id.resp_p":53,"proto":"udp","service":"dns","duration":0.06685185432434082,"orig_bytes":62,"resp_bytes":141,"conn_state":"SF","local_orig":true,"local_resp":true,"missed_bytes":0,"history":"Dd","orig_pkts":2,"orig_ip_bytes":118,"resp_pkts":2,"resp_ip_bytes":197,"tunnel_parents":["CZ0kqu2qTqDz2Zk2pa"],"community_id":"1:Tfl0PjdUy1U4xWQ/Z8tO0RvWqFg="}`,
+		TypeZeekHTTP:   `{"ts":1591367999.512593,"uid":"C5bLoe2Mvxqhawzqqd","id.orig_h":"192.168.4.76","id.orig_p":46378,"id.resp_h":"31.3.245.133","id.resp_p":80,"trans_depth":1,"method":"GET","host":"testmyids.com","uri":"/","version":"1.1","user_agent":"curl/7.47.0","request_body_len":0,"response_body_len":39,"status_code":200,"status_msg":"OK","tags":[],"resp_fuids":["FEEsZS1w0Z0VJIb5x4"],"resp_mime_types":["text/plain"]}`,
+		TypeZeekSSL:    `{"ts":1591368000.120861,"uid":"CsukF91Bx9mrqdEaH9","id.orig_h":"192.168.4.49","id.orig_p":56718,"id.resp_h":"13.32.202.10","id.resp_p":443,"version":"TLSv12","cipher":"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256","curve":"secp256r1","server_name":"www.taosecurity.com","resumed":false,"next_protocol":"h2","established":true,"cert_chain_fuids":["F2XEvj1CahhdhtfvT4","FZ7ygD3ERPfEVVohG9"],"client_cert_chain_fuids":[],"subject":"CN=www.taosecurity.com","issuer":"CN=Amazon,OU=Server CA 1B,O=Amazon,C=US","validation_status":"ok","ja3":"72a589da586844d7f0818ce684948eea","ja3s":"d2b4e6f8b1e8a7f9d3c2b1a0e9f8d7c6"}`,
+		TypeZeekSSH:    `{"ts":1591368101.873221,"uid":"CbOjYpkXn9LfqV51c","id.orig_h":"198.51.100.40","id.orig_p":52104,"id.resp_h":"10.0.0.5","id.resp_p":22,"version":2,"auth_success":false,"auth_attempts":3,"direction":"INBOUND","client":"SSH-2.0-libssh2_1.8.0","server":"SSH-2.0-OpenSSH_7.4","cipher_alg":"aes128-ctr","mac_alg":"hmac-sha2-256","compression_alg":"none","kex_alg":"ecdh-sha2-nistp256","host_key_alg":"ecdsa-sha2-nistp256","host_key":"e2:5f:6d:8e:11:2f:aa:b1:54:c3:2d:9c:1c:23:80:41","remote_location.country_code":"US","remote_location.latitude":37.751,"remote_location.longitude":-97.822}`,
+		TypeZeekFiles:  `{"ts":1591367999.58031,"fuid":"FEEsZS1w0Z0VJIb5x4","tx_hosts":["31.3.245.133"],"rx_hosts":["192.168.4.76"],"conn_uids":["C5bLoe2Mvxqhawzqqd"],"source":"HTTP","depth":0,"analyzers":["MD5","SHA1"],"mime_type":"text/plain","duration":0.0,"local_orig":false,"is_orig":false,"seen_bytes":39,"total_bytes":39,"missing_bytes":0,"overflow_bytes":0,"timedout":false,"md5":"2a3a4ad9ca1e9ab0b3c7b4c4a4d1e1cb","sha1":"a2bd47a3cfe1c2fa3cc94c1ff6d1c08c0b7f0b07"}`,
+		TypeZeekNotice: `{"ts":1591368010.402349,"uid":"CHhAvVGS1DHFjwGM9","id.orig_h":"10.0.0.12","id.orig_p":50236,"id.resp_h":"203.0.113.22","id.resp_p":443,"proto":"tcp","note":"SSL::Invalid_Server_Cert","msg":"SSL certificate validation failed with (unable to get local issuer certificate)","sub":"CN=evil.example.com","src":"10.0.0.12","dst":"203.0.113.22","p":443,"peer_descr":"worker-1-1","actions":["Notice::ACTION_LOG"],"suppress_for":86400.0}`,
+		TypeZeekSMTP:   `{"ts":1591368042.114502,"uid":"CmES5u32sYpV7JYN","id.orig_h":"10.0.0.21","id.orig_p":1470,"id.resp_h":"192.0.2.25","id.resp_p":25,"trans_depth":1,"helo":"mail.example.org","mailfrom":"<alice@example.org>","rcptto":["<bob@example.com>"],"date":"Fri, 5 Jun 2020 14:40:41 +0000","from":"Alice <alice@example.org>","to":["Bob <bob@example.com>"],"msg_id":"<20200605144041.1234@example.org>","subject":"Quarterly report","x_originating_ip":"198.51.100.7","last_reply":"250 2.0.0 Ok: queued","path":["192.0.2.25","10.0.0.21"],"user_agent":"Thunderbird","tls":false,"fuids":["Fel9gs4OtNEV6gUJZ5"],"is_webmail":false}`,
+		TypeZeekX509:   `{"ts":1591368000.144587,"id":"F2XEvj1CahhdhtfvT4","certificate.version":3,"certificate.serial":"0C8D9A0E4B5E2D1F","certificate.subject":"CN=www.taosecurity.com","certificate.issuer":"CN=Amazon,OU=Server CA 1B,O=Amazon,C=US","certificate.not_valid_before":1580515200.0,"certificate.not_valid_after":1614600000.0,"certificate.key_alg":"rsaEncryption","certificate.sig_alg":"sha256WithRSAEncryption","certificate.key_type":"rsa","certificate.key_length":2048,"certificate.exponent":"65537","san.dns":["www.taosecurity.com","taosecurity.com"],"san.ip":["13.32.202.10"],"basic_constraints.ca":false}`,
+	}
+	for sampleType, sample := range samples {
+		for logType := range samples {
+			entry := logtypes.DefaultRegistry().Get(logType)
+			require.NotNil(t, entry, logType)
+			parser, err := entry.NewParser(nil)
+			require.NoError(t, err)
+			_, err = parser.ParseLog(sample)
+			if logType == sampleType {
+				assert.NoError(t, err, "%s sample", sampleType)
+				continue
+			}
+			assert.Error(t, err, "%s sample parsed as %s", sampleType, logType)
+		}
+	}
+}
+
+// Fields Zeek declares &optional must not be required
+func TestZeekOptionalFields(t *testing.T) {
+	// nolint:lll
+	samples := map[string]string{
+		// no completed handshake
+		TypeZeekSSL: `{"ts":1591368000.120861,"uid":"CsukF91Bx9mrqdEaH9","id.orig_h":"192.168.4.49","id.orig_p":56718,"id.resp_h":"13.32.202.10","id.resp_p":443,"resumed":false,"established":false}`,
+		// no version or authentication observed
+		TypeZeekSSH: `{"ts":1591368101.873221,"uid":"CbOjYpkXn9LfqV51c","id.orig_h":"198.51.100.40","id.orig_p":52104,"id.resp_h":"10.0.0.5","id.resp_p":22,"auth_attempts":0}`,
+		// no headers
+		TypeZeekSMTP: `{"ts":1591368042.114502,"uid":"CmES5u32sYpV7JYN","id.orig_h":"10.0.0.21","id.orig_p":1470,"id.resp_h":"192.0.2.25","id.resp_p":25,"trans_depth":1,"helo":"mail.example.org","last_reply":"421 4.7.0 Try again later","tls":false,"fuids":[],"is_webmail":false}`,
+	}
+	for logType, sample := range samples {
+		entry := logtypes.DefaultRegistry().Get(logType)
+		require.NotNil(t, entry, logType)
+		parser, err := entry.NewParser(nil)
+		require.NoError(t, err)
+		_, err = parser.ParseLog(sample)
+		assert.NoError(t, err, logType)
+	}
+}
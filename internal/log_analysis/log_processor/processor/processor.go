@@ -56,9 +56,12 @@ var (
 
 // Process orchestrates the tasks of parsing logs, classification, normalization
 // and forwarding the logs to the appropriate destination. Any errors will cause Lambda invocation to fail
-func Process(dataStreams chan *common.DataStream, destination destinations.Destination) error {
+// If enricher is not nil, it is used to add enrichment data to all events.
+func Process(dataStreams chan *common.DataStream, destination destinations.Destination, enricher pantherlog.Enricher) error {
 	factory := func(r *common.DataStream) *Processor {
-		return MustBuildProcessor(r, registry.Default())
+		p := MustBuildProcessor(r, registry.Default())
+		p.enricher = enricher
+		return p
 	}
 	return process(dataStreams, destination, factory)
 }
@@ -156,6 +159,9 @@ func (p *Processor) classifyLogLine(line string) *classification.ClassifierResul
 
 func (p *Processor) sendEvents(result *classification.ClassifierResult, outputChan chan *parsers.Result) {
 	for _, event := range result.Events {
+		if p.enricher != nil {
+			event.Enricher = p.enricher
+		}
 		outputChan <- event
 	}
 }
@@ -182,6 +188,7 @@ type Processor struct {
 	input      *common.DataStream
 	classifier classification.ClassifierAPI
 	operation  *oplog.Operation
+	enricher   pantherlog.Enricher
 }
 
 func MustBuildProcessor(input *common.DataStream, registry *logtypes.Registry) *Processor {
@@ -526,3 +526,18 @@ func mockLogger() *observer.ObservedLogs {
 	zap.ReplaceGlobals(zap.New(core))
 	return mockLog
 }
+
+type testEnricher struct{}
+
+func (testEnricher) Enrich(_ string, _ []byte) []byte {
+	return nil
+}
+
+func TestProcessorSendEventsEnricher(t *testing.T) {
+	p := Processor{enricher: testEnricher{}}
+	outputChan := make(chan *parsers.Result, 1)
+	p.sendEvents(&classification.ClassifierResult{
+		Events: []*parsers.Result{newTestLog()},
+	}, outputChan)
+	require.Equal(t, testEnricher{}, (<-outputChan).Enricher)
+}
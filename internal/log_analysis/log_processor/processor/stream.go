@@ -32,6 +32,7 @@ import (
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/destinations"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/sources"
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
@@ -51,8 +52,13 @@ The function will attempt to read more messages from the queue when the queue ha
 the lambda will continue to read events and maximally aggregate data to produce fewer, bigger files.
 Fewer, bigger files makes Athena queries much faster.
 */
-func StreamEvents(sqsClient sqsiface.SQSAPI, deadlineTime time.Time, event events.SQSEvent) (sqsMessageCount int, err error) {
-	return streamEvents(sqsClient, deadlineTime, event, Process, sources.ReadSnsMessages)
+func StreamEvents(sqsClient sqsiface.SQSAPI, deadlineTime time.Time, event events.SQSEvent,
+	enricher pantherlog.Enricher) (sqsMessageCount int, err error) {
+
+	process := func(dataStreams chan *common.DataStream, destination destinations.Destination) error {
+		return Process(dataStreams, destination, enricher)
+	}
+	return streamEvents(sqsClient, deadlineTime, event, process, sources.ReadSnsMessages)
 }
 
 // entry point for unit testing, pass in read/process functions
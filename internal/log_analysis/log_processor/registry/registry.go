@@ -28,16 +28,24 @@ import (
 	// Register log types in init() blocks
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/apachelogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/cloudflarelogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/duologs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/fastlylogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/fluentdsyslogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/fortinetlogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/gitlablogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/gravitationallogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/juniperlogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/laceworklogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/nginxlogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/onepasswordlogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/osquerylogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/osseclogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/paloaltologs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/slacklogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/suricatalogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
 	_ "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/zeeklogs"
 )
 
@@ -42,6 +42,13 @@ import (
 const (
 	s3TestEvent                 = "s3:TestEvent"
 	cloudTrailValidationMessage = "CloudTrail validation message."
+
+	// SourceIDAttribute is the SNS message attribute used to set the source of the S3 objects in a notification.
+	// When set, the source is looked up by id instead of matching the bucket and prefix of the objects,
+	// the objects must still be in the bucket and under the prefix of the source.
+	// It is used to backfill objects that match more than one source and is only honored on notifications
+	// published in the Panther account (e.g. by s3queue).
+	SourceIDAttribute = "PantherSourceID"
 )
 
 // ReadSnsMessages reads incoming messages containing SNS notifications and returns a slice of DataStream items
@@ -103,7 +110,9 @@ func handleNotificationMessage(notification *SnsNotification) (result []*common.
 	if err != nil {
 		return nil, err
 	}
+	sourceID := notificationSourceID(notification)
 	for _, s3Object := range s3Objects {
+		s3Object.SourceID = sourceID
 		var dataStream *common.DataStream
 		dataStream, err = readS3Object(s3Object)
 		if err != nil {
@@ -309,6 +318,36 @@ type cloudTrailNotification struct {
 type S3ObjectInfo struct {
 	S3Bucket    string
 	S3ObjectKey string
+	// SourceID is the id of the source to use for the object, if empty the source is matched by bucket and prefix
+	SourceID string
+}
+
+// notificationSourceID returns the value of the SourceIDAttribute message attribute of a notification.
+// The input queue accepts topics from every onboarded account, so the attribute is ignored unless the
+// notification comes from a topic in the Panther account.
+func notificationSourceID(notification *SnsNotification) string {
+	attr, ok := notification.MessageAttributes[SourceIDAttribute].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := attr["Value"].(string)
+	if value != "" && !isPantherAccountTopic(notification.TopicArn) {
+		zap.L().Warn("ignoring source id of notification from another account",
+			zap.String("topicArn", notification.TopicArn),
+			zap.String("sourceId", value))
+		return ""
+	}
+	return value
+}
+
+// isPantherAccountTopic returns true if the topic is in the same account as the log processor
+func isPantherAccountTopic(topicARN string) bool {
+	topic, err := arn.Parse(topicARN)
+	if err != nil {
+		return false
+	}
+	panther, err := arn.Parse(common.Config.SnsTopicARN)
+	return err == nil && topic.AccountID == panther.AccountID
 }
 
 // SnsNotification struct represents an SNS message arriving to Panther SQS from a customer account.
@@ -58,11 +58,14 @@ type s3ClientCacheKey struct {
 type sourceCacheStruct struct {
 	cacheUpdateTime time.Time
 	byBucket        map[string][]*models.SourceIntegration
+	byID            map[string]*models.SourceIntegration
 }
 
 func (c *sourceCacheStruct) Update(now time.Time, sources []*models.SourceIntegration) {
 	byBucket := make(map[string][]*models.SourceIntegration)
+	byID := make(map[string]*models.SourceIntegration, len(sources))
 	for _, source := range sources {
+		byID[source.IntegrationID] = source
 		bucketName, _ := getSourceS3Info(source)
 		bucketSources := byBucket[bucketName]
 		byBucket[bucketName] = append(bucketSources, source)
@@ -84,9 +87,25 @@ func (c *sourceCacheStruct) Update(now time.Time, sources []*models.SourceIntegr
 	}
 	*c = sourceCacheStruct{
 		byBucket:        byBucket,
+		byID:            byID,
 		cacheUpdateTime: now,
 	}
 }
+
+// FindByID returns the source with the given integration id or nil if no such source exists
+// or the object is not in the bucket and under the prefix of the source.
+func (c *sourceCacheStruct) FindByID(id, bucketName, objectKey string) *models.SourceIntegration {
+	source := c.byID[id]
+	if source == nil {
+		return nil
+	}
+	sourceBucket, sourcePrefix := getSourceS3Info(source)
+	if sourceBucket != bucketName || !strings.HasPrefix(objectKey, sourcePrefix) {
+		return nil
+	}
+	return source
+}
+
 func (c *sourceCacheStruct) Find(bucketName, objectKey string) *models.SourceIntegration {
 	sources := c.byBucket[bucketName]
 	for _, source := range sources {
@@ -228,7 +247,11 @@ func getSourceInfo(s3Object *S3ObjectInfo) (result *models.SourceIntegration, er
 		sourceCache.Update(now, output)
 	}
 
-	result = sourceCache.Find(s3Object.S3Bucket, s3Object.S3ObjectKey)
+	if s3Object.SourceID != "" {
+		result = sourceCache.FindByID(s3Object.SourceID, s3Object.S3Bucket, s3Object.S3ObjectKey)
+	} else {
+		result = sourceCache.Find(s3Object.S3Bucket, s3Object.S3ObjectKey)
+	}
 
 	// If the incoming notification maps to a known source, update the source information
 	if result != nil {
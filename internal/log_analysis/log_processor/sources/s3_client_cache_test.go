@@ -260,3 +260,25 @@ func TestSourceCacheStruct_Find(t *testing.T) {
 		assert.Nil(src)
 	}
 }
+
+func TestSourceCacheStruct_FindByID(t *testing.T) {
+	cache := sourceCacheStruct{}
+	sources := []*models.SourceIntegration{
+		{
+			SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+				IntegrationID: "foo-id",
+				S3Bucket:      "foo",
+				S3Prefix:      "/foo",
+			},
+		},
+	}
+	assert := require.New(t)
+	cache.Update(time.Now(), sources)
+	src := cache.FindByID("foo-id", "foo", "/foo/bar.json")
+	assert.NotNil(src)
+	assert.Equal("/foo", src.S3Prefix)
+	assert.Nil(cache.FindByID("bar-id", "foo", "/foo/bar.json"))
+	// objects outside the bucket or prefix of the source are rejected
+	assert.Nil(cache.FindByID("foo-id", "bar", "/foo/bar.json"))
+	assert.Nil(cache.FindByID("foo-id", "foo", "/bar/baz.json"))
+}
@@ -21,6 +21,7 @@ package sources
 import (
 	"bytes"
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -163,3 +164,30 @@ func TestHandleUnsupportedFileType(t *testing.T) {
 	// Method should not return data stream
 	require.Equal(t, 0, len(dataStreams))
 }
+
+func TestNotificationSourceID(t *testing.T) {
+	common.Config.SnsTopicARN = "arn:aws:sns:us-west-2:123456789012:panther-processed-data-notifications"
+	defer func() { common.Config.SnsTopicARN = "" }()
+
+	message := `{
+		"Type": "Notification",
+		"TopicArn": "arn:aws:sns:us-east-1:123456789012:panther-fake-s3queue-topic",
+		"Message": "{}",
+		"MessageAttributes": {
+			"PantherSourceID": {"Type": "String", "Value": "3e4b1734-e678-4581-b291-4b8a176219e9"}
+		}
+	}`
+	notification := SnsNotification{}
+	require.NoError(t, jsoniter.UnmarshalFromString(message, &notification))
+	require.Equal(t, "3e4b1734-e678-4581-b291-4b8a176219e9", notificationSourceID(&notification))
+
+	// source accounts cannot pick the source of their objects
+	notification = SnsNotification{}
+	otherAccount := strings.Replace(message, "123456789012", "210987654321", 1)
+	require.NoError(t, jsoniter.UnmarshalFromString(otherAccount, &notification))
+	require.Equal(t, "", notificationSourceID(&notification))
+
+	notification = SnsNotification{}
+	require.NoError(t, jsoniter.UnmarshalFromString(`{"Type": "Notification", "Message": "{}"}`, &notification))
+	require.Equal(t, "", notificationSourceID(&notification))
+}
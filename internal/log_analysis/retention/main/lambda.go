@@ -0,0 +1,115 @@
+package main
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/glue"
+	awslambda "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/core/logtypesapi"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/internal/log_analysis/retention"
+	"github.com/panther-labs/panther/pkg/lambdalogger"
+)
+
+// The panther-log-retention lambda runs on a schedule deleting log data past the retention set for each log type.
+
+const (
+	maxRetries = 20 // setting Max Retries to a higher number - we'd like to retry VERY hard before failing.
+
+	logTypesAPIFunction = "panther-logtypes-api"
+)
+
+type envConfig struct {
+	ProcessedDataBucket string `required:"true" split_words:"true"`
+}
+
+var (
+	enforcer    *retention.Enforcer
+	logTypesAPI *logtypesapi.LogTypesAPILambdaClient
+)
+
+func handle(ctx context.Context, _ events.CloudWatchEvent) (err error) {
+	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
+	operation := common.OpLogManager.Start(lc.InvokedFunctionArn, common.OpLogLambdaServiceDim).WithMemUsed(lambdacontext.MemoryLimitInMB)
+	var (
+		deletedObjects    int
+		deletedPartitions int
+		deletedTables     int
+	)
+	defer func() {
+		operation.Stop().Log(err,
+			zap.Int("deletedObjects", deletedObjects),
+			zap.Int("deletedPartitions", deletedPartitions),
+			zap.Int("deletedTables", deletedTables))
+	}()
+
+	settings, err := logTypesAPI.ListLogTypeRetention(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list log type retention")
+	}
+
+	for _, setting := range settings.Retention {
+		entry := registry.Default().Get(setting.LogType)
+		if entry == nil {
+			zap.L().Warn("skipping retention of unknown log type", zap.String("logType", setting.LogType))
+			continue
+		}
+		logTable := entry.GlueTableMeta()
+		// rule matches are kept as long as the logs they matched
+		for _, table := range []*awsglue.GlueTableMetadata{logTable, logTable.RuleTable()} {
+			result, err := enforcer.Expire(table, setting.RetentionDays)
+			if result != nil {
+				deletedObjects += result.DeletedObjects
+				deletedPartitions += result.DeletedPartitions
+				deletedTables += len(result.DeletedTables)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func main() {
+	var env envConfig
+	envconfig.MustProcess("", &env)
+
+	awsSession := session.Must(session.NewSession(aws.NewConfig().WithMaxRetries(maxRetries)))
+	enforcer = retention.New(s3.New(awsSession), glue.New(awsSession), env.ProcessedDataBucket)
+	logTypesAPI = &logtypesapi.LogTypesAPILambdaClient{
+		LambdaName: logTypesAPIFunction,
+		LambdaAPI:  awslambda.New(awsSession),
+	}
+	lambda.Start(handle)
+}
@@ -0,0 +1,269 @@
+package retention
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+// Enforcer deletes the expired data of log tables and their partitions from the catalog
+type Enforcer struct {
+	S3Client   s3iface.S3API
+	GlueClient glueiface.GlueAPI
+	// Bucket is the processed data bucket
+	Bucket string
+
+	now func() time.Time // so we can set in tests
+}
+
+// New creates an enforcer for the tables in the processed data bucket
+func New(s3Client s3iface.S3API, glueClient glueiface.GlueAPI, bucket string) *Enforcer {
+	return &Enforcer{
+		S3Client:   s3Client,
+		GlueClient: glueClient,
+		Bucket:     bucket,
+	}
+}
+
+// Result reports the data deleted from a table
+type Result struct {
+	DatabaseName string
+	TableName    string
+	// Data in partitions before this time were deleted
+	Cutoff time.Time
+	// The S3 prefixes that were deleted
+	DeletedPrefixes []string
+	// The number of S3 objects deleted
+	DeletedObjects int
+	// The number of partitions removed from the catalog
+	DeletedPartitions int
+	// The archive tables of previous schema versions that were deleted because all their data expired
+	DeletedTables []string
+}
+
+// Expire deletes the data of the table in partitions that ended more than retentionDays ago.
+// Expired partitions that no longer have data in S3 are also removed from the catalog, both from the table
+// and from the archive tables of its previous schema versions.
+func (e *Enforcer) Expire(table *awsglue.GlueTableMetadata, retentionDays int) (*Result, error) {
+	if retentionDays <= 0 {
+		return nil, errors.Errorf("invalid retention for %s.%s: %d days", table.DatabaseName(), table.TableName(),
+			retentionDays)
+	}
+
+	result := &Result{
+		DatabaseName: table.DatabaseName(),
+		TableName:    table.TableName(),
+		Cutoff:       e.currentTime().Add(-time.Duration(retentionDays) * 24 * time.Hour),
+	}
+
+	// the values of the first partition to keep, all partitions before it have expired
+	var boundary []int
+	for _, value := range table.Timebin().PartitionValuesFromTime(result.Cutoff) {
+		n, _ := strconv.Atoi(aws.StringValue(value))
+		boundary = append(boundary, n)
+	}
+
+	prefixes, err := e.expiredPrefixes(table.Prefix(), table.PartitionKeys(), boundary)
+	if err != nil {
+		return nil, err
+	}
+	for _, prefix := range prefixes {
+		n, err := e.deletePrefix(prefix)
+		result.DeletedObjects += n
+		if err != nil {
+			return result, err
+		}
+		result.DeletedPrefixes = append(result.DeletedPrefixes, prefix)
+		zap.L().Debug("deleted expired data",
+			zap.String("bucket", e.Bucket),
+			zap.String("prefix", prefix),
+			zap.Int("objects", n))
+	}
+
+	partitions, err := table.ExpiredPartitions(e.GlueClient, result.Cutoff)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to list expired partitions of %s.%s", table.DatabaseName(), table.TableName())
+	}
+	if err = table.DeletePartitions(e.GlueClient, partitions); err != nil {
+		return result, err
+	}
+	result.DeletedPartitions = len(partitions)
+
+	if err := e.expireArchives(table, result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// expireArchives removes the expired partitions of the tables archived by breaking schema changes.
+// Archives share the S3 location of the live table so their expired data is already deleted.
+// An archive is deleted once it was created before the cutoff, since all its partitions ended before it was created.
+func (e *Enforcer) expireArchives(table *awsglue.GlueTableMetadata, result *Result) error {
+	tableOutput, err := awsglue.GetTable(e.GlueClient, table.DatabaseName(), table.TableName())
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get table %s.%s", table.DatabaseName(), table.TableName())
+	}
+	for version := 1; version < awsglue.TableSchemaVersion(tableOutput.Table); version++ {
+		archive := table.ArchiveTable(version)
+		archiveOutput, err := awsglue.GetTable(e.GlueClient, archive.DatabaseName(), archive.TableName())
+		if err != nil {
+			if isNotFound(err) {
+				continue // already deleted or the table was updated without archiving
+			}
+			return errors.Wrapf(err, "failed to get table %s.%s", archive.DatabaseName(), archive.TableName())
+		}
+		if aws.TimeValue(archiveOutput.Table.CreateTime).Before(result.Cutoff) {
+			if _, err := awsglue.DeleteTable(e.GlueClient, archive.DatabaseName(), archive.TableName()); err != nil && !isNotFound(err) {
+				return errors.Wrapf(err, "failed to delete table %s.%s", archive.DatabaseName(), archive.TableName())
+			}
+			result.DeletedTables = append(result.DeletedTables, archive.TableName())
+			continue
+		}
+		partitions, err := archive.ExpiredPartitions(e.GlueClient, result.Cutoff)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list expired partitions of %s.%s", archive.DatabaseName(), archive.TableName())
+		}
+		if err := archive.DeletePartitions(e.GlueClient, partitions); err != nil {
+			return err
+		}
+		result.DeletedPartitions += len(partitions)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == glue.ErrCodeEntityNotFoundException
+}
+
+// expiredPrefixes walks the partition prefixes of a table and returns the topmost prefixes holding only expired data.
+// The prefix of a partition is expired if its values sort before the boundary values.
+func (e *Enforcer) expiredPrefixes(prefix string, keys []awsglue.PartitionKey, boundary []int) (expired []string, err error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(e.Bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+	var current []string // prefixes that can hold both expired and retained data
+	err = e.S3Client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, commonPrefix := range page.CommonPrefixes {
+			partitionPrefix := aws.StringValue(commonPrefix.Prefix)
+			// year=2020/
+			name := strings.TrimSuffix(strings.TrimPrefix(partitionPrefix, prefix), "/")
+			value := strings.TrimPrefix(name, keys[0].Name+"=")
+			if value == name {
+				continue // not a partition
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue // not a partition
+			}
+			switch {
+			case n < boundary[0]:
+				expired = append(expired, partitionPrefix)
+			case n == boundary[0]:
+				current = append(current, partitionPrefix)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list s3://%s/%s", e.Bucket, prefix)
+	}
+
+	for _, partitionPrefix := range current {
+		nested, err := e.expiredPrefixes(partitionPrefix, keys[1:], boundary[1:])
+		if err != nil {
+			return nil, err
+		}
+		expired = append(expired, nested...)
+	}
+	return expired, nil
+}
+
+// deletePrefix deletes all objects under a prefix and returns the number of objects deleted
+func (e *Enforcer) deletePrefix(prefix string) (deleted int, err error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(e.Bucket),
+		Prefix: aws.String(prefix),
+	}
+	var deleteErr error
+	err = e.S3Client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		if len(page.Contents) == 0 {
+			return true
+		}
+		// pages have at most 1000 keys, the limit of the DeleteObjects API
+		deleteInput := &s3.DeleteObjectsInput{
+			Bucket: aws.String(e.Bucket),
+			Delete: &s3.Delete{
+				Objects: make([]*s3.ObjectIdentifier, len(page.Contents)),
+				Quiet:   aws.Bool(true),
+			},
+		}
+		for i, object := range page.Contents {
+			deleteInput.Delete.Objects[i] = &s3.ObjectIdentifier{Key: object.Key}
+		}
+		output, err := e.S3Client.DeleteObjects(deleteInput)
+		if err != nil {
+			deleteErr = err
+			return false
+		}
+		if len(output.Errors) > 0 {
+			deleteErr = errors.Errorf("%d objects failed to delete, first failure %s: %s", len(output.Errors),
+				aws.StringValue(output.Errors[0].Key), aws.StringValue(output.Errors[0].Message))
+			deleted += len(page.Contents) - len(output.Errors)
+			return false
+		}
+		deleted += len(page.Contents)
+		return true
+	})
+	if err == nil {
+		err = deleteErr
+	}
+	if err != nil {
+		return deleted, errors.Wrapf(err, "failed to delete s3://%s/%s", e.Bucket, prefix)
+	}
+	return deleted, nil
+}
+
+func (e *Enforcer) currentTime() time.Time {
+	if e.now != nil {
+		return e.now()
+	}
+	return time.Now().UTC()
+}
@@ -0,0 +1,255 @@
+package retention
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const testBucket = "panther-bucket"
+
+var (
+	testTable = awsglue.NewGlueTableMetadata(models.LogData, "Test.Logs", "Test logs", awsglue.GlueTableHourly, testEvent{})
+	testNow   = time.Date(2020, 5, 6, 7, 30, 0, 0, time.UTC)
+)
+
+type testEvent struct {
+	Name string `json:"name" description:"test field"`
+}
+
+func listPrefixes(prefix string) *s3.ListObjectsV2Input {
+	return &s3.ListObjectsV2Input{
+		Bucket:    aws.String(testBucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+}
+
+func commonPrefixes(prefixes ...string) *s3.ListObjectsV2Output {
+	output := &s3.ListObjectsV2Output{}
+	for _, prefix := range prefixes {
+		output.CommonPrefixes = append(output.CommonPrefixes, &s3.CommonPrefix{Prefix: aws.String(prefix)})
+	}
+	return output
+}
+
+func listObjects(prefix string) *s3.ListObjectsV2Input {
+	return &s3.ListObjectsV2Input{
+		Bucket: aws.String(testBucket),
+		Prefix: aws.String(prefix),
+	}
+}
+
+func objects(keys ...string) *s3.ListObjectsV2Output {
+	output := &s3.ListObjectsV2Output{}
+	for _, key := range keys {
+		output.Contents = append(output.Contents, &s3.Object{Key: aws.String(key)})
+	}
+	return output
+}
+
+func getTable(name string) *glue.GetTableInput {
+	return &glue.GetTableInput{
+		DatabaseName: aws.String(testTable.DatabaseName()),
+		Name:         aws.String(name),
+	}
+}
+
+func table(createTime time.Time, version string) *glue.GetTableOutput {
+	return &glue.GetTableOutput{
+		Table: &glue.TableData{
+			CreateTime: aws.Time(createTime),
+			Parameters: map[string]*string{
+				awsglue.SchemaVersionParameter: aws.String(version),
+			},
+		},
+	}
+}
+
+func newTestEnforcer() (*Enforcer, *testutils.S3Mock, *testutils.GlueMock) {
+	s3Mock := &testutils.S3Mock{}
+	glueMock := &testutils.GlueMock{}
+	enforcer := New(s3Mock, glueMock, testBucket)
+	enforcer.now = func() time.Time { return testNow }
+	return enforcer, s3Mock, glueMock
+}
+
+func TestExpiredPrefixes(t *testing.T) {
+	enforcer, s3Mock, _ := newTestEnforcer()
+	const prefix = "logs/test_logs/"
+	s3Mock.On("ListObjectsV2Pages", listPrefixes(prefix), mock.Anything).Return(commonPrefixes(
+		prefix+"year=2019/",
+		prefix+"year=2020/",
+		prefix+"not_a_partition/",
+	), nil).Once()
+	s3Mock.On("ListObjectsV2Pages", listPrefixes(prefix+"year=2020/"), mock.Anything).Return(commonPrefixes(
+		prefix+"year=2020/month=03/",
+		prefix+"year=2020/month=04/",
+		prefix+"year=2020/month=05/",
+	), nil).Once()
+	s3Mock.On("ListObjectsV2Pages", listPrefixes(prefix+"year=2020/month=04/"), mock.Anything).Return(commonPrefixes(
+		prefix+"year=2020/month=04/day=05/",
+		prefix+"year=2020/month=04/day=06/",
+		prefix+"year=2020/month=04/day=07/",
+	), nil).Once()
+	s3Mock.On("ListObjectsV2Pages", listPrefixes(prefix+"year=2020/month=04/day=06/"), mock.Anything).Return(commonPrefixes(
+		prefix+"year=2020/month=04/day=06/hour=06/",
+		prefix+"year=2020/month=04/day=06/hour=07/",
+		prefix+"year=2020/month=04/day=06/hour=08/",
+	), nil).Once()
+
+	expired, err := enforcer.expiredPrefixes(prefix, testTable.PartitionKeys(), []int{2020, 4, 6, 7})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		prefix + "year=2019/",
+		prefix + "year=2020/month=03/",
+		prefix + "year=2020/month=04/day=05/",
+		prefix + "year=2020/month=04/day=06/hour=06/",
+	}, expired)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestDeletePrefix(t *testing.T) {
+	enforcer, s3Mock, _ := newTestEnforcer()
+	const prefix = "logs/test_logs/year=2019/"
+	s3Mock.On("ListObjectsV2Pages", listObjects(prefix), mock.Anything).Return(objects(
+		prefix+"month=01/day=01/hour=00/a.json.gz",
+		prefix+"month=01/day=01/hour=01/b.json.gz",
+	), nil).Once()
+	s3Mock.On("DeleteObjects", &s3.DeleteObjectsInput{
+		Bucket: aws.String(testBucket),
+		Delete: &s3.Delete{
+			Objects: []*s3.ObjectIdentifier{
+				{Key: aws.String(prefix + "month=01/day=01/hour=00/a.json.gz")},
+				{Key: aws.String(prefix + "month=01/day=01/hour=01/b.json.gz")},
+			},
+			Quiet: aws.Bool(true),
+		},
+	}).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+
+	deleted, err := enforcer.deletePrefix(prefix)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestDeletePrefixErrors(t *testing.T) {
+	enforcer, s3Mock, _ := newTestEnforcer()
+	const prefix = "logs/test_logs/year=2019/"
+	s3Mock.On("ListObjectsV2Pages", listObjects(prefix), mock.Anything).Return(objects(
+		prefix+"a.json.gz",
+		prefix+"b.json.gz",
+	), nil).Once()
+	s3Mock.On("DeleteObjects", mock.Anything).Return(&s3.DeleteObjectsOutput{
+		Errors: []*s3.Error{{Key: aws.String(prefix + "b.json.gz"), Message: aws.String("Access Denied")}},
+	}, nil).Once()
+
+	deleted, err := enforcer.deletePrefix(prefix)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Access Denied")
+	require.Equal(t, 1, deleted)
+	s3Mock.AssertExpectations(t)
+}
+
+func TestExpire(t *testing.T) {
+	enforcer, s3Mock, glueMock := newTestEnforcer()
+	const prefix = "logs/test_logs/"
+	s3Mock.On("ListObjectsV2Pages", listPrefixes(prefix), mock.Anything).Return(commonPrefixes(
+		prefix+"year=2019/",
+		prefix+"year=2020/",
+	), nil).Once()
+	s3Mock.On("ListObjectsV2Pages", listPrefixes(prefix+"year=2020/"), mock.Anything).Return(commonPrefixes(
+		prefix+"year=2020/month=05/",
+	), nil).Once()
+	s3Mock.On("ListObjectsV2Pages", listObjects(prefix+"year=2019/"), mock.Anything).Return(objects(
+		prefix+"year=2019/month=12/day=31/hour=23/a.json.gz",
+	), nil).Once()
+	s3Mock.On("DeleteObjects", mock.Anything).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+	glueMock.On("GetPartitions", mock.Anything).Return(&glue.GetPartitionsOutput{
+		Partitions: []*glue.Partition{
+			{Values: aws.StringSlice([]string{"2019", "12", "31", "23"})},
+			{Values: aws.StringSlice([]string{"2020", "01", "01", "00"})},
+		},
+	}, nil).Once()
+	glueMock.On("BatchDeletePartition", mock.Anything).Return(&glue.BatchDeletePartitionOutput{}, nil).Once()
+	glueMock.On("GetTable", getTable(testTable.TableName())).Return(table(testNow.AddDate(-1, 0, 0), "1"), nil).Once()
+
+	result, err := enforcer.Expire(testTable, 30)
+	require.NoError(t, err)
+	require.Equal(t, &Result{
+		DatabaseName:      testTable.DatabaseName(),
+		TableName:         testTable.TableName(),
+		Cutoff:            time.Date(2020, 4, 6, 7, 30, 0, 0, time.UTC),
+		DeletedPrefixes:   []string{prefix + "year=2019/"},
+		DeletedObjects:    1,
+		DeletedPartitions: 2,
+	}, result)
+	s3Mock.AssertExpectations(t)
+	glueMock.AssertExpectations(t)
+}
+
+func TestExpireArchives(t *testing.T) {
+	enforcer, _, glueMock := newTestEnforcer()
+	result := &Result{Cutoff: testNow.AddDate(0, 0, -30)}
+	glueMock.On("GetTable", getTable(testTable.TableName())).Return(table(testNow.AddDate(-1, 0, 0), "4"), nil).Once()
+	// archived before the cutoff, all data expired
+	glueMock.On("GetTable", getTable(testTable.TableName()+"_v1")).Return(table(testNow.AddDate(0, -6, 0), "1"), nil).Once()
+	glueMock.On("DeleteTable", &glue.DeleteTableInput{
+		DatabaseName: aws.String(testTable.DatabaseName()),
+		Name:         aws.String(testTable.TableName() + "_v1"),
+	}).Return(&glue.DeleteTableOutput{}, nil).Once()
+	// already deleted
+	glueMock.On("GetTable", getTable(testTable.TableName()+"_v2")).Return(
+		(*glue.GetTableOutput)(nil), awserr.New(glue.ErrCodeEntityNotFoundException, "not found", nil)).Once()
+	// archived after the cutoff, only some partitions expired
+	glueMock.On("GetTable", getTable(testTable.TableName()+"_v3")).Return(table(testNow.AddDate(0, 0, -7), "3"), nil).Once()
+	glueMock.On("GetPartitions", mock.MatchedBy(func(input *glue.GetPartitionsInput) bool {
+		return aws.StringValue(input.TableName) == testTable.TableName()+"_v3"
+	})).Return(&glue.GetPartitionsOutput{
+		Partitions: []*glue.Partition{
+			{Values: aws.StringSlice([]string{"2020", "04", "01", "00"})},
+		},
+	}, nil).Once()
+	glueMock.On("BatchDeletePartition", mock.MatchedBy(func(input *glue.BatchDeletePartitionInput) bool {
+		return aws.StringValue(input.TableName) == testTable.TableName()+"_v3"
+	})).Return(&glue.BatchDeletePartitionOutput{}, nil).Once()
+
+	require.NoError(t, enforcer.expireArchives(testTable, result))
+	require.Equal(t, []string{testTable.TableName() + "_v1"}, result.DeletedTables)
+	require.Equal(t, 1, result.DeletedPartitions)
+	glueMock.AssertExpectations(t)
+}
+
+func TestExpireInvalidRetention(t *testing.T) {
+	enforcer, _, _ := newTestEnforcer()
+	_, err := enforcer.Expire(testTable, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,114 @@
+package main
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	analysisclient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/api/gateway/analysis/client/operations"
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/common"
+	"github.com/panther-labs/panther/internal/log_analysis/scheduled_queries/scheduler"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/lambdalogger"
+)
+
+// The panther-scheduled-queries lambda runs every minute, running the scheduled queries that are due.
+//
+// Each result row is stored in the alert dedup table, where the alert forwarder turns it into an alert.
+
+type envConfig struct {
+	AlertsDedupTable string `required:"true" split_words:"true"`
+	AnalysisAPIHost  string `required:"true" split_words:"true"`
+	AnalysisAPIPath  string `required:"true" split_words:"true"`
+}
+
+var (
+	sched          *scheduler.Scheduler
+	httpClient     *http.Client
+	analysisClient *analysisclient.PantherAnalysisAPI
+)
+
+func handle(ctx context.Context, event events.CloudWatchEvent) (err error) {
+	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
+	operation := common.OpLogManager.Start(lc.InvokedFunctionArn, common.OpLogLambdaServiceDim).WithMemUsed(lambdacontext.MemoryLimitInMB)
+	stats := &scheduler.Stats{}
+	defer func() {
+		operation.Stop().Log(err,
+			zap.Int("queriesRun", stats.QueriesRun),
+			zap.Int("queriesFailed", stats.QueriesFailed),
+			zap.Int("rows", stats.Rows))
+	}()
+
+	response, err := analysisClient.Operations.GetEnabledPolicies(&operations.GetEnabledPoliciesParams{
+		HTTPClient: httpClient,
+		Type:       string(models.AnalysisTypeSCHEDULEDQUERY),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list scheduled queries")
+	}
+
+	// the scheduled time of the event, the lambda may start a few seconds later
+	scheduledAt := event.Time
+	if scheduledAt.IsZero() {
+		scheduledAt = time.Now()
+	}
+	result, err := sched.Run(response.Payload.Policies, scheduledAt)
+	if result != nil {
+		stats = result
+	}
+	if err != nil {
+		// The failed queries are logged, returning an error would make Lambda retry the invocation with the same
+		// scheduled time and run the queries that succeeded again, storing their alerts twice.
+		zap.L().Error("scheduled queries failed", zap.Error(err))
+	}
+	return nil
+}
+
+func main() {
+	var env envConfig
+	envconfig.MustProcess("", &env)
+
+	awsSession := session.Must(session.NewSession())
+	sched = &scheduler.Scheduler{
+		AthenaClient:     athena.New(awsSession),
+		DdbClient:        dynamodb.New(awsSession),
+		Database:         awsglue.LogProcessingDatabaseName,
+		AlertsDedupTable: env.AlertsDedupTable,
+	}
+	httpClient = gatewayapi.GatewayClient(awsSession)
+	analysisClient = analysisclient.NewHTTPClientWithConfig(nil, analysisclient.DefaultTransportConfig().
+		WithHost(env.AnalysisAPIHost).
+		WithBasePath(env.AnalysisAPIPath))
+	lambda.Start(handle)
+}
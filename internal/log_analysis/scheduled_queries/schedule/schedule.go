@@ -0,0 +1,190 @@
+package schedule
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Schedule decides when a scheduled query runs.
+//
+// Schedules have a resolution of one minute and are evaluated in UTC.
+type Schedule interface {
+	// Due returns true if a run is scheduled at the minute of t
+	Due(t time.Time) bool
+}
+
+// Parse parses a schedule expression, either:
+//
+//	rate(<value> <unit>) where unit is minute(s), hour(s) or day(s), e.g. rate(15 minutes)
+//	cron(<minute> <hour> <day of month> <month> <day of week>), e.g. cron(0 */6 * * 1-5)
+func Parse(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	switch {
+	case strings.HasPrefix(expr, "rate(") && strings.HasSuffix(expr, ")"):
+		return parseRate(expr[len("rate(") : len(expr)-1])
+	case strings.HasPrefix(expr, "cron(") && strings.HasSuffix(expr, ")"):
+		return parseCron(expr[len("cron(") : len(expr)-1])
+	default:
+		return nil, errors.Errorf("invalid schedule %q: expected rate(...) or cron(...)", expr)
+	}
+}
+
+// rate runs every period, aligned to the unix epoch (e.g. rate(1 day) runs at midnight UTC)
+type rate struct {
+	minutes int64
+}
+
+func parseRate(expr string) (*rate, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 2 {
+		return nil, errors.Errorf("invalid rate %q: expected <value> <unit>", expr)
+	}
+	value, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || value < 1 {
+		return nil, errors.Errorf("invalid rate value %q", fields[0])
+	}
+	switch unit := strings.TrimSuffix(fields[1], "s"); unit {
+	case "minute":
+	case "hour":
+		value *= 60
+	case "day":
+		value *= 24 * 60
+	default:
+		return nil, errors.Errorf("invalid rate unit %q", fields[1])
+	}
+	return &rate{minutes: value}, nil
+}
+
+func (r *rate) Due(t time.Time) bool {
+	return (t.Unix()/60)%r.minutes == 0
+}
+
+// cron runs at the minutes matching all of its fields
+type cron struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// if both day fields are restricted a day matches either of them
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7}, // 0 and 7 are Sunday
+}
+
+func parseCron(expr string) (*cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, errors.Errorf("invalid cron %q: expected <minute> <hour> <day of month> <month> <day of week>", expr)
+	}
+	var bits [5]uint64
+	for i, field := range fields {
+		b, err := cronFields[i].parse(field)
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+	// Sunday is both 0 and 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &cron{
+		minute:        bits[0],
+		hour:          bits[1],
+		dayOfMonth:    bits[2],
+		month:         bits[3],
+		dayOfWeek:     bits[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}, nil
+}
+
+// parse parses a comma separated list of values, ranges (a-b) or wildcards with optional steps (*/n, a-b/n)
+func (f *cronField) parse(expr string) (bits uint64, err error) {
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, step := item, 1
+		if i := strings.IndexByte(item, '/'); i != -1 {
+			rangeExpr = item[:i]
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step < 1 {
+				return 0, errors.Errorf("invalid %s step %q", f.name, item)
+			}
+		}
+		low, high := f.min, f.max
+		if rangeExpr != "*" {
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = f.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				high = f.max // a/n means from a to the end
+			}
+			if high < low {
+				return 0, errors.Errorf("invalid %s range %q", f.name, item)
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f *cronField) value(s string) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.Errorf("invalid %s %q: expected a value from %d to %d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (c *cron) Due(t time.Time) bool {
+	t = t.UTC()
+	if c.minute&(1<<uint(t.Minute())) == 0 || c.hour&(1<<uint(t.Hour())) == 0 || c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	dayOfMonth := c.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := c.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if c.anyDayOfMonth || c.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
@@ -0,0 +1,92 @@
+package schedule
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, expr string) Schedule {
+	s, err := Parse(expr)
+	require.NoError(t, err)
+	return s
+}
+
+func TestRate(t *testing.T) {
+	s := mustParse(t, "rate(15 minutes)")
+	require.True(t, s.Due(time.Date(2020, 6, 17, 10, 0, 0, 0, time.UTC)))
+	require.True(t, s.Due(time.Date(2020, 6, 17, 10, 45, 30, 0, time.UTC)))
+	require.False(t, s.Due(time.Date(2020, 6, 17, 10, 50, 0, 0, time.UTC)))
+
+	s = mustParse(t, "rate(1 hour)")
+	require.True(t, s.Due(time.Date(2020, 6, 17, 10, 0, 0, 0, time.UTC)))
+	require.False(t, s.Due(time.Date(2020, 6, 17, 10, 1, 0, 0, time.UTC)))
+
+	s = mustParse(t, "rate(1 day)")
+	require.True(t, s.Due(time.Date(2020, 6, 17, 0, 0, 0, 0, time.UTC)))
+	require.False(t, s.Due(time.Date(2020, 6, 17, 1, 0, 0, 0, time.UTC)))
+}
+
+func TestCron(t *testing.T) {
+	// every 6 hours on weekdays
+	s := mustParse(t, "cron(0 */6 * * 1-5)")
+	require.True(t, s.Due(time.Date(2020, 6, 17, 12, 0, 0, 0, time.UTC))) // Wednesday
+	require.False(t, s.Due(time.Date(2020, 6, 17, 13, 0, 0, 0, time.UTC)))
+	require.False(t, s.Due(time.Date(2020, 6, 17, 12, 1, 0, 0, time.UTC)))
+	require.False(t, s.Due(time.Date(2020, 6, 20, 12, 0, 0, 0, time.UTC))) // Saturday
+
+	// lists and Sunday as 7
+	s = mustParse(t, "cron(5,35 8 * 1,6 7)")
+	require.True(t, s.Due(time.Date(2020, 6, 21, 8, 35, 0, 0, time.UTC))) // Sunday
+	require.False(t, s.Due(time.Date(2020, 6, 21, 8, 36, 0, 0, time.UTC)))
+	require.False(t, s.Due(time.Date(2020, 7, 5, 8, 35, 0, 0, time.UTC))) // Sunday in July
+
+	// restricted days match either the day of the month or the day of the week
+	s = mustParse(t, "cron(0 0 1 * 1)")
+	require.True(t, s.Due(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)))  // Wednesday the 1st
+	require.True(t, s.Due(time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC)))  // Monday
+	require.False(t, s.Due(time.Date(2020, 7, 7, 0, 0, 0, 0, time.UTC))) // Tuesday
+
+	// steps from a value
+	s = mustParse(t, "cron(10/20 * * * *)")
+	require.True(t, s.Due(time.Date(2020, 7, 7, 3, 50, 0, 0, time.UTC)))
+	require.False(t, s.Due(time.Date(2020, 7, 7, 3, 0, 0, 0, time.UTC)))
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"every hour",
+		"rate(0 minutes)",
+		"rate(5 weeks)",
+		"rate(five minutes)",
+		"cron(* * * *)",
+		"cron(60 * * * *)",
+		"cron(* * 0 * *)",
+		"cron(* 5-1 * * *)",
+		"cron(*/0 * * * *)",
+		"cron(? * * * *)",
+	} {
+		_, err := Parse(expr)
+		require.Error(t, err, expr)
+	}
+}
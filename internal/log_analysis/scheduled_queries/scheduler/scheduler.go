@@ -0,0 +1,314 @@
+package scheduler
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"crypto/md5" // nolint(gosec)
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/athena/athenaiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/internal/log_analysis/scheduled_queries/schedule"
+	"github.com/panther-labs/panther/pkg/awsathena"
+)
+
+// Placeholders replaced in the SQL of a scheduled query before it runs
+const (
+	// The start of the lookback window as a timestamp literal
+	StartTimePlaceholder = "{start_time}"
+	// The end of the lookback window (the scheduled time) as a timestamp literal
+	EndTimePlaceholder = "{end_time}"
+	// A condition selecting the hourly partitions of the lookback window
+	PartitionFilterPlaceholder = "{partition_filter}"
+)
+
+// Result columns with a special meaning, every other column is part of the default dedup string
+const (
+	// The dedup string of the alert, rows with the same dedup string are grouped in the same alert
+	DedupColumn = "p_alert_dedup"
+	// The title of the alert
+	TitleColumn = "p_alert_title"
+)
+
+const (
+	// Alert dedup table attributes, these must match the ones written by the rules engine
+	dedupTablePartitionKey     = "partitionKey"
+	dedupRuleIDAttribute       = "ruleId"
+	dedupRuleVersionAttribute  = "ruleVersion"
+	dedupStringAttribute       = "dedup"
+	dedupCreationTimeAttribute = "alertCreationTime"
+	dedupUpdateTimeAttribute   = "alertUpdateTime"
+	dedupAlertCountAttribute   = "alertCount"
+	dedupEventCountAttribute   = "eventCount"
+	dedupLogTypesAttribute     = "logTypes"
+	dedupTitleAttribute        = "title"
+	dedupAnalysisTypeAttribute = "analysisType"
+)
+
+// Scheduler runs the scheduled queries that are due and turns their results into alerts
+type Scheduler struct {
+	AthenaClient athenaiface.AthenaAPI
+	DdbClient    dynamodbiface.DynamoDBAPI
+	// The database queries run in, tables in other databases must be fully qualified
+	Database string
+	// The S3 path for query results, the workgroup default is used if nil
+	AthenaResultsPath *string
+	// The alert dedup table shared with the rules engine
+	AlertsDedupTable string
+	// The maximum time to wait for the queries of a run, queries still running after that are stopped.
+	// If zero DefaultQueryTimeout is used.
+	QueryTimeout time.Duration
+}
+
+// DefaultQueryTimeout leaves time to store the alerts of the queries within the 5 minute timeout of the lambda
+const DefaultQueryTimeout = 4 * time.Minute
+
+// Stats are the counts of a scheduler run
+type Stats struct {
+	QueriesRun    int
+	QueriesFailed int
+	Rows          int
+}
+
+type runningQuery struct {
+	query            *models.EnabledPolicy
+	queryExecutionID string
+}
+
+// Run runs the queries due at the minute of now.
+//
+// All due queries are started before waiting for any of them, so they share the QueryTimeout.
+// A failed query does not prevent the others from generating alerts, an error is returned after all queries are done.
+func (s *Scheduler) Run(queries []*models.EnabledPolicy, now time.Time) (*Stats, error) {
+	now = now.UTC().Truncate(time.Minute)
+	stats := &Stats{}
+
+	timeout := s.QueryTimeout
+	if timeout == 0 {
+		timeout = DefaultQueryTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	running := make([]runningQuery, 0, len(queries))
+	for _, query := range queries {
+		sched, err := schedule.Parse(string(query.Schedule))
+		if err != nil {
+			// the analysis api validates schedules, this is not expected
+			zap.L().Error("invalid schedule", zap.String("queryId", string(query.ID)), zap.Error(err))
+			stats.QueriesFailed++
+			continue
+		}
+		if !sched.Due(now) {
+			continue
+		}
+
+		sql := QuerySQL(string(query.Body), now, time.Duration(query.LookbackMinutes)*time.Minute)
+		zap.L().Debug("starting scheduled query", zap.String("queryId", string(query.ID)), zap.String("sql", sql))
+		startOutput, err := awsathena.StartQuery(s.AthenaClient, s.Database, sql, s.AthenaResultsPath)
+		if err != nil {
+			zap.L().Error("failed to start scheduled query", zap.String("queryId", string(query.ID)), zap.Error(err))
+			stats.QueriesFailed++
+			continue
+		}
+		running = append(running, runningQuery{
+			query:            query,
+			queryExecutionID: aws.StringValue(startOutput.QueryExecutionId),
+		})
+	}
+
+	for _, r := range running {
+		rows, err := s.alert(ctx, r, now)
+		stats.Rows += rows
+		if err != nil {
+			zap.L().Error("scheduled query failed",
+				zap.String("queryId", string(r.query.ID)),
+				zap.String("queryExecutionId", r.queryExecutionID),
+				zap.Error(err))
+			stats.QueriesFailed++
+			continue
+		}
+		stats.QueriesRun++
+	}
+
+	if stats.QueriesFailed > 0 {
+		return stats, errors.Errorf("%d scheduled queries failed", stats.QueriesFailed)
+	}
+	return stats, nil
+}
+
+// alert waits for a query and stores an alert dedup event for each result row.
+// If ctx is done before the query completes the query is stopped.
+func (s *Scheduler) alert(ctx context.Context, r runningQuery, now time.Time) (int, error) {
+	if _, err := awsathena.Wait(ctx, s.AthenaClient, r.queryExecutionID); err != nil {
+		return 0, err
+	}
+	results, err := awsathena.Results(s.AthenaClient, r.queryExecutionID, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	if results.NextToken != nil {
+		// a scheduled query should return few rows (e.g. an aggregation), more are most likely a mistake
+		zap.L().Warn("scheduled query returned more than one page of results, ignoring the rest",
+			zap.String("queryId", string(r.query.ID)))
+	}
+
+	rows := results.ResultSet.Rows
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	// the first row has the column names
+	columns := rowValues(rows[0])
+	for i, row := range rows[1:] {
+		dedup, title := rowAlert(columns, rowValues(row))
+		if err := s.updateAlertDedup(r.query, dedup, title, now); err != nil {
+			return i, err
+		}
+	}
+	return len(rows) - 1, nil
+}
+
+// QuerySQL replaces the placeholders in the SQL of a scheduled query for a run at a time
+func QuerySQL(sql string, scheduledAt time.Time, lookback time.Duration) string {
+	endTime := scheduledAt.UTC()
+	startTime := endTime.Add(-lookback)
+	return strings.NewReplacer(
+		StartTimePlaceholder, timestampLiteral(startTime),
+		EndTimePlaceholder, timestampLiteral(endTime),
+		PartitionFilterPlaceholder, fmt.Sprintf("year*1000000+month*10000+day*100+hour BETWEEN %s AND %s",
+			startTime.Format("2006010215"), endTime.Format("2006010215")),
+	).Replace(sql)
+}
+
+func timestampLiteral(t time.Time) string {
+	return "timestamp " + awsathena.QuoteString(t.Format(awsathena.TimestampLayout))
+}
+
+func rowValues(row *athena.Row) []string {
+	values := make([]string, len(row.Data))
+	for i, datum := range row.Data {
+		values[i] = aws.StringValue(datum.VarCharValue)
+	}
+	return values
+}
+
+// rowAlert returns the dedup string and the (optional) title of the alert for a result row
+func rowAlert(columns, values []string) (dedup string, title *string) {
+	var defaultDedup []string
+	for i, column := range columns {
+		if i >= len(values) {
+			break
+		}
+		switch column {
+		case DedupColumn:
+			dedup = values[i]
+		case TitleColumn:
+			if values[i] != "" {
+				title = aws.String(values[i])
+			}
+		default:
+			defaultDedup = append(defaultDedup, values[i])
+		}
+	}
+	if dedup == "" {
+		dedup = strings.Join(defaultDedup, ":")
+	}
+	return dedup, title
+}
+
+// updateAlertDedup stores an event in the alert dedup table the same way the rules engine does.
+//
+// The first event of a dedup string in a dedup period starts a new alert, the following ones are merged in it.
+func (s *Scheduler) updateAlertDedup(query *models.EnabledPolicy, dedup string, title *string, now time.Time) error {
+	key := map[string]*dynamodb.AttributeValue{
+		dedupTablePartitionKey: {S: aws.String(dedupKey(string(query.ID), dedup))},
+	}
+	logTypes := &dynamodb.AttributeValue{SS: aws.StringSlice(query.ResourceTypes)}
+
+	dedupPeriod := time.Duration(query.DedupPeriodMinutes) * time.Minute
+	condition := expression.Name(dedupCreationTimeAttribute).LessThan(expression.Value(now.Add(-dedupPeriod).Unix())).
+		Or(expression.AttributeNotExists(expression.Name(dedupTablePartitionKey)))
+	update := expression.
+		Add(expression.Name(dedupAlertCountAttribute), expression.Value(1)).
+		Set(expression.Name(dedupRuleIDAttribute), expression.Value(query.ID)).
+		Set(expression.Name(dedupRuleVersionAttribute), expression.Value(query.VersionID)).
+		Set(expression.Name(dedupStringAttribute), expression.Value(dedup)).
+		Set(expression.Name(dedupCreationTimeAttribute), expression.Value(now.Unix())).
+		Set(expression.Name(dedupUpdateTimeAttribute), expression.Value(now.Unix())).
+		Set(expression.Name(dedupEventCountAttribute), expression.Value(1)).
+		Set(expression.Name(dedupLogTypesAttribute), expression.Value(logTypes)).
+		Set(expression.Name(dedupAnalysisTypeAttribute), expression.Value(models.AnalysisTypeSCHEDULEDQUERY))
+	if title != nil {
+		update = update.Set(expression.Name(dedupTitleAttribute), expression.Value(*title))
+	}
+	expr, err := expression.NewBuilder().WithCondition(condition).WithUpdate(update).Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build alert dedup update")
+	}
+	_, err = s.DdbClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 &s.AlertsDedupTable,
+		Key:                       key,
+		ConditionExpression:       expr.Condition(),
+		UpdateExpression:          expr.Update(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err == nil {
+		return nil
+	}
+	if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+		return errors.Wrap(err, "failed to create alert dedup event")
+	}
+
+	// The alert exists and is in its dedup period, merge the event in it
+	update = expression.
+		Set(expression.Name(dedupUpdateTimeAttribute), expression.Value(now.Unix())).
+		Add(expression.Name(dedupEventCountAttribute), expression.Value(1)).
+		Add(expression.Name(dedupLogTypesAttribute), expression.Value(logTypes))
+	if expr, err = expression.NewBuilder().WithUpdate(update).Build(); err != nil {
+		return errors.Wrap(err, "failed to build alert dedup update")
+	}
+	_, err = s.DdbClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 &s.AlertsDedupTable,
+		Key:                       key,
+		UpdateExpression:          expr.Update(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	return errors.Wrap(err, "failed to update alert dedup event")
+}
+
+// dedupKey is the key of the alert dedup table, it must match the one of the rules engine
+func dedupKey(id, dedup string) string {
+	keyHash := md5.Sum([]byte(id + ":" + dedup)) // nolint(gosec)
+	return hex.EncodeToString(keyHash[:])
+}
@@ -0,0 +1,199 @@
+package scheduler
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var testNow = time.Date(2020, 6, 17, 10, 0, 42, 0, time.UTC)
+
+func TestQuerySQL(t *testing.T) {
+	sql := QuerySQL("SELECT * FROM t WHERE {partition_filter} AND p_event_time >= {start_time} AND p_event_time < {end_time}",
+		testNow.Truncate(time.Minute), 90*time.Minute)
+	require.Equal(t, "SELECT * FROM t WHERE year*1000000+month*10000+day*100+hour BETWEEN 2020061708 AND 2020061710"+
+		" AND p_event_time >= timestamp '2020-06-17 08:30:00.000' AND p_event_time < timestamp '2020-06-17 10:00:00.000'", sql)
+}
+
+func TestRowAlert(t *testing.T) {
+	dedup, title := rowAlert([]string{"user", "country_count"}, []string{"alice", "4"})
+	require.Equal(t, "alice:4", dedup)
+	require.Nil(t, title)
+
+	dedup, title = rowAlert([]string{"p_alert_dedup", "country_count", "p_alert_title"}, []string{"alice", "4", "Alice in 4 countries"})
+	require.Equal(t, "alice", dedup)
+	require.Equal(t, "Alice in 4 countries", aws.StringValue(title))
+}
+
+func testRow(values ...string) *athena.Row {
+	row := &athena.Row{}
+	for _, value := range values {
+		row.Data = append(row.Data, &athena.Datum{VarCharValue: aws.String(value)})
+	}
+	return row
+}
+
+func TestRun(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	ddbMock := &testutils.DynamoDBMock{}
+	s := &Scheduler{
+		AthenaClient:     athenaMock,
+		DdbClient:        ddbMock,
+		Database:         "panther_logs",
+		AlertsDedupTable: "panther-alerts-dedup",
+	}
+	queries := []*models.EnabledPolicy{
+		{
+			ID:                 "Query.Due",
+			Body:               "SELECT user AS p_alert_dedup FROM aws_cloudtrail WHERE {partition_filter}",
+			DedupPeriodMinutes: 60,
+			LookbackMinutes:    60,
+			ResourceTypes:      []string{"AWS.CloudTrail"},
+			Schedule:           "rate(1 hour)",
+			VersionID:          "v1",
+		},
+		{
+			ID:       "Query.NotDue",
+			Body:     "SELECT 1",
+			Schedule: "cron(30 * * * *)",
+		},
+	}
+
+	athenaMock.On("StartQueryExecution", mock.MatchedBy(func(input *athena.StartQueryExecutionInput) bool {
+		return aws.StringValue(input.QueryString) ==
+			"SELECT user AS p_alert_dedup FROM aws_cloudtrail WHERE year*1000000+month*10000+day*100+hour BETWEEN 2020061709 AND 2020061710"
+	})).Return(&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("execution")}, nil).Once()
+	athenaMock.On("GetQueryExecutionWithContext", mock.Anything, mock.Anything, mock.Anything).Return(&athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String("execution"),
+			Status:           &athena.QueryExecutionStatus{State: aws.String(athena.QueryExecutionStateSucceeded)},
+		},
+	}, nil).Once()
+	athenaMock.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{
+		ResultSet: &athena.ResultSet{
+			Rows: []*athena.Row{testRow("p_alert_dedup"), testRow("alice"), testRow("bob")},
+		},
+	}, nil).Once()
+
+	// alice starts a new alert
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		values := input.ExpressionAttributeValues
+		return aws.StringValue(input.Key["partitionKey"].S) == dedupKey("Query.Due", "alice") &&
+			input.ConditionExpression != nil &&
+			hasValue(values, &dynamodb.AttributeValue{S: aws.String("SCHEDULED_QUERY")}) &&
+			hasValue(values, &dynamodb.AttributeValue{SS: aws.StringSlice([]string{"AWS.CloudTrail"})}) &&
+			hasValue(values, &dynamodb.AttributeValue{N: aws.String("1592388000")})
+	})).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	// bob is merged in an existing alert
+	isBob := mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return aws.StringValue(input.Key["partitionKey"].S) == dedupKey("Query.Due", "bob")
+	})
+	ddbMock.On("UpdateItem", isBob).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "exists", nil)).Once()
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return aws.StringValue(input.Key["partitionKey"].S) == dedupKey("Query.Due", "bob") && input.ConditionExpression == nil
+	})).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	stats, err := s.Run(queries, testNow)
+	require.NoError(t, err)
+	require.Equal(t, &Stats{QueriesRun: 1, Rows: 2}, stats)
+	athenaMock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+	ddbMock.AssertNumberOfCalls(t, "UpdateItem", 3)
+}
+
+func TestRunQueryFailed(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	s := &Scheduler{
+		AthenaClient:     athenaMock,
+		DdbClient:        &testutils.DynamoDBMock{},
+		Database:         "panther_logs",
+		AlertsDedupTable: "panther-alerts-dedup",
+	}
+	queries := []*models.EnabledPolicy{
+		{ID: "Query.Fails", Body: "SELECT nope", Schedule: "rate(1 minute)"},
+	}
+
+	athenaMock.On("StartQueryExecution", mock.Anything).
+		Return(&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("execution")}, nil).Once()
+	athenaMock.On("GetQueryExecutionWithContext", mock.Anything, mock.Anything, mock.Anything).Return(&athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String("execution"),
+			Status: &athena.QueryExecutionStatus{
+				State:             aws.String(athena.QueryExecutionStateFailed),
+				StateChangeReason: aws.String("COLUMN_NOT_FOUND"),
+			},
+		},
+	}, nil).Once()
+
+	stats, err := s.Run(queries, testNow)
+	require.Error(t, err)
+	require.Equal(t, &Stats{QueriesFailed: 1}, stats)
+	athenaMock.AssertExpectations(t)
+}
+
+func TestRunQueryTimeout(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	s := &Scheduler{
+		AthenaClient:     athenaMock,
+		DdbClient:        &testutils.DynamoDBMock{},
+		Database:         "panther_logs",
+		AlertsDedupTable: "panther-alerts-dedup",
+		QueryTimeout:     time.Nanosecond,
+	}
+	queries := []*models.EnabledPolicy{
+		{ID: "Query.Slow", Body: "SELECT 1", Schedule: "rate(1 minute)"},
+	}
+
+	athenaMock.On("StartQueryExecution", mock.Anything).
+		Return(&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("execution")}, nil).Once()
+	athenaMock.On("GetQueryExecutionWithContext", mock.Anything, mock.Anything, mock.Anything).Return(&athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String("execution"),
+			Status:           &athena.QueryExecutionStatus{State: aws.String(athena.QueryExecutionStateRunning)},
+		},
+	}, nil).Once()
+	// the query is stopped when the timeout has passed
+	athenaMock.On("StopQueryExecution", mock.Anything).Return(&athena.StopQueryExecutionOutput{}, nil).Once()
+
+	stats, err := s.Run(queries, testNow)
+	require.Error(t, err)
+	require.Equal(t, &Stats{QueriesFailed: 1}, stats)
+	athenaMock.AssertExpectations(t)
+}
+
+func hasValue(values map[string]*dynamodb.AttributeValue, expect *dynamodb.AttributeValue) bool {
+	for _, value := range values {
+		if value.String() == expect.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package awsathena
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TimestampLayout is the layout of timestamp values in query results and of timestamp literals
+	TimestampLayout = "2006-01-02 15:04:05.000"
+	// DateLayout is the layout of date values in query results and of date literals
+	DateLayout = "2006-01-02"
+)
+
+// DecodeValue decodes a result value to a Go type using the type of its column:
+//
+//	boolean                              bool
+//	tinyint, smallint, integer, bigint   int64
+//	real, float, double                  float64
+//	date, timestamp                      time.Time (UTC)
+//	NULL                                 nil
+//
+// Values of all other types (varchar, decimal, array, map, row, json, ...) are returned as strings.
+func DecodeValue(column *athena.ColumnInfo, value *string) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	var (
+		result interface{}
+		err    error
+	)
+	switch strings.ToLower(aws.StringValue(column.Type)) {
+	case "boolean":
+		result, err = strconv.ParseBool(*value)
+	case "tinyint", "smallint", "integer", "bigint":
+		result, err = strconv.ParseInt(*value, 10, 64)
+	case "real", "float", "double":
+		result, err = strconv.ParseFloat(*value, 64)
+	case "date":
+		result, err = time.Parse(DateLayout, *value)
+	case "timestamp":
+		result, err = time.Parse(TimestampLayout, *value)
+	default:
+		return *value, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s value in column %s", aws.StringValue(column.Type), aws.StringValue(column.Name))
+	}
+	return result, nil
+}
+
+// Scan copies the values of the current row into the values pointed at by dest.
+//
+// Supported destinations are *interface{}, *string (the text value), *int64, *int, *float64, *bool and *time.Time.
+// NULL values set the destination to its zero value.
+func (r *Rows) Scan(dest ...interface{}) error {
+	values, err := r.Values()
+	if err != nil {
+		return err
+	}
+	if len(dest) != len(values) {
+		return errors.Errorf("expected %d destinations, got %d", len(values), len(dest))
+	}
+	for i, d := range dest {
+		if err := scanValue(d, values[i], r.current.Data[i].VarCharValue); err != nil {
+			return errors.Wrapf(err, "column %s", aws.StringValue(r.columns[i].Name))
+		}
+	}
+	return nil
+}
+
+func scanValue(dest, value interface{}, text *string) error {
+	switch d := dest.(type) {
+	case *interface{}:
+		*d = value
+		return nil
+	case *string:
+		*d = aws.StringValue(text)
+		return nil
+	}
+	if value == nil {
+		switch d := dest.(type) {
+		case *int64:
+			*d = 0
+		case *int:
+			*d = 0
+		case *float64:
+			*d = 0
+		case *bool:
+			*d = false
+		case *time.Time:
+			*d = time.Time{}
+		default:
+			return errors.Errorf("unsupported destination %T", dest)
+		}
+		return nil
+	}
+	ok := false
+	switch d := dest.(type) {
+	case *int64:
+		*d, ok = value.(int64)
+	case *int:
+		var n int64
+		n, ok = value.(int64)
+		*d = int(n)
+	case *float64:
+		switch v := value.(type) {
+		case float64:
+			*d, ok = v, true
+		case int64:
+			*d, ok = float64(v), true
+		}
+	case *bool:
+		*d, ok = value.(bool)
+	case *time.Time:
+		*d, ok = value.(time.Time)
+	default:
+		return errors.Errorf("unsupported destination %T", dest)
+	}
+	if !ok {
+		return errors.Errorf("cannot scan %T into %T", value, dest)
+	}
+	return nil
+}
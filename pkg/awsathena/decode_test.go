@@ -0,0 +1,71 @@
+package awsathena
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeValue(t *testing.T) {
+	for _, tc := range []struct {
+		columnType string
+		value      *string
+		expect     interface{}
+	}{
+		{"boolean", aws.String("true"), true},
+		{"integer", aws.String("-42"), int64(-42)},
+		{"bigint", aws.String("9007199254740993"), int64(9007199254740993)},
+		{"double", aws.String("1.5"), 1.5},
+		{"date", aws.String("2020-06-17"), time.Date(2020, 6, 17, 0, 0, 0, 0, time.UTC)},
+		{"timestamp", aws.String("2020-06-17 10:01:02.123"), time.Date(2020, 6, 17, 10, 1, 2, 123000000, time.UTC)},
+		{"varchar", aws.String("text"), "text"},
+		{"decimal", aws.String("10.00"), "10.00"},
+		{"array", aws.String("[1, 2]"), "[1, 2]"},
+		{"bigint", nil, nil},
+	} {
+		column := &athena.ColumnInfo{Name: aws.String("c"), Type: aws.String(tc.columnType)}
+		value, err := DecodeValue(column, tc.value)
+		require.NoError(t, err, tc.columnType)
+		require.Equal(t, tc.expect, value, tc.columnType)
+	}
+
+	_, err := DecodeValue(&athena.ColumnInfo{Name: aws.String("c"), Type: aws.String("integer")}, aws.String("x"))
+	require.Error(t, err)
+}
+
+func TestScanValue(t *testing.T) {
+	var f float64
+	require.NoError(t, scanValue(&f, int64(3), aws.String("3")))
+	require.Equal(t, 3.0, f)
+
+	var b bool
+	require.Error(t, scanValue(&b, "yes", aws.String("yes")))
+
+	var ts time.Time
+	require.NoError(t, scanValue(&ts, nil, nil))
+	require.True(t, ts.IsZero())
+
+	var unsupported []string
+	require.Error(t, scanValue(&unsupported, "a", aws.String("a")))
+}
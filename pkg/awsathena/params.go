@@ -0,0 +1,155 @@
+package awsathena
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/hex"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// QuoteString returns s as an SQL string literal
+func QuoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Literal returns a value as an SQL literal:
+//
+//	nil                   NULL
+//	string                'string' (quotes are escaped)
+//	bool                  TRUE, FALSE
+//	integers, floats      the number
+//	time.Time             timestamp '2006-01-02 15:04:05.000' (UTC)
+//	[]byte                X'hex'
+//	other slices          the comma separated literals of the items, e.g. for `IN (?)`
+func Literal(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return QuoteString(v), nil
+	case bool:
+		if v {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case time.Time:
+		return "timestamp " + QuoteString(v.UTC().Format(TimestampLayout)), nil
+	case []byte:
+		return "X'" + hex.EncodeToString(v) + "'", nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", errors.Errorf("invalid number %v", f)
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), nil
+	case reflect.String:
+		return QuoteString(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			return "", errors.New("empty list")
+		}
+		items := make([]string, rv.Len())
+		for i := range items {
+			item, err := Literal(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return strings.Join(items, ", "), nil
+	default:
+		return "", errors.Errorf("unsupported parameter type %T", value)
+	}
+}
+
+// BindParams replaces the ? placeholders of sql with the literals of params (see Literal), in order.
+//
+// Question marks in string literals, quoted identifiers and comments are not placeholders.
+func BindParams(sql string, params ...interface{}) (string, error) {
+	var (
+		result strings.Builder
+		n      int
+	)
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// quoted until the closing quote, doubled quotes are escaped quotes
+			end := i + 1
+			for ; end < len(sql); end++ {
+				if sql[end] == c {
+					if end+1 < len(sql) && sql[end+1] == c {
+						end++
+						continue
+					}
+					break
+				}
+			}
+			if end >= len(sql) {
+				return "", errors.Errorf("unterminated quote at %d", i)
+			}
+			result.WriteString(sql[i : end+1])
+			i = end
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end == -1 {
+				end = len(sql) - i - 1
+			}
+			result.WriteString(sql[i : i+end+1])
+			i += end
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end == -1 {
+				return "", errors.Errorf("unterminated comment at %d", i)
+			}
+			result.WriteString(sql[i : i+2+end+2])
+			i += 2 + end + 1
+		case c == '?':
+			if n >= len(params) {
+				return "", errors.Errorf("not enough parameters, got %d", len(params))
+			}
+			literal, err := Literal(params[n])
+			if err != nil {
+				return "", errors.Wrapf(err, "parameter %d", n+1)
+			}
+			result.WriteString(literal)
+			n++
+		default:
+			result.WriteByte(c)
+		}
+	}
+	if n != len(params) {
+		return "", errors.Errorf("expected %d parameters, got %d", n, len(params))
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,68 @@
+package awsathena
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiteral(t *testing.T) {
+	for _, tc := range []struct {
+		value  interface{}
+		expect string
+	}{
+		{nil, "NULL"},
+		{"O'Brien", "'O''Brien'"},
+		{true, "TRUE"},
+		{42, "42"},
+		{uint8(7), "7"},
+		{1.25, "1.25"},
+		{time.Date(2020, 6, 17, 10, 0, 0, 0, time.UTC), "timestamp '2020-06-17 10:00:00.000'"},
+		{[]byte{0xca, 0xfe}, "X'cafe'"},
+		{[]string{"a", "b'c"}, "'a', 'b''c'"},
+		{[]int64{1, 2}, "1, 2"},
+	} {
+		literal, err := Literal(tc.value)
+		require.NoError(t, err)
+		require.Equal(t, tc.expect, literal)
+	}
+
+	for _, value := range []interface{}{[]string{}, struct{}{}, map[string]string{}} {
+		_, err := Literal(value)
+		require.Error(t, err, "%v", value)
+	}
+}
+
+func TestBindParams(t *testing.T) {
+	sql, err := BindParams(`SELECT '?', "a?" FROM t -- why?
+WHERE /* ? */ name = ? AND id IN (?) AND x = 'it''s ?'`, "x' OR 1=1 --", []int{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, `SELECT '?', "a?" FROM t -- why?
+WHERE /* ? */ name = 'x'' OR 1=1 --' AND id IN (1, 2) AND x = 'it''s ?'`, sql)
+
+	_, err = BindParams("SELECT ?, ?", 1)
+	require.Error(t, err)
+	_, err = BindParams("SELECT ?", 1, 2)
+	require.Error(t, err)
+	_, err = BindParams("SELECT 'unterminated ?", 1)
+	require.Error(t, err)
+}
@@ -0,0 +1,272 @@
+package awsathena
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/athena/athenaiface"
+	"github.com/pkg/errors"
+)
+
+// QueryStats are the statistics of a query execution
+type QueryStats struct {
+	DataScannedBytes    int64
+	QueryQueueTime      time.Duration
+	QueryPlanningTime   time.Duration
+	EngineExecutionTime time.Duration
+	TotalExecutionTime  time.Duration
+}
+
+// NewQueryStats returns the statistics of a query execution
+func NewQueryStats(execution *athena.QueryExecution) *QueryStats {
+	stats := execution.Statistics
+	if stats == nil {
+		return &QueryStats{}
+	}
+	millis := func(ms *int64) time.Duration {
+		return time.Duration(aws.Int64Value(ms)) * time.Millisecond
+	}
+	return &QueryStats{
+		DataScannedBytes:    aws.Int64Value(stats.DataScannedInBytes),
+		QueryQueueTime:      millis(stats.QueryQueueTimeInMillis),
+		QueryPlanningTime:   millis(stats.QueryPlanningTimeInMillis),
+		EngineExecutionTime: millis(stats.EngineExecutionTimeInMillis),
+		TotalExecutionTime:  millis(stats.TotalExecutionTimeInMillis),
+	}
+}
+
+// Query runs a query and returns an iterator over all of its results.
+//
+// If ctx is done before the query completes the query is stopped.
+func Query(ctx context.Context, client athenaiface.AthenaAPI, database, sql string, s3Path *string) (*Rows, error) {
+	startInput := &athena.StartQueryExecutionInput{
+		QueryString:           &sql,
+		QueryExecutionContext: &athena.QueryExecutionContext{Database: &database},
+		ResultConfiguration:   &athena.ResultConfiguration{OutputLocation: s3Path},
+	}
+	startOutput, err := client.StartQueryExecutionWithContext(ctx, startInput)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start query")
+	}
+	execution, err := Wait(ctx, client, aws.StringValue(startOutput.QueryExecutionId))
+	if err != nil {
+		return nil, err
+	}
+	rows := NewRows(ctx, client, aws.StringValue(execution.QueryExecutionId))
+	rows.execution = execution
+	return rows, nil
+}
+
+// Wait waits for a query to complete.
+//
+// If ctx is done before the query completes the query is stopped.
+func Wait(ctx context.Context, client athenaiface.AthenaAPI, queryExecutionID string) (*athena.QueryExecution, error) {
+	input := &athena.GetQueryExecutionInput{QueryExecutionId: &queryExecutionID}
+	for {
+		output, err := client.GetQueryExecutionWithContext(ctx, input)
+		if err != nil {
+			if ctx.Err() != nil {
+				stopQuery(client, queryExecutionID)
+				return nil, ctx.Err()
+			}
+			return nil, errors.Wrapf(err, "failed to get status of query %s", queryExecutionID)
+		}
+		execution := output.QueryExecution
+		switch state := aws.StringValue(execution.Status.State); state {
+		case athena.QueryExecutionStateSucceeded:
+			return execution, nil
+		case athena.QueryExecutionStateFailed, athena.QueryExecutionStateCancelled:
+			return nil, errors.Errorf("query %s %s: %s", queryExecutionID, state,
+				aws.StringValue(execution.Status.StateChangeReason))
+		}
+
+		select {
+		case <-ctx.Done():
+			stopQuery(client, queryExecutionID)
+			return nil, ctx.Err()
+		case <-time.After(pollDelay):
+		}
+	}
+}
+
+// stopQuery stops a query that is no longer waited for, ignoring errors (the query may have completed)
+func stopQuery(client athenaiface.AthenaAPI, queryExecutionID string) {
+	_, _ = client.StopQueryExecution(&athena.StopQueryExecutionInput{QueryExecutionId: &queryExecutionID})
+}
+
+// Rows iterates over all the result rows of a completed query, fetching result pages as needed.
+//
+//	rows := awsathena.NewRows(ctx, client, queryExecutionID)
+//	for rows.Next() {
+//		values, err := rows.Values()
+//		...
+//	}
+//	if err := rows.Err(); err != nil {
+//		...
+//	}
+type Rows struct {
+	ctx              context.Context
+	client           athenaiface.AthenaAPI
+	queryExecutionID string
+	execution        *athena.QueryExecution
+	// PageSize is the number of rows fetched per request (max 1000), the Athena default if zero
+	PageSize int64
+
+	columns   []*athena.ColumnInfo
+	page      []*athena.Row
+	current   *athena.Row
+	nextToken *string
+	fetched   bool
+	err       error
+}
+
+// NewRows returns an iterator over the results of a completed query
+func NewRows(ctx context.Context, client athenaiface.AthenaAPI, queryExecutionID string) *Rows {
+	return &Rows{
+		ctx:              ctx,
+		client:           client,
+		queryExecutionID: queryExecutionID,
+	}
+}
+
+// QueryExecutionID returns the id of the query
+func (r *Rows) QueryExecutionID() string {
+	return r.queryExecutionID
+}
+
+// Stats returns the statistics of the query, nil if the iterator was not returned by Query
+func (r *Rows) Stats() *QueryStats {
+	if r.execution == nil {
+		return nil
+	}
+	return NewQueryStats(r.execution)
+}
+
+// Columns returns the columns of the results, fetching the first page if needed
+func (r *Rows) Columns() ([]*athena.ColumnInfo, error) {
+	if !r.fetched {
+		r.fetch()
+	}
+	return r.columns, r.err
+}
+
+// Next advances to the next row, returning false when there are no more rows or an error occurred
+func (r *Rows) Next() bool {
+	for r.err == nil {
+		if len(r.page) > 0 {
+			r.current, r.page = r.page[0], r.page[1:]
+			return true
+		}
+		if r.fetched && r.nextToken == nil {
+			break
+		}
+		r.fetch()
+	}
+	r.current = nil
+	return false
+}
+
+// Err returns the error that stopped the iteration, if any
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Row returns the current row as returned by Athena
+func (r *Rows) Row() *athena.Row {
+	return r.current
+}
+
+// Strings returns the text values of the current row, NULL values are empty
+func (r *Rows) Strings() []string {
+	if r.current == nil {
+		return nil
+	}
+	values := make([]string, len(r.current.Data))
+	for i, datum := range r.current.Data {
+		values[i] = aws.StringValue(datum.VarCharValue)
+	}
+	return values
+}
+
+// Values returns the values of the current row decoded to Go types (see DecodeValue)
+func (r *Rows) Values() ([]interface{}, error) {
+	if r.current == nil {
+		return nil, errors.New("no current row")
+	}
+	if len(r.current.Data) != len(r.columns) {
+		return nil, errors.Errorf("row has %d values, expected %d", len(r.current.Data), len(r.columns))
+	}
+	values := make([]interface{}, len(r.columns))
+	for i, column := range r.columns {
+		value, err := DecodeValue(column, r.current.Data[i].VarCharValue)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (r *Rows) fetch() {
+	if err := r.ctx.Err(); err != nil {
+		r.err = err
+		return
+	}
+	input := &athena.GetQueryResultsInput{
+		QueryExecutionId: &r.queryExecutionID,
+		NextToken:        r.nextToken,
+	}
+	if r.PageSize > 0 {
+		input.MaxResults = &r.PageSize
+	}
+	output, err := r.client.GetQueryResultsWithContext(r.ctx, input)
+	if err != nil {
+		r.err = errors.Wrapf(err, "athena failed reading results for: %s", r.queryExecutionID)
+		return
+	}
+	rows := output.ResultSet.Rows
+	if !r.fetched {
+		r.fetched = true
+		if output.ResultSet.ResultSetMetadata != nil {
+			r.columns = output.ResultSet.ResultSetMetadata.ColumnInfo
+		}
+		// results of SELECT queries start with a row of the column names
+		if len(rows) > 0 && isHeader(rows[0], r.columns) {
+			rows = rows[1:]
+		}
+	}
+	r.page = rows
+	r.nextToken = output.NextToken
+}
+
+func isHeader(row *athena.Row, columns []*athena.ColumnInfo) bool {
+	if len(row.Data) != len(columns) {
+		return false
+	}
+	for i, column := range columns {
+		if aws.StringValue(row.Data[i].VarCharValue) != aws.StringValue(column.Name) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package awsathena
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func testColumns() []*athena.ColumnInfo {
+	return []*athena.ColumnInfo{
+		{Name: aws.String("name"), Type: aws.String("varchar")},
+		{Name: aws.String("count"), Type: aws.String("bigint")},
+	}
+}
+
+func testRow(values ...*string) *athena.Row {
+	row := &athena.Row{}
+	for _, value := range values {
+		row.Data = append(row.Data, &athena.Datum{VarCharValue: value})
+	}
+	return row
+}
+
+// mockResults mocks two pages of results: a header and 2 rows, then 1 row
+func mockResults(athenaMock *testutils.AthenaMock) {
+	athenaMock.On("GetQueryResultsWithContext", mock.Anything, &athena.GetQueryResultsInput{
+		QueryExecutionId: aws.String("execution"),
+	}, mock.Anything).Return(&athena.GetQueryResultsOutput{
+		NextToken: aws.String("page2"),
+		ResultSet: &athena.ResultSet{
+			ResultSetMetadata: &athena.ResultSetMetadata{ColumnInfo: testColumns()},
+			Rows: []*athena.Row{
+				testRow(aws.String("name"), aws.String("count")),
+				testRow(aws.String("alice"), aws.String("4")),
+				testRow(aws.String("bob"), nil),
+			},
+		},
+	}, nil).Once()
+	athenaMock.On("GetQueryResultsWithContext", mock.Anything, &athena.GetQueryResultsInput{
+		QueryExecutionId: aws.String("execution"),
+		NextToken:        aws.String("page2"),
+	}, mock.Anything).Return(&athena.GetQueryResultsOutput{
+		ResultSet: &athena.ResultSet{
+			ResultSetMetadata: &athena.ResultSetMetadata{ColumnInfo: testColumns()},
+			Rows:              []*athena.Row{testRow(aws.String("carol"), aws.String("1"))},
+		},
+	}, nil).Once()
+}
+
+func TestRows(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	mockResults(athenaMock)
+
+	rows := NewRows(context.Background(), athenaMock, "execution")
+	var result [][]interface{}
+	for rows.Next() {
+		values, err := rows.Values()
+		require.NoError(t, err)
+		result = append(result, values)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, [][]interface{}{
+		{"alice", int64(4)},
+		{"bob", nil},
+		{"carol", int64(1)},
+	}, result)
+	require.False(t, rows.Next())
+	athenaMock.AssertExpectations(t)
+}
+
+func TestRowsScan(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	mockResults(athenaMock)
+
+	rows := NewRows(context.Background(), athenaMock, "execution")
+	counts := map[string]int{}
+	for rows.Next() {
+		var (
+			name  string
+			count int
+		)
+		require.NoError(t, rows.Scan(&name, &count))
+		counts[name] = count
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, map[string]int{"alice": 4, "bob": 0, "carol": 1}, counts)
+
+	var name string
+	require.Error(t, rows.Scan(&name))
+}
+
+func TestRowsCanceled(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rows := NewRows(ctx, athenaMock, "execution")
+	require.False(t, rows.Next())
+	require.Equal(t, context.Canceled, rows.Err())
+	athenaMock.AssertExpectations(t)
+}
+
+func TestQuery(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	athenaMock.On("StartQueryExecutionWithContext", mock.Anything, &athena.StartQueryExecutionInput{
+		QueryString:           aws.String("SELECT name, count FROM t"),
+		QueryExecutionContext: &athena.QueryExecutionContext{Database: aws.String("panther_logs")},
+		ResultConfiguration:   &athena.ResultConfiguration{},
+	}, mock.Anything).Return(&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("execution")}, nil).Once()
+	athenaMock.On("GetQueryExecutionWithContext", mock.Anything, mock.Anything, mock.Anything).Return(&athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String("execution"),
+			Status:           &athena.QueryExecutionStatus{State: aws.String(athena.QueryExecutionStateSucceeded)},
+			Statistics: &athena.QueryExecutionStatistics{
+				DataScannedInBytes:          aws.Int64(1024),
+				EngineExecutionTimeInMillis: aws.Int64(1500),
+				TotalExecutionTimeInMillis:  aws.Int64(2000),
+			},
+		},
+	}, nil).Once()
+	mockResults(athenaMock)
+
+	rows, err := Query(context.Background(), athenaMock, "panther_logs", "SELECT name, count FROM t", nil)
+	require.NoError(t, err)
+	require.Equal(t, &QueryStats{
+		DataScannedBytes:    1024,
+		EngineExecutionTime: 1500 * time.Millisecond,
+		TotalExecutionTime:  2 * time.Second,
+	}, rows.Stats())
+	n := 0
+	for rows.Next() {
+		n++
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, 3, n)
+	athenaMock.AssertExpectations(t)
+}
+
+func TestWaitFailed(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	athenaMock.On("GetQueryExecutionWithContext", mock.Anything, mock.Anything, mock.Anything).Return(&athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String("execution"),
+			Status: &athena.QueryExecutionStatus{
+				State:             aws.String(athena.QueryExecutionStateFailed),
+				StateChangeReason: aws.String("SYNTAX_ERROR"),
+			},
+		},
+	}, nil).Once()
+	_, err := Wait(context.Background(), athenaMock, "execution")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "SYNTAX_ERROR")
+}
+
+func TestWaitCanceled(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	ctx, cancel := context.WithCancel(context.Background())
+	athenaMock.On("GetQueryExecutionWithContext", mock.Anything, mock.Anything, mock.Anything).Return(&athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String("execution"),
+			Status:           &athena.QueryExecutionStatus{State: aws.String(athena.QueryExecutionStateRunning)},
+		},
+	}, nil).Run(func(mock.Arguments) { cancel() }).Once()
+	// the query is stopped when no longer waited for
+	athenaMock.On("StopQueryExecution", &athena.StopQueryExecutionInput{QueryExecutionId: aws.String("execution")}).
+		Return(&athena.StopQueryExecutionOutput{}, nil).Once()
+	_, err := Wait(ctx, athenaMock, "execution")
+	require.Equal(t, context.Canceled, err)
+	athenaMock.AssertExpectations(t)
+}
@@ -0,0 +1,174 @@
+package awsathena
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// ExportFormat is the format of exported results
+type ExportFormat string
+
+const (
+	// ExportNDJSON writes a JSON object per row with the decoded values (see DecodeValue)
+	ExportNDJSON ExportFormat = "ndjson"
+	// ExportCSV writes a header of the column names and the text values of the rows
+	ExportCSV ExportFormat = "csv"
+)
+
+// WriteNDJSON writes the remaining rows as newline delimited JSON objects, returning the number of rows written
+func WriteNDJSON(w io.Writer, rows *Rows) (int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	stream := jsoniter.ConfigDefault.BorrowStream(w)
+	defer jsoniter.ConfigDefault.ReturnStream(stream)
+
+	n := 0
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return n, err
+		}
+		// the keys are written in the order of the columns
+		stream.WriteObjectStart()
+		for i, column := range columns {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			stream.WriteObjectField(aws.StringValue(column.Name))
+			stream.WriteVal(values[i])
+		}
+		stream.WriteObjectEnd()
+		stream.WriteRaw("\n")
+		if stream.Error != nil {
+			return n, stream.Error
+		}
+		n++
+		if stream.Buffered() > 4096 {
+			if err := stream.Flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return n, err
+	}
+	return n, stream.Flush()
+}
+
+// WriteCSV writes a header of the column names and the remaining rows as CSV, returning the number of rows written
+func WriteCSV(w io.Writer, rows *Rows) (int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	writer := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = aws.StringValue(column.Name)
+	}
+	if err := writer.Write(header); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for rows.Next() {
+		if err := writer.Write(rows.Strings()); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, err
+	}
+	writer.Flush()
+	return n, writer.Error()
+}
+
+// Write writes the remaining rows in a format, returning the number of rows written
+func (f ExportFormat) Write(w io.Writer, rows *Rows) (int, error) {
+	switch f {
+	case ExportNDJSON:
+		return WriteNDJSON(w, rows)
+	case ExportCSV:
+		return WriteCSV(w, rows)
+	default:
+		return 0, errors.Errorf("unsupported export format %q", f)
+	}
+}
+
+// ExportS3 writes the remaining rows in a format to a gzip compressed S3 object, returning the number of rows written.
+//
+// Rows are streamed to S3 as they are fetched, the results are never fully held in memory.
+func ExportS3(ctx context.Context, uploader s3manageriface.UploaderAPI, bucket, key string,
+	format ExportFormat, rows *Rows) (int, error) {
+
+	reader, writer := io.Pipe()
+	type writeResult struct {
+		n   int
+		err error
+	}
+	done := make(chan writeResult, 1)
+	go func() {
+		gz := gzip.NewWriter(writer)
+		n, err := format.Write(gz, rows)
+		if err == nil {
+			err = gz.Close()
+		}
+		// an error here aborts the upload
+		_ = writer.CloseWithError(err)
+		done <- writeResult{n: n, err: err}
+	}()
+
+	_, uploadErr := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:          &bucket,
+		Key:             &key,
+		Body:            reader,
+		ContentEncoding: aws.String("gzip"),
+		ContentType:     aws.String(format.contentType()),
+	})
+	// unblock the writer if the upload stopped reading
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+	result := <-done
+	if uploadErr != nil {
+		return result.n, errors.Wrapf(uploadErr, "failed to upload results to s3://%s/%s", bucket, key)
+	}
+	if result.err != nil {
+		return result.n, errors.Wrapf(result.err, "failed to write results to s3://%s/%s", bucket, key)
+	}
+	return result.n, nil
+}
+
+func (f ExportFormat) contentType() string {
+	if f == ExportCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
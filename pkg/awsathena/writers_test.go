@@ -0,0 +1,111 @@
+package awsathena
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	expectNDJSON = `{"name":"alice","count":4}
+{"name":"bob","count":null}
+{"name":"carol","count":1}
+`
+	expectCSV = `name,count
+alice,4
+bob,
+carol,1
+`
+)
+
+func TestWriteNDJSON(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	mockResults(athenaMock)
+	var buf bytes.Buffer
+	n, err := WriteNDJSON(&buf, NewRows(context.Background(), athenaMock, "execution"))
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, expectNDJSON, buf.String())
+}
+
+func TestWriteCSV(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	mockResults(athenaMock)
+	var buf bytes.Buffer
+	n, err := WriteCSV(&buf, NewRows(context.Background(), athenaMock, "execution"))
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, expectCSV, buf.String())
+}
+
+func TestExportS3(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	mockResults(athenaMock)
+	uploaderMock := &testutils.S3UploaderMock{}
+	var uploaded []byte
+	uploaderMock.On("UploadWithContext", mock.Anything, mock.Anything, mock.Anything).
+		Return(&s3manager.UploadOutput{}, nil).
+		Run(func(args mock.Arguments) {
+			input := args.Get(1).(*s3manager.UploadInput)
+			require.Equal(t, "bucket", aws.StringValue(input.Bucket))
+			require.Equal(t, "export.csv.gz", aws.StringValue(input.Key))
+			require.Equal(t, "gzip", aws.StringValue(input.ContentEncoding))
+			gz, err := gzip.NewReader(input.Body)
+			require.NoError(t, err)
+			uploaded, err = ioutil.ReadAll(gz)
+			require.NoError(t, err)
+		}).Once()
+
+	rows := NewRows(context.Background(), athenaMock, "execution")
+	n, err := ExportS3(context.Background(), uploaderMock, "bucket", "export.csv.gz", ExportCSV, rows)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, expectCSV, string(uploaded))
+	uploaderMock.AssertExpectations(t)
+}
+
+func TestExportS3UploadFailed(t *testing.T) {
+	athenaMock := &testutils.AthenaMock{}
+	mockResults(athenaMock)
+	uploaderMock := &testutils.S3UploaderMock{}
+	uploaderMock.On("UploadWithContext", mock.Anything, mock.Anything, mock.Anything).
+		Return(&s3manager.UploadOutput{}, errors.New("denied")).
+		Run(func(args mock.Arguments) {
+			// read a little and give up
+			_, _ = io.ReadFull(args.Get(1).(*s3manager.UploadInput).Body, make([]byte, 1))
+		}).Once()
+
+	rows := NewRows(context.Background(), athenaMock, "execution")
+	_, err := ExportS3(context.Background(), uploaderMock, "bucket", "export.json.gz", ExportNDJSON, rows)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "denied")
+}
@@ -0,0 +1,63 @@
+package sqsbatch
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/pkg/errors"
+)
+
+// ChangeMessageVisibilityBatch sets the visibility timeout of received messages in batches.
+// A timeout of 0 makes the messages immediately available to other consumers.
+// Unlike DeleteMessageBatch, it returns an error if the visibility of any message could not be changed.
+func ChangeMessageVisibilityBatch(sqsClient sqsiface.SQSAPI, queueURL string, messageReceipts []*string,
+	visibilityTimeoutSeconds int64) error {
+
+	for len(messageReceipts) > 0 {
+		n := len(messageReceipts)
+		if n > maxMessages {
+			n = maxMessages
+		}
+		entries := make([]*sqs.ChangeMessageVisibilityBatchRequestEntry, n)
+		for i, receipt := range messageReceipts[:n] {
+			entries[i] = &sqs.ChangeMessageVisibilityBatchRequestEntry{
+				Id:                aws.String(strconv.Itoa(i)),
+				ReceiptHandle:     receipt,
+				VisibilityTimeout: aws.Int64(visibilityTimeoutSeconds),
+			}
+		}
+		output, err := sqsClient.ChangeMessageVisibilityBatch(&sqs.ChangeMessageVisibilityBatchInput{
+			Entries:  entries,
+			QueueUrl: aws.String(queueURL),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failure changing visibility of messages in %s", queueURL)
+		}
+		if len(output.Failed) > 0 {
+			return errors.Errorf("failure changing visibility of %d messages in %s: %s",
+				len(output.Failed), queueURL, aws.StringValue(output.Failed[0].Message))
+		}
+		messageReceipts = messageReceipts[n:]
+	}
+	return nil
+}
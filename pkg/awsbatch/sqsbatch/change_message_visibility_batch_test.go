@@ -0,0 +1,62 @@
+package sqsbatch
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestChangeMessageVisibilityBatch(t *testing.T) {
+	t.Parallel()
+	queueURL := "fakeqURL"
+
+	// 11 events, 2 batches
+	mockSqsClient := &testutils.SqsMock{}
+	mockSqsClient.On("ChangeMessageVisibilityBatch", mock.Anything).
+		Return(&sqs.ChangeMessageVisibilityBatchOutput{}, nil).Times(2)
+	require.NoError(t, ChangeMessageVisibilityBatch(mockSqsClient, queueURL, make([]*string, 11), 0))
+	mockSqsClient.AssertExpectations(t)
+	input := mockSqsClient.Calls[1].Arguments.Get(0).(*sqs.ChangeMessageVisibilityBatchInput)
+	require.Len(t, input.Entries, 1)
+	require.Equal(t, int64(0), aws.Int64Value(input.Entries[0].VisibilityTimeout))
+
+	// partial failure
+	mockSqsClient = &testutils.SqsMock{}
+	mockSqsClient.On("ChangeMessageVisibilityBatch", mock.Anything).
+		Return(&sqs.ChangeMessageVisibilityBatchOutput{
+			Failed: []*sqs.BatchResultErrorEntry{{Id: aws.String("0"), Message: aws.String("failed")}},
+		}, nil).Once()
+	require.Error(t, ChangeMessageVisibilityBatch(mockSqsClient, queueURL, make([]*string, 5), 0))
+	mockSqsClient.AssertExpectations(t)
+
+	// request failure
+	mockSqsClient = &testutils.SqsMock{}
+	mockSqsClient.On("ChangeMessageVisibilityBatch", mock.Anything).
+		Return(&sqs.ChangeMessageVisibilityBatchOutput{}, errors.New("failed")).Once()
+	require.Error(t, ChangeMessageVisibilityBatch(mockSqsClient, queueURL, make([]*string, 5), 0))
+	mockSqsClient.AssertExpectations(t)
+}
@@ -27,7 +27,9 @@ import (
 	"go.uber.org/zap"
 )
 
-func DeleteMessageBatch(sqsClient sqsiface.SQSAPI, queueURL string, messageReceipts []*string) {
+// DeleteMessageBatch deletes messages in batches, errors are logged.
+// It returns the receipts of the messages that could not be deleted.
+func DeleteMessageBatch(sqsClient sqsiface.SQSAPI, queueURL string, messageReceipts []*string) (failed []*string) {
 	// pre-allocate space
 	deleteMessageBatchRequestEntries := make([]*sqs.DeleteMessageBatchRequestEntry, maxMessages)
 	messagesInBatchCounter := 0
@@ -42,7 +44,7 @@ func DeleteMessageBatch(sqsClient sqsiface.SQSAPI, queueURL string, messageRecei
 		deleteMessageBatchRequestEntries[messagesInBatchCounter].ReceiptHandle = messageReceipt        // set
 		messagesInBatchCounter++
 		if messagesInBatchCounter == maxMessages {
-			deleteMessageBatch(sqsClient, queueURL, deleteMessageBatchRequestEntries)
+			failed = append(failed, deleteMessageBatch(sqsClient, queueURL, deleteMessageBatchRequestEntries)...)
 			// reset
 			messagesInBatchCounter = 0
 			deleteMessageBatchRequestEntries = deleteMessageBatchRequestEntries[:0]
@@ -50,12 +52,13 @@ func DeleteMessageBatch(sqsClient sqsiface.SQSAPI, queueURL string, messageRecei
 	}
 	// the rest
 	if messagesInBatchCounter > 0 {
-		deleteMessageBatch(sqsClient, queueURL, deleteMessageBatchRequestEntries)
+		failed = append(failed, deleteMessageBatch(sqsClient, queueURL, deleteMessageBatchRequestEntries)...)
 	}
+	return failed
 }
 
 func deleteMessageBatch(sqsClient sqsiface.SQSAPI, queueURL string,
-	deleteMessageBatchRequestEntries []*sqs.DeleteMessageBatchRequestEntry) {
+	deleteMessageBatchRequestEntries []*sqs.DeleteMessageBatchRequestEntry) (failed []*string) {
 
 	// NOTE: this is a best effort, and we log any errors. Failed deleted messages will be re-processed
 	deleteMessageBatchOutput, err := sqsClient.DeleteMessageBatch(&sqs.DeleteMessageBatchInput{
@@ -63,11 +66,37 @@ func deleteMessageBatch(sqsClient sqsiface.SQSAPI, queueURL string,
 		QueueUrl: &queueURL,
 	})
 	if err != nil {
+		// the output is nil when the whole request failed
+		if deleteMessageBatchOutput == nil {
+			deleteMessageBatchOutput = &sqs.DeleteMessageBatchOutput{}
+		}
 		zap.L().Error("failure deleting sqs messages",
 			zap.String("guidance", "failed messages will be reprocessed"),
 			zap.String("queueURL", queueURL),
 			zap.Int("numberOfFailedMessages", len(deleteMessageBatchOutput.Failed)),
 			zap.Int("numberOfSuccessfulMessages", len(deleteMessageBatchOutput.Successful)),
 			zap.Error(err))
+		// the whole request failed
+		for _, entry := range deleteMessageBatchRequestEntries {
+			failed = append(failed, entry.ReceiptHandle)
+		}
+		return failed
+	}
+	// some entries can fail without an error for the request
+	if len(deleteMessageBatchOutput.Failed) > 0 {
+		zap.L().Error("failure deleting sqs messages",
+			zap.String("guidance", "failed messages will be reprocessed"),
+			zap.String("queueURL", queueURL),
+			zap.Int("numberOfFailedMessages", len(deleteMessageBatchOutput.Failed)),
+			zap.String("error", aws.StringValue(deleteMessageBatchOutput.Failed[0].Message)))
+	}
+	for _, result := range deleteMessageBatchOutput.Failed {
+		for _, entry := range deleteMessageBatchRequestEntries {
+			if aws.StringValue(entry.Id) == aws.StringValue(result.Id) {
+				failed = append(failed, entry.ReceiptHandle)
+				break
+			}
+		}
 	}
+	return failed
 }
@@ -19,10 +19,13 @@ package sqsbatch
  */
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/panther-labs/panther/pkg/testutils"
 )
@@ -61,3 +64,26 @@ func TestDeleteMessageBatch(t *testing.T) {
 	DeleteMessageBatch(mockSqsClient, queueURL, make([]*string, 100))
 	mockSqsClient.AssertExpectations(t)
 }
+
+func TestDeleteMessageBatchError(t *testing.T) {
+	t.Parallel()
+	mockSqsClient := &testutils.SqsMock{}
+	mockSqsClient.On("DeleteMessageBatch", mock.Anything).
+		Return((*sqs.DeleteMessageBatchOutput)(nil), errors.New("failed")).Once()
+	// errors are logged, failed messages will be reprocessed
+	failed := DeleteMessageBatch(mockSqsClient, "fakeqURL", []*string{aws.String("receipt")})
+	mockSqsClient.AssertExpectations(t)
+	require.Equal(t, []*string{aws.String("receipt")}, failed)
+}
+
+func TestDeleteMessageBatchFailedEntries(t *testing.T) {
+	t.Parallel()
+	mockSqsClient := &testutils.SqsMock{}
+	mockSqsClient.On("DeleteMessageBatch", mock.Anything).Return(&sqs.DeleteMessageBatchOutput{
+		Successful: []*sqs.DeleteMessageBatchResultEntry{{Id: aws.String("0")}},
+		Failed:     []*sqs.BatchResultErrorEntry{{Id: aws.String("1"), Message: aws.String("invalid receipt")}},
+	}, nil).Once()
+	failed := DeleteMessageBatch(mockSqsClient, "fakeqURL", []*string{aws.String("receipt-0"), aws.String("receipt-1")})
+	mockSqsClient.AssertExpectations(t)
+	require.Equal(t, []*string{aws.String("receipt-1")}, failed)
+}
@@ -45,3 +45,22 @@ func ReceiveMessage(sqsClient sqsiface.SQSAPI, queueURL string, waitTimeSeconds
 
 	return receiveMessageOutput.Messages, messageReceipts, err
 }
+
+// ReceiveMessageWithAttributes receives messages along with all their attributes (ie ApproximateReceiveCount)
+// and message attributes. Received messages are hidden from other consumers for visibilityTimeoutSeconds.
+func ReceiveMessageWithAttributes(sqsClient sqsiface.SQSAPI, queueURL string,
+	waitTimeSeconds, visibilityTimeoutSeconds int64) ([]*sqs.Message, error) {
+
+	receiveMessageOutput, err := sqsClient.ReceiveMessage(&sqs.ReceiveMessageInput{
+		WaitTimeSeconds:       aws.Int64(waitTimeSeconds), // wait this long UNLESS MaxNumberOfMessages read
+		MaxNumberOfMessages:   aws.Int64(maxMessages),     // max size allowed
+		VisibilityTimeout:     aws.Int64(visibilityTimeoutSeconds),
+		AttributeNames:        aws.StringSlice([]string{sqs.QueueAttributeNameAll}),
+		MessageAttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameAll}),
+		QueueUrl:              aws.String(queueURL),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failure receiving messages from %s", queueURL)
+	}
+	return receiveMessageOutput.Messages, nil
+}
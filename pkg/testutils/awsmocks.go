@@ -54,6 +54,13 @@ func (m *S3UploaderMock) Upload(input *s3manager.UploadInput, f ...func(*s3manag
 	return args.Get(0).(*s3manager.UploadOutput), args.Error(1)
 }
 
+func (m *S3UploaderMock) UploadWithContext(ctx aws.Context, input *s3manager.UploadInput,
+	f ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+
+	args := m.Called(ctx, input, f)
+	return args.Get(0).(*s3manager.UploadOutput), args.Error(1)
+}
+
 type S3Mock struct {
 	s3iface.S3API
 	mock.Mock
@@ -69,11 +76,33 @@ func (m *S3Mock) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput,
 	return args.Get(0).(*s3.GetObjectOutput), args.Error(1)
 }
 
+func (m *S3Mock) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.DeleteObjectOutput), args.Error(1)
+}
+
+func (m *S3Mock) DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.DeleteObjectsOutput), args.Error(1)
+}
+
 func (m *S3Mock) GetBucketLocation(input *s3.GetBucketLocationInput) (*s3.GetBucketLocationOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*s3.GetBucketLocationOutput), args.Error(1)
 }
 
+func (m *S3Mock) ListObjectsV2(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.ListObjectsV2Output), args.Error(1)
+}
+
+func (m *S3Mock) GetBucketNotificationConfiguration(
+	input *s3.GetBucketNotificationConfigurationRequest) (*s3.NotificationConfiguration, error) {
+
+	args := m.Called(input)
+	return args.Get(0).(*s3.NotificationConfiguration), args.Error(1)
+}
+
 func (m *S3Mock) ListObjectsV2Pages(input *s3.ListObjectsV2Input, f func(page *s3.ListObjectsV2Output, morePages bool) bool) error {
 	args := m.Called(input, f)
 	f(args.Get(0).(*s3.ListObjectsV2Output), false)
@@ -192,6 +221,19 @@ func (m *SqsMock) DeleteQueue(input *sqs.DeleteQueueInput) (*sqs.DeleteQueueOutp
 	return args.Get(0).(*sqs.DeleteQueueOutput), args.Error(1)
 }
 
+// nolint (golint)
+func (m *SqsMock) GetQueueUrl(input *sqs.GetQueueUrlInput) (*sqs.GetQueueUrlOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.GetQueueUrlOutput), args.Error(1)
+}
+
+func (m *SqsMock) ChangeMessageVisibilityBatch(
+	input *sqs.ChangeMessageVisibilityBatchInput) (*sqs.ChangeMessageVisibilityBatchOutput, error) {
+
+	args := m.Called(input)
+	return args.Get(0).(*sqs.ChangeMessageVisibilityBatchOutput), args.Error(1)
+}
+
 type EventBridgeMock struct {
 	eventbridgeiface.EventBridgeAPI
 	mock.Mock
@@ -237,11 +279,21 @@ func (m *GlueMock) GetTable(input *glue.GetTableInput) (*glue.GetTableOutput, er
 	return args.Get(0).(*glue.GetTableOutput), args.Error(1)
 }
 
+func (m *GlueMock) UpdateTable(input *glue.UpdateTableInput) (*glue.UpdateTableOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*glue.UpdateTableOutput), args.Error(1)
+}
+
 func (m *GlueMock) DeleteTable(input *glue.DeleteTableInput) (*glue.DeleteTableOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*glue.DeleteTableOutput), args.Error(1)
 }
 
+func (m *GlueMock) GetTables(input *glue.GetTablesInput) (*glue.GetTablesOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*glue.GetTablesOutput), args.Error(1)
+}
+
 func (m *GlueMock) CreatePartition(input *glue.CreatePartitionInput) (*glue.CreatePartitionOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*glue.CreatePartitionOutput), args.Error(1)
@@ -257,6 +309,16 @@ func (m *GlueMock) GetPartitions(input *glue.GetPartitionsInput) (*glue.GetParti
 	return args.Get(0).(*glue.GetPartitionsOutput), args.Error(1)
 }
 
+func (m *GlueMock) BatchCreatePartition(input *glue.BatchCreatePartitionInput) (*glue.BatchCreatePartitionOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*glue.BatchCreatePartitionOutput), args.Error(1)
+}
+
+func (m *GlueMock) BatchDeletePartition(input *glue.BatchDeletePartitionInput) (*glue.BatchDeletePartitionOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*glue.BatchDeletePartitionOutput), args.Error(1)
+}
+
 func (m *GlueMock) UpdatePartition(input *glue.UpdatePartitionInput) (*glue.UpdatePartitionOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*glue.UpdatePartitionOutput), args.Error(1)
@@ -282,6 +344,39 @@ func (m *AthenaMock) GetQueryResults(input *athena.GetQueryResultsInput) (*athen
 	return args.Get(0).(*athena.GetQueryResultsOutput), args.Error(1)
 }
 
+func (m *AthenaMock) StopQueryExecution(input *athena.StopQueryExecutionInput) (*athena.StopQueryExecutionOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*athena.StopQueryExecutionOutput), args.Error(1)
+}
+
+func (m *AthenaMock) StartQueryExecutionWithContext(ctx aws.Context, input *athena.StartQueryExecutionInput,
+	options ...request.Option) (*athena.StartQueryExecutionOutput, error) {
+
+	args := m.Called(ctx, input, options)
+	return args.Get(0).(*athena.StartQueryExecutionOutput), args.Error(1)
+}
+
+func (m *AthenaMock) GetQueryExecutionWithContext(ctx aws.Context, input *athena.GetQueryExecutionInput,
+	options ...request.Option) (*athena.GetQueryExecutionOutput, error) {
+
+	args := m.Called(ctx, input, options)
+	return args.Get(0).(*athena.GetQueryExecutionOutput), args.Error(1)
+}
+
+func (m *AthenaMock) GetQueryResultsWithContext(ctx aws.Context, input *athena.GetQueryResultsInput,
+	options ...request.Option) (*athena.GetQueryResultsOutput, error) {
+
+	args := m.Called(ctx, input, options)
+	return args.Get(0).(*athena.GetQueryResultsOutput), args.Error(1)
+}
+
+func (m *AthenaMock) StopQueryExecutionWithContext(ctx aws.Context, input *athena.StopQueryExecutionInput,
+	options ...request.Option) (*athena.StopQueryExecutionOutput, error) {
+
+	args := m.Called(ctx, input, options)
+	return args.Get(0).(*athena.StopQueryExecutionOutput), args.Error(1)
+}
+
 type SnsMock struct {
 	snsiface.SNSAPI
 	mock.Mock
@@ -292,6 +387,16 @@ func (m *SnsMock) Publish(input *sns.PublishInput) (*sns.PublishOutput, error) {
 	return args.Get(0).(*sns.PublishOutput), args.Error(1)
 }
 
+func (m *SnsMock) GetTopicAttributes(input *sns.GetTopicAttributesInput) (*sns.GetTopicAttributesOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sns.GetTopicAttributesOutput), args.Error(1)
+}
+
+func (m *SnsMock) ListSubscriptionsByTopic(input *sns.ListSubscriptionsByTopicInput) (*sns.ListSubscriptionsByTopicOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sns.ListSubscriptionsByTopicOutput), args.Error(1)
+}
+
 func (m *SnsMock) ConfirmSubscription(input *sns.ConfirmSubscriptionInput) (*sns.ConfirmSubscriptionOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*sns.ConfirmSubscriptionOutput), args.Error(1)
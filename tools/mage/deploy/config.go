@@ -35,6 +35,7 @@ type PantherConfig struct {
 
 type Infra struct {
 	BaseLayerVersionArns          string   `yaml:"BaseLayerVersionArns"`
+	EnablePartitionProjection     bool     `yaml:"EnablePartitionProjection"`
 	LoadBalancerSecurityGroupCidr string   `yaml:"LoadBalancerSecurityGroupCidr"`
 	LogProcessorLambdaMemorySize  int      `yaml:"LogProcessorLambdaMemorySize"`
 	PipLayer                      []string `yaml:"PipLayer"`
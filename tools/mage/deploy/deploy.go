@@ -419,6 +419,7 @@ func deployCoreStack(settings *PantherConfig, outputs map[string]string) error {
 		"CustomResourceVersion":      customResourceVersion(),
 		"Debug":                      strconv.FormatBool(settings.Monitoring.Debug),
 		"DynamoScalingRoleArn":       outputs["DynamoScalingRoleArn"],
+		"EnablePartitionProjection":  strconv.FormatBool(settings.Infra.EnablePartitionProjection),
 		"InputDataBucket":            outputs["InputDataBucket"],
 		"InputDataTopicArn":          outputs["InputDataTopicArn"],
 		"LayerVersionArns":           settings.Infra.BaseLayerVersionArns,
@@ -450,6 +451,7 @@ func deployLogAnalysisStack(settings *PantherConfig, outputs map[string]string)
 		"CloudWatchLogRetentionDays":   strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
 		"CustomResourceVersion":        customResourceVersion(),
 		"Debug":                        strconv.FormatBool(settings.Monitoring.Debug),
+		"EnablePartitionProjection":    strconv.FormatBool(settings.Infra.EnablePartitionProjection),
 		"InputDataBucket":              outputs["InputDataBucket"],
 		"InputDataTopicArn":            outputs["InputDataTopicArn"],
 		"LayerVersionArns":             settings.Infra.BaseLayerVersionArns,
@@ -98,11 +98,17 @@ export type AddS3LogIntegrationInput = {
   kmsKey?: Maybe<Scalars['String']>;
   s3Prefix?: Maybe<Scalars['String']>;
   logTypes: Array<Scalars['String']>;
+  freshnessThresholdMins?: Maybe<Scalars['Int']>;
+  freshnessAlertSeverity?: Maybe<SeverityEnum>;
+  freshnessAlertOutputIds?: Maybe<Array<Scalars['ID']>>;
 };
 
 export type AddSqsLogIntegrationInput = {
   integrationLabel: Scalars['String'];
   sqsConfig: SqsLogConfigInput;
+  freshnessThresholdMins?: Maybe<Scalars['Int']>;
+  freshnessAlertSeverity?: Maybe<SeverityEnum>;
+  freshnessAlertOutputIds?: Maybe<Array<Scalars['ID']>>;
 };
 
 export type Alert = {
@@ -386,6 +392,7 @@ export type IntegrationItemHealthStatus = {
   healthy: Scalars['Boolean'];
   message: Scalars['String'];
   rawErrorMessage?: Maybe<Scalars['String']>;
+  remediation?: Maybe<Scalars['String']>;
 };
 
 export type IntegrationTemplate = {
@@ -1055,6 +1062,9 @@ export type S3LogIntegration = {
   logTypes: Array<Scalars['String']>;
   health: S3LogIntegrationHealth;
   stackName: Scalars['String'];
+  freshnessThresholdMins?: Maybe<Scalars['Int']>;
+  freshnessAlertSeverity?: Maybe<SeverityEnum>;
+  freshnessAlertOutputIds?: Maybe<Array<Scalars['ID']>>;
 };
 
 export type S3LogIntegrationHealth = {
@@ -1062,6 +1072,11 @@ export type S3LogIntegrationHealth = {
   processingRoleStatus: IntegrationItemHealthStatus;
   s3BucketStatus: IntegrationItemHealthStatus;
   kmsKeyStatus: IntegrationItemHealthStatus;
+  s3NotificationsStatus?: Maybe<IntegrationItemHealthStatus>;
+  snsTopicPolicyStatus?: Maybe<IntegrationItemHealthStatus>;
+  snsSubscriptionStatus?: Maybe<IntegrationItemHealthStatus>;
+  latestObjectStatus?: Maybe<IntegrationItemHealthStatus>;
+  sampleObjectStatus?: Maybe<IntegrationItemHealthStatus>;
 };
 
 export type ScannedResources = {
@@ -1159,6 +1174,7 @@ export type SqsLogConfigInput = {
 export type SqsLogIntegrationHealth = {
   __typename?: 'SqsLogIntegrationHealth';
   sqsStatus?: Maybe<IntegrationItemHealthStatus>;
+  sqsPolicyStatus?: Maybe<IntegrationItemHealthStatus>;
 };
 
 export type SqsLogSourceIntegration = {
@@ -1171,6 +1187,9 @@ export type SqsLogSourceIntegration = {
   lastEventReceived?: Maybe<Scalars['AWSDateTime']>;
   sqsConfig: SqsConfig;
   health: SqsLogIntegrationHealth;
+  freshnessThresholdMins?: Maybe<Scalars['Int']>;
+  freshnessAlertSeverity?: Maybe<SeverityEnum>;
+  freshnessAlertOutputIds?: Maybe<Array<Scalars['ID']>>;
 };
 
 export type SuppressPoliciesInput = {
@@ -1253,12 +1272,18 @@ export type UpdateS3LogIntegrationInput = {
   kmsKey?: Maybe<Scalars['String']>;
   s3Prefix?: Maybe<Scalars['String']>;
   logTypes?: Maybe<Array<Scalars['String']>>;
+  freshnessThresholdMins?: Maybe<Scalars['Int']>;
+  freshnessAlertSeverity?: Maybe<SeverityEnum>;
+  freshnessAlertOutputIds?: Maybe<Array<Scalars['ID']>>;
 };
 
 export type UpdateSqsLogIntegrationInput = {
   integrationId: Scalars['String'];
   integrationLabel: Scalars['String'];
   sqsConfig: SqsLogConfigInput;
+  freshnessThresholdMins?: Maybe<Scalars['Int']>;
+  freshnessAlertSeverity?: Maybe<SeverityEnum>;
+  freshnessAlertOutputIds?: Maybe<Array<Scalars['ID']>>;
 };
 
 export type UpdateUserInput = {
@@ -1900,6 +1925,7 @@ export type IntegrationItemHealthStatusResolvers<
   healthy?: Resolver<ResolversTypes['Boolean'], ParentType, ContextType>;
   message?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
   rawErrorMessage?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
+  remediation?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -2601,6 +2627,9 @@ export type S3LogIntegrationResolvers<
   logTypes?: Resolver<Array<ResolversTypes['String']>, ParentType, ContextType>;
   health?: Resolver<ResolversTypes['S3LogIntegrationHealth'], ParentType, ContextType>;
   stackName?: Resolver<ResolversTypes['String'], ParentType, ContextType>;
+  freshnessThresholdMins?: Resolver<Maybe<ResolversTypes['Int']>, ParentType, ContextType>;
+  freshnessAlertSeverity?: Resolver<Maybe<ResolversTypes['SeverityEnum']>, ParentType, ContextType>;
+  freshnessAlertOutputIds?: Resolver<Maybe<Array<ResolversTypes['ID']>>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -2615,6 +2644,31 @@ export type S3LogIntegrationHealthResolvers<
   >;
   s3BucketStatus?: Resolver<ResolversTypes['IntegrationItemHealthStatus'], ParentType, ContextType>;
   kmsKeyStatus?: Resolver<ResolversTypes['IntegrationItemHealthStatus'], ParentType, ContextType>;
+  s3NotificationsStatus?: Resolver<
+    Maybe<ResolversTypes['IntegrationItemHealthStatus']>,
+    ParentType,
+    ContextType
+  >;
+  snsTopicPolicyStatus?: Resolver<
+    Maybe<ResolversTypes['IntegrationItemHealthStatus']>,
+    ParentType,
+    ContextType
+  >;
+  snsSubscriptionStatus?: Resolver<
+    Maybe<ResolversTypes['IntegrationItemHealthStatus']>,
+    ParentType,
+    ContextType
+  >;
+  latestObjectStatus?: Resolver<
+    Maybe<ResolversTypes['IntegrationItemHealthStatus']>,
+    ParentType,
+    ContextType
+  >;
+  sampleObjectStatus?: Resolver<
+    Maybe<ResolversTypes['IntegrationItemHealthStatus']>,
+    ParentType,
+    ContextType
+  >;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -2730,6 +2784,11 @@ export type SqsLogIntegrationHealthResolvers<
     ParentType,
     ContextType
   >;
+  sqsPolicyStatus?: Resolver<
+    Maybe<ResolversTypes['IntegrationItemHealthStatus']>,
+    ParentType,
+    ContextType
+  >;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -2745,6 +2804,9 @@ export type SqsLogSourceIntegrationResolvers<
   lastEventReceived?: Resolver<Maybe<ResolversTypes['AWSDateTime']>, ParentType, ContextType>;
   sqsConfig?: Resolver<ResolversTypes['SqsConfig'], ParentType, ContextType>;
   health?: Resolver<ResolversTypes['SqsLogIntegrationHealth'], ParentType, ContextType>;
+  freshnessThresholdMins?: Resolver<Maybe<ResolversTypes['Int']>, ParentType, ContextType>;
+  freshnessAlertSeverity?: Resolver<Maybe<ResolversTypes['SeverityEnum']>, ParentType, ContextType>;
+  freshnessAlertOutputIds?: Resolver<Maybe<Array<ResolversTypes['ID']>>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
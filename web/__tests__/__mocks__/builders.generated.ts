@@ -627,6 +627,7 @@ export const buildIntegrationItemHealthStatus = (
     healthy: 'healthy' in overrides ? overrides.healthy : false,
     message: 'message' in overrides ? overrides.message : 'Home Loan Account',
     rawErrorMessage: 'rawErrorMessage' in overrides ? overrides.rawErrorMessage : 'Markets',
+    remediation: 'remediation' in overrides ? overrides.remediation : null,
   };
 };
 
@@ -1203,6 +1204,14 @@ export const buildS3LogIntegrationHealth = (
       's3BucketStatus' in overrides ? overrides.s3BucketStatus : buildIntegrationItemHealthStatus(),
     kmsKeyStatus:
       'kmsKeyStatus' in overrides ? overrides.kmsKeyStatus : buildIntegrationItemHealthStatus(),
+    s3NotificationsStatus:
+      's3NotificationsStatus' in overrides ? overrides.s3NotificationsStatus : null,
+    snsTopicPolicyStatus:
+      'snsTopicPolicyStatus' in overrides ? overrides.snsTopicPolicyStatus : null,
+    snsSubscriptionStatus:
+      'snsSubscriptionStatus' in overrides ? overrides.snsSubscriptionStatus : null,
+    latestObjectStatus: 'latestObjectStatus' in overrides ? overrides.latestObjectStatus : null,
+    sampleObjectStatus: 'sampleObjectStatus' in overrides ? overrides.sampleObjectStatus : null,
   };
 };
 
@@ -1340,6 +1349,7 @@ export const buildSqsLogIntegrationHealth = (
   return {
     __typename: 'SqsLogIntegrationHealth',
     sqsStatus: 'sqsStatus' in overrides ? overrides.sqsStatus : buildIntegrationItemHealthStatus(),
+    sqsPolicyStatus: 'sqsPolicyStatus' in overrides ? overrides.sqsPolicyStatus : null,
   };
 };
 
@@ -57,6 +57,11 @@ const SourceHealthBadge: React.FC<SourceHealthBadgeProps> = ({ healthMetrics })
                   {healthMetric.rawErrorMessage}
                 </Text>
               )}
+              {!healthMetric.healthy && !!healthMetric.remediation && (
+                <Text my={1} fontSize="x-small" maxWidth="fit-content">
+                  {healthMetric.remediation}
+                </Text>
+              )}
             </Box>
           </Flex>
         );
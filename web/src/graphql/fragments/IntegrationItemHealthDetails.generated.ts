@@ -23,7 +23,7 @@ import gql from 'graphql-tag';
 
 export type IntegrationItemHealthDetails = Pick<
   Types.IntegrationItemHealthStatus,
-  'healthy' | 'message' | 'rawErrorMessage'
+  'healthy' | 'message' | 'rawErrorMessage' | 'remediation'
 >;
 
 export const IntegrationItemHealthDetails = gql`
@@ -31,5 +31,6 @@ export const IntegrationItemHealthDetails = gql`
     healthy
     message
     rawErrorMessage
+    remediation
   }
 `;
@@ -41,6 +41,11 @@ export type S3LogIntegrationDetails = Pick<
     processingRoleStatus: IntegrationItemHealthDetails;
     s3BucketStatus: IntegrationItemHealthDetails;
     kmsKeyStatus: IntegrationItemHealthDetails;
+    s3NotificationsStatus?: Types.Maybe<IntegrationItemHealthDetails>;
+    snsTopicPolicyStatus?: Types.Maybe<IntegrationItemHealthDetails>;
+    snsSubscriptionStatus?: Types.Maybe<IntegrationItemHealthDetails>;
+    latestObjectStatus?: Types.Maybe<IntegrationItemHealthDetails>;
+    sampleObjectStatus?: Types.Maybe<IntegrationItemHealthDetails>;
   };
 };
 
@@ -68,6 +73,21 @@ export const S3LogIntegrationDetails = gql`
       kmsKeyStatus {
         ...IntegrationItemHealthDetails
       }
+      s3NotificationsStatus {
+        ...IntegrationItemHealthDetails
+      }
+      snsTopicPolicyStatus {
+        ...IntegrationItemHealthDetails
+      }
+      snsSubscriptionStatus {
+        ...IntegrationItemHealthDetails
+      }
+      latestObjectStatus {
+        ...IntegrationItemHealthDetails
+      }
+      sampleObjectStatus {
+        ...IntegrationItemHealthDetails
+      }
     }
   }
   ${IntegrationItemHealthDetails}
@@ -35,7 +35,10 @@ export type SqsLogSourceIntegrationDetails = Pick<
     Types.SqsConfig,
     'logTypes' | 'allowedPrincipalArns' | 'allowedSourceArns' | 'queueUrl'
   >;
-  health: { sqsStatus?: Types.Maybe<IntegrationItemHealthDetails> };
+  health: {
+    sqsStatus?: Types.Maybe<IntegrationItemHealthDetails>;
+    sqsPolicyStatus?: Types.Maybe<IntegrationItemHealthDetails>;
+  };
 };
 
 export const SqsLogSourceIntegrationDetails = gql`
@@ -56,6 +59,9 @@ export const SqsLogSourceIntegrationDetails = gql`
       sqsStatus {
         ...IntegrationItemHealthDetails
       }
+      sqsPolicyStatus {
+        ...IntegrationItemHealthDetails
+      }
     }
   }
   ${IntegrationItemHealthDetails}
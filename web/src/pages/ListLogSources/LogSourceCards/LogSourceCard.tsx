@@ -39,13 +39,18 @@ const LogSourceCard: React.FC<LogSourceCardProps> = ({ source, children, logo })
   const healthMetrics = React.useMemo(() => {
     switch (sourceHealth.__typename) {
       case 'SqsLogIntegrationHealth':
-        return [sourceHealth.sqsStatus];
+        return [sourceHealth.sqsStatus, sourceHealth.sqsPolicyStatus].filter(Boolean);
       case 'S3LogIntegrationHealth':
         return [
           sourceHealth.processingRoleStatus,
           sourceHealth.s3BucketStatus,
           sourceHealth.kmsKeyStatus,
-        ];
+          sourceHealth.s3NotificationsStatus,
+          sourceHealth.snsTopicPolicyStatus,
+          sourceHealth.snsSubscriptionStatus,
+          sourceHealth.latestObjectStatus,
+          sourceHealth.sampleObjectStatus,
+        ].filter(Boolean);
       default:
         throw new Error(`Unknown source health item`);
     }